@@ -276,6 +276,7 @@ func stateServingInfo() params.StateServingInfo {
 		APIPort:        47,
 		SharedSecret:   "shared",
 		SystemIdentity: "identity",
+		SecretsKey:     "secrets key",
 	}
 }
 
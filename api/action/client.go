@@ -118,3 +118,72 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// AddActionSchedules adds schedules that enqueue actions on the units
+// of applications at regular intervals.
+func (c *Client) AddActionSchedules(arg params.AddActionScheduleArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ActionSchedules returns the details of the named action schedules,
+// including their most recent runs, or all action schedules if no
+// names are specified.
+func (c *Client) ActionSchedules(arg params.ActionScheduleNames) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("ActionSchedules", arg, &results)
+	return results, err
+}
+
+// PauseActionSchedules pauses the named action schedules.
+func (c *Client) PauseActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("PauseActionSchedules", arg)
+}
+
+// ResumeActionSchedules resumes the named paused action schedules.
+func (c *Client) ResumeActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("ResumeActionSchedules", arg)
+}
+
+// RemoveActionSchedules removes the named action schedules.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("RemoveActionSchedules", arg)
+}
+
+func (c *Client) updateActionSchedules(method string, arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall(method, arg, &results)
+	return results, err
+}
+
+// AddActionRollouts adds rollouts that enqueue an action on every unit
+// of an application in batches, and returns their initial details.
+func (c *Client) AddActionRollouts(arg params.AddActionRolloutArgs) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall("AddActionRollouts", arg, &results)
+	return results, err
+}
+
+// ActionRollouts returns the details of the action rollouts with the
+// specified IDs.
+func (c *Client) ActionRollouts(arg params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall("ActionRollouts", arg, &results)
+	return results, err
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing/factory"
 )
 
 type actionSuite struct {
@@ -127,3 +128,72 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestActionSchedules(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "dummy",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+
+	addResults, err := s.client.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Name:           "backup",
+			ApplicationTag: names.NewApplicationTag("dummy").String(),
+			Action:         "snapshot",
+			Cron:           "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addResults.OneError(), jc.ErrorIsNil)
+
+	pauseResults, err := s.client.PauseActionSchedules(params.ActionScheduleNames{Names: []string{"backup"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pauseResults.OneError(), jc.ErrorIsNil)
+
+	results, err := s.client.ActionSchedules(params.ActionScheduleNames{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Name, gc.Equals, "backup")
+	c.Assert(results.Results[0].Result.Cron, gc.Equals, "@daily")
+	c.Assert(results.Results[0].Result.Paused, jc.IsTrue)
+
+	removeResults, err := s.client.RemoveActionSchedules(params.ActionScheduleNames{Names: []string{"backup"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removeResults.OneError(), jc.ErrorIsNil)
+
+	results, err = s.client.ActionSchedules(params.ActionScheduleNames{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestActionRollouts(c *gc.C) {
+	dummy := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "dummy",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: dummy})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: dummy})
+
+	addResults, err := s.client.AddActionRollouts(params.AddActionRolloutArgs{
+		Rollouts: []params.AddActionRolloutArg{{
+			ApplicationTag: names.NewApplicationTag("dummy").String(),
+			Action:         "snapshot",
+			MaxParallel:    1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addResults.Results, gc.HasLen, 1)
+	c.Assert(addResults.Results[0].Error, gc.IsNil)
+	id := addResults.Results[0].Result.Id
+
+	results, err := s.client.ActionRollouts(params.ActionRolloutIds{Ids: []string{id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	details := results.Results[0].Result
+	c.Assert(details.Status, gc.Equals, "running")
+	c.Assert(details.PendingUnits, jc.DeepEquals, []string{"dummy/1"})
+	c.Assert(details.Actions, gc.HasLen, 1)
+	c.Assert(details.Actions[0].Action.Receiver, gc.Equals, "unit-dummy-0")
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// API provides access to the ActionScheduler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionScheduler facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, actionSchedulerFacade)
+	return &API{facade: facadeCaller}
+}
+
+// RunActionSchedules calls the server-side RunActionSchedules method.
+func (api *API) RunActionSchedules() error {
+	return api.facade.FacadeCall("RunActionSchedules", nil, nil)
+}
+
+// ProgressActionRollouts calls the server-side ProgressActionRollouts
+// method.
+func (api *API) ProgressActionRollouts() error {
+	return api.facade.FacadeCall("ProgressActionRollouts", nil, nil)
+}
+
+// WatchActionResults returns a StringsWatcher that notifies of the IDs
+// of actions in the model that have finished.
+func (api *API) WatchActionResults() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := api.facade.FacadeCall("WatchActionResults", nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(api.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) TestRunActionSchedules(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunActionSchedules",
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.RunActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller.CallCount, gc.Equals, 1)
+}
+
+func (s *ActionSchedulerSuite) TestRunActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunActionSchedules",
+		Error:         errors.New("boom"),
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.RunActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulerSuite) TestProgressActionRollouts(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ProgressActionRollouts",
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller.CallCount, gc.Equals, 1)
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionResultsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchActionResults")
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResult{})
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller)
+	w, err := api.WatchActionResults()
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
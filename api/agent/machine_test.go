@@ -52,6 +52,8 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		SharedSecret: ssi.SharedSecret,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
+		// The secrets key comes from the controller, not the database.
+		SecretsKey: string(coretesting.SecretsKey),
 	}
 	err := s.State.SetStateServingInfo(ssi)
 	c.Assert(err, jc.ErrorIsNil)
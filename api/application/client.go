@@ -282,10 +282,18 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// Rollout, if set, releases the upgrade to the application's units
+	// a batch at a time. This field is only understood by Application
+	// facade version 9 and greater.
+	Rollout *params.CharmRolloutParams
 }
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.Rollout != nil && c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("rolling charm upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -299,9 +307,10 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 				countPtr = &count
 			}
 			storageConstraints[name] = params.StorageConstraints{
-				Pool:  cons.Pool,
-				Size:  sizePtr,
-				Count: countPtr,
+				Pool:     cons.Pool,
+				Size:     sizePtr,
+				Count:    countPtr,
+				Snapshot: cons.Snapshot,
 			}
 		}
 	}
@@ -315,10 +324,55 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		Rollout:            cfg.Rollout,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// CharmRollout returns the details of the most recent rolling upgrade
+// of the application's charm.
+func (c *Client) CharmRollout(application string) (params.CharmRolloutDetails, error) {
+	if c.BestAPIVersion() < 9 {
+		return params.CharmRolloutDetails{}, errors.NotSupportedf("showing charm rollouts")
+	}
+	if !names.IsValidApplication(application) {
+		return params.CharmRolloutDetails{}, errors.NotValidf("application %q", application)
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewApplicationTag(application).String(),
+	}}}
+	var results params.CharmRolloutResults
+	if err := c.facade.FacadeCall("CharmRollouts", args, &results); err != nil {
+		return params.CharmRolloutDetails{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.CharmRolloutDetails{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.CharmRolloutDetails{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// ResumeCharmRollout resumes the paused rolling upgrade of the
+// application's charm.
+func (c *Client) ResumeCharmRollout(application string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("resuming charm rollouts")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application %q", application)
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewApplicationTag(application).String(),
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResumeCharmRollouts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
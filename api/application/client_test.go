@@ -30,7 +30,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+	return application.NewClient(basetesting.BestVersionCaller{f, 9})
 }
 
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
@@ -296,6 +296,112 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmRollout(c *gc.C) {
+	var called bool
+	rollout := &params.CharmRolloutParams{
+		BatchSize: 2,
+		OnError:   "rollback",
+	}
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.Rollout, jc.DeepEquals, rollout)
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		Rollout: rollout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmRolloutNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		Rollout: &params.CharmRolloutParams{BatchSize: 2},
+	})
+	c.Assert(err, gc.ErrorMatches, "rolling charm upgrades not supported")
+}
+
+func (s *applicationSuite) TestCharmRollout(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "CharmRollouts")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result, ok := response.(*params.CharmRolloutResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.CharmRolloutResult{{
+			Result: &params.CharmRolloutDetails{
+				ApplicationTag: "application-foo",
+				Status:         "running",
+			},
+		}}
+		return nil
+	})
+	details, err := client.CharmRollout("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details, jc.DeepEquals, params.CharmRolloutDetails{
+		ApplicationTag: "application-foo",
+		Status:         "running",
+	})
+}
+
+func (s *applicationSuite) TestCharmRolloutError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.CharmRolloutResults)
+		result.Results = []params.CharmRolloutResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	_, err := client.CharmRollout("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestResumeCharmRollout(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ResumeCharmRollouts")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := client.ResumeCharmRollout("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestResumeCharmRolloutNotSupported(c *gc.C) {
+	client := newClientV4(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.ResumeCharmRollout("foo")
+	c.Assert(err, gc.ErrorMatches, "resuming charm rollouts not supported")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+// Client provides methods for querying the audit log of a controller
+// machine.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the entries matching the filter in the audit log of
+// the controller machine the client is connected to, along with the
+// ID of that machine.
+func (c *Client) Query(filter auditlog.Filter) (string, []auditlog.Entry, error) {
+	args := params.AuditLogQueryArgs{
+		User:       filter.User,
+		Model:      filter.Model,
+		Facade:     filter.Facade,
+		Method:     filter.Method,
+		ErrorsOnly: filter.ErrorsOnly,
+	}
+	if !filter.After.IsZero() {
+		args.After = &filter.After
+	}
+	if !filter.Before.IsZero() {
+		args.Before = &filter.Before
+	}
+	var result params.AuditLogQueryResult
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	entries := make([]auditlog.Entry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = convertEntry(entry)
+	}
+	return result.ControllerMachine, entries, nil
+}
+
+func convertEntry(entry params.AuditLogEntry) auditlog.Entry {
+	c := entry.Conversation
+	result := auditlog.Entry{
+		Conversation: auditlog.Conversation{
+			Who:            c.Who,
+			What:           c.What,
+			When:           c.When,
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+		},
+	}
+	for _, r := range entry.Requests {
+		request := auditlog.RequestEntry{
+			Request: auditlog.Request{
+				ConversationID: c.ConversationID,
+				ConnectionID:   c.ConnectionID,
+				RequestID:      r.RequestID,
+				When:           r.When,
+				Facade:         r.Facade,
+				Method:         r.Method,
+				Version:        r.Version,
+				Args:           r.Args,
+			},
+		}
+		for _, err := range r.Errors {
+			request.Errors = append(request.Errors, &auditlog.Error{
+				Message: err.Message,
+				Code:    err.Code,
+			})
+		}
+		result.Requests = append(result.Requests, request)
+	}
+	return result
+}
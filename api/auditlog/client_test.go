@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	auditlogapi "github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestQuery(c *gc.C) {
+	after := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQueryArgs{
+				User:       "mary",
+				Facade:     "Application",
+				After:      &after,
+				ErrorsOnly: true,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogQueryResult{})
+			*(result.(*params.AuditLogQueryResult)) = params.AuditLogQueryResult{
+				ControllerMachine: "1",
+				Entries: []params.AuditLogEntry{{
+					Conversation: params.AuditLogConversation{
+						Who:            "mary",
+						What:           "juju deploy",
+						When:           "2018-10-01T09:00:00Z",
+						ConversationID: "c1",
+						ConnectionID:   "1",
+					},
+					Requests: []params.AuditLogRequest{{
+						RequestID: 2,
+						When:      "2018-10-01T09:00:01Z",
+						Facade:    "Application",
+						Method:    "Deploy",
+						Version:   6,
+						Errors:    []params.AuditLogError{{Message: "boom"}},
+					}},
+				}},
+			}
+			return nil
+		},
+	)
+
+	client := auditlogapi.NewClient(apiCaller)
+	machine, entries, err := client.Query(auditlog.Filter{
+		User:       "mary",
+		Facade:     "Application",
+		After:      after,
+		ErrorsOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine, gc.Equals, "1")
+	c.Assert(entries, jc.DeepEquals, []auditlog.Entry{{
+		Conversation: auditlog.Conversation{
+			Who:            "mary",
+			What:           "juju deploy",
+			When:           "2018-10-01T09:00:00Z",
+			ConversationID: "c1",
+			ConnectionID:   "1",
+		},
+		Requests: []auditlog.RequestEntry{{
+			Request: auditlog.Request{
+				ConversationID: "c1",
+				ConnectionID:   "1",
+				RequestID:      2,
+				When:           "2018-10-01T09:00:01Z",
+				Facade:         "Application",
+				Method:         "Deploy",
+				Version:        6,
+			},
+			Errors: []*auditlog.Error{{Message: "boom"}},
+		}},
+	}})
+}
+
+func (s *clientSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	client := auditlogapi.NewClient(apiCaller)
+	_, _, err := client.Query(auditlog.Filter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package caasunitprovisioner
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -102,6 +104,86 @@ func (c *Client) WatchApplicationScale(application string) (watcher.NotifyWatche
 	return w, nil
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the application config of the specified CAAS application.
+func (c *Client) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// WatchApplicationMetrics returns a NotifyWatcher that notifies of
+// metrics being recorded by the units of the specified CAAS application.
+func (c *Client) WatchApplicationMetrics(application string) (watcher.NotifyWatcher, error) {
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsMetrics", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// ApplicationMetrics returns the latest value of each metric recorded
+// by each unit of the specified application, keyed on unit name and
+// then metric key. Values which are not numbers are omitted.
+func (c *Client) ApplicationMetrics(application string) (map[string]map[string]float64, error) {
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.MetricResults
+	if err := c.facade.FacadeCall("ApplicationsMetrics", entities(applicationTag), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitMetrics := make(map[string]map[string]float64)
+	for _, m := range results.Results[0].Metrics {
+		value, err := strconv.ParseFloat(m.Value, 64)
+		if err != nil {
+			continue
+		}
+		metrics, ok := unitMetrics[m.Unit]
+		if !ok {
+			metrics = make(map[string]float64)
+			unitMetrics[m.Unit] = metrics
+		}
+		metrics[m.Key] = value
+	}
+	return unitMetrics, nil
+}
+
 // ApplicationScale returns the scale for the specified application.
 func (c *Client) ApplicationScale(applicationName string) (int, error) {
 	var results params.IntResults
@@ -118,6 +200,36 @@ func (c *Client) ApplicationScale(applicationName string) (int, error) {
 	return results.Results[0].Result, nil
 }
 
+// UnitProviderIds returns the units of the specified application
+// which have a cloud container, keyed on the container's provider id.
+func (c *Client) UnitProviderIds(applicationName string) (map[string]names.UnitTag, error) {
+	appTag, err := applicationTag(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.UnitProviderIdsResults
+	if err := c.facade.FacadeCall("UnitProviderIds", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Entities) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	ids := make(map[string]names.UnitTag)
+	for _, id := range results.Results[0].Result {
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids[id.ProviderId] = unitTag
+	}
+	return ids, nil
+}
+
 // WatchPodSpec returns a NotifyWatcher that notifies of
 // changes to the pod spec of the specified CAAS application in
 // the current model.
@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+const charmRolloutFacade = "CharmRollout"
+
+// API provides access to the CharmRollout API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side CharmRollout facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, charmRolloutFacade)
+	return &API{facade: facadeCaller}
+}
+
+// ProgressCharmRollouts calls the server-side ProgressCharmRollouts
+// method.
+func (api *API) ProgressCharmRollouts() error {
+	return api.facade.FacadeCall("ProgressCharmRollouts", nil, nil)
+}
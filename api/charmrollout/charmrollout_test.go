@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmrollout"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmRolloutSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) TestProgressCharmRollouts(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "CharmRollout",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ProgressCharmRollouts",
+	})
+	api := charmrollout.NewAPI(caller)
+	err := api.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller.CallCount, gc.Equals, 1)
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRolloutsError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "CharmRollout",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ProgressCharmRollouts",
+		Error:         errors.New("boom"),
+	})
+	api := charmrollout.NewAPI(caller)
+	err := api.ProgressCharmRollouts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -53,6 +53,15 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, limits the response to records with a log time
+	// on or before EndTime. Once EndTime has passed the server stops
+	// waiting for new logs.
+	EndTime time.Time
+	// MessageRegex, if set, is a regular expression that the message of
+	// each record must match for the record to be included in the
+	// response. It uses the RE2 syntax of Go's regexp package. The
+	// matching is done by the server.
+	MessageRegex string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -80,6 +89,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
 	return attrs
 }
 
@@ -91,6 +106,7 @@ type LogMessage struct {
 	Module    string
 	Location  string
 	Message   string
+	ModelUUID string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +144,7 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				ModelUUID: msg.ModelUUID,
 			}
 		}
 	}()
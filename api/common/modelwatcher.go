@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 )
 
 // ModelWatcher provides common client-side API functions
@@ -71,6 +72,20 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return cfg, ok, nil
 }
 
+// LogForwardWebhookConfig returns the current log forward webhook
+// configuration. If it is set, it takes the place of the syslog
+// configuration.
+func (e *ModelWatcher) LogForwardWebhookConfig() (*webhook.RawConfig, bool, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdWebhook()
+	return cfg, ok, nil
+}
+
 // UpdateStatusHookInterval returns the current update status hook interval.
 func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
@@ -257,6 +258,17 @@ func (c *Client) ConfigSet(values map[string]interface{}) error {
 	)
 }
 
+// BackupStatus returns the schedule and outcome of the controller's
+// most recent scheduled backups.
+func (c *Client) BackupStatus() (params.ControllerBackupStatus, error) {
+	var result params.ControllerBackupStatus
+	if c.BestAPIVersion() < 6 {
+		return result, errors.NotSupportedf("scheduled backups on this controller version")
+	}
+	err := c.facade.FacadeCall("BackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
@@ -297,16 +309,65 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationPrechecks runs all of the prechecks for migrating the
+// specified model, without starting the migration, and returns every
+// problem found.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) (coremigration.PrecheckReport, error) {
+	var report coremigration.PrecheckReport
+	if c.BestAPIVersion() < 7 {
+		return report, errors.NotSupportedf("migration precheck reports on this controller version")
+	}
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return report, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return report, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return report, errors.Trace(result.Error)
+	}
+	if result.Report != nil {
+		report.Blockers = result.Report.Blockers
+		report.Warnings = result.Report.Warnings
+	}
+	return report, nil
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -318,19 +379,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -170,6 +172,66 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				stub.AddCall(objType+"."+request, arg)
+				out := result.(*params.MigrationPrecheckResults)
+				*out = params.MigrationPrecheckResults{
+					Results: []params.MigrationPrecheckResult{{
+						Report: &params.MigrationPrecheckReport{
+							Blockers: []string{"cleanup needed"},
+							Warnings: []string{"watch out"},
+						},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	report, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, coremigration.PrecheckReport{
+		Blockers: []string{"cleanup needed"},
+		Warnings: []string{"watch out"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				out := result.(*params.MigrationPrecheckResults)
+				*out = params.MigrationPrecheckResults{
+					Results: []params.MigrationPrecheckResult{{
+						Error: common.ServerError(errors.New("boom")),
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationPrechecksNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -380,3 +442,37 @@ func (s *Suite) TestConfigSetAgainstOlderAPIVersion(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support updating controller config")
 }
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	lastSuccess := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Assert(objType, gc.Equals, "Controller")
+			c.Assert(version, gc.Equals, 6)
+			c.Assert(request, gc.Equals, "BackupStatus")
+			c.Assert(args, gc.IsNil)
+			*(result.(*params.ControllerBackupStatus)) = params.ControllerBackupStatus{
+				Schedule:     "24h0m0s",
+				LastSuccess:  &lastSuccess,
+				LastBackupID: "backup-id",
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.ControllerBackupStatus{
+		Schedule:     "24h0m0s",
+		LastSuccess:  &lastSuccess,
+		LastBackupID: "backup-id",
+	})
+}
+
+func (s *Suite) TestBackupStatusAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -11,16 +11,18 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       4,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  8,
+	"Application":                  9,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
+	"AuditLog":                     1,
 	"Backups":                      2,
 	"Block":                        2,
 	"Bundle":                       2,
@@ -28,13 +30,14 @@ var facadeVersions = map[string]int{
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          3,
 	"CharmRevisionUpdater":         2,
+	"CharmRollout":                 1,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   5,
+	"Controller":                   7,
 	"CredentialManager":            1,
 	"CredentialValidator":          1,
 	"CrossController":              1,
@@ -71,9 +74,9 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  2,
-	"ModelManager":                 4,
+	"ModelManager":                 5,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
@@ -90,17 +93,18 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     2,
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           4,
+	"Storage":                      6,
+	"StorageProvisioner":           6,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       8,
+	"Uniter":                       9,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
@@ -43,14 +43,43 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := makeModelInfoParams(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// PrecheckReport runs all of the target controller's prechecks for
+// the model, returning every problem found rather than just the
+// first.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo) (coremigration.PrecheckReport, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return coremigration.PrecheckReport{}, errors.NotSupportedf("precheck reports")
+	}
+	var result params.MigrationPrecheckReport
+	args := makeModelInfoParams(model)
+	if err := c.caller.FacadeCall("PrecheckReport", args, &result); err != nil {
+		return coremigration.PrecheckReport{}, errors.Trace(err)
+	}
+	return coremigration.PrecheckReport{
+		Blockers: result.Blockers,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+func makeModelInfoParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	var userTags []string
+	for _, user := range model.Users {
+		userTags = append(userTags, user.String())
+	}
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudName:              model.CloudName,
+		CloudRegion:            model.CloudRegion,
+		UserTags:               userTags,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
@@ -77,6 +77,56 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestPrecheckReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationPrecheckReport)) = params.MigrationPrecheckReport{
+			Blockers: []string{"upgrade in progress"},
+			Warnings: []string{"watch out"},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{apiCaller, 2})
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	report, err := client.PrecheckReport(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+		CloudName:    "dummy",
+		CloudRegion:  "east",
+		Users:        []names.UserTag{ownerTag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, coremigration.PrecheckReport{
+		Blockers: []string{"upgrade in progress"},
+		Warnings: []string{"watch out"},
+	})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+		CloudName:    "dummy",
+		CloudRegion:  "east",
+		UserTags:     []string{ownerTag.String()},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckReport", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckReportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckReport(coremigration.ModelInfo{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -381,6 +381,32 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 	return result.Result, nil
 }
 
+// ExportModel returns the serialized representation of the specified
+// model, along with the details of the charms, agent binaries and
+// resources that must be archived with it so that it can be imported
+// into another controller.
+func (c *Client) ExportModel(model names.ModelTag) (params.ExportedModel, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.ExportedModel{}, errors.NotSupportedf("exporting models")
+	}
+	var results params.ExportedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	err := c.facade.FacadeCall("ExportModels", entities, &results)
+	if err != nil {
+		return params.ExportedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return params.ExportedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ExportedModel{}, result.Error
+	}
+	return *result.Result, nil
+}
+
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
 // be removed.
@@ -385,6 +385,69 @@ func (s *modelmanagerSuite) TestModelStatus(c *gc.C) {
 	c.Assert(results[1].Error, gc.ErrorMatches, "model error")
 }
 
+func (s *modelmanagerSuite) TestExportModel(c *gc.C) {
+	exported := params.ExportedModel{
+		Info: params.MigrationModelInfo{
+			UUID:     coretesting.ModelTag.Id(),
+			Name:     "mymodel",
+			OwnerTag: "user-glenda",
+		},
+		Model: params.SerializedModel{
+			Bytes:  []byte("model-uuid: " + coretesting.ModelTag.Id()),
+			Charms: []string{"cs:mysql-1"},
+		},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExportModels")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				[]params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ExportedModelResults{})
+			*(result.(*params.ExportedModelResults)) = params.ExportedModelResults{
+				Results: []params.ExportedModelResult{{Result: &exported}},
+			}
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	result, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, exported)
+}
+
+func (s *modelmanagerSuite) TestExportModelError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.ExportedModelResults)) = params.ExportedModelResults{
+				Results: []params.ExportedModelResult{{Error: common.ServerError(errors.New("boom"))}},
+			}
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelmanagerSuite) TestExportModelNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "exporting models not supported")
+}
+
 func (s *modelmanagerSuite) TestModelStatusEmpty(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "ModelManager")
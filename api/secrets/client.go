@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the secrets API end point.
+type Client struct {
+	base.ClientFacade
+	st     base.APICallCloser
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the secrets api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Secrets")
+	return &Client{ClientFacade: frontend, st: st, facade: backend}
+}
+
+// ListSecrets returns the details of all secrets in the model.
+// Secret values are not included.
+func (c *Client) ListSecrets() ([]params.SecretDetails, error) {
+	var results params.ListSecretsResults
+	if err := c.facade.FacadeCall("ListSecrets", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// RotateSecret adds a new revision of the secret with the specified
+// ID, holding the specified values.
+func (c *Client) RotateSecret(id string, values map[string]string) error {
+	args := params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Id:     id,
+			Values: values,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RotateSecret", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
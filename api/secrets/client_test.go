@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSecrets")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListSecretsResults{})
+			*(result.(*params.ListSecretsResults)) = params.ListSecretsResults{
+				Results: []params.SecretDetails{{
+					Id:          "mysql/password",
+					Application: "mysql",
+					Revision:    1,
+				}},
+			}
+			return nil
+		})
+
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.SecretDetails{{
+		Id:          "mysql/password",
+		Application: "mysql",
+		Revision:    1,
+	}})
+}
+
+func (s *SecretsSuite) TestListSecretsFacadeCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("facade failure")
+		})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "facade failure")
+}
+
+func (s *SecretsSuite) TestRotateSecret(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RotateSecret")
+			c.Check(a, jc.DeepEquals, params.RotateSecretArgs{
+				Args: []params.RotateSecretArg{{
+					Id:     "mysql/password",
+					Values: map[string]string{"root": "foo"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: common.ServerError(errors.New("fail")),
+				}},
+			}
+			return nil
+		})
+
+	client := secrets.NewClient(apiCaller)
+	err := client.RotateSecret("mysql/password", map[string]string{"root": "foo"})
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
@@ -229,6 +229,83 @@ func (c *Client) Remove(storageIds []string, destroyAttachments, destroyStorage
 	return results.Results, nil
 }
 
+// Resize requests that the specified storage instance be grown to the
+// given size in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.Errorf("this juju controller does not support resizing storage")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorage{[]params.ResizeStorageInstance{{
+		Tag:  names.NewStorageTag(storageId).String(),
+		Size: size,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CreateSnapshot requests snapshots of the volumes backing the specified
+// storage instances.
+func (c *Client) CreateSnapshot(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.Errorf("this juju controller does not support storage snapshots")
+	}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("CreateSnapshot", params.Entities{entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists all volume snapshots in the model.
+func (c *Client) ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.Errorf("this juju controller does not support storage snapshots")
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// RemoveSnapshots removes the volume snapshots with the specified IDs.
+func (c *Client) RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.Errorf("this juju controller does not support storage snapshots")
+	}
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{snapshotIds}
+	if err := c.facade.FacadeCall("RemoveSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
 // Detach detaches the specified storage entities.
 func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	results := params.ErrorResults{}
@@ -622,6 +622,193 @@ func (s *storageMockSuite) TestRemove(c *gc.C) {
 	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
 }
 
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.ResizeStorage{[]params.ResizeStorageInstance{
+					{Tag: "storage-foo-0", Size: 2048},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support resizing storage")
+}
+
+func (s *storageMockSuite) TestCreateSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshot")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+					{Tag: "storage-foo-1"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+				results := result.(*params.VolumeSnapshotDetailsResults)
+				results.Results = []params.VolumeSnapshotDetailsResult{
+					{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshot([]string{"foo/0", "foo/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshot([]string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+				results := result.(*params.VolumeSnapshotDetailsResults)
+				results.Results = []params.VolumeSnapshotDetailsResult{
+					{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+	})
+}
+
+func (s *storageMockSuite) TestRemoveSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveSnapshots")
+				c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{[]string{"0", "0/1"}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.RemoveSnapshots([]string{"0", "0/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshot([]string{"foo/0"})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support storage snapshots")
+	_, err = client.ListSnapshots()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support storage snapshots")
+	_, err = client.RemoveSnapshots([]string{"0"})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support storage snapshots")
+}
+
 func (s *storageMockSuite) TestRemoveDestroyAttachments(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
@@ -94,6 +94,26 @@ func (st *State) WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, erro
 	return st.watchStorageEntities("WatchFilesystems", scope)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, so that requests to resize them can
+// be acted upon.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the specified tag, so that requests to resize them
+// can be acted upon.
+func (st *State) WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchFilesystemResizes", scope)
+}
+
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the specified tag.
+func (st *State) WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots", scope)
+}
+
 func (st *State) watchStorageEntities(method string, scope names.Tag) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -271,6 +291,41 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking or destroying
+// the volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -311,6 +366,26 @@ func (st *State) RemoveFilesystemParams(tags []names.FilesystemTag) ([]params.Re
 	return results.Results, nil
 }
 
+// ResizeFilesystemParams returns the parameters for growing the
+// filesystems with the specified tags.
+func (st *State) ResizeFilesystemParams(tags []names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeFilesystemParamsResults
+	err := st.facade.FacadeCall("ResizeFilesystemParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified tags.
 func (st *State) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
@@ -355,6 +430,21 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{VolumeSnapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
@@ -478,6 +568,20 @@ func (st *State) RemoveAttachments(ids []params.MachineStorageId) ([]params.Erro
 	return results.Results, nil
 }
 
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{Ids: ids}
+	if err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // InstanceIds returns the provider specific instance ID for each machine,
 // or an CodeNotProvisioned error if not set.
 func (st *State) InstanceIds(tags []names.MachineTag) ([]params.StringResult, error) {
@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddSecret adds a secret with the specified name and values, owned by
+// the unit's application, and returns the ID of the secret. If the
+// application already owns a secret with the name, a new revision of
+// the secret is added.
+func (st *State) AddSecret(name string, values map[string]string) (string, error) {
+	if st.BestAPIVersion() < 9 {
+		return "", errors.NotImplementedf("AddSecret() (need V9+)")
+	}
+	args := params.SecretAddArgs{
+		Args: []params.SecretAddArg{{
+			UnitTag: st.unitTag.String(),
+			Name:    name,
+			Values:  values,
+		}},
+	}
+	var results params.StringResults
+	if err := st.facade.FacadeCall("SecretAdd", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// GetSecret returns the values of the specified revision of the secret
+// with the specified ID. If revision is zero, the values of the latest
+// revision are returned.
+func (st *State) GetSecret(id string, revision int) (map[string]string, error) {
+	if st.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("GetSecret() (need V9+)")
+	}
+	args := params.SecretGetArgs{
+		Args: []params.SecretGetArg{{
+			UnitTag:  st.unitTag.String(),
+			Id:       id,
+			Revision: revision,
+		}},
+	}
+	var results params.SecretValuesResults
+	if err := st.facade.FacadeCall("SecretGet", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Values, nil
+}
+
+// GrantSecret grants the secret with the specified ID to the application
+// at the other end of the specified relation. If unitName is not empty,
+// the secret is granted only to that unit of the application.
+func (st *State) GrantSecret(id string, relationId int, unitName string) error {
+	arg := params.SecretGrantArg{
+		UnitTag:    st.unitTag.String(),
+		Id:         id,
+		RelationId: relationId,
+	}
+	if unitName != "" {
+		if !names.IsValidUnit(unitName) {
+			return errors.NotValidf("unit name %q", unitName)
+		}
+		arg.GranteeTag = names.NewUnitTag(unitName).String()
+	}
+	if st.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("GrantSecret() (need V9+)")
+	}
+	args := params.SecretGrantArgs{Args: []params.SecretGrantArg{arg}}
+	var results params.ErrorResults
+	if err := st.facade.FacadeCall("SecretGrant", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestAddSecret(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(request, gc.Equals, "SecretAdd")
+		c.Assert(arg, jc.DeepEquals, params.SecretAddArgs{
+			Args: []params.SecretAddArg{{
+				UnitTag: "unit-mysql-0",
+				Name:    "password",
+				Values:  map[string]string{"root": "foo"},
+			}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "mysql/password"}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	id, err := st.AddSecret("password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "mysql/password")
+}
+
+func (s *secretsSuite) TestGetSecret(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SecretGet")
+		c.Assert(arg, jc.DeepEquals, params.SecretGetArgs{
+			Args: []params.SecretGetArg{{
+				UnitTag:  "unit-wordpress-0",
+				Id:       "mysql/password",
+				Revision: 2,
+			}},
+		})
+		*(result.(*params.SecretValuesResults)) = params.SecretValuesResults{
+			Results: []params.SecretValuesResult{{Values: map[string]string{"root": "foo"}}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	values, err := st.GetSecret("mysql/password", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"root": "foo"})
+}
+
+func (s *secretsSuite) TestGetSecretError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretValuesResults)) = params.SecretValuesResults{
+			Results: []params.SecretValuesResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	_, err := st.GetSecret("mysql/password", 0)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *secretsSuite) TestGrantSecret(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SecretGrant")
+		c.Assert(arg, jc.DeepEquals, params.SecretGrantArgs{
+			Args: []params.SecretGrantArg{{
+				UnitTag:    "unit-mysql-0",
+				Id:         "mysql/password",
+				RelationId: 1,
+				GranteeTag: "unit-wordpress-0",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	err := st.GrantSecret("mysql/password", 1, "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestGrantSecretInvalidUnit(c *gc.C) {
+	st := uniter.NewState(nil, names.NewUnitTag("mysql/0"))
+	err := st.GrantSecret("mysql/password", 1, "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unit name "wordpress" not valid`)
+}
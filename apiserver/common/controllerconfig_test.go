@@ -44,6 +44,8 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
 	}, nil
 }
 
@@ -65,6 +67,7 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	)
 	result, err := cc.ControllerConfig()
 	c.Assert(err, jc.ErrorIsNil)
+	// The backup storage credentials are not returned.
 	c.Assert(map[string]interface{}(result.Config), jc.DeepEquals, map[string]interface{}{
 		"ca-cert":         testing.CACert,
 		"controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
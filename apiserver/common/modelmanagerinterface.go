@@ -60,6 +60,7 @@ type ModelManagerBackend interface {
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
 	ExportPartial(state.ExportConfig) (description.Model, error)
+	UnmigratableEntities() ([]string, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
 	SetModelMeterStatus(string, string) error
 	ReloadSpaces(environ environs.Environ) error
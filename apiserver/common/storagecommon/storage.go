@@ -149,8 +149,9 @@ func volumeStorageAttachmentInfo(
 		return nil, errors.Trace(err)
 	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     volumeInfo.Size,
 	}, nil
 }
 
@@ -170,6 +171,10 @@ func filesystemStorageAttachmentInfo(
 	} else if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	filesystemAttachment, err := st.FilesystemAttachment(hostTag, filesystem.FilesystemTag())
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment")
@@ -179,8 +184,9 @@ func filesystemStorageAttachmentInfo(
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     filesystemInfo.Size,
 	}, nil
 }
 
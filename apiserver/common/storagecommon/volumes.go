@@ -55,9 +55,32 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		"",  // snapshot ID set by the caller
 	}, nil
 }
 
+// VolumeSnapshotId returns the storage provider's unique ID for the
+// volume snapshot that the volume is to be created from, or "" if the
+// volume is not to be created from a snapshot.
+func VolumeSnapshotId(
+	v state.Volume,
+	getSnapshot func(string) (state.VolumeSnapshot, error),
+) (string, error) {
+	stateVolumeParams, ok := v.Params()
+	if !ok || stateVolumeParams.Snapshot == "" {
+		return "", nil
+	}
+	snapshot, err := getSnapshot(stateVolumeParams.Snapshot)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	info, err := snapshot.Info()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.SnapshotId, nil
+}
+
 // StoragePoolConfig returns the storage provider type and
 // configuration for a named storage pool. If there is no
 // such pool with the specified name, but it identifies a
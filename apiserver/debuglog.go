@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -82,6 +83,11 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only send logs on or after this time
+//   endTime -> string - RFC3339 time, only send logs on or before this time
+//      - no new logs are waited for once this time has passed
+//   messageRegex -> string - only send logs whose message matches this
+//      regular expression, in the RE2 syntax of Go's regexp package
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -175,6 +181,8 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
 	startTime     time.Time
+	endTime       time.Time
+	messageRegex  string
 	maxLines      uint
 	fromTheStart  bool
 	noTail        bool
@@ -239,6 +247,26 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		params.endTime = endTime
+	}
+
+	if !params.endTime.IsZero() && params.endTime.Before(params.startTime) {
+		return params, errors.Errorf("end time %q is before start time %q",
+			params.endTime.Format(time.RFC3339Nano), params.startTime.Format(time.RFC3339Nano))
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("message regex %q is not valid: %v", value, err)
+		}
+		params.messageRegex = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 
@@ -37,11 +38,22 @@ func handleDebugLogDBRequest(
 	// Indicate that all is well.
 	socket.sendOk()
 
+	// If the requested time window ends in the future, stop tailing
+	// once it has been reached; nothing after it will match.
+	var endOfWindow <-chan time.Time
+	if !params.NoTail && !reqParams.endTime.IsZero() {
+		timer := time.NewTimer(reqParams.endTime.Sub(time.Now()))
+		defer timer.Stop()
+		endOfWindow = timer.C
+	}
+
 	var lineCount uint
 	for {
 		select {
 		case <-stop:
 			return nil
+		case <-endOfWindow:
+			return nil
 		case rec, ok := <-tailer.Logs():
 			if !ok {
 				return errors.Annotate(tailer.Err(), "tailer stopped")
@@ -64,6 +76,8 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
+		MessageRegex:  reqParams.messageRegex,
 		InitialLines:  int(reqParams.backlog),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
@@ -73,6 +87,11 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
 	}
+	if !reqParams.endTime.IsZero() && !reqParams.endTime.After(time.Now()) {
+		// There is no point waiting for new logs if the
+		// requested time window has already closed.
+		params.NoTail = true
+	}
 	return params
 }
 
@@ -84,6 +103,7 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		ModelUUID: r.ModelUUID,
 	}
 }
 
@@ -36,6 +36,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		noTail:        true,
 		backlog:       11,
 		startTime:     t1,
+		endTime:       t1.Add(time.Hour),
+		messageRegex:  "connection refused",
 		filterLevel:   loggo.INFO,
 		includeEntity: []string{"foo"},
 		includeModule: []string{"bar"},
@@ -50,6 +52,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		// Start time will be used once the client is extended to send
 		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t1.Add(time.Hour))
+		c.Assert(params.MessageRegex, gc.Equals, "connection refused")
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -91,6 +95,42 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionEndTimeInPast(c *gc.C) {
+	reqParams := debugLogParams{
+		endTime: time.Now().Add(-time.Minute),
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		// The time window has closed so there is nothing to tail.
+		c.Assert(params.NoTail, jc.IsTrue)
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestRequestStopsAtEndTime(c *gc.C) {
+	tailer := newFakeLogTailer()
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		c.Assert(params.NoTail, jc.IsFalse)
+		return tailer, nil
+	})
+
+	reqParams := debugLogParams{
+		endTime: time.Now().Add(100 * time.Millisecond),
+	}
+	done := s.runRequest(reqParams, make(chan struct{}))
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
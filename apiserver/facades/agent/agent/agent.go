@@ -122,6 +122,9 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		CAPrivateKey:   info.CAPrivateKey,
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
+		// The secrets key is not stored with the rest of the
+		// serving info, so it is passed on from this controller.
+		SecretsKey: string(api.st.SecretsKey()),
 	}
 
 	return result, nil
@@ -175,6 +175,11 @@ func (p *ProvisionerAPI) machineVolumeParams(
 		} else if !errors.IsNotProvisioned(err) {
 			return nil, nil, errors.Annotate(err, "getting volume info")
 		}
+		if stateVolumeParams, ok := volume.Params(); ok && stateVolumeParams.Snapshot != "" {
+			// Volumes are only created from snapshots by the
+			// storage provisioner, once the machine is running.
+			continue
+		}
 		stateVolumeAttachmentParams, volumeDetached := volumeAttachment.Params()
 		if !volumeDetached {
 			// Volume is already attached to the machine, so
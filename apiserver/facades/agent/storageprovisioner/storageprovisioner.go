@@ -22,6 +22,16 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv6 provides the StorageProvisioner API v6 facade.
+type StorageProvisionerAPIv6 struct {
+	*StorageProvisionerAPIv5
+}
+
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -47,6 +57,16 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv6 creates a new server-side StorageProvisioner v6 facade.
+func NewStorageProvisionerAPIv6(v5 *StorageProvisionerAPIv5) *StorageProvisionerAPIv6 {
+	return &StorageProvisionerAPIv6{v5}
+}
+
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -329,6 +349,26 @@ func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params
 		w.WatchUnitManagedFilesystems)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that requests to resize
+// them can be acted upon.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeResizes, s.sb.WatchMachineVolumeResizes, nil)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, so that requests to resize
+// them can be acted upon.
+func (s *StorageProvisionerAPIv5) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelFilesystemResizes, s.sb.WatchMachineFilesystemResizes, nil)
+}
+
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPIv6) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeSnapshots, s.sb.WatchMachineVolumeSnapshots, nil)
+}
+
 func (s *StorageProvisionerAPIv3) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -660,6 +700,10 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		volumeParams.SnapshotId, err = storagecommon.VolumeSnapshotId(volume, s.sb.VolumeSnapshot)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
 		if len(volumeAttachments) == 1 {
 			// There is exactly one attachment to be made, so make
 			// it immediately. Otherwise we will defer attachments
@@ -765,6 +809,225 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags. The size is zero for volumes that have no
+// resize pending.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		size, _ := volume.RequestedSize()
+		return params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  string(provider),
+			VolumeId:  volumeInfo.VolumeId,
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// getVolumeSnapshotAuthFunc returns a function that reports whether the
+// authenticated agent may access the volume snapshot with the given ID.
+// Snapshots are scoped in the same way as volumes, and their IDs take the
+// same form, so access is determined as for a volume with the same ID.
+func (s *StorageProvisionerAPIv6) getVolumeSnapshotAuthFunc() (func(string) bool, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return nil, err
+	}
+	return func(id string) bool {
+		if !state.IsValidVolumeSnapshotId(id) {
+			return false
+		}
+		return canAccess(names.NewVolumeTag(id))
+	}, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking or destroying
+// the volume snapshots with the specified IDs.
+func (s *StorageProvisionerAPIv6) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelConfig, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		if !canAccess(id) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		snapshot, err := s.sb.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		var snapshotId string
+		if info, err := snapshot.Info(); err == nil {
+			snapshotId = info.SnapshotId
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		// The volume may have been removed since the snapshot was
+		// taken, in which case the volume ID is left empty.
+		var volumeId string
+		volume, err := s.sb.Volume(snapshot.Volume())
+		if err == nil {
+			volumeInfo, err := volume.Info()
+			if err != nil {
+				return params.VolumeSnapshotParams{}, err
+			}
+			volumeId = volumeInfo.VolumeId
+		} else if !errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			snapshot.StorageInstance,
+			s.sb.StorageInstance,
+		)
+		if errors.IsNotFound(err) {
+			// The storage instance may be removed once the
+			// snapshot is taken; tag the snapshot with the
+			// model and controller only.
+			storageInstance = nil
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		snapshotTags, err := storagecommon.StorageTags(
+			storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(), modelConfig,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(
+			snapshot.Pool(), s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return params.VolumeSnapshotParams{
+			Id:         id,
+			Life:       params.Life(snapshot.Life().String()),
+			VolumeTag:  snapshot.Volume().String(),
+			VolumeId:   volumeId,
+			Provider:   string(providerType),
+			Attributes: cfg.Attrs(),
+			Tags:       snapshotTags,
+			SnapshotId: snapshotId,
+		}, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPIv6) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeSnapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		if !canAccess(arg.Id) {
+			return common.ErrPerm
+		}
+		err := s.sb.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.Info.SnapshotId,
+			Size:       arg.Info.Size,
+		})
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.VolumeSnapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state. The snapshots must not be alive, and their cloud
+// resources must already have been destroyed.
+func (s *StorageProvisionerAPIv6) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if !canAccess(id) {
+			return common.ErrPerm
+		}
+		return s.sb.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -879,6 +1142,66 @@ func (s *StorageProvisionerAPIv4) RemoveFilesystemParams(args params.Entities) (
 	return results, nil
 }
 
+// ResizeFilesystemParams returns the parameters for growing the
+// filesystems with the specified tags. The size is zero for filesystems
+// that have no resize pending.
+func (s *StorageProvisionerAPIv5) ResizeFilesystemParams(args params.Entities) (params.ResizeFilesystemParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeFilesystemParamsResults{}, err
+	}
+	results := params.ResizeFilesystemParamsResults{
+		Results: make([]params.ResizeFilesystemParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeFilesystemParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeFilesystemParams{}, common.ErrPerm
+		}
+		filesystem, err := s.sb.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeFilesystemParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		filesystemInfo, err := filesystem.Info()
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			filesystemInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		var volumeTag string
+		if tag, err := filesystem.Volume(); err == nil {
+			volumeTag = tag.String()
+		} else if errors.Cause(err) != state.ErrNoBackingVolume {
+			return params.ResizeFilesystemParams{}, err
+		}
+		size, _ := filesystem.RequestedSize()
+		return params.ResizeFilesystemParams{
+			FilesystemTag: tag.String(),
+			VolumeTag:     volumeTag,
+			Provider:      string(provider),
+			FilesystemId:  filesystemInfo.FilesystemId,
+			Size:          size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeFilesystemParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified IDs.
 func (s *StorageProvisionerAPIv3) VolumeAttachmentParams(
@@ -1159,6 +1482,14 @@ func (s *StorageProvisionerAPIv3) SetVolumeInfo(args params.Volumes) (params.Err
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		// The pool is not known to the provisioner. It is taken
+		// from the params when a volume is first provisioned, and
+		// must be carried over when a resized volume is updated.
+		if volume, err := s.sb.Volume(volumeTag); err == nil {
+			if oldInfo, err := volume.Info(); err == nil {
+				volumeInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.sb.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1188,6 +1519,15 @@ func (s *StorageProvisionerAPIv3) SetFilesystemInfo(args params.Filesystems) (pa
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		// The pool is not known to the provisioner. It is taken
+		// from the params when a filesystem is first provisioned,
+		// and must be carried over when a resized filesystem is
+		// updated.
+		if filesystem, err := s.sb.Filesystem(filesystemTag); err == nil {
+			if oldInfo, err := filesystem.Info(); err == nil {
+				filesystemInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.sb.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
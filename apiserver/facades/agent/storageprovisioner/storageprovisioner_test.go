@@ -53,7 +53,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv6
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -84,7 +84,9 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -116,7 +118,9 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -623,6 +627,150 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-0-0"},
+			{"volume-2"},
+			{"volume-1"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: params.ResizeVolumeParams{
+				VolumeTag: "volume-0-0",
+				Provider:  "machinescoped",
+				VolumeId:  "abc",
+				Size:      2048,
+			},
+		}, {
+			Result: params.ResizeVolumeParams{
+				VolumeTag: "volume-2",
+				Provider:  "modelscoped",
+				VolumeId:  "def",
+			},
+		}, {
+			Error: &params.Error{Message: `volume "1" not provisioned`, Code: "not provisioned"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshots(c *gc.C) {
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	storage, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage, gc.HasLen, 1)
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(storage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := sb.CreateStorageSnapshot(storage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectParams := params.VolumeSnapshotParams{
+		Id:        snapshot.Id(),
+		Life:      params.Alive,
+		VolumeTag: storageVolume.Tag().String(),
+		VolumeId:  "zing",
+		Provider:  "modelscoped",
+		Tags: map[string]string{
+			tags.JujuController:      testing.ControllerTag.Id(),
+			tags.JujuModel:           testing.ModelTag.Id(),
+			tags.JujuStorageInstance: "data/0",
+			tags.JujuStorageOwner:    unit.Name(),
+		},
+	}
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{snapshot.Id(), "42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: expectParams},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	errResults, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		VolumeSnapshots: []params.VolumeSnapshot{{
+			Id:   snapshot.Id(),
+			Info: params.VolumeSnapshotInfo{SnapshotId: "snap-zing", Size: 1},
+		}, {
+			Id:   "42",
+			Info: params.VolumeSnapshotInfo{SnapshotId: "snap-42"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+	snapshot, err = sb.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-zing", Size: 1})
+
+	// The snapshot must be dying before it can be removed.
+	errResults, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{snapshot.Id()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.Results[0].Error, gc.ErrorMatches, `cannot remove volume snapshot "0": volume snapshot is not dying`)
+
+	err = sb.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{snapshot.Id()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectParams.Life = params.Dying
+	expectParams.SnapshotId = "snap-zing"
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{Result: expectParams}})
+
+	errResults, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{snapshot.Id()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	_, err = sb.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
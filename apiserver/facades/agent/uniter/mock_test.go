@@ -21,6 +21,7 @@ type fakeStorage struct {
 	volumeAttachment       func(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
 	blockDevices           func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	watchVolumeAttachment  func(names.Tag, names.VolumeTag) state.NotifyWatcher
+	watchVolume            func(names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
 	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 }
@@ -50,6 +51,11 @@ func (s *fakeStorage) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) s
 	return s.watchVolumeAttachment(host, v)
 }
 
+func (s *fakeStorage) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchVolume", v)
+	return s.watchVolume(v)
+}
+
 func (s *fakeStorage) WatchBlockDevices(m names.MachineTag) state.NotifyWatcher {
 	s.MethodCall(s, "WatchBlockDevices", m)
 	return s.watchBlockDevices(m)
@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Mask the secrets methods from the v8 API. The API reflection code
+// in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the methods as far as the RPC machinery is concerned.
+
+// SecretAdd isn't on the v8 API.
+func (u *UniterAPIV8) SecretAdd(_, _ struct{}) {}
+
+// SecretGet isn't on the v8 API.
+func (u *UniterAPIV8) SecretGet(_, _ struct{}) {}
+
+// SecretGrant isn't on the v8 API.
+func (u *UniterAPIV8) SecretGrant(_, _ struct{}) {}
+
+// SecretAdd adds secrets owned by the applications of the specified
+// units, returning the IDs of the secrets. If an application already
+// owns a secret with the same name, a new revision of the secret is
+// added. Only the leader of an application may add its secrets.
+func (u *UniterAPI) SecretAdd(args params.SecretAddArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		id, err := u.addSecret(canAccess, arg)
+		result.Results[i].Result = id
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) addSecret(canAccess common.AuthFunc, arg params.SecretAddArg) (string, error) {
+	unitTag, err := u.checkSecretsLeader(canAccess, arg.UnitTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	secret, err := u.st.AddSecret(names.NewApplicationTag(appName), arg.Name, arg.Values)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return secret.Id(), nil
+}
+
+// SecretGet returns the values of the specified secret revisions.
+// A unit may read a secret only if it is owned by the unit's
+// application, or if it has been granted to the unit.
+func (u *UniterAPI) SecretGet(args params.SecretGetArgs) (params.SecretValuesResults, error) {
+	result := params.SecretValuesResults{
+		Results: make([]params.SecretValuesResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretValuesResults{}, err
+	}
+	for i, arg := range args.Args {
+		values, err := u.getSecret(canAccess, arg)
+		result.Results[i].Values = values
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) getSecret(canAccess common.AuthFunc, arg params.SecretGetArg) (map[string]string, error) {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil || !canAccess(unitTag) {
+		return nil, common.ErrPerm
+	}
+	ok, err := u.st.CanReadSecret(arg.Id, unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return u.st.SecretValues(arg.Id, arg.Revision)
+}
+
+// SecretGrant grants the specified secrets to the applications, or
+// individual units, at the other end of the specified relations.
+// Only the leader of the application owning a secret may grant it.
+func (u *UniterAPI) SecretGrant(args params.SecretGrantArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := u.grantSecret(canAccess, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) grantSecret(canAccess common.AuthFunc, arg params.SecretGrantArg) error {
+	unitTag, err := u.checkSecretsLeader(canAccess, arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	secret, err := u.st.Secret(arg.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Application() != appName {
+		return common.ErrPerm
+	}
+	rel, err := u.st.Relation(arg.RelationId)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var granteeUnit string
+	if arg.GranteeTag != "" {
+		granteeTag, err := names.ParseUnitTag(arg.GranteeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		granteeUnit = granteeTag.Id()
+	}
+	return u.st.GrantSecret(arg.Id, rel.String(), granteeUnit)
+}
+
+// checkSecretsLeader checks that the specified unit may be accessed,
+// and is the leader of its application.
+func (u *UniterAPI) checkSecretsLeader(canAccess common.AuthFunc, tag string) (names.UnitTag, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil || !canAccess(unitTag) {
+		return names.UnitTag{}, common.ErrPerm
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return names.UnitTag{}, errors.Trace(err)
+	}
+	token := u.leadershipChecker.LeadershipCheck(appName, unitTag.Id())
+	if err := token.Check(nil); err != nil {
+		return names.UnitTag{}, errors.Trace(err)
+	}
+	return unitTag, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/agent/uniter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type uniterSecretsSuite struct {
+	uniterSuiteBase
+}
+
+var _ = gc.Suite(&uniterSecretsSuite{})
+
+func (s *uniterSecretsSuite) claimLeadership(c *gc.C, appName, unitName string) {
+	err := s.State.LeadershipClaimer().ClaimLeadership(appName, unitName, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSecretsSuite) mysqlUniter(c *gc.C) *uniter.UniterAPI {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.mysqlUnit.Tag()}
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:             s.State,
+		Resources_:         s.resources,
+		Auth_:              authorizer,
+		LeadershipChecker_: s.State.LeadershipChecker(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *uniterSecretsSuite) TestSecretAdd(c *gc.C) {
+	s.claimLeadership(c, "wordpress", "wordpress/0")
+	result, err := s.uniter.SecretAdd(params.SecretAddArgs{Args: []params.SecretAddArg{{
+		UnitTag: "unit-wordpress-0",
+		Name:    "api-key",
+		Values:  map[string]string{"key": "foo"},
+	}, {
+		UnitTag: "unit-wordpress-0",
+		Name:    "api-key",
+		Values:  map[string]string{"key": "bar"},
+	}, {
+		UnitTag: "unit-mysql-0",
+		Name:    "password",
+		Values:  map[string]string{"root": "foo"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{Results: []params.StringResult{
+		{Result: "wordpress/api-key"},
+		{Result: "wordpress/api-key"},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	secret, err := s.State.Secret("wordpress/api-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+}
+
+func (s *uniterSecretsSuite) TestSecretAddNotLeader(c *gc.C) {
+	result, err := s.uniter.SecretAdd(params.SecretAddArgs{Args: []params.SecretAddArg{{
+		UnitTag: "unit-wordpress-0",
+		Name:    "api-key",
+		Values:  map[string]string{"key": "foo"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `"wordpress/0" is not leader of "wordpress"`)
+}
+
+func (s *uniterSecretsSuite) TestSecretGet(c *gc.C) {
+	_, err := s.State.AddSecret(s.wordpress.ApplicationTag(), "api-key", map[string]string{"key": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(s.wordpress.ApplicationTag(), "api-key", map[string]string{"key": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "baz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretGet(params.SecretGetArgs{Args: []params.SecretGetArg{
+		{UnitTag: "unit-wordpress-0", Id: "wordpress/api-key"},
+		{UnitTag: "unit-wordpress-0", Id: "wordpress/api-key", Revision: 1},
+		{UnitTag: "unit-wordpress-0", Id: "mysql/password"},
+		{UnitTag: "unit-mysql-0", Id: "wordpress/api-key"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValuesResults{Results: []params.SecretValuesResult{
+		{Values: map[string]string{"key": "bar"}},
+		{Values: map[string]string{"key": "foo"}},
+		{Error: apiservertesting.ErrUnauthorized},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+}
+
+func (s *uniterSecretsSuite) TestSecretGrant(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	s.claimLeadership(c, "mysql", "mysql/0")
+	mysqlUniter := s.mysqlUniter(c)
+
+	getArgs := params.SecretGetArgs{Args: []params.SecretGetArg{
+		{UnitTag: "unit-wordpress-0", Id: "mysql/password"},
+	}}
+	result, err := s.uniter.SecretGet(getArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	grantResult, err := mysqlUniter.SecretGrant(params.SecretGrantArgs{Args: []params.SecretGrantArg{{
+		UnitTag:    "unit-mysql-0",
+		Id:         "mysql/password",
+		RelationId: rel.Id(),
+		GranteeTag: "unit-wordpress-0",
+	}, {
+		UnitTag:    "unit-mysql-0",
+		Id:         "mysql/password",
+		RelationId: 123,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantResult, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	result, err = s.uniter.SecretGet(getArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0], jc.DeepEquals, params.SecretValuesResult{
+		Values: map[string]string{"root": "foo"},
+	})
+}
+
+func (s *uniterSecretsSuite) TestSecretGrantNotOwner(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	s.claimLeadership(c, "wordpress", "wordpress/0")
+
+	result, err := s.uniter.SecretGrant(params.SecretGrantArgs{Args: []params.SecretGrantArg{{
+		UnitTag:    "unit-wordpress-0",
+		Id:         "mysql/password",
+		RelationId: rel.Id(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+}
@@ -199,12 +199,13 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		ownerTag = owner.String()
 	}
 	return params.StorageAttachment{
-		stateStorageAttachment.StorageInstance().String(),
-		ownerTag,
-		stateStorageAttachment.Unit().String(),
-		params.StorageKind(stateStorageInstance.Kind()),
-		info.Location,
-		params.Life(stateStorageAttachment.Life().String()),
+		StorageTag: stateStorageAttachment.StorageInstance().String(),
+		OwnerTag:   ownerTag,
+		UnitTag:    stateStorageAttachment.Unit().String(),
+		Kind:       params.StorageKind(stateStorageInstance.Kind()),
+		Location:   info.Location,
+		Size:       info.Size,
+		Life:       params.Life(stateStorageAttachment.Life().String()),
 	}, nil
 }
 
@@ -432,7 +433,7 @@ func accessUnitTag(tag string, canAccess func(names.Tag) bool) (names.UnitTag, e
 
 // watchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the
-// tags specified.
+// tags specified, or to the size of the volume or filesystem.
 func watchStorageAttachment(
 	st storageInterface,
 	stVolume storageVolumeInterface,
@@ -460,6 +461,7 @@ func watchStorageAttachment(
 		// device could change (most likely, become present).
 		watchers = []state.NotifyWatcher{
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
+			stVolume.WatchVolume(volume.VolumeTag()),
 		}
 
 		// TODO(caas) - we currently only support block devices on machines.
@@ -483,6 +485,7 @@ func watchStorageAttachment(
 		}
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -35,7 +35,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v8) of the Uniter API.
+// UniterAPI implements the latest version (v9) of the Uniter API,
+// which adds the SecretAdd, SecretGet and SecretGrant methods.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -64,9 +65,15 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV8 doesn't have the SecretAdd, SecretGet or SecretGrant
+// methods.
+type UniterAPIV8 struct {
+	UniterAPI
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -253,14 +260,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(context facade.Context) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
 func NewUniterAPIV7(context facade.Context) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV8(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
@@ -730,6 +748,11 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
+	if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+		// A rolling charm upgrade may not yet have been released
+		// to the unit asking.
+		return application.UnitCharmModifiedVersion(unitTag.Id())
+	}
 	return application.CharmModifiedVersion(), nil
 }
 
@@ -754,10 +777,19 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				unitTag, isUnit := u.auth.GetAuthTag().(names.UnitTag)
+				if application, isApplication := unitOrApplication.(*state.Application); isApplication && isUnit {
+					// A rolling charm upgrade may not yet have
+					// been released to the unit asking.
+					curl, ok, err = application.UnitCharmURL(unitTag.Id())
+				} else {
+					charmURLer := unitOrApplication.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
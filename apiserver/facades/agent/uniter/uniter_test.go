@@ -931,6 +931,58 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLHeldByRollout(c *gc.C) {
+	heldUnit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+	oldVersion := s.wordpress.CharmModifiedVersion()
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:   newCharm,
+		Rollout: &state.CharmRolloutParams{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	heldUniter, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:             s.State,
+		Resources_:         s.resources,
+		Auth_:              apiservertesting.FakeAuthorizer{Tag: heldUnit.Tag()},
+		LeadershipChecker_: s.State.LeadershipChecker(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	for i, test := range []struct {
+		api     *uniter.UniterAPI
+		curl    string
+		version int
+	}{{
+		api:     s.uniter,
+		curl:    newCharm.String(),
+		version: oldVersion + 1,
+	}, {
+		api:     heldUniter,
+		curl:    s.wpCharm.String(),
+		version: oldVersion,
+	}} {
+		c.Logf("test %d", i)
+		urlResult, err := test.api.CharmURL(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(urlResult, gc.DeepEquals, params.StringBoolResults{
+			Results: []params.StringBoolResult{{Result: test.curl}},
+		})
+		versionResult, err := test.api.CharmModifiedVersion(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(versionResult, gc.DeepEquals, params.IntResults{
+			Results: []params.IntResult{{Result: test.version}},
+		})
+	}
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
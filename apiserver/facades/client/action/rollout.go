@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV3 provides the Action API facade for version 3.
+type ActionAPIV3 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV3 returns an initialized ActionAPIV3.
+func NewActionAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV3, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV3{api}, nil
+}
+
+// Mask the action rollout methods from the v3 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the methods as far as the RPC machinery is concerned.
+
+// AddActionRollouts isn't on the v3 API.
+func (a *ActionAPIV3) AddActionRollouts(_, _ struct{}) {}
+
+// ActionRollouts isn't on the v3 API.
+func (a *ActionAPIV3) ActionRollouts(_, _ struct{}) {}
+
+// AddActionRollouts adds rollouts that enqueue an action on every unit
+// of an application, in batches of at most the specified size. The
+// rollouts are progressed by the controller, so they continue after
+// the client disconnects.
+func (a *ActionAPI) AddActionRollouts(args params.AddActionRolloutArgs) (params.ActionRolloutResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	result := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Rollouts)),
+	}
+	for i, arg := range args.Rollouts {
+		rollout, err := a.addActionRollout(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details, err := a.actionRolloutDetails(rollout)
+		result.Results[i].Result = details
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (a *ActionAPI) addActionRollout(arg params.AddActionRolloutArg) (*state.ActionRollout, error) {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rollout, err := a.state.AddActionRollout(state.AddActionRolloutParams{
+		Application:    appTag.Id(),
+		Action:         arg.Action,
+		Parameters:     arg.Parameters,
+		MaxParallel:    arg.MaxParallel,
+		StopOnFailures: arg.StopOnFailures,
+	})
+	return rollout, errors.Trace(err)
+}
+
+// ActionRollouts returns the details of the action rollouts with the
+// specified IDs, including the actions they have enqueued so far.
+func (a *ActionAPI) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	result := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		rollout, err := a.state.ActionRollout(id)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details, err := a.actionRolloutDetails(rollout)
+		result.Results[i].Result = details
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (a *ActionAPI) actionRolloutDetails(rollout *state.ActionRollout) (*params.ActionRolloutDetails, error) {
+	details := &params.ActionRolloutDetails{
+		Id:             rollout.Id(),
+		ApplicationTag: names.NewApplicationTag(rollout.Application()).String(),
+		Action:         rollout.Action(),
+		Parameters:     rollout.Parameters(),
+		MaxParallel:    rollout.MaxParallel(),
+		StopOnFailures: rollout.StopOnFailures(),
+		Status:         string(rollout.Status()),
+		Created:        rollout.Created(),
+		Finished:       rollout.Finished(),
+		PendingUnits:   rollout.PendingUnits(),
+		FailedUnits:    rollout.FailedUnits(),
+	}
+	for _, id := range rollout.ActionIds() {
+		action, err := a.model.Action(id)
+		if errors.IsNotFound(err) {
+			// The action has been pruned.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details.Actions = append(details.Actions, common.MakeActionResult(receiverTag, action))
+	}
+	return details, nil
+}
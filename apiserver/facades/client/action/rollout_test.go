@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *actionSuite) TestAddActionRollouts(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.dummy})
+	}
+
+	result, err := s.action.AddActionRollouts(params.AddActionRolloutArgs{
+		Rollouts: []params.AddActionRolloutArg{{
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "snapshot",
+			Parameters:     map[string]interface{}{"outfile": "out.tar.bz2"},
+			MaxParallel:    2,
+			StopOnFailures: 1,
+		}, {
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "missing",
+		}, {
+			ApplicationTag: "unit-dummy-0",
+			Action:         "snapshot",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	details := result.Results[0].Result
+	c.Assert(details, gc.NotNil)
+	c.Assert(details.ApplicationTag, gc.Equals, "application-dummy")
+	c.Assert(details.Action, gc.Equals, "snapshot")
+	c.Assert(details.MaxParallel, gc.Equals, 2)
+	c.Assert(details.StopOnFailures, gc.Equals, 1)
+	c.Assert(details.Status, gc.Equals, "running")
+	c.Assert(details.PendingUnits, jc.DeepEquals, []string{"dummy/2"})
+	c.Assert(details.Actions, gc.HasLen, 2)
+	c.Assert(details.Actions[0].Action.Receiver, gc.Equals, "unit-dummy-0")
+	c.Assert(details.Actions[0].Status, gc.Equals, "pending")
+	c.Assert(details.Actions[1].Action.Receiver, gc.Equals, "unit-dummy-1")
+	c.Assert(result.Results[1].Error, gc.ErrorMatches,
+		`cannot add action rollout for application "dummy": action "missing" not defined on application "dummy"`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-dummy-0" is not a valid application tag`)
+
+	rollout, err := s.State.ActionRollout(details.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutRunning)
+}
+
+func (s *actionSuite) TestActionRollouts(c *gc.C) {
+	rollout, err := s.State.AddActionRollout(state.AddActionRolloutParams{
+		Application: "wordpress",
+		Action:      "fakeaction",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.action.ActionRollouts(params.ActionRolloutIds{
+		Ids: []string{rollout.Id(), "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	details := result.Results[0].Result
+	c.Assert(details, gc.NotNil)
+	c.Assert(details.Id, gc.Equals, rollout.Id())
+	c.Assert(details.ApplicationTag, gc.Equals, "application-wordpress")
+	c.Assert(details.Status, gc.Equals, "running")
+	c.Assert(details.Actions, gc.HasLen, 1)
+	c.Assert(details.Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *actionSuite) TestBlockAddActionRollouts(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionRollouts")
+	_, err := s.action.AddActionRollouts(params.AddActionRolloutArgs{})
+	s.AssertBlocked(c, err, "AddActionRollouts")
+}
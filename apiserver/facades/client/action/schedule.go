@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV2 provides the Action API facade for version 2.
+type ActionAPIV2 struct {
+	*ActionAPIV3
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// Mask the action schedule methods from the v2 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the methods as far as the RPC machinery is concerned.
+
+// AddActionSchedules isn't on the v2 API.
+func (a *ActionAPIV2) AddActionSchedules(_, _ struct{}) {}
+
+// ActionSchedules isn't on the v2 API.
+func (a *ActionAPIV2) ActionSchedules(_, _ struct{}) {}
+
+// PauseActionSchedules isn't on the v2 API.
+func (a *ActionAPIV2) PauseActionSchedules(_, _ struct{}) {}
+
+// ResumeActionSchedules isn't on the v2 API.
+func (a *ActionAPIV2) ResumeActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v2 API.
+func (a *ActionAPIV2) RemoveActionSchedules(_, _ struct{}) {}
+
+// AddActionSchedules adds schedules that enqueue actions on the units
+// of an application at regular intervals.
+func (a *ActionAPI) AddActionSchedules(args params.AddActionScheduleArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		result.Results[i].Error = common.ServerError(a.addActionSchedule(arg))
+	}
+	return result, nil
+}
+
+func (a *ActionAPI) addActionSchedule(arg params.AddActionScheduleArg) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = a.state.AddActionSchedule(state.AddActionScheduleParams{
+		Name:        arg.Name,
+		Application: appTag.Id(),
+		Action:      arg.Action,
+		Parameters:  arg.Parameters,
+		Cron:        arg.Cron,
+		Interval:    arg.Interval,
+		Target:      state.ActionScheduleTarget(arg.Target),
+	})
+	return errors.Trace(err)
+}
+
+// ActionSchedules returns the details of the named action schedules,
+// including their most recent runs. If no names are specified, all
+// action schedules in the model are returned.
+func (a *ActionAPI) ActionSchedules(args params.ActionScheduleNames) (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	if len(args.Names) == 0 {
+		schedules, err := a.state.AllActionSchedules()
+		if err != nil {
+			return params.ActionScheduleResults{}, errors.Trace(err)
+		}
+		result := params.ActionScheduleResults{
+			Results: make([]params.ActionScheduleResult, len(schedules)),
+		}
+		for i, schedule := range schedules {
+			details, err := actionScheduleDetails(schedule)
+			result.Results[i].Result = details
+			result.Results[i].Error = common.ServerError(err)
+		}
+		return result, nil
+	}
+
+	result := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		schedule, err := a.state.ActionSchedule(name)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details, err := actionScheduleDetails(schedule)
+		result.Results[i].Result = details
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func actionScheduleDetails(schedule *state.ActionSchedule) (*params.ActionScheduleDetails, error) {
+	runs, err := schedule.Runs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details := &params.ActionScheduleDetails{
+		Name:           schedule.Name(),
+		ApplicationTag: names.NewApplicationTag(schedule.Application()).String(),
+		Action:         schedule.Action(),
+		Parameters:     schedule.Parameters(),
+		Cron:           schedule.Cron(),
+		Interval:       schedule.Interval(),
+		Target:         string(schedule.Target()),
+		Paused:         schedule.Paused(),
+		Created:        schedule.Created(),
+		NextRun:        schedule.NextRun(),
+	}
+	for _, run := range runs {
+		actionTags := make([]string, len(run.ActionIds()))
+		for i, id := range run.ActionIds() {
+			actionTags[i] = names.NewActionTag(id).String()
+		}
+		details.Runs = append(details.Runs, params.ActionScheduleRun{
+			Run:          run.Run(),
+			Started:      run.Started(),
+			ActionTags:   actionTags,
+			PendingUnits: run.PendingUnits(),
+		})
+	}
+	return details, nil
+}
+
+// PauseActionSchedules pauses the named action schedules, so that
+// they do not enqueue actions until resumed.
+func (a *ActionAPI) PauseActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, a.state.PauseActionSchedule)
+}
+
+// ResumeActionSchedules resumes the named paused action schedules.
+func (a *ActionAPI) ResumeActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, a.state.ResumeActionSchedule)
+}
+
+// RemoveActionSchedules removes the named action schedules, along
+// with the record of their runs. Actions already enqueued by the
+// schedules are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, a.state.RemoveActionSchedule)
+}
+
+func (a *ActionAPI) updateActionSchedules(args params.ActionScheduleNames, update func(string) error) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		result.Results[i].Error = common.ServerError(update(name))
+	}
+	return result, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) addSnapshotSchedule(c *gc.C, name string) {
+	result, err := s.action.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Name:           name,
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "snapshot",
+			Parameters:     map[string]interface{}{"outfile": "out.tar.bz2"},
+			Interval:       time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
+func (s *actionSuite) TestAddActionSchedules(c *gc.C) {
+	result, err := s.action.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Name:           "nightly",
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "snapshot",
+			Cron:           "@daily",
+			Target:         "leader",
+		}, {
+			Name:           "hourly",
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "snapshot",
+			Cron:           "@hourly",
+			Interval:       time.Hour,
+		}, {
+			Name:           "missing",
+			ApplicationTag: s.dummy.Tag().String(),
+			Action:         "missing",
+			Interval:       time.Hour,
+		}, {
+			Name:           "bad-tag",
+			ApplicationTag: "unit-dummy-0",
+			Action:         "snapshot",
+			Interval:       time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches,
+		`cannot add action schedule "hourly": cron specification and interval are mutually exclusive`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches,
+		`cannot add action schedule "missing": action "missing" not defined on application "dummy"`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `"unit-dummy-0" is not a valid application tag`)
+
+	schedule, err := s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Application(), gc.Equals, "dummy")
+	c.Assert(schedule.Cron(), gc.Equals, "@daily")
+	c.Assert(string(schedule.Target()), gc.Equals, "leader")
+}
+
+func (s *actionSuite) TestActionSchedules(c *gc.C) {
+	s.addSnapshotSchedule(c, "backup")
+	s.addSnapshotSchedule(c, "another-backup")
+
+	result, err := s.action.ActionSchedules(params.ActionScheduleNames{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Name, gc.Equals, "another-backup")
+	c.Assert(result.Results[1].Result.Name, gc.Equals, "backup")
+
+	result, err = s.action.ActionSchedules(params.ActionScheduleNames{
+		Names: []string{"backup", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	details := result.Results[0].Result
+	c.Assert(details, gc.NotNil)
+	c.Assert(details.Name, gc.Equals, "backup")
+	c.Assert(details.ApplicationTag, gc.Equals, "application-dummy")
+	c.Assert(details.Action, gc.Equals, "snapshot")
+	c.Assert(details.Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "out.tar.bz2"})
+	c.Assert(details.Interval, gc.Equals, time.Hour)
+	c.Assert(details.Target, gc.Equals, "all")
+	c.Assert(details.Paused, jc.IsFalse)
+	c.Assert(details.Runs, gc.HasLen, 0)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *actionSuite) TestPauseResumeActionSchedules(c *gc.C) {
+	s.addSnapshotSchedule(c, "backup")
+	args := params.ActionScheduleNames{Names: []string{"backup", "missing"}}
+
+	result, err := s.action.PauseActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	schedule, err := s.State.ActionSchedule("backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsTrue)
+
+	result, err = s.action.ResumeActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	schedule, err = s.State.ActionSchedule("backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsFalse)
+}
+
+func (s *actionSuite) TestRemoveActionSchedules(c *gc.C) {
+	s.addSnapshotSchedule(c, "backup")
+
+	result, err := s.action.RemoveActionSchedules(params.ActionScheduleNames{
+		Names: []string{"backup"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule("backup")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *actionSuite) TestBlockAddActionSchedules(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionSchedules")
+	_, err := s.action.AddActionSchedules(params.AddActionScheduleArgs{})
+	s.AssertBlocked(c, err, "AddActionSchedules")
+}
+
+func (s *actionSuite) TestBlockRemoveActionSchedules(c *gc.C) {
+	s.BlockAllChanges(c, "RemoveActionSchedules")
+	_, err := s.action.RemoveActionSchedules(params.ActionScheduleNames{})
+	s.AssertBlocked(c, err, "RemoveActionSchedules")
+}
@@ -62,6 +62,11 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
 	*APIBase
 }
 
@@ -130,13 +135,23 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 }
 
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -335,6 +350,40 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	return AddTrustSchemaAndDefaults(schema, defaults)
 }
 
+// validateApplicationConfigChanges returns an error if the application
+// config resulting from the specified changes is not valid for the
+// provider. Only CAAS models have provider specific validation.
+func (api *APIBase) validateApplicationConfigChanges(
+	app Application,
+	changes application.ConfigAttributes,
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	if api.modelType != state.ModelTypeCAAS {
+		return nil
+	}
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attrs := make(map[string]interface{})
+	for k, v := range current {
+		attrs[k] = v
+	}
+	for k, v := range changes {
+		attrs[k] = v
+	}
+	for _, k := range reset {
+		delete(attrs, k)
+	}
+	config, err := application.NewConfig(attrs, schema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k8s.ValidateApplicationConfig(config.Attributes()))
+}
+
 func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[string]string) (
 	appCfg map[string]interface{},
 	charmCfg map[string]string,
@@ -427,6 +476,11 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if modelType == state.ModelTypeCAAS {
+		if err := k8s.ValidateApplicationConfig(applicationConfig.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var settings = make(charm.Settings)
 	if len(args.ConfigYAML) > 0 {
@@ -559,6 +613,7 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // rollout
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -659,6 +714,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.Rollout,
 	)
 }
 
@@ -716,7 +772,11 @@ func (api *APIBase) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rollout *params.CharmRolloutParams,
 ) error {
+	if rollout != nil && api.modelType == state.ModelTypeCAAS {
+		return errors.NotSupportedf("rolling charm upgrades on a container model")
+	}
 	curl, err := charm.ParseURL(url)
 	if err != nil {
 		return errors.Trace(err)
@@ -738,7 +798,10 @@ func (api *APIBase) applicationSetCharm(
 	if len(storageConstraints) > 0 {
 		stateStorageConstraints = make(map[string]state.StorageConstraints)
 		for name, cons := range storageConstraints {
-			stateCons := state.StorageConstraints{Pool: cons.Pool}
+			stateCons := state.StorageConstraints{
+				Pool:     cons.Pool,
+				Snapshot: cons.Snapshot,
+			}
 			if cons.Size != nil {
 				stateCons.Size = *cons.Size
 			}
@@ -757,6 +820,13 @@ func (api *APIBase) applicationSetCharm(
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
+	if rollout != nil {
+		cfg.Rollout = &state.CharmRolloutParams{
+			BatchSize:   rollout.BatchSize,
+			ReadyStatus: status.Status(rollout.ReadyStatus),
+			OnError:     state.CharmRolloutOnError(rollout.OnError),
+		}
+	}
 	return application.SetCharm(cfg)
 }
 
@@ -1779,6 +1849,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := api.validateApplicationConfigChanges(app, appConfigAttrs, nil, schema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -1843,6 +1916,9 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 	}
 
 	if len(appConfigKeys) > 0 {
+		if err := api.validateApplicationConfigChanges(app, nil, appConfigKeys, schema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(nil, appConfigKeys, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -39,7 +41,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv8
+	api          *application.APIv9
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -61,7 +63,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv9{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -237,6 +239,113 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmRollout(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Rollout: &params.CharmRolloutParams{
+			BatchSize:   2,
+			ReadyStatus: "maintenance",
+			OnError:     "rollback",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+		Rollout: &state.CharmRolloutParams{
+			BatchSize:   2,
+			ReadyStatus: status.Maintenance,
+			OnError:     state.CharmRolloutRollback,
+		},
+	})
+}
+
+func (s *ApplicationSuite) TestSetCharmRolloutCAASModel(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Rollout:         &params.CharmRolloutParams{BatchSize: 2},
+	})
+	c.Assert(err, gc.ErrorMatches, "rolling charm upgrades on a container model not supported")
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestCharmRollouts(c *gc.C) {
+	started := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].rollout = &mockCharmRollout{
+		application:   "postgresql",
+		from:          "cs:postgresql-1",
+		to:            "cs:postgresql-2",
+		status:        state.CharmRolloutPaused,
+		message:       "unit postgresql/0 in error state",
+		started:       started,
+		releasedUnits: []string{"postgresql/0", "postgresql/1"},
+		pendingUnits:  []string{"postgresql/2"},
+	}
+	results, err := s.api.CharmRollouts(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-postgresql-subordinate"},
+		{Tag: "unit-postgresql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CharmRolloutResults{
+		Results: []params.CharmRolloutResult{{
+			Result: &params.CharmRolloutDetails{
+				ApplicationTag: "application-postgresql",
+				FromCharmURL:   "cs:postgresql-1",
+				ToCharmURL:     "cs:postgresql-2",
+				BatchSize:      2,
+				ReadyStatus:    "active",
+				OnError:        "pause",
+				Status:         "paused",
+				Message:        "unit postgresql/0 in error state",
+				Started:        started,
+				ReleasedUnits:  []string{"postgresql/0", "postgresql/1"},
+				PendingUnits:   []string{"postgresql/2"},
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `charm rollout for application "postgresql-subordinate" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-postgresql-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) TestResumeCharmRollouts(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(nil, errors.New("rollout is running, not paused"))
+	results, err := s.api.ResumeCharmRollouts(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "rollout is running, not paused"}},
+		},
+	})
+	app.CheckCallNames(c, "ResumeCharmRollout", "ResumeCharmRollout")
+}
+
+func (s *ApplicationSuite) TestResumeCharmRolloutsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ResumeCharmRollouts(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -496,6 +605,22 @@ func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, "Placement may not be specified for caas models")
 }
 
+func (s *ApplicationSuite) TestDeployCAASModelInvalidAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Config:          map[string]string{"kubernetes-autoscaling-max-units": "3"},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "autoscaling without kubernetes-autoscaling-target-cpu or kubernetes-autoscaling-metric not valid")
+}
+
 func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	results, err := s.api.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "postgresql",
@@ -1073,7 +1198,7 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1081,10 +1206,43 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 2, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-units": 3,
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"kubernetes-autoscaling-min-units": "5"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "kubernetes-autoscaling-max-units 3 less than kubernetes-autoscaling-min-units 5 not valid")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestUnsetApplicationConfigInvalidAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-units":  3,
+		"kubernetes-autoscaling-target-cpu": 80,
+	}
+	result, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
+		Args: []params.ApplicationUnset{{
+			ApplicationName: "postgresql",
+			Options:         []string{"kubernetes-autoscaling-target-cpu"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "autoscaling without kubernetes-autoscaling-target-cpu or kubernetes-autoscaling-metric not valid")
+	app.CheckCallNames(c, "ApplicationConfig")
 }
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
@@ -1117,7 +1275,7 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1125,9 +1283,9 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
+	app.CheckCall(c, 2, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
 }
 
 func (s *ApplicationSuite) TestBlockUnsetApplicationConfig(c *gc.C) {
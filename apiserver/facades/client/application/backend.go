@@ -4,6 +4,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/schema"
 	"gopkg.in/juju/charm.v6"
 	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
@@ -61,6 +63,7 @@ type Application interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
+	CharmRollout() (CharmRollout, error)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
 	ClearExposed() error
@@ -70,6 +73,7 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	ResumeCharmRollout() error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
@@ -91,6 +95,25 @@ type Charm interface {
 	charm.Charm
 }
 
+// CharmRollout defines a subset of the functionality provided by the
+// state.CharmRollout type, as required by the application facade. For
+// details on the methods, see the methods on state.CharmRollout with
+// the same names.
+type CharmRollout interface {
+	Application() string
+	FromCharmURL() string
+	ToCharmURL() string
+	BatchSize() int
+	ReadyStatus() status.Status
+	OnError() state.CharmRolloutOnError
+	Status() state.CharmRolloutStatus
+	Message() string
+	Started() time.Time
+	Finished() time.Time
+	ReleasedUnits() []string
+	PendingUnits() []string
+}
+
 // Machine defines a subset of the functionality provided by the
 // state.Machine type, as required by the application facade. For
 // details on the methods, see the methods on state.Machine with
@@ -348,6 +371,14 @@ func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	return ch, force, nil
 }
 
+func (a stateApplicationShim) CharmRollout() (CharmRollout, error) {
+	rollout, err := a.Application.CharmRollout()
+	if err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
 func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	units, err := a.Application.AllUnits()
 	if err != nil {
@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Mask the charm rollout methods from the v8 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the methods as far as the RPC machinery is concerned.
+
+// CharmRollouts isn't on the v8 API.
+func (u *APIv8) CharmRollouts(_, _ struct{}) {}
+
+// ResumeCharmRollouts isn't on the v8 API.
+func (u *APIv8) ResumeCharmRollouts(_, _ struct{}) {}
+
+// CharmRollouts returns the details of the most recent rolling charm
+// upgrade of each of the specified applications.
+func (api *APIBase) CharmRollouts(args params.Entities) (params.CharmRolloutResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmRolloutResults{}, errors.Trace(err)
+	}
+	results := params.CharmRolloutResults{
+		Results: make([]params.CharmRolloutResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		details, err := api.charmRollout(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+func (api *APIBase) charmRollout(tagString string) (*params.CharmRolloutDetails, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rollout, err := app.CharmRollout()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.CharmRolloutDetails{
+		ApplicationTag: tag.String(),
+		FromCharmURL:   rollout.FromCharmURL(),
+		ToCharmURL:     rollout.ToCharmURL(),
+		BatchSize:      rollout.BatchSize(),
+		ReadyStatus:    rollout.ReadyStatus().String(),
+		OnError:        string(rollout.OnError()),
+		Status:         string(rollout.Status()),
+		Message:        rollout.Message(),
+		Started:        rollout.Started(),
+		Finished:       rollout.Finished(),
+		ReleasedUnits:  rollout.ReleasedUnits(),
+		PendingUnits:   rollout.PendingUnits(),
+	}, nil
+}
+
+// ResumeCharmRollouts resumes the paused rolling charm upgrades of the
+// specified applications.
+func (api *APIBase) ResumeCharmRollouts(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(app.ResumeCharmRollout())
+	}
+	return results, nil
+}
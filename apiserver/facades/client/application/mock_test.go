@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -30,6 +31,29 @@ import (
 	coretesting "github.com/juju/juju/testing"
 )
 
+type mockCharmRollout struct {
+	application   string
+	from, to      string
+	status        state.CharmRolloutStatus
+	message       string
+	started       time.Time
+	releasedUnits []string
+	pendingUnits  []string
+}
+
+func (r *mockCharmRollout) Application() string                { return r.application }
+func (r *mockCharmRollout) FromCharmURL() string               { return r.from }
+func (r *mockCharmRollout) ToCharmURL() string                 { return r.to }
+func (r *mockCharmRollout) BatchSize() int                     { return 2 }
+func (r *mockCharmRollout) ReadyStatus() status.Status         { return status.Active }
+func (r *mockCharmRollout) OnError() state.CharmRolloutOnError { return state.CharmRolloutPause }
+func (r *mockCharmRollout) Status() state.CharmRolloutStatus   { return r.status }
+func (r *mockCharmRollout) Message() string                    { return r.message }
+func (r *mockCharmRollout) Started() time.Time                 { return r.started }
+func (r *mockCharmRollout) Finished() time.Time                { return time.Time{} }
+func (r *mockCharmRollout) ReleasedUnits() []string            { return r.releasedUnits }
+func (r *mockCharmRollout) PendingUnits() []string             { return r.pendingUnits }
+
 type mockEnviron struct {
 	environs.NetworkingEnviron
 
@@ -78,6 +102,7 @@ type mockApplication struct {
 	units       []*mockUnit
 	addedUnit   mockUnit
 	config      coreapplication.ConfigAttributes
+	rollout     *mockCharmRollout
 }
 
 func (m *mockApplication) Name() string {
@@ -117,6 +142,22 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) CharmRollout() (application.CharmRollout, error) {
+	a.MethodCall(a, "CharmRollout")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	if a.rollout == nil {
+		return nil, errors.NotFoundf("charm rollout for application %q", a.name)
+	}
+	return a.rollout, nil
+}
+
+func (a *mockApplication) ResumeCharmRollout() error {
+	a.MethodCall(a, "ResumeCharmRollout")
+	return a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
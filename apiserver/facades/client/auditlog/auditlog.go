@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for querying the audit
+// log kept by a controller machine.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+)
+
+// API provides access to the audit log of the controller machine
+// serving the API connection. Each controller machine keeps its own
+// audit log, so clients wanting the whole audit log of an HA
+// controller need to query each controller machine in turn.
+type API struct {
+	logDir    string
+	machineID string
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State().ControllerTag(), ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new AuditLog API facade. Only controller
+// superusers may read the audit log.
+func NewAPI(
+	controllerTag names.ControllerTag,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+
+	logDir, err := extractResourceValue(resources, "logDir")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineID, err := extractResourceValue(resources, "machineID")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		logDir:    logDir,
+		machineID: machineID,
+	}, nil
+}
+
+func extractResourceValue(resources facade.Resources, key string) (string, error) {
+	res := resources.Get(key)
+	strRes, ok := res.(common.StringResource)
+	if !ok {
+		return "", errors.Errorf("invalid %s resource: %v", key, res)
+	}
+	return strRes.String(), nil
+}
+
+// Query returns the entries in this controller machine's audit log
+// which match the given filters.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	filter := auditlog.Filter{
+		User:       args.User,
+		Model:      args.Model,
+		Facade:     args.Facade,
+		Method:     args.Method,
+		ErrorsOnly: args.ErrorsOnly,
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	entries, err := auditlog.ReadLog(api.logDir, filter)
+	if err != nil {
+		return params.AuditLogQueryResult{}, errors.Trace(err)
+	}
+	result := params.AuditLogQueryResult{
+		ControllerMachine: api.machineID,
+		Entries:           make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = convertEntry(entry)
+	}
+	return result, nil
+}
+
+func convertEntry(entry auditlog.Entry) params.AuditLogEntry {
+	c := entry.Conversation
+	result := params.AuditLogEntry{
+		Conversation: params.AuditLogConversation{
+			Who:            c.Who,
+			What:           c.What,
+			When:           c.When,
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+		},
+	}
+	for _, req := range entry.Requests {
+		r := req.Request
+		request := params.AuditLogRequest{
+			RequestID: r.RequestID,
+			When:      r.When,
+			Facade:    r.Facade,
+			Method:    r.Method,
+			Version:   r.Version,
+			Args:      r.Args,
+		}
+		for _, err := range req.Errors {
+			// A nil error records a successful response.
+			if err != nil {
+				request.Errors = append(request.Errors, params.AuditLogError{
+					Message: err.Message,
+					Code:    err.Code,
+				})
+			}
+		}
+		result.Requests = append(result.Requests, request)
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	apiauditlog "github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	logDir     string
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.logDir = c.MkDir()
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	err := s.resources.RegisterNamed("logDir", common.StringResource(s.logDir))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.resources.RegisterNamed("machineID", common.StringResource("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+
+	logFile := auditlog.NewLogFile(s.logDir, 300, 10)
+	defer logFile.Close()
+	err = logFile.AddConversation(auditlog.Conversation{
+		Who:            "mary",
+		What:           "juju deploy",
+		When:           "2018-10-01T09:00:00Z",
+		ModelName:      "default",
+		ModelUUID:      "deadbeef",
+		ConversationID: "c1",
+		ConnectionID:   "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i, method := range []string{"Deploy", "AddRelation"} {
+		requestID := uint64(i + 1)
+		err = logFile.AddRequest(auditlog.Request{
+			ConversationID: "c1",
+			ConnectionID:   "1",
+			RequestID:      requestID,
+			When:           "2018-10-01T09:00:01Z",
+			Facade:         "Application",
+			Method:         method,
+			Version:        6,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-10-01T09:00:02Z",
+		Errors:         []*auditlog.Error{nil},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      2,
+		When:           "2018-10-01T09:00:02Z",
+		Errors:         []*auditlog.Error{{Message: "no such application", Code: "not found"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *apiauditlog.API {
+	api, err := apiauditlog.NewAPI(coretesting.ControllerTag, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *auditLogSuite) TestNonSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-bob")
+	_, err := apiauditlog.NewAPI(coretesting.ControllerTag, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestNonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := apiauditlog.NewAPI(coretesting.ControllerTag, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	result, err := s.newAPI(c).Query(params.AuditLogQueryArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogQueryResult{
+		ControllerMachine: "2",
+		Entries: []params.AuditLogEntry{{
+			Conversation: params.AuditLogConversation{
+				Who:            "mary",
+				What:           "juju deploy",
+				When:           "2018-10-01T09:00:00Z",
+				ModelName:      "default",
+				ModelUUID:      "deadbeef",
+				ConversationID: "c1",
+				ConnectionID:   "1",
+			},
+			Requests: []params.AuditLogRequest{{
+				RequestID: 1,
+				When:      "2018-10-01T09:00:01Z",
+				Facade:    "Application",
+				Method:    "Deploy",
+				Version:   6,
+			}, {
+				RequestID: 2,
+				When:      "2018-10-01T09:00:01Z",
+				Facade:    "Application",
+				Method:    "AddRelation",
+				Version:   6,
+				Errors: []params.AuditLogError{{
+					Message: "no such application",
+					Code:    "not found",
+				}},
+			}},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryFilters(c *gc.C) {
+	after := time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC)
+	result, err := s.newAPI(c).Query(params.AuditLogQueryArgs{After: &after})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 0)
+
+	result, err = s.newAPI(c).Query(params.AuditLogQueryArgs{ErrorsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Entries[0].Requests, gc.HasLen, 1)
+	c.Assert(result.Entries[0].Requests[0].Method, gc.Equals, "AddRelation")
+}
@@ -124,9 +124,12 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
-var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+var newBackups = func(backend Backend) (backups.Backups, io.Closer, error) {
+	stor, err := backups.OpenStorage(backend)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // CreateResult updates the result with the information in the
@@ -31,15 +31,18 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 }
 
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
+	result := params.BackupsMetadataResult{}
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
 
-	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
+	err = waitUntilReady(session, 60)
 	if err != nil {
 		return result, errors.Annotatef(err, "HA not ready; try again later")
 	}
@@ -7,6 +7,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/juju/errors"
@@ -45,11 +46,24 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv6 provides the v6 Controller API. The only difference
+// between this and v7 is that v6 doesn't have the MigrationPrechecks
+// method.
+type ControllerAPIv6 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv5 provides the v5 Controller API. The only difference
+// between this and v6 is that v5 doesn't have the BackupStatus method.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
 // ControllerAPIv4 provides the v4 Controller API. The only difference
 // between this and v5 is that v4 doesn't have the
 // UpdateControllerConfig method.
 type ControllerAPIv4 struct {
-	*ControllerAPI
+	*ControllerAPIv5
 }
 
 // ControllerAPIv3 provides the v3 Controller API.
@@ -57,8 +71,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv5 creates a new ControllerAPIv5.
-func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -76,6 +90,24 @@ func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v5, err := NewControllerAPIv5(ctx)
@@ -428,41 +460,106 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationPrechecks runs all of the prechecks for the migration of
+// one or more models to other controllers, without starting the
+// migrations. Every problem found is reported, rather than just the
+// first.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		report, err := c.oneMigrationPrecheckReport(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Report = &params.MigrationPrecheckReport{
+				Blockers: report.Blockers,
+				Warnings: report.Warnings,
+			}
+		}
+	}
+	return out, nil
+}
+
+// MigrationPrechecks isn't on the v6 API.
+func (c *ControllerAPIv6) MigrationPrechecks(_, _ struct{}) {}
+
+func (c *ControllerAPI) oneMigrationPrecheckReport(spec params.MigrationSpec) (coremigration.PrecheckReport, error) {
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
+	if err != nil {
+		return coremigration.PrecheckReport{}, errors.Trace(err)
+	}
 	defer hostedState.Release()
+	return runMigrationPrecheckReport(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
+// migrationSpecState returns the state for the model to be migrated,
+// along with the details of the target controller, as described by
+// the migration spec. The returned state must be released.
+func (c *ControllerAPI) migrationSpecState(spec params.MigrationSpec) (
+	*state.PooledState, coremigration.TargetInfo, error,
+) {
+	var targetInfo coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, targetInfo, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, targetInfo, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, targetInfo, errors.NotFoundf("model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, targetInfo, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, targetInfo, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, targetInfo, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	targetInfo = coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
@@ -471,20 +568,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, targetInfo, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -557,6 +645,41 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// BackupStatus returns the schedule and outcome of the controller's
+// most recent scheduled backups.
+func (c *ControllerAPI) BackupStatus() (params.ControllerBackupStatus, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ControllerBackupStatus{}, errors.Trace(err)
+	}
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return params.ControllerBackupStatus{}, errors.Trace(err)
+	}
+	status, err := c.state.BackupStatus()
+	if err != nil {
+		return params.ControllerBackupStatus{}, errors.Trace(err)
+	}
+	result := params.ControllerBackupStatus{
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if schedule := cfg.BackupSchedule(); schedule > 0 {
+		result.Schedule = schedule.String()
+	}
+	if !status.LastSuccess.IsZero() {
+		lastSuccess := status.LastSuccess
+		result.LastSuccess = &lastSuccess
+	}
+	if !status.LastFailure.IsZero() {
+		lastFailure := status.LastFailure
+		result.LastFailure = &lastFailure
+	}
+	return result, nil
+}
+
+// BackupStatus isn't on the v5 API.
+func (c *ControllerAPIv5) BackupStatus(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
@@ -598,6 +721,68 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationPrecheckReport runs all of the source and target
+// prechecks for the migration and reports every problem found. Problems
+// connecting to the target controller are reported as blockers so that
+// the source checks are still seen.
+var runMigrationPrecheckReport = func(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence,
+) (coremigration.PrecheckReport, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return coremigration.PrecheckReport{}, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	report, err := migration.SourcePrecheckReport(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return report, errors.Annotate(err, "running source prechecks")
+	}
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+
+	// Check target controller.
+	addBlocker := func(format string, args ...interface{}) (coremigration.PrecheckReport, error) {
+		report.Blockers = append(report.Blockers, "target controller: "+fmt.Sprintf(format, args...))
+		return report, nil
+	}
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return addBlocker("cannot connect: %v", err)
+	}
+	defer conn.Close()
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		if _, err := client.CACert(); params.IsCodeNotImplemented(err) {
+			return addBlocker("controller API version is too old")
+		} else if err != nil {
+			return addBlocker("cannot retrieve CA certificate: %v", err)
+		}
+	}
+	targetReport, err := client.PrecheckReport(modelInfo)
+	if errors.IsNotSupported(err) {
+		// Older controllers can only report the first problem.
+		report.Warnings = append(report.Warnings,
+			"target controller: only the first blocker can be reported by this controller version")
+		if err := client.Prechecks(modelInfo); err != nil {
+			return addBlocker("%v", err)
+		}
+		return report, nil
+	} else if err != nil {
+		return report, errors.Annotate(err, "running target prechecks")
+	}
+	for _, blocker := range targetReport.Blockers {
+		report.Blockers = append(report.Blockers, "target controller: "+blocker)
+	}
+	for _, warning := range targetReport.Warnings {
+		report.Warnings = append(report.Warnings, "target controller: "+warning)
+	}
+	return report, nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -624,12 +809,25 @@ func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	}
 	controllerVersion, _ := controllerConfig.AgentVersion()
 
+	// Retrieve the users with access to the model.
+	modelUsers, err := model.Users()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	users := make([]names.UserTag, len(modelUsers))
+	for i, user := range modelUsers {
+		users[i] = user.UserTag
+	}
+
 	return coremigration.ModelInfo{
 		UUID:                   model.UUID(),
 		Name:                   model.Name(),
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		CloudName:              model.Cloud(),
+		CloudRegion:            model.CloudRegion(),
+		Users:                  users,
 	}, nil
 }
 
@@ -25,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -64,7 +65,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv7(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -570,6 +571,102 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckReport(s, coremigration.PrecheckReport{
+		Blockers: []string{"machine 0 is dying"},
+		Warnings: []string{"target controller: user \"bob\" is missing"},
+	}, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Report, jc.DeepEquals, &params.MigrationPrecheckReport{
+		Blockers: []string{"machine 0 is dying"},
+		Warnings: []string{"target controller: user \"bob\" is missing"},
+	})
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+	c.Check(out.Results[1].Report, gc.IsNil)
+
+	// Running the prechecks doesn't start a migration.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationPrechecksError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckReport(s, coremigration.PrecheckReport{}, errors.New("boom"))
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(out.Results[0].Report, gc.IsNil)
+}
+
+func (s *controllerSuite) TestMigrationPrechecksRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv7(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.MigrationPrechecks(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -931,7 +1028,7 @@ func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -968,3 +1065,51 @@ func (s *controllerSuite) TestConfigSetPublishesEvent(c *gc.C) {
 
 	c.Assert(config.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
 }
+
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.BackupSchedule: "24h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	succeeded := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	err = s.State.SetBackupSucceeded("backup-id", succeeded)
+	c.Assert(err, jc.ErrorIsNil)
+	failed := succeeded.Add(24 * time.Hour)
+	err = s.State.SetBackupFailed("disk full", failed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedule, gc.Equals, "24h0m0s")
+	c.Check(result.LastBackupID, gc.Equals, "backup-id")
+	c.Assert(result.LastSuccess, gc.NotNil)
+	c.Check(result.LastSuccess.Equal(succeeded), jc.IsTrue)
+	c.Assert(result.LastFailure, gc.NotNil)
+	c.Check(result.LastFailure.Equal(failed), jc.IsTrue)
+	c.Check(result.LastError, gc.Equals, "disk full")
+}
+
+func (s *controllerSuite) TestBackupStatusNoBackups(c *gc.C) {
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerBackupStatus{})
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
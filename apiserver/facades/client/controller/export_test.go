@@ -18,3 +18,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPrecheckReport(p patcher, report migration.PrecheckReport, err error) {
+	p.PatchValue(&runMigrationPrecheckReport, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) (migration.PrecheckReport, error) {
+		return report, err
+	})
+}
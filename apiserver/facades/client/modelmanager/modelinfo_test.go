@@ -559,6 +559,7 @@ type mockState struct {
 	block           state.BlockType
 	migration       *mockMigration
 	modelConfig     *config.Config
+	unmigratable    []string
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
 }
@@ -569,6 +570,14 @@ type fakeModelDescription struct {
 	UUID string `yaml:"model-uuid"`
 }
 
+func (*fakeModelDescription) Applications() []description.Application {
+	return nil
+}
+
+func (*fakeModelDescription) Machines() []description.Machine {
+	return nil
+}
+
 func (st *mockState) ModelUUID() string {
 	st.MethodCall(st, "ModelUUID")
 	return st.model.UUID()
@@ -597,6 +606,11 @@ func (st *mockState) ExportPartial(state.ExportConfig) (description.Model, error
 	return st.Export()
 }
 
+func (st *mockState) UnmigratableEntities() ([]string, error) {
+	st.MethodCall(st, "UnmigratableEntities")
+	return st.unmigratable, st.NextErr()
+}
+
 func (st *mockState) AllModelUUIDs() ([]string, error) {
 	st.MethodCall(st, "AllModelUUIDs")
 	return []string{st.model.UUID()}, st.NextErr()
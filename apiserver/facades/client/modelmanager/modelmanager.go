@@ -10,6 +10,7 @@ package modelmanager
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/description"
@@ -39,7 +40,21 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
-// ModelManagerV4 defines the methods on the version 2 facade for the
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ExportModels(args params.Entities) params.ExportedModelResults
+	ListModelSummaries(request params.ModelSummariesRequest) (params.ModelSummaryResults, error)
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+	ModelInfo(args params.Entities) (params.ModelInfoResults, error)
+	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
+}
+
+// ModelManagerV4 defines the methods on the version 4 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
@@ -90,10 +105,16 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
-// ModelManagerAPIV2 provides a way to wrap the different calls between
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV3 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV4
 }
 
 // ModelManagerAPIV2 provides a way to wrap the different calls between
@@ -103,13 +124,14 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -138,6 +160,15 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
 	v4, err := NewFacadeV4(ctx)
@@ -702,6 +733,95 @@ func (m *ModelManagerAPI) DumpModelsDB(args params.Entities) params.MapResults {
 	return results
 }
 
+// ExportModels serializes the specified models, along with lists of the
+// charms, agent binaries and resources they use, so that they can be
+// archived and imported into another controller. The user needs to
+// either be a controller admin, or have admin privileges on the model
+// itself.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.ExportedModelResults {
+	results := params.ExportedModelResults{
+		Results: make([]params.ExportedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		exported, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = exported
+	}
+	return results
+}
+
+// ExportModels isn't on the v4 API.
+func (m *ModelManagerAPIV4) ExportModels(_, _ struct{}) {}
+
+func (m *ModelManagerAPI) exportModel(args params.Entity) (*params.ExportedModel, error) {
+	modelTag, err := names.ParseModelTag(args.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isModelAdmin && !m.isAdmin {
+		return nil, common.ErrPerm
+	}
+
+	st, release, err := m.state.GetBackend(modelTag.Id())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.Trace(common.ErrBadId)
+		}
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return nil, errors.New("agent version not set in model config")
+	}
+
+	// As with migrations, models with entities which are not
+	// exported cannot be exported without losing them.
+	entities, err := st.UnmigratableEntities()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking for unmigratable entities")
+	}
+	if len(entities) > 0 {
+		return nil, errors.Errorf("model has %s, which exports don't support", strings.Join(entities, ", "))
+	}
+
+	description, err := st.Export()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	serialized, err := common.SerializeModel(description)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ExportedModel{
+		Info: params.MigrationModelInfo{
+			UUID:                   model.UUID(),
+			Name:                   model.Name(),
+			OwnerTag:               model.Owner().String(),
+			AgentVersion:           agentVersion,
+			ControllerAgentVersion: jujuversion.Current,
+		},
+		Model: serialized,
+	}, nil
+}
+
 // ListModelSummaries returns models that the specified user
 // has access to in the current server.  Controller admins (superuser)
 // can list models for any user.  Other users
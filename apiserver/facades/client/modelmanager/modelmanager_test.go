@@ -641,7 +641,7 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -781,6 +781,78 @@ func (s *modelManagerSuite) TestDumpModelsDBUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModels(c *gc.C) {
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{
+		Tag: "bad-tag",
+	}, {
+		Tag: "application-foo",
+	}, {
+		Tag: s.st.ModelTag().String(),
+	}}})
+
+	c.Assert(results.Results, gc.HasLen, 3)
+	bad, notApp, good := results.Results[0], results.Results[1], results.Results[2]
+	c.Check(bad.Result, gc.IsNil)
+	c.Check(bad.Error.Message, gc.Equals, `"bad-tag" is not a valid tag`)
+
+	c.Check(notApp.Result, gc.IsNil)
+	c.Check(notApp.Error.Message, gc.Equals, `"application-foo" is not a valid model tag`)
+
+	c.Assert(good.Error, gc.IsNil)
+	c.Check(good.Result, jc.DeepEquals, &params.ExportedModel{
+		Info: params.MigrationModelInfo{
+			UUID:                   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Name:                   "only",
+			OwnerTag:               "user-admin",
+			AgentVersion:           jujuversion.Current,
+			ControllerAgentVersion: jujuversion.Current,
+		},
+		Model: params.SerializedModel{
+			Bytes:  []byte("model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d\n"),
+			Charms: []string{},
+			Tools:  []params.SerializedModelTools{},
+		},
+	})
+}
+
+func (s *modelManagerSuite) TestExportModelsMissingModel(c *gc.C) {
+	s.st.SetErrors(errors.NotFoundf("boom"))
+	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000")
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: tag.String()}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, `not found`)
+	c.Check(result.Error.Message, gc.Equals, `id not found`)
+}
+
+func (s *modelManagerSuite) TestExportModelsUnmigratableEntities(c *gc.C) {
+	s.st.unmigratable = []string{"secrets (1)", "action schedules (2)"}
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `model has secrets (1), action schedules (2), which exports don't support`)
+}
+
+func (s *modelManagerSuite) TestExportModelsUsers(c *gc.C) {
+	models := params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}}
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		results := s.api.ExportModels(models)
+		c.Assert(results.Results, gc.HasLen, 1)
+		result := results.Results[0]
+		c.Assert(result.Result, gc.IsNil)
+		c.Assert(result.Error, gc.NotNil)
+		c.Check(result.Error.Message, gc.Equals, `permission denied`)
+	}
+}
+
 func (s *modelManagerSuite) TestAddModelCanCreateModel(c *gc.C) {
 	addModelUser := names.NewUserTag("add-model")
 	userAccess := permission.UserAccess{
@@ -821,7 +893,7 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -1586,7 +1658,7 @@ func (s *modelManagerStateSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 
 func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -1615,7 +1687,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the secrets
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllSecrets() ([]Secret, error)
+	RotateSecret(id string, values map[string]string) error
+}
+
+// Secret defines the secret functionality required by the secrets
+// facade. This is implemented by *state.Secret.
+type Secret interface {
+	Id() string
+	Application() string
+	Revision() int
+	Created() time.Time
+	Updated() time.Time
+	Grants() []state.SecretGrant
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the secrets facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) AllSecrets() ([]Secret, error) {
+	secrets, err := s.State.AllSecrets()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = secret
+	}
+	return result, nil
+}
+
+func (s stateShim) RotateSecret(id string, values map[string]string) error {
+	_, err := s.State.RotateSecret(id, values)
+	return err
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	secrets.Backend
+
+	modelUUID string
+	rotated   map[string]map[string]string
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	m.PopNoErr()
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) AllSecrets() ([]secrets.Secret, error) {
+	m.MethodCall(m, "AllSecrets")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return []secrets.Secret{&mockSecret{
+		id:          "mysql/password",
+		application: "mysql",
+		revision:    2,
+		grants: []state.SecretGrant{{
+			RelationId:  1,
+			RelationKey: "wordpress:db mysql:server",
+			Application: "wordpress",
+		}},
+	}}, nil
+}
+
+func (m *mockBackend) RotateSecret(id string, values map[string]string) error {
+	m.MethodCall(m, "RotateSecret", id, values)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.rotated[id] = values
+	return nil
+}
+
+type mockSecret struct {
+	id          string
+	application string
+	revision    int
+	grants      []state.SecretGrant
+}
+
+func (s *mockSecret) Id() string {
+	return s.id
+}
+
+func (s *mockSecret) Application() string {
+	return s.application
+}
+
+func (s *mockSecret) Revision() int {
+	return s.revision
+}
+
+func (s *mockSecret) Created() time.Time {
+	return time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *mockSecret) Updated() time.Time {
+	return time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *mockSecret) Grants() []state.SecretGrant {
+	return s.grants
+}
+
+type mockBlockChecker struct {
+	jtesting.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// API provides the secrets facade APIs for v1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(
+		NewStateBackend(ctx.State()),
+		ctx.Auth(),
+		common.NewBlockChecker(ctx.State()),
+	)
+}
+
+// NewAPI returns a new secrets API facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	blockChecker BlockChecker,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkAdmin() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.AdminAccess)
+}
+
+func (api *API) checkCanRead() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
+}
+
+// ListSecrets returns the details of all secrets in the model.
+// Secret values are never returned.
+func (api *API) ListSecrets() (params.ListSecretsResults, error) {
+	var listResults params.ListSecretsResults
+	if err := api.checkCanRead(); err != nil {
+		return listResults, errors.Trace(err)
+	}
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return listResults, errors.Trace(err)
+	}
+	listResults.Results = make([]params.SecretDetails, len(secrets))
+	for i, secret := range secrets {
+		details := params.SecretDetails{
+			Id:          secret.Id(),
+			Application: secret.Application(),
+			Revision:    secret.Revision(),
+			Created:     secret.Created(),
+			Updated:     secret.Updated(),
+		}
+		for _, grant := range secret.Grants() {
+			details.Grants = append(details.Grants, params.SecretGrantDetails{
+				RelationKey: grant.RelationKey,
+				Application: grant.Application,
+				Unit:        grant.Unit,
+			})
+		}
+		listResults.Results[i] = details
+	}
+	return listResults, nil
+}
+
+// RotateSecret adds new revisions of the specified secrets, holding
+// the specified values.
+func (api *API) RotateSecret(args params.RotateSecretArgs) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.backend.RotateSecret(arg.Id, arg.Values)
+		results[i].Error = common.ServerError(err)
+	}
+	errResults.Results = results
+	return errResults, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+
+	backend      mockBackend
+	blockChecker mockBlockChecker
+	authorizer   apiservertesting.FakeAuthorizer
+	api          *secrets.API
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+		rotated:   make(map[string]map[string]string),
+	}
+	s.blockChecker = mockBlockChecker{}
+	s.setAPIUser(c, names.NewUserTag("admin"))
+}
+
+func (s *SecretsSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer.Tag = user
+	api, err := secrets.NewAPI(
+		&s.backend,
+		s.authorizer,
+		&s.blockChecker,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *SecretsSuite) TestNewAPINonClient(c *gc.C) {
+	_, err := secrets.NewAPI(
+		&s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+		&s.blockChecker,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	result, err := s.api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListSecretsResults{
+		Results: []params.SecretDetails{{
+			Id:          "mysql/password",
+			Application: "mysql",
+			Revision:    2,
+			Created:     time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+			Grants: []params.SecretGrantDetails{{
+				RelationKey: "wordpress:db mysql:server",
+				Application: "wordpress",
+			}},
+		}},
+	})
+}
+
+func (s *SecretsSuite) TestListSecretsPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
+
+func (s *SecretsSuite) TestRotateSecret(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.NotFoundf(`secret "mysql/missing"`))
+	result, err := s.api.RotateSecret(params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Id:     "mysql/password",
+			Values: map[string]string{"root": "foo"},
+		}, {
+			Id:     "mysql/missing",
+			Values: map[string]string{"root": "foo"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `secret "mysql/missing" not found`)
+	c.Assert(s.backend.rotated, jc.DeepEquals, map[string]map[string]string{
+		"mysql/password": {"root": "foo"},
+	})
+}
+
+func (s *SecretsSuite) TestRotateSecretPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.RotateSecret(params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Id:     "mysql/password",
+			Values: map[string]string{"root": "foo"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+	c.Assert(s.backend.rotated, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRotateSecretBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.RotateSecret(params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Id:     "mysql/password",
+			Values: map[string]string{"root": "foo"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	c.Assert(s.backend.rotated, gc.HasLen, 0)
+}
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -26,7 +28,7 @@ type baseStorageSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 
-	api             *storage.APIv4
+	api             *storage.APIv6
 	apiv3           *storage.APIv3
 	storageAccessor *mockStorageAccessor
 	state           *mockState
@@ -39,6 +41,7 @@ type baseStorageSuite struct {
 	volumeTag            names.VolumeTag
 	volume               *mockVolume
 	volumeAttachment     *mockVolumeAttachment
+	volumeSnapshot       *mockVolumeSnapshot
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
@@ -66,7 +69,7 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 
 	s.callContext = context.NewCloudCallContext()
 	var err error
-	s.api, err = storage.NewAPIv4(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
+	s.api, err = storage.NewAPIv6(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
 	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
 	c.Assert(err, jc.ErrorIsNil)
@@ -99,6 +102,10 @@ const (
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	createStorageSnapshotCall               = "createStorageSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
 	addExistingFilesystemCall               = "addExistingFilesystem"
 )
 
@@ -150,6 +157,14 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 		HostTag:   s.machineTag,
 		life:      state.Alive,
 	}
+	s.volumeSnapshot = &mockVolumeSnapshot{
+		id:      "0",
+		volume:  s.volumeTag,
+		storage: &s.storageTag,
+		pool:    "loop",
+		created: time.Date(2018, 10, 16, 9, 30, 0, 0, time.UTC),
+		life:    state.Alive,
+	}
 
 	return &mockStorageAccessor{
 		allStorageInstances: func() ([]state.StorageInstance, error) {
@@ -279,10 +294,29 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached)
 			return errors.New("cannae do it")
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		createStorageSnapshot: func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+			s.stub.AddCall(createStorageSnapshotCall, tag)
+			if tag == s.storageTag {
+				return s.volumeSnapshot, nil
+			}
+			return nil, errors.NotFoundf("%s", names.ReadableString(tag))
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, nil
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.stub.AddCall(destroyVolumeSnapshotCall, id)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -58,9 +60,13 @@ type mockStorageAccessor struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	createStorageSnapshot               func(names.StorageTag) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -158,6 +164,10 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -166,6 +176,63 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockStorageAccessor) CreateStorageSnapshot(tag names.StorageTag) (state.VolumeSnapshot, error) {
+	return st.createStorageSnapshot(tag)
+}
+
+func (st *mockStorageAccessor) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockStorageAccessor) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	volume  names.VolumeTag
+	storage *names.StorageTag
+	pool    string
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+	life    state.Life
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if m.storage != nil {
+		return *m.storage, nil
+	}
+	return names.StorageTag{}, errors.NewNotAssigned(nil, "error from mock")
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return m.life
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
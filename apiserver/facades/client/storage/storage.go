@@ -38,6 +38,50 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// APIv6 implements the storage v6 API.
+type APIv6 struct {
+	*APIv5
+}
+
+// NewAPIv6 returns a new storage v6 API facade.
+func NewAPIv6(
+	backend backend,
+	storageAccess storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	callContext context.ProviderCallContext,
+) (*APIv6, error) {
+	apiv5, err := NewAPIv5(backend, storageAccess, registry, pm, resources, authorizer, callContext)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	backend backend,
+	storageAccess storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	callContext context.ProviderCallContext,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(backend, storageAccess, registry, pm, resources, authorizer, callContext)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	backend backend,
@@ -782,7 +826,10 @@ func (a *APIv3) addToUnit(args params.StoragesAddParams) (params.AddStorageResul
 	}
 
 	paramsToState := func(p params.StorageConstraints) state.StorageConstraints {
-		s := state.StorageConstraints{Pool: p.Pool}
+		s := state.StorageConstraints{
+			Pool:     p.Pool,
+			Snapshot: p.Snapshot,
+		}
 		if p.Size != nil {
 			s.Size = *p.Size
 		}
@@ -872,6 +919,140 @@ func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
 	return params.ErrorResults{result}, nil
 }
 
+// Resize requests that the specified storage instances be grown to the
+// given sizes. The volumes and filesystems backing the storage are
+// grown by the storage provisioners, while the storage remains in use.
+func (a *APIv5) Resize(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Error = common.ServerError(
+			a.storageAccess.ResizeStorageInstance(tag, arg.Size),
+		)
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// CreateSnapshot requests snapshots of the volumes backing the specified
+// storage instances. The snapshots are taken by the storage provisioner
+// responsible for each volume.
+func (a *APIv6) CreateSnapshot(args params.Entities) (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	createOne := func(arg params.Entity) (*params.VolumeSnapshotDetails, error) {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return nil, err
+		}
+		snapshot, err := a.storageAccess.CreateStorageSnapshot(tag)
+		if err != nil {
+			return nil, err
+		}
+		return createVolumeSnapshotDetails(snapshot)
+	}
+
+	results := make([]params.VolumeSnapshotDetailsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		details, err := createOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.VolumeSnapshotDetailsResults{results}, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the model.
+func (a *APIv6) ListSnapshots() (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storageAccess.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	results := make([]params.VolumeSnapshotDetailsResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		details, err := createVolumeSnapshotDetails(snapshot)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.VolumeSnapshotDetailsResults{results}, nil
+}
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) (*params.VolumeSnapshotDetails, error) {
+	details := &params.VolumeSnapshotDetails{
+		Id:        snapshot.Id(),
+		VolumeTag: snapshot.Volume().String(),
+		Pool:      snapshot.Pool(),
+		Life:      params.Life(snapshot.Life().String()),
+		Created:   snapshot.Created(),
+	}
+	if storageTag, err := snapshot.StorageInstance(); err == nil {
+		details.StorageTag = storageTag.String()
+	} else if !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	if info, err := snapshot.Info(); err == nil {
+		details.Info = &params.VolumeSnapshotInfo{
+			SnapshotId: info.SnapshotId,
+			Size:       info.Size,
+		}
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+	return details, nil
+}
+
+// RemoveSnapshots sets the specified volume snapshots to Dying. The
+// snapshots are destroyed and removed by the storage provisioner.
+func (a *APIv6) RemoveSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if !state.IsValidVolumeSnapshotId(id) {
+			result[i].Error = common.ServerError(errors.NotValidf("volume snapshot ID %q", id))
+			continue
+		}
+		result[i].Error = common.ServerError(
+			a.storageAccess.DestroyVolumeSnapshot(id),
+		)
+	}
+	return params.ErrorResults{result}, nil
+}
+
 // Detach sets the specified storage attachments to Dying, unless they are
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
@@ -343,6 +343,100 @@ func (s *storageSuite) TestRemove(c *gc.C) {
 	s.stub.CheckCall(c, 4, releaseStorageInstanceCall, names.NewStorageTag("foo/1"), true)
 }
 
+func (s *storageSuite) TestResize(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannot shrink volume"))
+	results, err := s.api.Resize(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-foo-0", Size: 2048},
+		{Tag: "storage-foo-1", Size: 512},
+		{Tag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "cannot shrink volume"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		resizeStorageInstanceCall,
+		resizeStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 1, resizeStorageInstanceCall, names.NewStorageTag("foo/0"), uint64(2048))
+	s.stub.CheckCall(c, 2, resizeStorageInstanceCall, names.NewStorageTag("foo/1"), uint64(512))
+}
+
+func (s *storageSuite) TestCreateSnapshot(c *gc.C) {
+	results, err := s.api.CreateSnapshot(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-foo-0"},
+		{Tag: "volume-22"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{
+			Id:         "0",
+			VolumeTag:  "volume-22",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Life:       params.Alive,
+			Created:    s.volumeSnapshot.created,
+		}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `storage foo/0 not found`}},
+		{Error: &params.Error{Message: `"volume-22" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		createStorageSnapshotCall,
+		createStorageSnapshotCall,
+	)
+}
+
+func (s *storageSuite) TestCreateSnapshotBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotBlocked")
+	_, err := s.api.CreateSnapshot(params.Entities{[]params.Entity{{Tag: "storage-data-0"}}})
+	s.assertBlocked(c, err, "TestCreateSnapshotBlocked")
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	s.volumeSnapshot.info = &state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	results, err := s.api.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0",
+			VolumeTag:  "volume-22",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Life:       params.Alive,
+			Created:    s.volumeSnapshot.created,
+			Info: &params.VolumeSnapshotInfo{
+				SnapshotId: "snap-0",
+				Size:       1024,
+			},
+		},
+	}})
+	s.stub.CheckCallNames(c, allVolumeSnapshotsCall)
+}
+
+func (s *storageSuite) TestRemoveSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannae do it"))
+	results, err := s.api.RemoveSnapshots(params.VolumeSnapshotIds{[]string{"0", "0/1", "snapshot-0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "cannae do it"}},
+		{Error: &params.Error{Message: `volume snapshot ID "snapshot-0" not valid`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Remove
+		getBlockForTypeCall, // Change
+		destroyVolumeSnapshotCall,
+		destroyVolumeSnapshotCall,
+	)
+	s.stub.CheckCall(c, 2, destroyVolumeSnapshotCall, "0")
+	s.stub.CheckCall(c, 3, destroyVolumeSnapshotCall, "0/1")
+}
+
 func (s *storageSuite) TestDestroyV3(c *gc.C) {
 	results, err := s.apiv3.Destroy(params.Entities{[]params.Entity{
 		{Tag: "storage-foo-0"},
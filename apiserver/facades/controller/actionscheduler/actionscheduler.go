@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler implements the API interface used by the
+// action scheduler worker.
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state functionality required by the action
+// scheduler facade. For details on the methods, see the methods on
+// state.State and state.Model with the same names.
+type Backend interface {
+	RunDueActionSchedules() error
+	ProgressActionRollouts() error
+	WatchActionResults() state.StringsWatcher
+}
+
+// API implements the API used by the action scheduler worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(backendShim{st, m}, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new action scheduler API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// RunActionSchedules enqueues the actions of every action schedule
+// that is due to run.
+func (api *API) RunActionSchedules() error {
+	return api.backend.RunDueActionSchedules()
+}
+
+// ProgressActionRollouts enqueues the next actions of every action
+// rollout for which earlier actions have finished.
+func (api *API) ProgressActionRollouts() error {
+	return api.backend.ProgressActionRollouts()
+}
+
+// WatchActionResults returns a StringsWatcher that notifies of the IDs
+// of actions in the model that have finished.
+func (api *API) WatchActionResults() (params.StringsWatchResult, error) {
+	w := api.backend.WatchActionResults()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// backendShim combines the state.State and state.Model methods
+// required by the facade.
+type backendShim struct {
+	st *state.State
+	m  *state.Model
+}
+
+func (b backendShim) RunDueActionSchedules() error {
+	return b.st.RunDueActionSchedules()
+}
+
+func (b backendShim) ProgressActionRollouts() error {
+	return b.st.ProgressActionRollouts()
+}
+
+func (b backendShim) WatchActionResults() state.StringsWatcher {
+	return b.m.WatchActionResults()
+}
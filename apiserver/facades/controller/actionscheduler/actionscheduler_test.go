@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionSchedulerSuite struct {
+	testing.IsolationSuite
+	backend   mockBackend
+	resources *common.Resources
+	api       *actionscheduler.API
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = mockBackend{
+		actionResults: make(chan []string, 1),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	var err error
+	s.api, err = actionscheduler.NewAPI(&s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := actionscheduler.NewAPI(&s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *ActionSchedulerSuite) TestRunActionSchedules(c *gc.C) {
+	err := s.api.RunActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "RunDueActionSchedules")
+}
+
+func (s *ActionSchedulerSuite) TestRunActionSchedulesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := s.api.RunActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulerSuite) TestProgressActionRollouts(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := s.api.ProgressActionRollouts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.CheckCallNames(c, "ProgressActionRollouts")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionResults(c *gc.C) {
+	s.backend.actionResults <- []string{"1", "2"}
+	result, err := s.api.WatchActionResults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"1", "2"},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+	s.backend.CheckCallNames(c, "WatchActionResults")
+}
+
+type mockBackend struct {
+	testing.Stub
+	actionResults chan []string
+	watcher       *statetesting.MockStringsWatcher
+}
+
+func (m *mockBackend) RunDueActionSchedules() error {
+	m.MethodCall(m, "RunDueActionSchedules")
+	return m.NextErr()
+}
+
+func (m *mockBackend) ProgressActionRollouts() error {
+	m.MethodCall(m, "ProgressActionRollouts")
+	return m.NextErr()
+}
+
+func (m *mockBackend) WatchActionResults() state.StringsWatcher {
+	m.MethodCall(m, "WatchActionResults")
+	m.watcher = statetesting.NewMockStringsWatcher(m.actionResults)
+	return m.watcher
+}
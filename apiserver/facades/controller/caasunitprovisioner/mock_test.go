@@ -105,8 +105,11 @@ func (m *mockModel) Containers(providerIds ...string) ([]state.CloudContainer, e
 
 type mockApplication struct {
 	testing.Stub
-	life         state.Life
-	scaleWatcher *statetesting.MockNotifyWatcher
+	life           state.Life
+	scaleWatcher   *statetesting.MockNotifyWatcher
+	configWatcher  *statetesting.MockNotifyWatcher
+	metricsWatcher *statetesting.MockNotifyWatcher
+	metrics        map[string][]state.Metric
 
 	tag        names.Tag
 	units      []caasunitprovisioner.Unit
@@ -134,11 +137,31 @@ func (a *mockApplication) WatchScale() state.NotifyWatcher {
 	return a.scaleWatcher
 }
 
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) WatchMetrics() state.NotifyWatcher {
+	a.MethodCall(a, "WatchMetrics")
+	return a.metricsWatcher
+}
+
+func (a *mockApplication) LatestUnitMetrics() (map[string][]state.Metric, error) {
+	a.MethodCall(a, "LatestUnitMetrics")
+	return a.metrics, a.NextErr()
+}
+
 func (a *mockApplication) GetScale() int {
 	a.MethodCall(a, "GetScale")
 	return 5
 }
 
+func (a *mockApplication) Scale(scale int) error {
+	a.MethodCall(a, "Scale", scale)
+	return a.NextErr()
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
@@ -40,6 +40,51 @@ type Facade struct {
 	clock                   clock.Clock
 }
 
+// FacadeV2 is the v2 CAAS unit provisioner API, which lacks
+// WatchApplicationsConfig, WatchApplicationsMetrics and
+// ApplicationsMetrics.
+type FacadeV2 struct {
+	Facade
+}
+
+// FacadeV1 is the v1 CAAS unit provisioner API, which lacks
+// UnitProviderIds.
+type FacadeV1 struct {
+	FacadeV2
+}
+
+// WatchApplicationsConfig isn't on the v2 API.
+func (*FacadeV2) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsMetrics isn't on the v2 API.
+func (*FacadeV2) WatchApplicationsMetrics(_, _ struct{}) {}
+
+// ApplicationsMetrics isn't on the v2 API.
+func (*FacadeV2) ApplicationsMetrics(_, _ struct{}) {}
+
+// UnitProviderIds isn't on the v1 API.
+func (*FacadeV1) UnitProviderIds(_, _ struct{}) {}
+
+// NewStateFacadeV2 provides the signature required for
+// registering the v2 facade.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	facade, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{*facade}, nil
+}
+
+// NewStateFacadeV1 provides the signature required for
+// registering the v1 facade.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	facade, err := NewStateFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{*facade}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -149,6 +194,72 @@ func (f *Facade) watchApplicationScale(tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the applications' config.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchApplicationsMetrics starts a NotifyWatcher to watch the
+// metrics recorded by the applications' units.
+func (f *Facade) WatchApplicationsMetrics(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationMetrics(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationMetrics(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchMetrics()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // WatchPodSpec starts a NotifyWatcher to watch changes to the
 // pod spec for specified units in this model.
 func (f *Facade) WatchPodSpec(args params.Entities) (params.NotifyWatchResults, error) {
@@ -214,6 +325,105 @@ func (f *Facade) applicationScale(tagString string) (int, error) {
 	return app.GetScale(), nil
 }
 
+// ApplicationsMetrics returns the latest value of each metric
+// recorded by each unit of the specified applications.
+func (f *Facade) ApplicationsMetrics(args params.Entities) (params.MetricResults, error) {
+	results := params.MetricResults{
+		Results: make([]params.EntityMetrics, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		metrics, err := f.applicationMetrics(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Metrics = metrics
+	}
+	return results, nil
+}
+
+func (f *Facade) applicationMetrics(tagString string) ([]params.MetricResult, error) {
+	appTag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitMetrics, err := app.LatestUnitMetrics()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, 0, len(unitMetrics))
+	for unitName := range unitMetrics {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	var result []params.MetricResult
+	for _, unitName := range unitNames {
+		for _, m := range unitMetrics[unitName] {
+			result = append(result, params.MetricResult{
+				Time:  m.Time,
+				Key:   m.Key,
+				Value: m.Value,
+				Unit:  unitName,
+			})
+		}
+	}
+	return result, nil
+}
+
+// UnitProviderIds returns the provider ids of the units of the
+// specified applications. Units without a cloud container are omitted.
+func (f *Facade) UnitProviderIds(args params.Entities) (params.UnitProviderIdsResults, error) {
+	results := params.UnitProviderIdsResults{
+		Results: make([]params.UnitProviderIdsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		ids, err := f.unitProviderIds(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = ids
+	}
+	return results, nil
+}
+
+func (f *Facade) unitProviderIds(tagString string) ([]params.UnitProviderId, error) {
+	appTag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.UnitProviderId
+	for _, u := range units {
+		info, err := u.ContainerInfo()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.ProviderId() == "" {
+			continue
+		}
+		result = append(result, params.UnitProviderId{
+			UnitTag:    u.UnitTag().String(),
+			ProviderId: info.ProviderId(),
+		})
+	}
+	return result, nil
+}
+
 // ProvisioningInfo returns the provisioning info for specified applications in this model.
 func (f *Facade) ProvisioningInfo(args params.Entities) (params.KubernetesProvisioningInfoResults, error) {
 	model, err := f.state.Model()
@@ -472,6 +682,16 @@ func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs)
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		// The scale is updated before the units are, as changing
+		// the units invalidates the application's unit count.
+		// As with the units, updates for dying applications are ignored.
+		if appUpdate.Scale != nil && app.Life() == state.Alive && *appUpdate.Scale != app.GetScale() {
+			logger.Debugf("application %q scaled to %d by the cloud", appTag.Id(), *appUpdate.Scale)
+			if err := app.Scale(*appUpdate.Scale); err != nil {
+				result.Results[i].Error = common.ServerError(errors.Mask(err))
+				continue
+			}
+		}
 		err = a.updateUnitsFromCloud(app, appUpdate.Units)
 		if err != nil {
 			// Mask any not found errors as the worker (caller) treats them specially
@@ -503,6 +723,16 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 			Status:  status.Waiting,
 			Message: status.MessageWaitForContainer,
 		}
+	case status.Waiting:
+		// A pod is running but its readiness probes are failing,
+		// so the workload isn't yet able to do its job.
+		agentStatus = &status.StatusInfo{
+			Status: status.Idle,
+		}
+		unitStatus = &status.StatusInfo{
+			Status:  status.Waiting,
+			Message: params.Info,
+		}
 	case status.Running:
 		// A pod has finished starting so the workload is now active.
 		agentStatus = &status.StatusInfo{
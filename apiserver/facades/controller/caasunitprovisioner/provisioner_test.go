@@ -39,6 +39,8 @@ type CAASProvisionerSuite struct {
 	applicationsChanges     chan []string
 	podSpecChanges          chan struct{}
 	scaleChanges            chan struct{}
+	configChanges           chan struct{}
+	metricsChanges          chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -51,11 +53,15 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
 	s.scaleChanges = make(chan struct{}, 1)
+	s.configChanges = make(chan struct{}, 1)
+	s.metricsChanges = make(chan struct{}, 1)
 	s.st = &mockState{
 		application: mockApplication{
-			tag:          names.NewApplicationTag("gitlab"),
-			life:         state.Alive,
-			scaleWatcher: statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			tag:            names.NewApplicationTag("gitlab"),
+			life:           state.Alive,
+			scaleWatcher:   statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			configWatcher:  statetesting.NewMockNotifyWatcher(s.configChanges),
+			metricsWatcher: statetesting.NewMockNotifyWatcher(s.metricsChanges),
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
@@ -75,6 +81,8 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.devices = &mockDeviceBackend{}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.configWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.metricsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
 
 	s.resources = common.NewResources()
@@ -154,6 +162,80 @@ func (s *CAASProvisionerSuite) TestWatchApplicationsScale(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.application.scaleWatcher)
 }
 
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
+
+func (s *CAASProvisionerSuite) TestWatchApplicationsMetrics(c *gc.C) {
+	s.metricsChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsMetrics(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.metricsWatcher)
+}
+
+func (s *CAASProvisionerSuite) TestApplicationsMetrics(c *gc.C) {
+	now := time.Now()
+	s.st.application.metrics = map[string][]state.Metric{
+		"gitlab/1": {{Key: "pings", Value: "6", Time: now}},
+		"gitlab/0": {
+			{Key: "pings", Value: "5", Time: now},
+			{Key: "pongs", Value: "4", Time: now},
+		},
+	}
+	results, err := s.facade.ApplicationsMetrics(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MetricResults{
+		Results: []params.EntityMetrics{{
+			Metrics: []params.MetricResult{
+				{Time: now, Key: "pings", Value: "5", Unit: "gitlab/0"},
+				{Time: now, Key: "pongs", Value: "4", Unit: "gitlab/0"},
+				{Time: now, Key: "pings", Value: "6", Unit: "gitlab/1"},
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.application.CheckCallNames(c, "LatestUnitMetrics")
+}
+
 func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Dying},
@@ -229,6 +311,34 @@ func (s *CAASProvisionerSuite) TestApplicationScale(c *gc.C) {
 	s.st.CheckCallNames(c, "Application")
 }
 
+func (s *CAASProvisionerSuite) TestUnitProviderIds(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}},
+		&mockUnit{name: "gitlab/1"},
+		&mockUnit{name: "gitlab/2", containerInfo: &mockContainerInfo{}},
+	}
+	results, err := s.facade.UnitProviderIds(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UnitProviderIdsResults{
+		Results: []params.UnitProviderIdsResult{{
+			Result: []params.UnitProviderId{{
+				UnitTag:    "unit-gitlab-0",
+				ProviderId: "uuid",
+			}},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCallNames(c, "Application")
+}
+
 func (s *CAASProvisionerSuite) TestLife(c *gc.C) {
 	results, err := s.facade.Life(params.Entities{
 		Entities: []params.Entity{
@@ -339,6 +449,63 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotReady(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "waiting", Info: "containers with unready status: [gitlab]"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+		},
+	})
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("uuid"),
+		Address:    strPtr("address"), Ports: &[]string{"port"},
+		UnitStatus:  &status.StatusInfo{Status: status.Waiting, Message: "containers with unready status: [gitlab]"},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsScale(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "running", Info: "message"},
+	}
+	scale := 3
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Scale: &scale, Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+		},
+	})
+	s.st.application.CheckCallNames(c, "Life", "GetScale", "Scale", "Life", "Name")
+	s.st.application.CheckCall(c, 2, "Scale", 3)
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotAlive(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Alive},
@@ -66,7 +66,11 @@ type Model interface {
 // required by the CAAS unit provisioner facade.
 type Application interface {
 	GetScale() int
+	Scale(int) error
 	WatchScale() state.NotifyWatcher
+	WatchApplicationConfig() state.NotifyWatcher
+	WatchMetrics() state.NotifyWatcher
+	LatestUnitMetrics() (map[string][]state.Metric, error)
 	ApplicationConfig() (application.ConfigAttributes, error)
 	AllUnits() (units []Unit, err error)
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
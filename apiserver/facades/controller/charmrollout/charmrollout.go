@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrollout implements the API interface used by the charm
+// rollout worker.
+package charmrollout
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// Backend defines the state functionality required by the charm
+// rollout facade. For details on the methods, see the methods on
+// state.State with the same names.
+type Backend interface {
+	ProgressCharmRollouts() error
+}
+
+// API implements the API used by the charm rollout worker.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), ctx.Auth())
+}
+
+// NewAPI returns a new charm rollout API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// ProgressCharmRollouts releases rolling charm upgrades to the next
+// batch of units where the previous batch is ready, and pauses or
+// rolls back upgrades for which a unit is in an error state.
+func (api *API) ProgressCharmRollouts() error {
+	return api.backend.ProgressCharmRollouts()
+}
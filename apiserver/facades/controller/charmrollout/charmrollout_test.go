@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/charmrollout"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type CharmRolloutSuite struct {
+	testing.IsolationSuite
+	backend mockBackend
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = mockBackend{}
+}
+
+func (s *CharmRolloutSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := charmrollout.NewAPI(&s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRollouts(c *gc.C) {
+	api, err := charmrollout.NewAPI(&s.backend, apiservertesting.FakeAuthorizer{
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.SetErrors(errors.New("boom"))
+	err = api.ProgressCharmRollouts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.CheckCallNames(c, "ProgressCharmRollouts", "ProgressCharmRollouts")
+}
+
+type mockBackend struct {
+	testing.Stub
+}
+
+func (m *mockBackend) ProgressCharmRollouts() error {
+	m.MethodCall(m, "ProgressCharmRollouts")
+	return m.NextErr()
+}
@@ -36,11 +36,26 @@ type API struct {
 	callContext context.ProviderCallContext
 }
 
+// APIV1 implements the v1 MigrationTarget API. The only difference
+// between this and v2 is that v1 doesn't have PrecheckReport.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New), state.CallContext(ctx.State()))
 }
 
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -78,7 +93,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := makeModelInfo(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -94,17 +109,66 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	return migration.TargetPrecheck(
 		backend,
 		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
+		modelInfo,
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
 }
 
+// PrecheckReport runs all of the checks that Prechecks does, without
+// stopping at the first failure, and reports every problem that would
+// prevent the model being migrated to this controller.
+func (api *API) PrecheckReport(model params.MigrationModelInfo) (params.MigrationPrecheckReport, error) {
+	modelInfo, err := makeModelInfo(model)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Trace(err)
+	}
+	controllerState := api.pool.SystemState()
+	backend, err := migration.PrecheckShim(api.state, controllerState)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Annotate(err, "creating backend")
+	}
+	report, err := migration.TargetPrecheckReport(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Trace(err)
+	}
+	return params.MigrationPrecheckReport{
+		Blockers: report.Blockers,
+		Warnings: report.Warnings,
+	}, nil
+}
+
+// PrecheckReport isn't on the v1 API.
+func (api *APIV1) PrecheckReport(_, _ struct{}) {}
+
+func makeModelInfo(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	users := make([]names.UserTag, len(model.UserTags))
+	for i, userTag := range model.UserTags {
+		users[i], err = names.ParseUserTag(userTag)
+		if err != nil {
+			return coremigration.ModelInfo{}, errors.Trace(err)
+		}
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudName:              model.CloudName,
+		CloudRegion:            model.CloudRegion,
+		Users:                  users,
+	}, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -139,6 +140,43 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           modelVersion,
+		ControllerAgentVersion: controllerVersion,
+		CloudName:              "nowhere",
+		UserTags:               []string{names.NewUserTag("bob").String()},
+	}
+	report, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.MigrationPrecheckReport{
+		Blockers: []string{
+			fmt.Sprintf("model has higher version than target controller (%s > %s)",
+				modelVersion, controllerVersion),
+			`cloud "nowhere" not found`,
+		},
+		Warnings: []string{
+			`user "bob" has access to the model but doesn't exist on the target controller`,
+		},
+	})
+}
+
+func (s *Suite) TestPrecheckReportInvalidOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.PrecheckReport(params.MigrationModelInfo{OwnerTag: "foo"})
+	c.Assert(err, gc.ErrorMatches, `"foo" is not a valid tag`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
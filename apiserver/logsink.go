@@ -16,6 +16,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -35,6 +36,7 @@ type agentLoggingStrategy struct {
 	releaser   func()
 	version    version.Number
 	entity     names.Tag
+	controller bool
 	filePrefix string
 }
 
@@ -143,6 +145,8 @@ func (s *agentLoggingStrategy) init(ctxt httpContext, req *http.Request) error {
 	}
 	s.version = ver
 	s.entity = entity.Tag()
+	authInfo, _ := httpcontext.RequestAuthInfo(req)
+	s.controller = authInfo.Controller
 	s.filePrefix = st.ModelUUID() + ":"
 	s.dblogger = s.dbloggers.get(st.State)
 	s.releaser = func() {
@@ -165,10 +169,20 @@ func (s *agentLoggingStrategy) Close() error {
 
 // WriteLog is part of the logsink.LogWriteCloser interface.
 func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
+	entity := s.entity
+	if s.controller && m.Entity != "" {
+		// Controller agents may log on behalf of the CAAS
+		// units whose workload logs they collect.
+		unitTag, err := names.ParseUnitTag(m.Entity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entity = unitTag
+	}
 	level, _ := loggo.ParseLevel(m.Level)
 	dbErr := errors.Annotate(s.dblogger.Log([]state.LogRecord{{
 		Time:     m.Time,
-		Entity:   s.entity,
+		Entity:   entity,
 		Version:  s.version,
 		Module:   m.Module,
 		Location: m.Location,
@@ -176,7 +190,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Message:  m.Message,
 	}}), "logging to DB failed")
 
-	m.Entity = s.entity.String()
+	m.Entity = entity.String()
 	fileErr := errors.Annotate(
 		logToFile(s.fileLogger, s.filePrefix, m),
 		"logging to logsink.log failed",
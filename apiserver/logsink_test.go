@@ -177,6 +177,74 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	}
 }
 
+func (s *logsinkSuite) TestLoggingOnBehalfOfUnit(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: s.nonce,
+		Jobs:  []state.MachineJob{state.JobManageModel},
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	header.Add(params.MachineNonceHeader, s.nonce)
+	conn, _, err := dialWebsocketFromURL(c, s.url, header)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	err = conn.WriteJSON(&params.LogRecord{
+		Time:    time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   loggo.INFO.String(),
+		Message: "all is well",
+		Entity:  "unit-gitlab-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	docs := s.waitForLogs(c, 1)
+	c.Assert(docs[0]["n"], gc.Equals, "unit-gitlab-0")
+	c.Assert(docs[0]["m"], gc.Equals, "workload.gitlab")
+	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+}
+
+func (s *logsinkSuite) TestLoggingIgnoresEntityFromNonController(c *gc.C) {
+	conn := s.dialWebsocket(c)
+	defer conn.Close()
+
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	err := conn.WriteJSON(&params.LogRecord{
+		Time:    time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:  "some.where",
+		Level:   loggo.INFO.String(),
+		Message: "all is well",
+		Entity:  "unit-gitlab-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	docs := s.waitForLogs(c, 1)
+	c.Assert(docs[0]["n"], gc.Equals, s.machineTag.String())
+}
+
+// waitForLogs waits for the specified number of log documents
+// to be written to the DB, and returns them.
+func (s *logsinkSuite) waitForLogs(c *gc.C, count int) []bson.M {
+	logsColl := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	var docs []bson.M
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := logsColl.Find(nil).Sort("t").All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(docs) == count {
+			break
+		}
+		if len(docs) > count {
+			c.Fatalf("saw more log documents than expected")
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for log writes")
+		}
+	}
+	return docs
+}
+
 func (s *logsinkSuite) TestReceiveErrorBreaksConn(c *gc.C) {
 	conn := s.dialWebsocket(c)
 	defer conn.Close()
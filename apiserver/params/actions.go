@@ -143,3 +143,115 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionScheduleArgs holds the parameters for adding action
+// schedules.
+type AddActionScheduleArgs struct {
+	Schedules []AddActionScheduleArg `json:"schedules"`
+}
+
+// AddActionScheduleArg holds the parameters for adding an action
+// schedule. Exactly one of Cron and Interval must be specified.
+type AddActionScheduleArg struct {
+	Name           string                 `json:"name"`
+	ApplicationTag string                 `json:"application-tag"`
+	Action         string                 `json:"action"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	Cron           string                 `json:"cron,omitempty"`
+	Interval       time.Duration          `json:"interval,omitempty"`
+	Target         string                 `json:"target,omitempty"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionScheduleResults holds the results of querying action
+// schedules.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleResult holds the details of an action schedule, or
+// an error.
+type ActionScheduleResult struct {
+	Result *ActionScheduleDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ActionScheduleDetails holds the details of an action schedule,
+// including its most recent runs.
+type ActionScheduleDetails struct {
+	Name           string                 `json:"name"`
+	ApplicationTag string                 `json:"application-tag"`
+	Action         string                 `json:"action"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	Cron           string                 `json:"cron,omitempty"`
+	Interval       time.Duration          `json:"interval,omitempty"`
+	Target         string                 `json:"target"`
+	Paused         bool                   `json:"paused"`
+	Created        time.Time              `json:"created"`
+	NextRun        time.Time              `json:"next-run"`
+	Runs           []ActionScheduleRun    `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun holds the details of a run of an action schedule.
+type ActionScheduleRun struct {
+	Run          int       `json:"run"`
+	Started      time.Time `json:"started"`
+	ActionTags   []string  `json:"action-tags,omitempty"`
+	PendingUnits []string  `json:"pending-units,omitempty"`
+}
+
+// AddActionRolloutArgs holds the parameters for adding action
+// rollouts.
+type AddActionRolloutArgs struct {
+	Rollouts []AddActionRolloutArg `json:"rollouts"`
+}
+
+// AddActionRolloutArg holds the parameters for adding an action
+// rollout, which enqueues an action on every unit of an application
+// in batches.
+type AddActionRolloutArg struct {
+	ApplicationTag string                 `json:"application-tag"`
+	Action         string                 `json:"action"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	MaxParallel    int                    `json:"max-parallel,omitempty"`
+	StopOnFailures int                    `json:"stop-on-failures,omitempty"`
+}
+
+// ActionRolloutIds holds the IDs of action rollouts.
+type ActionRolloutIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionRolloutResults holds the results of adding or querying action
+// rollouts.
+type ActionRolloutResults struct {
+	Results []ActionRolloutResult `json:"results"`
+}
+
+// ActionRolloutResult holds the details of an action rollout, or an
+// error.
+type ActionRolloutResult struct {
+	Result *ActionRolloutDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ActionRolloutDetails holds the details of an action rollout,
+// including the actions it has enqueued so far.
+type ActionRolloutDetails struct {
+	Id             string                 `json:"id"`
+	ApplicationTag string                 `json:"application-tag"`
+	Action         string                 `json:"action"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	MaxParallel    int                    `json:"max-parallel,omitempty"`
+	StopOnFailures int                    `json:"stop-on-failures,omitempty"`
+	Status         string                 `json:"status"`
+	Created        time.Time              `json:"created"`
+	Finished       time.Time              `json:"finished,omitempty"`
+	PendingUnits   []string               `json:"pending-units,omitempty"`
+	FailedUnits    []string               `json:"failed-units,omitempty"`
+	Actions        []ActionResult         `json:"actions,omitempty"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the filters for the AuditLog API Query
+// method. Empty fields match everything.
+type AuditLogQueryArgs struct {
+	User       string     `json:"user,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	Facade     string     `json:"facade,omitempty"`
+	Method     string     `json:"method,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	ErrorsOnly bool       `json:"errors-only,omitempty"`
+}
+
+// AuditLogQueryResult holds the audit log entries read by a single
+// controller machine.
+type AuditLogQueryResult struct {
+	ControllerMachine string          `json:"controller-machine"`
+	Entries           []AuditLogEntry `json:"entries"`
+}
+
+// AuditLogEntry holds a conversation from the audit log, and the
+// requests made in it.
+type AuditLogEntry struct {
+	Conversation AuditLogConversation `json:"conversation"`
+	Requests     []AuditLogRequest    `json:"requests,omitempty"`
+}
+
+// AuditLogConversation holds the details of a conversation: the
+// connection made by a single CLI command or API client.
+type AuditLogConversation struct {
+	Who            string `json:"who"`
+	What           string `json:"what"`
+	When           string `json:"when"`
+	ModelName      string `json:"model-name"`
+	ModelUUID      string `json:"model-uuid"`
+	ConversationID string `json:"conversation-id"`
+	ConnectionID   string `json:"connection-id"`
+}
+
+// AuditLogRequest holds an API request made in a conversation, and
+// any errors returned in response to it.
+type AuditLogRequest struct {
+	RequestID uint64          `json:"request-id"`
+	When      string          `json:"when"`
+	Facade    string          `json:"facade"`
+	Method    string          `json:"method"`
+	Version   int             `json:"version"`
+	Args      string          `json:"args,omitempty"`
+	Errors    []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response to an API
+// request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -96,6 +98,26 @@ type ControllerConfigSet struct {
 	Config map[string]interface{} `json:"config"`
 }
 
+// ControllerBackupStatus holds the schedule and outcome of the
+// controller's most recent scheduled backups.
+type ControllerBackupStatus struct {
+	// Schedule is the interval between scheduled backups, or empty
+	// if scheduled backups are disabled.
+	Schedule string `json:"schedule,omitempty"`
+
+	// LastSuccess is when the last successful backup completed.
+	LastSuccess *time.Time `json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful backup.
+	LastBackupID string `json:"last-backup-id,omitempty"`
+
+	// LastFailure is when the last backup failed.
+	LastFailure *time.Time `json:"last-failure,omitempty"`
+
+	// LastError is the error with which the last backup failed.
+	LastError string `json:"last-error,omitempty"`
+}
+
 // ControllerAction is an action that can be performed on a model.
 type ControllerAction string
 
@@ -794,6 +794,7 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+	ModelUUID string    `json:"uuid,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
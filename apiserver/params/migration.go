@@ -52,6 +52,27 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationPrecheckResults is used to return the outcome of running
+// the prechecks for one or more model migrations.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult holds the precheck report for one model
+// migration, or the error that stopped the prechecks being run.
+type MigrationPrecheckResult struct {
+	ModelTag string                   `json:"model-tag"`
+	Report   *MigrationPrecheckReport `json:"report,omitempty"`
+	Error    *Error                   `json:"error,omitempty"`
+}
+
+// MigrationPrecheckReport lists the problems found by running every
+// migration precheck.
+type MigrationPrecheckReport struct {
+	Blockers []string `json:"blockers,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -73,6 +94,24 @@ type SerializedModel struct {
 	Resources []SerializedModelResource `json:"resources"`
 }
 
+// ExportedModel holds a serialized model along with the details needed
+// to check that it can be imported into another controller.
+type ExportedModel struct {
+	Info  MigrationModelInfo `json:"info"`
+	Model SerializedModel    `json:"model"`
+}
+
+// ExportedModelResult holds an exported model or an error.
+type ExportedModelResult struct {
+	Result *ExportedModel `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// ExportedModelResults holds the results of exporting models.
+type ExportedModelResults struct {
+	Results []ExportedModelResult `json:"results"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -132,6 +171,9 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	CloudName              string         `json:"cloud-name,omitempty"`
+	CloudRegion            string         `json:"cloud-region,omitempty"`
+	UserTags               []string       `json:"user-tags,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -385,6 +385,57 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// Rollout, if set, releases the upgrade to the application's units
+	// a batch at a time. This field is only understood by Application
+	// facade version 9 and greater.
+	Rollout *CharmRolloutParams `json:"rollout,omitempty"`
+}
+
+// CharmRolloutParams holds the parameters for upgrading an
+// application's charm a batch of units at a time.
+type CharmRolloutParams struct {
+	// BatchSize is the number of units to which the upgrade is
+	// released at once.
+	BatchSize int `json:"batch-size"`
+
+	// ReadyStatus is the workload status that units must report, with
+	// an idle agent, before the next batch is released. If empty, the
+	// units must be active.
+	ReadyStatus string `json:"ready-status,omitempty"`
+
+	// OnError is what to do when a unit goes into an error state:
+	// "pause" or "rollback". If empty, the rollout is paused.
+	OnError string `json:"on-error,omitempty"`
+}
+
+// CharmRolloutResults holds the results of querying the rolling charm
+// upgrades of applications.
+type CharmRolloutResults struct {
+	Results []CharmRolloutResult `json:"results"`
+}
+
+// CharmRolloutResult holds the details of an application's most recent
+// rolling charm upgrade, or an error.
+type CharmRolloutResult struct {
+	Result *CharmRolloutDetails `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// CharmRolloutDetails holds the details of a rolling charm upgrade.
+type CharmRolloutDetails struct {
+	ApplicationTag string    `json:"application-tag"`
+	FromCharmURL   string    `json:"from-charm-url"`
+	ToCharmURL     string    `json:"to-charm-url"`
+	BatchSize      int       `json:"batch-size"`
+	ReadyStatus    string    `json:"ready-status"`
+	OnError        string    `json:"on-error"`
+	Status         string    `json:"status"`
+	Message        string    `json:"message,omitempty"`
+	Started        time.Time `json:"started"`
+	Finished       time.Time `json:"finished,omitempty"`
+	ReleasedUnits  []string  `json:"released-units,omitempty"`
+	PendingUnits   []string  `json:"pending-units,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
@@ -559,6 +610,7 @@ type UpdateApplicationUnitArgs struct {
 // UpdateApplicationUnits holds unit parameters for a specified application.
 type UpdateApplicationUnits struct {
 	ApplicationTag string                  `json:"application-tag"`
+	Scale          *int                    `json:"scale,omitempty"`
 	Units          []ApplicationUnitParams `json:"units"`
 }
 
@@ -574,6 +626,25 @@ type ApplicationUnitParams struct {
 	Data           map[string]interface{}     `json:"data,omitempty"`
 }
 
+// UnitProviderIdsResults holds the provider ids of the units
+// of a number of applications.
+type UnitProviderIdsResults struct {
+	Results []UnitProviderIdsResult `json:"results"`
+}
+
+// UnitProviderIdsResult holds the provider ids of an
+// application's units, or an error.
+type UnitProviderIdsResult struct {
+	Result []UnitProviderId `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// UnitProviderId holds the id the cloud provider uses for a unit.
+type UnitProviderId struct {
+	UnitTag    string `json:"unit-tag"`
+	ProviderId string `json:"provider-id"`
+}
+
 // UpdateApplicationServiceArgs holds the parameters for
 // updating application services.
 type UpdateApplicationServiceArgs struct {
@@ -771,6 +842,10 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// The key material from which the keys used to encrypt model
+	// secrets are derived. Unlike the rest of the serving info, it
+	// is held only in the agent configuration of the controllers.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
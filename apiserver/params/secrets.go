@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// SecretAddArgs holds the parameters for adding secrets.
+type SecretAddArgs struct {
+	Args []SecretAddArg `json:"args"`
+}
+
+// SecretAddArg holds the parameters for adding a secret, or a new
+// revision of an existing secret, owned by the unit's application.
+type SecretAddArg struct {
+	UnitTag string            `json:"unit-tag"`
+	Name    string            `json:"name"`
+	Values  map[string]string `json:"values"`
+}
+
+// SecretGetArgs holds the parameters for reading secrets.
+type SecretGetArgs struct {
+	Args []SecretGetArg `json:"args"`
+}
+
+// SecretGetArg holds the parameters for reading a revision of a
+// secret. If Revision is zero, the latest revision is read.
+type SecretGetArg struct {
+	UnitTag  string `json:"unit-tag"`
+	Id       string `json:"id"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+// SecretValuesResults holds the results of reading secrets.
+type SecretValuesResults struct {
+	Results []SecretValuesResult `json:"results"`
+}
+
+// SecretValuesResult holds the values of a secret, or an error.
+type SecretValuesResult struct {
+	Values map[string]string `json:"values,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// SecretGrantArgs holds the parameters for granting secrets.
+type SecretGrantArgs struct {
+	Args []SecretGrantArg `json:"args"`
+}
+
+// SecretGrantArg holds the parameters for granting a secret to the
+// application at the other end of a relation. If GranteeTag is set,
+// the secret is granted only to that unit of the application.
+type SecretGrantArg struct {
+	UnitTag    string `json:"unit-tag"`
+	Id         string `json:"id"`
+	RelationId int    `json:"relation-id"`
+	GranteeTag string `json:"grantee-tag,omitempty"`
+}
+
+// ListSecretsResults holds the results of listing secrets.
+type ListSecretsResults struct {
+	Results []SecretDetails `json:"results"`
+}
+
+// SecretDetails holds the details of a secret, excluding its values.
+type SecretDetails struct {
+	Id          string               `json:"id"`
+	Application string               `json:"application"`
+	Revision    int                  `json:"revision"`
+	Created     time.Time            `json:"created"`
+	Updated     time.Time            `json:"updated"`
+	Grants      []SecretGrantDetails `json:"grants,omitempty"`
+}
+
+// SecretGrantDetails holds the details of a grant of a secret.
+type SecretGrantDetails struct {
+	RelationKey string `json:"relation-key"`
+	Application string `json:"application"`
+	Unit        string `json:"unit,omitempty"`
+}
+
+// RotateSecretArgs holds the parameters for rotating secrets.
+type RotateSecretArgs struct {
+	Args []RotateSecretArg `json:"args"`
+}
+
+// RotateSecretArg holds the parameters for rotating a secret,
+// creating a new revision holding the specified values.
+type RotateSecretArg struct {
+	Id     string            `json:"id"`
+	Values map[string]string `json:"values"`
+}
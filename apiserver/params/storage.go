@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -93,6 +97,7 @@ type StorageAttachment struct {
 
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
+	Size     uint64      `json:"size,omitempty"`
 	Life     Life        `json:"life"`
 }
 
@@ -216,6 +221,10 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+
+	// SnapshotId is the storage provider's unique ID for the volume
+	// snapshot from which the volume should be created, if any.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -232,6 +241,23 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a provisioned
+// storage volume.
+type ResizeVolumeParams struct {
+	// VolumeTag is the tag of the volume to resize.
+	VolumeTag string `json:"volume-tag"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size in MiB that the volume should be grown to,
+	// or zero if there is no resize pending.
+	Size uint64 `json:"size,omitempty"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -301,6 +327,78 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParamsResult holds parameters for growing a volume.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for growing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotIds holds a set of volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking or destroying
+// a volume snapshot.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID of the snapshot in the model.
+	Id string `json:"id"`
+
+	// Life is the life of the snapshot.
+	Life Life `json:"life"`
+
+	// VolumeTag is the tag of the volume that the snapshot is of.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot,
+	// or empty if the snapshot has not yet been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds parameters for a volume snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds parameters for multiple volume
+// snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshot identifies and describes a volume snapshot that has
+// been taken.
+type VolumeSnapshot struct {
+	Id   string             `json:"id"`
+	Info VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot that has been taken.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshot-id"`
+	// Size is the size of the snapshotted volume in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	VolumeSnapshots []VolumeSnapshot `json:"volume-snapshots"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -381,6 +479,27 @@ type RemoveFilesystemParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeFilesystemParams holds the parameters for growing a provisioned
+// filesystem.
+type ResizeFilesystemParams struct {
+	// FilesystemTag is the tag of the filesystem to resize.
+	FilesystemTag string `json:"filesystem-tag"`
+
+	// VolumeTag is the tag of the volume backing the filesystem,
+	// if any.
+	VolumeTag string `json:"volume-tag,omitempty"`
+
+	// Provider is the storage provider that manages the filesystem.
+	Provider string `json:"provider"`
+
+	// FilesystemId is the storage provider's unique ID for the filesystem.
+	FilesystemId string `json:"filesystem-id"`
+
+	// Size is the size in MiB that the filesystem should be grown to,
+	// or zero if there is no resize pending.
+	Size uint64 `json:"size,omitempty"`
+}
+
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
@@ -440,6 +559,18 @@ type RemoveFilesystemParamsResults struct {
 	Results []RemoveFilesystemParamsResult `json:"results,omitempty"`
 }
 
+// ResizeFilesystemParamsResult holds parameters for growing a filesystem.
+type ResizeFilesystemParamsResult struct {
+	Result ResizeFilesystemParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ResizeFilesystemParamsResults holds parameters for growing multiple
+// filesystems.
+type ResizeFilesystemParamsResults struct {
+	Results []ResizeFilesystemParamsResult `json:"results,omitempty"`
+}
+
 // FilesystemAttachmentParamsResults holds provisioning parameters for a filesystem
 // attachment.
 type FilesystemAttachmentParamsResult struct {
@@ -672,6 +803,46 @@ type VolumeDetailsResults struct {
 	Results []VolumeDetailsResult `json:"results,omitempty"`
 }
 
+// VolumeSnapshotDetails describes a volume snapshot in the model.
+type VolumeSnapshotDetails struct {
+	// Id is the unique ID of the snapshot in the model.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that the snapshot is of.
+	VolumeTag string `json:"volume-tag"`
+
+	// StorageTag is the tag of the storage instance that the volume
+	// was assigned to when the snapshot was requested, if any.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// Pool is the name of the storage pool that the volume was
+	// provisioned from.
+	Pool string `json:"pool"`
+
+	// Life contains the lifecycle state of the snapshot.
+	Life Life `json:"life"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// Info contains information about the snapshot, if it has
+	// been taken.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+}
+
+// VolumeSnapshotDetailsResult holds the details of a volume snapshot,
+// or an error.
+type VolumeSnapshotDetailsResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsResults holds the details of multiple volume
+// snapshots.
+type VolumeSnapshotDetailsResults struct {
+	Results []VolumeSnapshotDetailsResult `json:"results,omitempty"`
+}
+
 // VolumeDetailsListResult holds a collection of volume details.
 type VolumeDetailsListResult struct {
 	Result []VolumeDetails `json:"result,omitempty"`
@@ -773,6 +944,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count *uint64 `json:"count,omitempty"`
+
+	// Snapshot is the ID of the volume snapshot from which to create
+	// the storage instance, if any.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StorageAddParams holds storage details to add to a unit dynamically.
@@ -813,6 +988,22 @@ type RemoveStorageInstance struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// ResizeStorage holds the parameters for growing storage instances.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for growing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the new size of the storage instance in MiB. Storage
+	// may only be grown.
+	Size uint64 `json:"size"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
@@ -5,6 +5,8 @@ package caas
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -86,6 +88,11 @@ type ServiceParams struct {
 
 	// Devices is a set of parameters for Devices that is required.
 	Devices []devices.KubernetesDeviceParams
+
+	// UnitMetrics holds the latest value of each metric recorded
+	// with add-metric by each of the application's units, keyed
+	// on unit name and then metric key.
+	UnitMetrics map[string]map[string]float64
 }
 
 // Broker instances interact with the CAAS substrate.
@@ -133,6 +140,23 @@ type Broker interface {
 	// via volumes bound to the unit.
 	Units(appName string) ([]Unit, error)
 
+	// AutoscaledUnits returns the number of units the substrate's
+	// autoscaler wants the specified application to have. It returns
+	// a NotFound error if the application is not autoscaled, or if the
+	// autoscaler is yet to decide on a scale.
+	AutoscaledUnits(appName string) (int, error)
+
+	// WorkloadContainers returns the containers of the specified
+	// unit of an application, and whether each has started.
+	WorkloadContainers(appName, unitId string) ([]WorkloadContainer, error)
+
+	// WorkloadLog returns a stream of the log output of the specified
+	// container of a unit of an application, starting from the given
+	// time. Each line written to the stream is prefixed with its
+	// RFC3339 timestamp. The stream is followed, so remains open
+	// until the container stops or the stream is closed.
+	WorkloadLog(appName, unitId, container string, since time.Time) (io.ReadCloser, error)
+
 	// ProviderRegistry is an interface for obtaining storage providers.
 	storage.ProviderRegistry
 }
@@ -174,6 +198,16 @@ type Unit struct {
 	FilesystemInfo []FilesystemInfo
 }
 
+// WorkloadContainer describes a container of a unit.
+type WorkloadContainer struct {
+	// Name is the name of the container.
+	Name string
+
+	// Started is true if the container has started,
+	// and so has log output to follow.
+	Started bool
+}
+
 // CharmStorageParams defines parameters used to create storage
 // for operators to use for charm state.
 type CharmStorageParams struct {
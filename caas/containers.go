@@ -23,6 +23,19 @@ type ContainerPort struct {
 	Protocol      string `yaml:"protocol" json:"protocol"`
 }
 
+// SecretKeySelector selects a key of one of the pod's secrets.
+type SecretKeySelector struct {
+	Secret string `yaml:"secret" json:"secret"`
+	Key    string `yaml:"key" json:"key"`
+}
+
+// SecretMount defines a secret whose keys are mounted
+// as files into the container.
+type SecretMount struct {
+	Secret    string `yaml:"secret" json:"secret"`
+	MountPath string `yaml:"mountPath" json:"mountPath"`
+}
+
 // ImageDetails defines all details required to pull a docker image from any registry
 type ImageDetails struct {
 	ImagePath string `yaml:"imagePath" json:"imagePath"`
@@ -51,6 +64,13 @@ type ContainerSpec struct {
 	Config map[string]string `yaml:"config,omitempty"`
 	Files  []FileSet         `yaml:"files,omitempty"`
 
+	// SecretConfig sets environment variables from keys of the pod's secrets.
+	SecretConfig map[string]SecretKeySelector `yaml:"secretConfig,omitempty"`
+	// EnvFromSecrets sets environment variables from all keys of the named secrets.
+	EnvFromSecrets []string `yaml:"envFromSecrets,omitempty"`
+	// SecretFiles mounts secrets into the container.
+	SecretFiles []SecretMount `yaml:"secretFiles,omitempty"`
+
 	// ProviderContainer defines config which is specific to a substrate, eg k8s
 	ProviderContainer `yaml:"-"`
 }
@@ -59,8 +79,59 @@ type ContainerSpec struct {
 // a pod on the CAAS substrate.
 type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
+	InitContainers            []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+	ServiceAccount            *ServiceAccountSpec        `yaml:"serviceAccount,omitempty"`
+	Secrets                   []Secret                   `yaml:"secrets,omitempty"`
+}
+
+// Secret defines sensitive data, such as passwords taken from the
+// charm's config or relation data, which is made available to the
+// pod's containers without being stored in plain config.
+type Secret struct {
+	Name string            `yaml:"name"`
+	Type string            `yaml:"type,omitempty"`
+	Data map[string]string `yaml:"data"`
+}
+
+// Validate returns an error if the secret is not valid.
+func (secret *Secret) Validate() error {
+	if secret.Name == "" {
+		return errors.NotValidf("missing secret name")
+	}
+	for key := range secret.Data {
+		if key == "" {
+			return errors.NotValidf("empty key in secret %q", secret.Name)
+		}
+	}
+	return nil
+}
+
+// PolicyRule defines a set of actions a role allows on a set of resources.
+type PolicyRule struct {
+	Verbs           []string `yaml:"verbs"`
+	APIGroups       []string `yaml:"apiGroups,omitempty"`
+	Resources       []string `yaml:"resources,omitempty"`
+	ResourceNames   []string `yaml:"resourceNames,omitempty"`
+	NonResourceURLs []string `yaml:"nonResourceURLs,omitempty"`
+}
+
+// RoleSpec defines a named set of permissions granted to the
+// application's service account. A global role applies across the
+// whole cluster rather than just the model, so is only granted to
+// applications which the operator has trusted.
+type RoleSpec struct {
+	Name   string       `yaml:"name"`
+	Global bool         `yaml:"global,omitempty"`
+	Rules  []PolicyRule `yaml:"rules"`
+}
+
+// ServiceAccountSpec defines the service account the application's
+// pods run as, and the roles bound to it.
+type ServiceAccountSpec struct {
+	AutomountServiceAccountToken *bool      `yaml:"automountServiceAccountToken,omitempty"`
+	Roles                        []RoleSpec `yaml:"roles,omitempty"`
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -94,6 +165,51 @@ func (crd *CustomResourceDefinition) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the rule is not valid.
+func (rule *PolicyRule) Validate() error {
+	if len(rule.Verbs) == 0 {
+		return errors.NotValidf("rule with no verbs")
+	}
+	if len(rule.Resources) == 0 && len(rule.NonResourceURLs) == 0 {
+		return errors.NotValidf("rule with no resources or non resource URLs")
+	}
+	return nil
+}
+
+// Validate returns an error if the role is not valid.
+func (role *RoleSpec) Validate() error {
+	if role.Name == "" {
+		return errors.NotValidf("missing role name")
+	}
+	if len(role.Rules) == 0 {
+		return errors.NotValidf("role %q with no rules", role.Name)
+	}
+	for _, rule := range role.Rules {
+		if len(rule.NonResourceURLs) > 0 && !role.Global {
+			return errors.NotValidf("non resource URLs in non global role %q", role.Name)
+		}
+		if err := rule.Validate(); err != nil {
+			return errors.Annotatef(err, "role %q", role.Name)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the service account is not valid.
+func (sa *ServiceAccountSpec) Validate() error {
+	names := make(map[string]bool)
+	for _, role := range sa.Roles {
+		if err := role.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if names[role.Name] {
+			return errors.NotValidf("duplicate role name %q", role.Name)
+		}
+		names[role.Name] = true
+	}
+	return nil
+}
+
 // Validate returns an error if the spec is not valid.
 func (spec *PodSpec) Validate() error {
 	for _, c := range spec.Containers {
@@ -101,11 +217,77 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	for _, c := range spec.InitContainers {
+		if err := c.Validate(); err != nil {
+			return errors.Annotatef(err, "init container %q", c.Name)
+		}
+		if len(c.Files) > 0 {
+			return errors.NotSupportedf("files in init container %q", c.Name)
+		}
+	}
 	for _, crd := range spec.CustomResourceDefinitions {
 		if err := crd.Validate(); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(spec.validateSecrets())
+}
+
+// AllContainers returns the pod's init containers
+// followed by its workload containers.
+func (spec *PodSpec) AllContainers() []ContainerSpec {
+	all := make([]ContainerSpec, 0, len(spec.InitContainers)+len(spec.Containers))
+	all = append(all, spec.InitContainers...)
+	return append(all, spec.Containers...)
+}
+
+// validateSecrets checks the pod's secrets and that
+// the containers only refer to secrets which exist.
+func (spec *PodSpec) validateSecrets() error {
+	secrets := make(map[string]bool)
+	for _, secret := range spec.Secrets {
+		if err := secret.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if secrets[secret.Name] {
+			return errors.NotValidf("duplicate secret name %q", secret.Name)
+		}
+		secrets[secret.Name] = true
+	}
+	checkSecret := func(container, name string) error {
+		if !secrets[name] {
+			return errors.NotFoundf("secret %q used by container %q", name, container)
+		}
+		return nil
+	}
+	for _, c := range spec.AllContainers() {
+		for envName, ref := range c.SecretConfig {
+			if err := checkSecret(c.Name, ref.Secret); err != nil {
+				return errors.Annotatef(err, "config %q", envName)
+			}
+			if ref.Key == "" {
+				return errors.NotValidf("config %q with missing secret key", envName)
+			}
+		}
+		for _, name := range c.EnvFromSecrets {
+			if err := checkSecret(c.Name, name); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, mount := range c.SecretFiles {
+			if err := checkSecret(c.Name, mount.Secret); err != nil {
+				return errors.Trace(err)
+			}
+			if mount.MountPath == "" {
+				return errors.Errorf("mount path is missing for secret %q", mount.Secret)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,191 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/juju/errors"
+	autoscaling "k8s.io/api/autoscaling/v2beta1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+// autoscalingPolicy describes how an application is autoscaled.
+type autoscalingPolicy struct {
+	minUnits     int
+	maxUnits     int
+	targetCPU    int
+	metric       string
+	metricTarget float64
+}
+
+// newAutoscalingPolicy returns the autoscaling policy set in the
+// application config, or nil if the application is not autoscaled.
+func newAutoscalingPolicy(config application.ConfigAttributes) (*autoscalingPolicy, error) {
+	maxUnits := config.GetInt(autoscalingMaxUnitsKey, 0)
+	if maxUnits == 0 {
+		return nil, nil
+	}
+	policy := &autoscalingPolicy{
+		minUnits:  config.GetInt(autoscalingMinUnitsKey, 1),
+		maxUnits:  maxUnits,
+		targetCPU: config.GetInt(autoscalingTargetCPUKey, 0),
+		metric:    config.GetString(autoscalingMetricKey, ""),
+	}
+	if policy.minUnits < 1 {
+		return nil, errors.NotValidf("%s %d", autoscalingMinUnitsKey, policy.minUnits)
+	}
+	if policy.maxUnits < policy.minUnits {
+		return nil, errors.NotValidf("%s %d less than %s %d",
+			autoscalingMaxUnitsKey, policy.maxUnits, autoscalingMinUnitsKey, policy.minUnits)
+	}
+	if policy.targetCPU < 0 {
+		return nil, errors.NotValidf("%s %d", autoscalingTargetCPUKey, policy.targetCPU)
+	}
+	if policy.metric != "" {
+		target := config.GetString(autoscalingMetricTargetKey, "")
+		if target == "" {
+			return nil, errors.NotValidf("%s without %s", autoscalingMetricKey, autoscalingMetricTargetKey)
+		}
+		var err error
+		policy.metricTarget, err = strconv.ParseFloat(target, 64)
+		if err != nil || policy.metricTarget <= 0 {
+			return nil, errors.NotValidf("%s %q", autoscalingMetricTargetKey, target)
+		}
+	}
+	if policy.targetCPU == 0 && policy.metric == "" {
+		return nil, errors.NotValidf("autoscaling without %s or %s", autoscalingTargetCPUKey, autoscalingMetricKey)
+	}
+	return policy, nil
+}
+
+// units returns the number of units, limited to the policy's range.
+func (p *autoscalingPolicy) units(numUnits int) int {
+	if numUnits < p.minUnits {
+		return p.minUnits
+	}
+	if numUnits > p.maxUnits {
+		return p.maxUnits
+	}
+	return numUnits
+}
+
+// metricUnits returns the number of units needed for the average of
+// the latest values of the policy's metric recorded by the units to
+// meet the metric's target, limited to the policy's range. It returns
+// false if no unit has recorded the metric.
+func (p *autoscalingPolicy) metricUnits(unitMetrics map[string]map[string]float64) (int, bool) {
+	var total float64
+	recorded := false
+	for _, metrics := range unitMetrics {
+		if value, ok := metrics[p.metric]; ok {
+			total += value
+			recorded = true
+		}
+	}
+	if !recorded {
+		return 0, false
+	}
+	return p.units(int(math.Ceil(total / p.metricTarget))), true
+}
+
+// replicas returns the fewest and most replicas the autoscaler may
+// scale the application to. The autoscaler itself scales on the CPU
+// utilisation of the pods, and the units needed by the charm's metric
+// set the fewest replicas. Without a CPU target, the replicas are
+// fixed at the units needed by the metric or, until the metric is
+// recorded, at the current number of units.
+func (p *autoscalingPolicy) replicas(numUnits int, unitMetrics map[string]map[string]float64) (int, int) {
+	minReplicas := p.minUnits
+	if p.metric != "" {
+		if units, ok := p.metricUnits(unitMetrics); ok {
+			minReplicas = units
+		} else if p.targetCPU == 0 {
+			minReplicas = p.units(numUnits)
+		}
+	}
+	if p.targetCPU == 0 {
+		return minReplicas, minReplicas
+	}
+	return minReplicas, p.maxUnits
+}
+
+func (p *autoscalingPolicy) metrics() []autoscaling.MetricSpec {
+	if p.targetCPU == 0 {
+		return nil
+	}
+	targetCPU := int32(p.targetCPU)
+	return []autoscaling.MetricSpec{{
+		Type: autoscaling.ResourceMetricSourceType,
+		Resource: &autoscaling.ResourceMetricSource{
+			Name:                     core.ResourceCPU,
+			TargetAverageUtilization: &targetCPU,
+		},
+	}}
+}
+
+// ensureAutoscaler creates or updates the horizontal pod autoscaler
+// which scales the specified application's deployment or stateful set.
+func (k *kubernetesClient) ensureAutoscaler(appName, kind string, policy *autoscalingPolicy, minReplicas, maxReplicas int) error {
+	minPods := int32(minReplicas)
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      deploymentName(appName),
+			Namespace: k.namespace,
+			Labels:    map[string]string{labelApplication: appName},
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName(appName),
+			},
+			MinReplicas: &minPods,
+			MaxReplicas: int32(maxReplicas),
+			Metrics:     policy.metrics(),
+		},
+	}
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	_, err := autoscalers.Update(hpa)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(hpa)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteAutoscaler(appName string) error {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	err := autoscalers.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// AutoscaledUnits returns the number of units the autoscaler wants
+// the specified application to have.
+func (k *kubernetesClient) AutoscaledUnits(appName string) (int, error) {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	hpa, err := autoscalers.Get(deploymentName(appName), v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return 0, errors.NotFoundf("autoscaler for %q", appName)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// A new autoscaler has yet to decide how many replicas
+	// there should be, and one whose target has been scaled
+	// to zero has stopped scaling it.
+	if hpa.Status.DesiredReplicas == 0 {
+		return 0, errors.NotFoundf("scale for autoscaler %q", hpa.Name)
+	}
+	return int(hpa.Status.DesiredReplicas), nil
+}
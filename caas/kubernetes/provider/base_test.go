@@ -7,6 +7,8 @@ import (
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +43,15 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+	mockSecrets                *mocks.MockSecretInterface
+	mockRbac                   *mocks.MockRbacV1Interface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
+	mockClusterRoles           *mocks.MockClusterRoleInterface
+	mockClusterRoleBindings    *mocks.MockClusterRoleBindingInterface
+	mockAutoscaling            *mocks.MockAutoscalingV2beta1Interface
+	mockAutoscalers            *mocks.MockHorizontalPodAutoscalerInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -90,6 +101,12 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockPersistentVolumeClaims = mocks.NewMockPersistentVolumeClaimInterface(ctrl)
 	mockCoreV1.EXPECT().PersistentVolumeClaims(testNamespace).AnyTimes().Return(s.mockPersistentVolumeClaims)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
+	s.mockSecrets = mocks.NewMockSecretInterface(ctrl)
+	mockCoreV1.EXPECT().Secrets(testNamespace).AnyTimes().Return(s.mockSecrets)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -106,6 +123,22 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	s.mockRbac = mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.mockClusterRoles = mocks.NewMockClusterRoleInterface(ctrl)
+	s.mockClusterRoleBindings = mocks.NewMockClusterRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(s.mockRbac)
+	s.mockRbac.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	s.mockRbac.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+	s.mockRbac.EXPECT().ClusterRoles().AnyTimes().Return(s.mockClusterRoles)
+	s.mockRbac.EXPECT().ClusterRoleBindings().AnyTimes().Return(s.mockClusterRoleBindings)
+
+	s.mockAutoscaling = mocks.NewMockAutoscalingV2beta1Interface(ctrl)
+	s.mockAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta1().AnyTimes().Return(s.mockAutoscaling)
+	s.mockAutoscaling.EXPECT().HorizontalPodAutoscalers(testNamespace).AnyTimes().Return(s.mockAutoscalers)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
@@ -143,3 +176,28 @@ func (s *BaseSuite) k8sAlreadyExists() *k8serrors.StatusError {
 func (s *BaseSuite) deleteOptions(policy v1.DeletionPropagation) *v1.DeleteOptions {
 	return &v1.DeleteOptions{PropagationPolicy: &policy}
 }
+
+// expectNoServiceAccount sets up the calls made when removing
+// the service account of an application which doesn't have one.
+func (s *BaseSuite) expectNoServiceAccount(appName string) {
+	s.mockRoles.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==" + appName}).Times(1).
+		Return(&rbacv1.RoleList{}, nil)
+	s.mockClusterRoles.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==" + appName + ",juju-model==" + testNamespace}).Times(1).
+		Return(&rbacv1.ClusterRoleList{}, nil)
+	s.mockServiceAccounts.EXPECT().Delete("juju-"+appName, s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(s.k8sNotFoundError())
+}
+
+// expectNoPodSecrets sets up the calls made when removing the
+// pod spec secrets of an application which doesn't have any.
+func (s *BaseSuite) expectNoPodSecrets(appName string) {
+	s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==" + appName + ",juju-secret"}).Times(1).
+		Return(&core.SecretList{}, nil)
+}
+
+// expectNoAutoscaler sets up the calls made when removing the
+// autoscaler of an application which isn't autoscaled.
+func (s *BaseSuite) expectNoAutoscaler(appName string) {
+	s.mockAutoscalers.EXPECT().Delete("juju-"+appName, s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(s.k8sNotFoundError())
+}
@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/core/application"
 )
 
 const (
@@ -27,6 +30,12 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	autoscalingMinUnitsKey     = "kubernetes-autoscaling-min-units"
+	autoscalingMaxUnitsKey     = "kubernetes-autoscaling-max-units"
+	autoscalingTargetCPUKey    = "kubernetes-autoscaling-target-cpu"
+	autoscalingMetricKey       = "kubernetes-autoscaling-metric"
+	autoscalingMetricTargetKey = "kubernetes-autoscaling-metric-target"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +89,31 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	autoscalingMinUnitsKey: {
+		Description: "the fewest units the application is autoscaled to",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMaxUnitsKey: {
+		Description: "the most units the application is autoscaled to; setting this enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetCPUKey: {
+		Description: "the average CPU utilisation of the pods to autoscale to, as a percentage of their CPU request",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMetricKey: {
+		Description: "the name of a metric recorded with add-metric to autoscale on",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMetricTargetKey: {
+		Description: "the average value per unit of the charm's metric to autoscale to",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -101,3 +135,10 @@ func ConfigSchema() environschema.Fields {
 func ConfigDefaults() schema.Defaults {
 	return schemaDefaults
 }
+
+// ValidateApplicationConfig returns an error if the
+// kubernetes specific application config is not valid.
+func ValidateApplicationConfig(config application.ConfigAttributes) error {
+	_, err := newAutoscalingPolicy(config)
+	return errors.Trace(err)
+}
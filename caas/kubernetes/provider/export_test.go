@@ -32,6 +32,16 @@ func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.P
 	return &storageProvider{&kubernetesClient{Interface: k8sClient, namespace: namespace}}
 }
 
+func EnsureServiceAccount(k8sClient kubernetes.Interface, namespace, appName string, spec *caas.ServiceAccountSpec, trusted bool) error {
+	k := &kubernetesClient{Interface: k8sClient, namespace: namespace}
+	return k.ensureServiceAccount(appName, spec, trusted)
+}
+
+func DeleteServiceAccount(k8sClient kubernetes.Interface, namespace, appName string) error {
+	k := &kubernetesClient{Interface: k8sClient, namespace: namespace}
+	return k.deleteServiceAccount(appName)
+}
+
 func StorageClass(cfg *storageConfig) string {
 	return cfg.storageClass
 }
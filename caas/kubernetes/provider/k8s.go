@@ -51,6 +51,7 @@ const (
 	labelVersion     = "juju-version"
 	labelApplication = "juju-application"
 	labelModel       = "juju-model"
+	labelSecret      = "juju-secret"
 
 	defaultOperatorStorageClassName = "juju-operator-storage"
 
@@ -72,9 +73,11 @@ type kubernetesClient struct {
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,ServiceAccountInterface,SecretInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv2beta1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta1 AutoscalingV2beta1Interface,HorizontalPodAutoscalerInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleInterface,ClusterRoleBindingInterface,RoleInterface,RoleBindingInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -180,8 +183,6 @@ func (k *kubernetesClient) EnsureSecret(imageSecretName, appName string, imageDe
 	if err != nil {
 		return errors.Trace(err)
 	}
-	secrets := k.CoreV1().Secrets(k.namespace)
-
 	newSecret := &core.Secret{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      imageSecretName,
@@ -192,10 +193,14 @@ func (k *kubernetesClient) EnsureSecret(imageSecretName, appName string, imageDe
 			core.DockerConfigJsonKey: secretData,
 		},
 	}
+	return errors.Trace(k.ensureSecret(newSecret))
+}
 
-	_, err = secrets.Update(newSecret)
+func (k *kubernetesClient) ensureSecret(secret *core.Secret) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	_, err := secrets.Update(secret)
 	if k8serrors.IsNotFound(err) {
-		_, err = secrets.Create(newSecret)
+		_, err = secrets.Create(secret)
 	}
 	return errors.Trace(err)
 }
@@ -565,6 +570,15 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteServiceAccount(appName); err != nil {
+		return errors.Annotatef(err, "deleting service account for %v", appName)
+	}
+	if err := k.deletePodSecrets(appName, nil); err != nil {
+		return errors.Annotatef(err, "deleting secrets for %v", appName)
+	}
+	if err := k.deleteAutoscaler(appName); err != nil {
+		return errors.Annotatef(err, "deleting autoscaler for %v", appName)
+	}
 	deploymentName := deploymentName(appName)
 	if err := k.deleteStatefulSet(deploymentName); err != nil {
 		return errors.Trace(err)
@@ -653,6 +667,21 @@ func (k *kubernetesClient) EnsureService(
 	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) == 0 {
 		return errors.Errorf("kubernetes service is required when using storage")
 	}
+	policy, err := newAutoscalingPolicy(config)
+	if err != nil {
+		return errors.Annotatef(err, "autoscaling %v", appName)
+	}
+	var minReplicas, maxReplicas int
+	if policy != nil {
+		// The autoscaler owns the number of units, but
+		// they are kept within the range it may scale to.
+		minReplicas, maxReplicas = policy.replicas(numUnits, params.UnitMetrics)
+		if numUnits < minReplicas {
+			numUnits = minReplicas
+		} else if numUnits > maxReplicas {
+			numUnits = maxReplicas
+		}
+	}
 
 	var cleanups []func()
 	defer func() {
@@ -674,7 +703,7 @@ func (k *kubernetesClient) EnsureService(
 		}
 	}
 
-	for _, c := range params.PodSpec.Containers {
+	for _, c := range params.PodSpec.AllContainers() {
 		if c.ImageDetails.Password == "" {
 			continue
 		}
@@ -685,6 +714,20 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
+	if err := k.ensurePodSecrets(appName, params.PodSpec.Secrets); err != nil {
+		return errors.Annotatef(err, "creating or updating secrets for %v", appName)
+	}
+
+	if params.PodSpec.ServiceAccount != nil {
+		trusted := config.GetBool(trustConfigKey, false)
+		if err := k.ensureServiceAccount(appName, params.PodSpec.ServiceAccount, trusted); err != nil {
+			return errors.Annotatef(err, "creating or updating service account for %v", appName)
+		}
+		unitSpec.Pod.ServiceAccountName = serviceAccountName(appName)
+	} else if err := k.deleteServiceAccount(appName); err != nil {
+		return errors.Annotatef(err, "deleting service account for %v", appName)
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -714,6 +757,18 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
 
+	if policy != nil {
+		kind := "Deployment"
+		if useStatefulSet {
+			kind = "StatefulSet"
+		}
+		if err := k.ensureAutoscaler(appName, kind, policy, minReplicas, maxReplicas); err != nil {
+			return errors.Annotatef(err, "creating or updating autoscaler for %v", appName)
+		}
+	} else if err := k.deleteAutoscaler(appName); err != nil {
+		return errors.Annotatef(err, "deleting autoscaler for %v", appName)
+	}
+
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
 		for _, p := range c.Ports {
@@ -1217,8 +1272,12 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 			}
 		}
 		terminated := p.DeletionTimestamp != nil
-		unitStatus := k.jujuStatus(p.Status.Phase, terminated)
+		ready, readyMessage := podReady(&p)
+		unitStatus := k.jujuStatus(p.Status.Phase, terminated, ready)
 		statusMessage := p.Status.Message
+		if statusMessage == "" && unitStatus == status.Waiting {
+			statusMessage = readyMessage
+		}
 		since := now
 		if statusMessage == "" {
 			for _, cond := range p.Status.Conditions {
@@ -1354,12 +1413,17 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 	return units, nil
 }
 
-func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) status.Status {
+func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated, ready bool) status.Status {
 	if terminated {
 		return status.Terminated
 	}
 	switch podPhase {
 	case core.PodRunning:
+		// A running pod whose readiness probes are
+		// failing isn't yet able to serve its workload.
+		if !ready {
+			return status.Waiting
+		}
 		return status.Running
 	case core.PodFailed:
 		return status.Error
@@ -1370,6 +1434,23 @@ func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) s
 	}
 }
 
+// podReady reports whether the pod's containers are passing their
+// readiness probes and, if not, the reason why. Pods which don't
+// report readiness are considered ready.
+func podReady(p *core.Pod) (bool, string) {
+	for _, cond := range p.Status.Conditions {
+		if cond.Type != core.PodReady || cond.Status == core.ConditionTrue {
+			continue
+		}
+		message := cond.Message
+		if message == "" {
+			message = "waiting for readiness probes to pass"
+		}
+		return false, message
+	}
+	return true, ""
+}
+
 func (k *kubernetesClient) jujuFilesystemStatus(pvcPhase core.PersistentVolumeClaimPhase) status.Status {
 	switch pvcPhase {
 	case core.ClaimPending:
@@ -1486,9 +1567,7 @@ type unitSpec struct {
 }
 
 var defaultPodTemplate = `
-pod:
-  containers:
-  {{- range .Containers }}
+{{- define "container" }}
   - name: {{.Name}}
     {{if .Ports}}
     ports:
@@ -1514,7 +1593,14 @@ pod:
           value: {{$v}}
     {{- end}}
     {{end}}
-  {{- end}}
+{{- end }}
+pod:
+  containers:
+  {{- range .Containers }}{{ template "container" . }}{{- end}}
+  {{if .InitContainers}}
+  initContainers:
+  {{- range .InitContainers }}{{ template "container" . }}{{- end}}
+  {{end}}
 `[1:]
 
 func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
@@ -1534,30 +1620,43 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 
 	var imageSecretNames []core.LocalObjectReference
 	// Now fill in the hard bits progamatically.
-	for i, c := range podSpec.Containers {
+	configure := func(container *core.Container, c caas.ContainerSpec) error {
 		if c.Image != "" {
 			logger.Warningf("Image parameter deprecated, use ImageDetails")
-			unitSpec.Pod.Containers[i].Image = c.Image
+			container.Image = c.Image
 		} else {
-			unitSpec.Pod.Containers[i].Image = c.ImageDetails.ImagePath
+			container.Image = c.ImageDetails.ImagePath
 		}
 		if c.ImageDetails.Password != "" {
 			imageSecretNames = append(imageSecretNames, core.LocalObjectReference{Name: appSecretName(appName, c.Name)})
 		}
+		configurePodSecrets(&unitSpec.Pod, container, appName, c)
 
 		if c.ProviderContainer == nil {
-			continue
+			return nil
 		}
 		spec, ok := c.ProviderContainer.(*K8sContainerSpec)
 		if !ok {
-			return nil, errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
+			return errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
 		}
-		unitSpec.Pod.Containers[i].ImagePullPolicy = spec.ImagePullPolicy
+		container.ImagePullPolicy = spec.ImagePullPolicy
 		if spec.LivenessProbe != nil {
-			unitSpec.Pod.Containers[i].LivenessProbe = spec.LivenessProbe
+			container.LivenessProbe = spec.LivenessProbe
 		}
 		if spec.ReadinessProbe != nil {
-			unitSpec.Pod.Containers[i].ReadinessProbe = spec.ReadinessProbe
+			container.ReadinessProbe = spec.ReadinessProbe
+		}
+		container.Resources = spec.Resources
+		return nil
+	}
+	for i, c := range podSpec.Containers {
+		if err := configure(&unitSpec.Pod.Containers[i], c); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for i, c := range podSpec.InitContainers {
+		if err := configure(&unitSpec.Pod.InitContainers[i], c); err != nil {
+			return nil, errors.Trace(err)
 		}
 	}
 	unitSpec.Pod.ImagePullSecrets = imageSecretNames
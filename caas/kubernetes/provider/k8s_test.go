@@ -4,13 +4,18 @@
 package provider_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -21,6 +26,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
@@ -156,6 +162,113 @@ func (s *K8sSuite) TestMakeUnitSpecConfigPairs(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestMakeUnitSpecSecrets(c *gc.C) {
+	podSpec := &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Image: "juju/image",
+			SecretConfig: map[string]caas.SecretKeySelector{
+				"PASSWORD": {Secret: "creds", Key: "password"},
+				"DB_USER":  {Secret: "creds", Key: "user"},
+			},
+			EnvFromSecrets: []string{"settings"},
+			SecretFiles:    []caas.SecretMount{{Secret: "creds", MountPath: "/etc/creds"}},
+		}, {
+			Name:        "test2",
+			Image:       "juju/image2",
+			SecretFiles: []caas.SecretMount{{Secret: "creds", MountPath: "/var/creds"}},
+		}},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	secretKeyRef := func(key string) *core.EnvVarSource {
+		return &core.EnvVarSource{
+			SecretKeyRef: &core.SecretKeySelector{
+				LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-secret-creds"},
+				Key:                  key,
+			},
+		}
+	}
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		Containers: []core.Container{
+			{
+				Name:  "test",
+				Image: "juju/image",
+				Env: []core.EnvVar{
+					{Name: "DB_USER", ValueFrom: secretKeyRef("user")},
+					{Name: "PASSWORD", ValueFrom: secretKeyRef("password")},
+				},
+				EnvFrom: []core.EnvFromSource{{
+					SecretRef: &core.SecretEnvSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-secret-settings"},
+					},
+				}},
+				VolumeMounts: []core.VolumeMount{{
+					Name:      "juju-app-name-secret-creds",
+					MountPath: "/etc/creds",
+					ReadOnly:  true,
+				}},
+			}, {
+				Name:  "test2",
+				Image: "juju/image2",
+				VolumeMounts: []core.VolumeMount{{
+					Name:      "juju-app-name-secret-creds",
+					MountPath: "/var/creds",
+					ReadOnly:  true,
+				}},
+			},
+		},
+		Volumes: []core.Volume{{
+			Name: "juju-app-name-secret-creds",
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{SecretName: "juju-app-name-secret-creds"},
+			},
+		}},
+	})
+}
+
+func (s *K8sSuite) TestMakeUnitSpecInitContainersAndResources(c *gc.C) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{
+			core.ResourceCPU:    resource.MustParse("250m"),
+			core.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: core.ResourceList{
+			core.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
+	podSpec := &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Image: "juju/image",
+			ProviderContainer: &provider.K8sContainerSpec{
+				Resources: resources,
+			},
+		}},
+		InitContainers: []caas.ContainerSpec{{
+			Name:    "init",
+			Image:   "juju/init-image",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"setup"},
+		}},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		Containers: []core.Container{{
+			Name:      "test",
+			Image:     "juju/image",
+			Resources: resources,
+		}},
+		InitContainers: []core.Container{{
+			Name:    "init",
+			Image:   "juju/init-image",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"setup"},
+		}},
+	})
+}
+
 func (s *K8sSuite) TestOperatorPodConfig(c *gc.C) {
 	pod := provider.OperatorPod("gitlab", "/var/lib/juju", "jujusolutions/caas-jujud-operator", "2.99.0")
 	c.Assert(pod.Name, gc.Equals, "juju-operator-gitlab")
@@ -310,6 +423,9 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	defer ctrl.Finish()
 
 	// Delete operations below return a not found to ensure it's treated as a no-op.
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
 	gomock.InOrder(
 		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
@@ -391,6 +507,9 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 		},
 	}
 
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -417,6 +536,656 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	automount := true
+	podSpecWithRBAC := *basicPodspec
+	podSpecWithRBAC.ServiceAccount = &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Roles: []caas.RoleSpec{{
+			Name: "reader",
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "watch", "list"},
+			}},
+		}, {
+			Name:   "nodes",
+			Global: true,
+			Rules: []caas.PolicyRule{{
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get"},
+			}},
+		}},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &podSpecWithRBAC)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.ServiceAccountName = "juju-test"
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test"},
+		},
+		AutomountServiceAccountToken: &automount,
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      "ServiceAccount",
+		Name:      "juju-test",
+		Namespace: "test",
+	}}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-reader",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test"},
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-reader",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test"},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "juju-test-reader",
+		},
+		Subjects: subjects,
+	}
+	clusterLabels := map[string]string{"juju-application": "test", "juju-model": "test"}
+	clusterRoleArg := &rbacv1.ClusterRole{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "test-juju-test-nodes",
+			Labels: clusterLabels,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			Resources: []string{"nodes"},
+			Verbs:     []string{"get"},
+		}},
+	}
+	clusterRoleBindingArg := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "test-juju-test-nodes",
+			Labels: clusterLabels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-juju-test-nodes",
+		},
+		Subjects: subjects,
+	}
+
+	// The "writer" role is no longer in the spec so gets removed.
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoles.EXPECT().Update(clusterRoleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Create(clusterRoleArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Update(clusterRoleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Create(clusterRoleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&rbacv1.RoleList{Items: []rbacv1.Role{
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-test-reader"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-test-writer"}},
+			}}, nil),
+		s.mockRoleBindings.EXPECT().Delete("juju-test-writer", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("juju-test-writer", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoles.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&rbacv1.ClusterRoleList{Items: []rbacv1.ClusterRole{
+				{ObjectMeta: v1.ObjectMeta{Name: "test-juju-test-nodes"}},
+			}}, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpecWithRBAC,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+		"trust":                   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithSecrets(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpecWithSecrets := *basicPodspec
+	podSpecWithSecrets.Secrets = []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "sekrit"},
+	}}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("test", &podSpecWithSecrets)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-secret-creds",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test", "juju-secret": "creds"},
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("sekrit")},
+	}
+
+	// The "old" secret is no longer in the spec so gets removed.
+	s.expectNoServiceAccount("test")
+	s.expectNoAutoscaler("test")
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-secret"}).Times(1).
+			Return(&core.SecretList{Items: []core.Secret{
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-test-secret-creds"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-test-secret-old"}},
+			}}, nil),
+		s.mockSecrets.EXPECT().Delete("juju-test-secret-old", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpecWithSecrets,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceAutoscaled(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// The number of units is limited to the autoscaling range.
+	numUnits := int32(3)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	minReplicas := int32(2)
+	targetCPU := int32(70)
+	autoscalerArg := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test"},
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "juju-test",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 3,
+			Metrics: []autoscalingv2beta1.MetricSpec{{
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     core.ResourceCPU,
+					TargetAverageUtilization: &targetCPU,
+				},
+			}},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Update(autoscalerArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockAutoscalers.EXPECT().Create(autoscalerArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("test", params, 5, application.ConfigAttributes{
+		"kubernetes-service-type":              "nodeIP",
+		"kubernetes-autoscaling-min-units":     2,
+		"kubernetes-autoscaling-max-units":     3,
+		"kubernetes-autoscaling-target-cpu":    70,
+		"kubernetes-autoscaling-metric":        "requests-per-second",
+		"kubernetes-autoscaling-metric-target": "100",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceAutoscaledOnCharmMetric(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// The units needed by the charm's metric fix the number of units:
+	// 25 pings over a target of 10 per unit needs 3 units.
+	numUnits := int32(3)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	minReplicas := int32(3)
+	autoscalerArg := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test"},
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "juju-test",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 3,
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Update(autoscalerArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockAutoscalers.EXPECT().Create(autoscalerArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+		UnitMetrics: map[string]map[string]float64{
+			"test/0": {"pings": 15, "pongs": 100},
+			"test/1": {"pings": 10},
+			"test/2": {"pongs": 100},
+		},
+	}
+	err = s.broker.EnsureService("test", params, 1, application.ConfigAttributes{
+		"kubernetes-service-type":              "nodeIP",
+		"kubernetes-autoscaling-max-units":     5,
+		"kubernetes-autoscaling-metric":        "pings",
+		"kubernetes-autoscaling-metric-target": "10",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceAutoscalingInvalid(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	for i, t := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-min-units":  4,
+			"kubernetes-autoscaling-max-units":  3,
+			"kubernetes-autoscaling-target-cpu": 70,
+		},
+		err: "autoscaling test: kubernetes-autoscaling-max-units 3 less than kubernetes-autoscaling-min-units 4 not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units": 3,
+		},
+		err: "autoscaling test: autoscaling without kubernetes-autoscaling-target-cpu or kubernetes-autoscaling-metric not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units": 3,
+			"kubernetes-autoscaling-metric":    "requests-per-second",
+		},
+		err: "autoscaling test: kubernetes-autoscaling-metric without kubernetes-autoscaling-metric-target not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units":     3,
+			"kubernetes-autoscaling-metric":        "requests-per-second",
+			"kubernetes-autoscaling-metric-target": "lots",
+		},
+		err: `autoscaling test: kubernetes-autoscaling-metric-target "lots" not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units":     3,
+			"kubernetes-autoscaling-metric":        "requests-per-second",
+			"kubernetes-autoscaling-metric-target": "0",
+		},
+		err: `autoscaling test: kubernetes-autoscaling-metric-target "0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.broker.EnsureService("test", params, 2, t.config)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *K8sBrokerSuite) TestAutoscaledUnits(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockAutoscalers.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+		Return(&autoscalingv2beta1.HorizontalPodAutoscaler{
+			ObjectMeta: v1.ObjectMeta{Name: "juju-test"},
+			Status: autoscalingv2beta1.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 2,
+				DesiredReplicas: 4,
+			},
+		}, nil)
+
+	units, err := s.broker.AutoscaledUnits("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 4)
+}
+
+func (s *K8sBrokerSuite) TestAutoscaledUnitsNotAutoscaled(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockAutoscalers.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.AutoscaledUnits("test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestWorkloadContainersUnknownUnit(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-test-0",
+			UID:  "uuid",
+		},
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{pod}}, nil)
+
+	_, err := s.broker.WorkloadContainers("test", "another-uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestWorkloadContainers(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-test-0",
+			UID:  "uuid",
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "test"}, {Name: "sidecar"}},
+		},
+		Status: core.PodStatus{
+			ContainerStatuses: []core.ContainerStatus{{
+				Name: "test",
+				State: core.ContainerState{
+					Running: &core.ContainerStateRunning{},
+				},
+			}, {
+				Name: "sidecar",
+				State: core.ContainerState{
+					Waiting: &core.ContainerStateWaiting{Reason: "ContainerCreating"},
+				},
+			}},
+		},
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{pod}}, nil)
+
+	containers, err := s.broker.WorkloadContainers("test", "uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, jc.DeepEquals, []caas.WorkloadContainer{
+		{Name: "test", Started: true},
+		{Name: "sidecar", Started: false},
+	})
+}
+
+func (s *K8sBrokerSuite) TestWorkloadLogUnknownUnit(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{}, nil)
+
+	_, err := s.broker.WorkloadLog("test", "uuid", "test", time.Time{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestUnitsNotReady(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-test-0",
+			UID:  "uuid",
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "test"}},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			PodIP: "10.0.0.1",
+			Conditions: []core.PodCondition{{
+				Type:   core.PodScheduled,
+				Status: core.ConditionTrue,
+			}, {
+				Type:    core.PodReady,
+				Status:  core.ConditionFalse,
+				Message: "containers with unready status: [test]",
+			}},
+		},
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{pod}}, nil)
+
+	units, err := s.broker.Units("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Id, gc.Equals, "uuid")
+	c.Assert(units[0].Status.Status, gc.Equals, status.Waiting)
+	c.Assert(units[0].Status.Message, gc.Equals, "containers with unready status: [test]")
+}
+
 func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -688,6 +1457,9 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 		},
 	}
 
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
 	gomock.InOrder(
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -778,6 +1550,9 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 		},
 	}
 
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -884,6 +1659,9 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 		},
 	}
 
+	s.expectNoServiceAccount("test")
+	s.expectNoPodSecrets("test")
+	s.expectNoAutoscaler("test")
 	gomock.InOrder(
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
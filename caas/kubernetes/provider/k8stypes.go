@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
@@ -22,22 +23,111 @@ type k8sContainer struct {
 }
 
 type k8sContainers struct {
-	Containers []k8sContainer `json:"containers"`
+	Containers     []k8sContainer `json:"containers"`
+	InitContainers []k8sContainer `json:"initContainers,omitempty"`
 }
 
 // K8sContainerSpec is a subset of v1.Container which defines
 // attributes we expose for charms to set.
 type K8sContainerSpec struct {
-	LivenessProbe   *core.Probe     `json:"livenessProbe,omitempty"`
-	ReadinessProbe  *core.Probe     `json:"readinessProbe,omitempty"`
-	ImagePullPolicy core.PullPolicy `json:"imagePullPolicy,omitempty"`
+	LivenessProbe   *core.Probe               `json:"livenessProbe,omitempty"`
+	ReadinessProbe  *core.Probe               `json:"readinessProbe,omitempty"`
+	ImagePullPolicy core.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	Resources       core.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // Validate is defined on ProviderContainer.
-func (*K8sContainerSpec) Validate() error {
+func (spec *K8sContainerSpec) Validate() error {
+	if spec == nil {
+		return nil
+	}
+	switch spec.ImagePullPolicy {
+	case "", core.PullAlways, core.PullNever, core.PullIfNotPresent:
+	default:
+		return errors.NotValidf("image pull policy %q", spec.ImagePullPolicy)
+	}
+	if err := validateProbe(spec.LivenessProbe); err != nil {
+		return errors.Annotate(err, "liveness probe")
+	}
+	if err := validateProbe(spec.ReadinessProbe); err != nil {
+		return errors.Annotate(err, "readiness probe")
+	}
+	for name, request := range spec.Resources.Requests {
+		limit, ok := spec.Resources.Limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			return errors.NotValidf("%v request %v greater than limit %v", name, request.String(), limit.String())
+		}
+	}
 	return nil
 }
 
+func validateProbe(probe *core.Probe) error {
+	if probe == nil {
+		return nil
+	}
+	handlers := 0
+	if probe.Exec != nil {
+		handlers++
+	}
+	if probe.HTTPGet != nil {
+		handlers++
+	}
+	if probe.TCPSocket != nil {
+		handlers++
+	}
+	if handlers != 1 {
+		return errors.NotValidf("probe with %d handlers", handlers)
+	}
+	if probe.InitialDelaySeconds < 0 || probe.TimeoutSeconds < 0 || probe.PeriodSeconds < 0 ||
+		probe.SuccessThreshold < 0 || probe.FailureThreshold < 0 {
+		return errors.NotValidf("probe with negative values")
+	}
+	return nil
+}
+
+// resourceNameRegexp matches the role and secret names which can
+// be used to make the names of k8s resources.
+var resourceNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// validateServiceAccount returns an error if the service account
+// spec can't be used to create k8s RBAC resources. Global roles are
+// only valid for trusted applications.
+func validateServiceAccount(sa *caas.ServiceAccountSpec, trusted bool) error {
+	if err := sa.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, role := range sa.Roles {
+		if !resourceNameRegexp.MatchString(role.Name) {
+			return errors.NotValidf("role name %q", role.Name)
+		}
+		if role.Global && !trusted {
+			return errors.NotValidf("global role %q for untrusted application", role.Name)
+		}
+	}
+	return nil
+}
+
+func (c *k8sContainer) containerSpec() caas.ContainerSpec {
+	result := caas.ContainerSpec{
+		ImageDetails:   c.ImageDetails,
+		Name:           c.Name,
+		Image:          c.Image,
+		Ports:          c.Ports,
+		Command:        c.Command,
+		Args:           c.Args,
+		WorkingDir:     c.WorkingDir,
+		Config:         c.Config,
+		Files:          c.Files,
+		SecretConfig:   c.SecretConfig,
+		EnvFromSecrets: c.EnvFromSecrets,
+		SecretFiles:    c.SecretFiles,
+	}
+	if c.K8sContainerSpec != nil {
+		result.ProviderContainer = c.K8sContainerSpec
+	}
+	return result
+}
+
 // parseK8sPodSpec parses a YAML file which defines how to
 // configure a CAAS pod. We allow for generic container
 // set up plus k8s select specific features.
@@ -65,19 +155,25 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		if err := c.Validate(); err != nil {
 			return nil, errors.Trace(err)
 		}
-		spec.Containers[i] = caas.ContainerSpec{
-			ImageDetails: c.ImageDetails,
-			Name:         c.Name,
-			Image:        c.Image,
-			Ports:        c.Ports,
-			Command:      c.Command,
-			Args:         c.Args,
-			WorkingDir:   c.WorkingDir,
-			Config:       c.Config,
-			Files:        c.Files,
+		spec.Containers[i] = c.containerSpec()
+	}
+	for _, c := range containers.InitContainers {
+		if err := c.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "init container %q", c.Name)
+		}
+		spec.InitContainers = append(spec.InitContainers, c.containerSpec())
+	}
+
+	for _, secret := range spec.Secrets {
+		if !resourceNameRegexp.MatchString(secret.Name) {
+			return nil, errors.NotValidf("secret name %q", secret.Name)
 		}
-		if c.K8sContainerSpec != nil {
-			spec.Containers[i].ProviderContainer = c.K8sContainerSpec
+	}
+	if spec.ServiceAccount != nil {
+		// Whether the application is trusted isn't known until its
+		// service is ensured, so global roles are checked then.
+		if err := validateServiceAccount(spec.ServiceAccount, true); err != nil {
+			return nil, errors.Annotate(err, "service account")
 		}
 	}
 	return &spec, nil
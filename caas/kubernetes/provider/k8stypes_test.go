@@ -143,3 +143,110 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseServiceAccount(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  automountServiceAccountToken: true
+  roles:
+    - name: pod-reader
+      rules:
+        - apiGroups: [""]
+          resources: ["pods"]
+          verbs: ["get", "watch", "list"]
+    - name: metrics
+      global: true
+      rules:
+        - nonResourceURLs: ["/metrics"]
+          verbs: ["get"]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	automount := true
+	c.Assert(spec.ServiceAccount, jc.DeepEquals, &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Roles: []caas.RoleSpec{{
+			Name: "pod-reader",
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "watch", "list"},
+			}},
+		}, {
+			Name:   "metrics",
+			Global: true,
+			Rules: []caas.PolicyRule{{
+				NonResourceURLs: []string{"/metrics"},
+				Verbs:           []string{"get"},
+			}},
+		}},
+	})
+}
+
+func (s *ContainersSuite) TestParseServiceAccountInvalid(c *gc.C) {
+	for i, test := range []struct {
+		roles string
+		err   string
+	}{{
+		roles: `
+    - rules:
+        - resources: ["pods"]
+          verbs: ["get"]
+`,
+		err: "service account: missing role name not valid",
+	}, {
+		roles: `
+    - name: Reader
+      rules:
+        - resources: ["pods"]
+          verbs: ["get"]
+`,
+		err: `service account: role name "Reader" not valid`,
+	}, {
+		roles: `
+    - name: reader
+`,
+		err: `service account: role "reader" with no rules not valid`,
+	}, {
+		roles: `
+    - name: reader
+      rules:
+        - resources: ["pods"]
+`,
+		err: `service account: role "reader": rule with no verbs not valid`,
+	}, {
+		roles: `
+    - name: reader
+      rules:
+        - nonResourceURLs: ["/metrics"]
+          verbs: ["get"]
+`,
+		err: `service account: non resource URLs in non global role "reader" not valid`,
+	}, {
+		roles: `
+    - name: reader
+      rules:
+        - resources: ["pods"]
+          verbs: ["get"]
+    - name: reader
+      rules:
+        - resources: ["nodes"]
+          verbs: ["get"]
+`,
+		err: `service account: duplicate role name "reader" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  roles:`[1:] + test.roles
+		_, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, gc.ErrorMatches, test.err)
+	}
+}
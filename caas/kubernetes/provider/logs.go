@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// WorkloadContainers returns the containers of the pod
+// for the specified unit, and whether each has started.
+func (k *kubernetesClient) WorkloadContainers(appName, unitId string) ([]caas.WorkloadContainer, error) {
+	pod, err := k.unitPod(appName, unitId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Containers waiting to start have no logs yet.
+	started := set.NewStrings()
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Running != nil || cs.State.Terminated != nil {
+			started.Add(cs.Name)
+		}
+	}
+	containers := make([]caas.WorkloadContainer, len(pod.Spec.Containers))
+	for i, c := range pod.Spec.Containers {
+		containers[i] = caas.WorkloadContainer{
+			Name:    c.Name,
+			Started: started.Contains(c.Name),
+		}
+	}
+	return containers, nil
+}
+
+// WorkloadLog returns a stream of the log output of the specified
+// container of the pod for the specified unit, following the logs
+// from the given time.
+func (k *kubernetesClient) WorkloadLog(appName, unitId, container string, since time.Time) (io.ReadCloser, error) {
+	pod, err := k.unitPod(appName, unitId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sinceTime := v1.NewTime(since)
+	stream, err := k.CoreV1().Pods(k.namespace).GetLogs(pod.Name, &core.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &sinceTime,
+	}).Stream()
+	if err != nil {
+		return nil, errors.Annotatef(err, "streaming logs of container %q", container)
+	}
+	return stream, nil
+}
+
+// unitPod returns the pod of the specified unit of an application.
+func (k *kubernetesClient) unitPod(appName, unitId string) (*core.Pod, error) {
+	podsList, err := k.CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, p := range podsList.Items {
+		if string(p.UID) == unitId {
+			return &podsList.Items[i], nil
+		}
+	}
+	return nil, errors.NotFoundf("pod for unit %q of application %q", unitId, appName)
+}
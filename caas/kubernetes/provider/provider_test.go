@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/juju/juju/caas"
@@ -360,3 +361,219 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *providerSuite) TestParsePodSpecSecrets(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    secretConfig:
+      DB_PASSWORD:
+        secret: database
+        key: password
+    envFromSecrets: [settings]
+    secretFiles:
+      - secret: database
+        mountPath: /etc/database
+secrets:
+  - name: database
+    data:
+      password: sekrit
+  - name: settings
+    type: Opaque
+    data:
+      LOG_LEVEL: debug
+`[1:]
+
+	k8sprovider := provider.NewProvider()
+	spec, err := k8sprovider.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "gitlab",
+			Image: "gitlab/latest",
+			SecretConfig: map[string]caas.SecretKeySelector{
+				"DB_PASSWORD": {Secret: "database", Key: "password"},
+			},
+			EnvFromSecrets: []string{"settings"},
+			SecretFiles: []caas.SecretMount{
+				{Secret: "database", MountPath: "/etc/database"},
+			},
+		}},
+		Secrets: []caas.Secret{{
+			Name: "database",
+			Data: map[string]string{"password": "sekrit"},
+		}, {
+			Name: "settings",
+			Type: "Opaque",
+			Data: map[string]string{"LOG_LEVEL": "debug"},
+		}},
+	})
+}
+
+func (s *providerSuite) TestValidateUnknownSecret(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFromSecrets: [settings]
+secrets:
+  - name: database
+    data:
+      password: sekrit
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `secret "settings" used by container "gitlab" not found`)
+}
+
+func (s *providerSuite) TestValidateSecretMissingMountPath(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    secretFiles:
+      - secret: database
+secrets:
+  - name: database
+    data:
+      password: sekrit
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `mount path is missing for secret "database"`)
+}
+
+func (s *providerSuite) TestValidateInvalidSecretName(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+secrets:
+  - name: Database
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `secret name "Database" not valid`)
+}
+
+func (s *providerSuite) TestParsePodSpecInitContainersAndResources(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      requests:
+        cpu: 250m
+        memory: 64Mi
+      limits:
+        memory: 128Mi
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    command: ["sh", "-c"]
+    args: ["setup"]
+`[1:]
+
+	k8sprovider := provider.NewProvider()
+	spec, err := k8sprovider.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "gitlab",
+			Image: "gitlab/latest",
+			ProviderContainer: &provider.K8sContainerSpec{
+				Resources: core.ResourceRequirements{
+					Requests: core.ResourceList{
+						core.ResourceCPU:    resource.MustParse("250m"),
+						core.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: core.ResourceList{
+						core.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			},
+		}},
+		InitContainers: []caas.ContainerSpec{{
+			Name:    "gitlab-init",
+			Image:   "gitlab-init/latest",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"setup"},
+		}},
+	})
+}
+
+func (s *providerSuite) TestValidateInitContainerFiles(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    files:
+      - name: configuration
+        mountPath: /var/lib/foo
+        files:
+          file1: foo
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `files in init container "gitlab-init" not supported`)
+}
+
+func (s *providerSuite) TestValidateResourceRequestOverLimit(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      requests:
+        memory: 256Mi
+      limits:
+        memory: 128Mi
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `memory request 256Mi greater than limit 128Mi not valid`)
+}
+
+func (s *providerSuite) TestValidateProbeHandlers(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    readinessProbe:
+      initialDelaySeconds: 10
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `readiness probe: probe with 0 handlers not valid`)
+}
+
+func (s *providerSuite) TestValidateImagePullPolicy(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    imagePullPolicy: Sometimes
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `image pull policy "Sometimes" not valid`)
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// trustConfigKey is the application config key set when the operator
+// trusts the application, allowing it to be granted global roles.
+const trustConfigKey = "trust"
+
+// ensureServiceAccount creates or updates the service account used by
+// the pods of the specified application, along with the roles and role
+// bindings granting it permissions. Any roles previously created for
+// the application which are no longer in the spec are removed. Global
+// roles are only granted if the application is trusted.
+func (k *kubernetesClient) ensureServiceAccount(appName string, spec *caas.ServiceAccountSpec, trusted bool) error {
+	if err := validateServiceAccount(spec, trusted); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.createOrUpdateServiceAccount(appName, spec); err != nil {
+		return errors.Annotate(err, "creating or updating service account")
+	}
+
+	roles := set.NewStrings()
+	clusterRoles := set.NewStrings()
+	for _, role := range spec.Roles {
+		if role.Global {
+			name := clusterRoleName(k.namespace, appName, role.Name)
+			if err := k.ensureClusterRole(appName, name, role.Rules); err != nil {
+				return errors.Annotatef(err, "creating or updating cluster role %q", name)
+			}
+			clusterRoles.Add(name)
+			continue
+		}
+		name := roleName(appName, role.Name)
+		if err := k.ensureRole(appName, name, role.Rules); err != nil {
+			return errors.Annotatef(err, "creating or updating role %q", name)
+		}
+		roles.Add(name)
+	}
+	return errors.Trace(k.deleteRoles(appName, roles, clusterRoles))
+}
+
+// createOrUpdateServiceAccount creates the application's service
+// account, or updates only the fields Juju manages if it exists, so
+// that those set by Kubernetes, such as the token secrets, are kept.
+func (k *kubernetesClient) createOrUpdateServiceAccount(appName string, spec *caas.ServiceAccountSpec) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	sa, err := serviceAccounts.Get(serviceAccountName(appName), v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(&core.ServiceAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      serviceAccountName(appName),
+				Namespace: k.namespace,
+				Labels:    map[string]string{labelApplication: appName},
+			},
+			AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+		})
+		return errors.Trace(err)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if sa.Labels == nil {
+		sa.Labels = make(map[string]string)
+	}
+	sa.Labels[labelApplication] = appName
+	sa.AutomountServiceAccountToken = spec.AutomountServiceAccountToken
+	_, err = serviceAccounts.Update(sa)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRole(appName, name string, rules []caas.PolicyRule) error {
+	labels := map[string]string{labelApplication: appName}
+	role := &rbac.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		Rules: policyRules(rules),
+	}
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	binding := &rbac.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: k.serviceAccountSubjects(appName),
+	}
+	bindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err = bindings.Update(binding)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(binding)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRole(appName, name string, rules []caas.PolicyRule) error {
+	// Cluster roles live outside the namespace so are
+	// also labelled with the model they belong to.
+	labels := map[string]string{labelApplication: appName, labelModel: k.namespace}
+	role := &rbac.ClusterRole{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Rules: policyRules(rules),
+	}
+	roles := k.RbacV1().ClusterRoles()
+	_, err := roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	binding := &rbac.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: k.serviceAccountSubjects(appName),
+	}
+	bindings := k.RbacV1().ClusterRoleBindings()
+	_, err = bindings.Update(binding)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(binding)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) serviceAccountSubjects(appName string) []rbac.Subject {
+	return []rbac.Subject{{
+		Kind:      rbac.ServiceAccountKind,
+		Name:      serviceAccountName(appName),
+		Namespace: k.namespace,
+	}}
+}
+
+// deleteServiceAccount deletes the service account of the specified
+// application along with all of its roles and role bindings.
+func (k *kubernetesClient) deleteServiceAccount(appName string) error {
+	if err := k.deleteRoles(appName, nil, nil); err != nil {
+		return errors.Trace(err)
+	}
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	err := serviceAccounts.Delete(serviceAccountName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// deleteRoles deletes the roles and cluster roles, and their bindings,
+// created for the specified application except those named in keep
+// and keepCluster.
+func (k *kubernetesClient) deleteRoles(appName string, keep, keepCluster set.Strings) error {
+	deleteOptions := &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}
+	roles := k.RbacV1().Roles(k.namespace)
+	roleList, err := roles.List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, role := range roleList.Items {
+		if keep.Contains(role.Name) {
+			continue
+		}
+		// The binding has the same name as its role.
+		err := k.RbacV1().RoleBindings(k.namespace).Delete(role.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting role binding %q", role.Name)
+		}
+		err = roles.Delete(role.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting role %q", role.Name)
+		}
+	}
+
+	clusterRoles := k.RbacV1().ClusterRoles()
+	clusterRoleList, err := clusterRoles.List(v1.ListOptions{
+		LabelSelector: clusterApplicationSelector(k.namespace, appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, role := range clusterRoleList.Items {
+		if keepCluster.Contains(role.Name) {
+			continue
+		}
+		err := k.RbacV1().ClusterRoleBindings().Delete(role.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting cluster role binding %q", role.Name)
+		}
+		err = clusterRoles.Delete(role.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting cluster role %q", role.Name)
+		}
+	}
+	return nil
+}
+
+func policyRules(rules []caas.PolicyRule) []rbac.PolicyRule {
+	result := make([]rbac.PolicyRule, len(rules))
+	for i, r := range rules {
+		result[i] = rbac.PolicyRule{
+			Verbs:           r.Verbs,
+			APIGroups:       r.APIGroups,
+			Resources:       r.Resources,
+			ResourceNames:   r.ResourceNames,
+			NonResourceURLs: r.NonResourceURLs,
+		}
+	}
+	return result
+}
+
+func clusterApplicationSelector(namespace, appName string) string {
+	return fmt.Sprintf("%v==%v,%v==%v", labelApplication, appName, labelModel, namespace)
+}
+
+func serviceAccountName(appName string) string {
+	return deploymentName(appName)
+}
+
+func roleName(appName, role string) string {
+	return deploymentName(appName) + "-" + role
+}
+
+func clusterRoleName(namespace, appName, role string) string {
+	// Cluster roles are not namespaced so need the model
+	// namespace in their name to keep them unique.
+	return namespace + "-" + roleName(appName, role)
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+)
+
+var _ = gc.Suite(&rbacSuite{})
+
+type rbacSuite struct {
+	testing.IsolationSuite
+}
+
+var serviceAccountSpec = &caas.ServiceAccountSpec{
+	Roles: []caas.RoleSpec{{
+		Name: "reader",
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}, {
+		Name:   "nodes",
+		Global: true,
+		Rules: []caas.PolicyRule{{
+			Resources: []string{"nodes"},
+			Verbs:     []string{"get"},
+		}},
+	}},
+}
+
+func (s *rbacSuite) ensureServiceAccount(c *gc.C, objects ...runtime.Object) kubernetes.Interface {
+	client := fake.NewSimpleClientset(objects...)
+	err := provider.EnsureServiceAccount(client, testNamespace, "test", serviceAccountSpec, true)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *rbacSuite) TestEnsureServiceAccountCreates(c *gc.C) {
+	client := s.ensureServiceAccount(c)
+
+	sa, err := client.CoreV1().ServiceAccounts(testNamespace).Get("juju-test", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Labels, jc.DeepEquals, map[string]string{"juju-application": "test"})
+
+	subjects := []rbacv1.Subject{{
+		Kind:      "ServiceAccount",
+		Name:      "juju-test",
+		Namespace: testNamespace,
+	}}
+	role, err := client.RbacV1().Roles(testNamespace).Get("juju-test-reader", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role.Labels, jc.DeepEquals, map[string]string{"juju-application": "test"})
+	c.Assert(role.Rules, jc.DeepEquals, []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "watch", "list"},
+	}})
+	binding, err := client.RbacV1().RoleBindings(testNamespace).Get("juju-test-reader", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(binding.RoleRef, jc.DeepEquals, rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     "juju-test-reader",
+	})
+	c.Assert(binding.Subjects, jc.DeepEquals, subjects)
+
+	clusterRole, err := client.RbacV1().ClusterRoles().Get("test-juju-test-nodes", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusterRole.Labels, jc.DeepEquals, map[string]string{"juju-application": "test", "juju-model": testNamespace})
+	clusterBinding, err := client.RbacV1().ClusterRoleBindings().Get("test-juju-test-nodes", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusterBinding.Subjects, jc.DeepEquals, subjects)
+}
+
+func (s *rbacSuite) TestEnsureServiceAccountUntrustedGlobalRole(c *gc.C) {
+	client := fake.NewSimpleClientset()
+	err := provider.EnsureServiceAccount(client, testNamespace, "test", serviceAccountSpec, false)
+	c.Assert(err, gc.ErrorMatches, `global role "nodes" for untrusted application not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	// Nothing is created for an invalid spec.
+	_, err = client.CoreV1().ServiceAccounts(testNamespace).Get("juju-test", v1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+	clusterRoles, err := client.RbacV1().ClusterRoles().List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusterRoles.Items, gc.HasLen, 0)
+}
+
+func (s *rbacSuite) TestEnsureServiceAccountKeepsSecrets(c *gc.C) {
+	automount := false
+	existing := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"other": "label"},
+		},
+		Secrets:                      []core.ObjectReference{{Name: "juju-test-token-abcde"}},
+		AutomountServiceAccountToken: &automount,
+	}
+	client := s.ensureServiceAccount(c, existing)
+
+	sa, err := client.CoreV1().ServiceAccounts(testNamespace).Get("juju-test", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Secrets, jc.DeepEquals, []core.ObjectReference{{Name: "juju-test-token-abcde"}})
+	c.Assert(sa.Labels, jc.DeepEquals, map[string]string{"other": "label", "juju-application": "test"})
+	c.Assert(sa.AutomountServiceAccountToken, gc.IsNil)
+}
+
+func (s *rbacSuite) TestEnsureServiceAccountRemovesOldRoles(c *gc.C) {
+	labels := map[string]string{"juju-application": "test"}
+	client := s.ensureServiceAccount(c,
+		&rbacv1.Role{ObjectMeta: v1.ObjectMeta{Name: "juju-test-writer", Namespace: testNamespace, Labels: labels}},
+		&rbacv1.RoleBinding{ObjectMeta: v1.ObjectMeta{Name: "juju-test-writer", Namespace: testNamespace, Labels: labels}},
+		&rbacv1.Role{ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-other-writer",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"juju-application": "other"},
+		}},
+	)
+
+	roles, err := client.RbacV1().Roles(testNamespace).List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, role := range roles.Items {
+		names = append(names, role.Name)
+	}
+	c.Assert(names, jc.SameContents, []string{"juju-test-reader", "juju-other-writer"})
+	_, err = client.RbacV1().RoleBindings(testNamespace).Get("juju-test-writer", v1.GetOptions{})
+	c.Assert(k8serrors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *rbacSuite) TestDeleteServiceAccount(c *gc.C) {
+	client := s.ensureServiceAccount(c)
+
+	err := provider.DeleteServiceAccount(client, testNamespace, "test")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.CoreV1().ServiceAccounts(testNamespace).Get("juju-test", v1.GetOptions{})
+	c.Assert(k8serrors.IsNotFound(err), jc.IsTrue)
+	roles, err := client.RbacV1().Roles(testNamespace).List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles.Items, gc.HasLen, 0)
+	clusterRoles, err := client.RbacV1().ClusterRoles().List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusterRoles.Items, gc.HasLen, 0)
+
+	// Deleting a missing service account is not an error.
+	err = provider.DeleteServiceAccount(client, testNamespace, "test")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// ensurePodSecrets creates or updates the secrets declared in the pod
+// spec of the specified application, and deletes any secrets previously
+// created for the application which the spec no longer declares.
+func (k *kubernetesClient) ensurePodSecrets(appName string, secrets []caas.Secret) error {
+	keep := set.NewStrings()
+	for _, secret := range secrets {
+		spec := k.podSecret(appName, &secret)
+		if err := k.ensureSecret(spec); err != nil {
+			return errors.Annotatef(err, "creating or updating secret %q", secret.Name)
+		}
+		keep.Add(spec.Name)
+	}
+	return errors.Trace(k.deletePodSecrets(appName, keep))
+}
+
+func (k *kubernetesClient) podSecret(appName string, secret *caas.Secret) *core.Secret {
+	secretType := core.SecretTypeOpaque
+	if secret.Type != "" {
+		secretType = core.SecretType(secret.Type)
+	}
+	data := make(map[string][]byte)
+	for key, value := range secret.Data {
+		data[key] = []byte(value)
+	}
+	return &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      podSecretName(appName, secret.Name),
+			Namespace: k.namespace,
+			Labels:    map[string]string{labelApplication: appName, labelSecret: secret.Name},
+		},
+		Type: secretType,
+		Data: data,
+	}
+}
+
+// deletePodSecrets deletes the pod spec secrets of the specified
+// application except those named in keep.
+func (k *kubernetesClient) deletePodSecrets(appName string, keep set.Strings) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	secretList, err := secrets.List(v1.ListOptions{
+		LabelSelector: podSecretSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, secret := range secretList.Items {
+		if keep.Contains(secret.Name) {
+			continue
+		}
+		err := secrets.Delete(secret.Name, &v1.DeleteOptions{
+			PropagationPolicy: &defaultPropagationPolicy,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting secret %q", secret.Name)
+		}
+	}
+	return nil
+}
+
+// configurePodSecrets adds the environment variables and volumes
+// which expose the pod spec secrets used by the container.
+func configurePodSecrets(pod *core.PodSpec, container *core.Container, appName string, c caas.ContainerSpec) {
+	// Sort the variables so the pod spec doesn't change between runs.
+	var envNames []string
+	for name := range c.SecretConfig {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		ref := c.SecretConfig[name]
+		container.Env = append(container.Env, core.EnvVar{
+			Name: name,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{
+						Name: podSecretName(appName, ref.Secret),
+					},
+					Key: ref.Key,
+				},
+			},
+		})
+	}
+
+	for _, name := range c.EnvFromSecrets {
+		container.EnvFrom = append(container.EnvFrom, core.EnvFromSource{
+			SecretRef: &core.SecretEnvSource{
+				LocalObjectReference: core.LocalObjectReference{
+					Name: podSecretName(appName, name),
+				},
+			},
+		})
+	}
+
+	for _, mount := range c.SecretFiles {
+		secretName := podSecretName(appName, mount.Secret)
+		// Containers mounting the same secret share its volume.
+		if !hasVolume(pod, secretName) {
+			pod.Volumes = append(pod.Volumes, core.Volume{
+				Name: secretName,
+				VolumeSource: core.VolumeSource{
+					Secret: &core.SecretVolumeSource{
+						SecretName: secretName,
+					},
+				},
+			})
+		}
+		container.VolumeMounts = append(container.VolumeMounts, core.VolumeMount{
+			Name:      secretName,
+			MountPath: mount.MountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+func hasVolume(pod *core.PodSpec, name string) bool {
+	for _, vol := range pod.Volumes {
+		if vol.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func podSecretSelector(appName string) string {
+	return fmt.Sprintf("%v==%v,%v", labelApplication, appName, labelSecret)
+}
+
+func podSecretName(appName, secretName string) string {
+	return deploymentName(appName) + "-secret-" + secretName
+}
@@ -56,6 +56,31 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// AddActionSchedules adds schedules that enqueue actions on the
+	// units of applications at regular intervals.
+	AddActionSchedules(params.AddActionScheduleArgs) (params.ErrorResults, error)
+
+	// ActionSchedules returns the details of the named action schedules,
+	// or all action schedules if no names are specified.
+	ActionSchedules(params.ActionScheduleNames) (params.ActionScheduleResults, error)
+
+	// PauseActionSchedules pauses the named action schedules.
+	PauseActionSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
+
+	// ResumeActionSchedules resumes the named paused action schedules.
+	ResumeActionSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
+
+	// RemoveActionSchedules removes the named action schedules.
+	RemoveActionSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
+
+	// AddActionRollouts adds rollouts that enqueue an action on every
+	// unit of an application in batches.
+	AddActionRollouts(params.AddActionRolloutArgs) (params.ActionRolloutResults, error)
+
+	// ActionRollouts returns the details of the action rollouts with
+	// the specified IDs.
+	ActionRollouts(params.ActionRolloutIds) (params.ActionRolloutResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds a schedule that enqueues an action on the
+// units of an application at regular intervals.
+type addScheduleCommand struct {
+	ActionCommandBase
+	name           string
+	applicationTag names.ApplicationTag
+	actionName     string
+	cron           string
+	interval       time.Duration
+	target         string
+	parseStrings   bool
+	args           [][]string
+}
+
+const addScheduleDoc = `
+Add a schedule that queues an action for execution on the units of an
+application at regular intervals.
+
+The schedule is specified either as a cron specification with the --cron
+flag, or as an interval with the --every flag. Cron specifications have
+five fields (minute, hour, day of month, month and day of week), and are
+evaluated in UTC. The shortcuts @hourly, @daily, @midnight, @weekly,
+@monthly and @yearly may be used in place of the five fields. Intervals
+must be at least one minute.
+
+By default the action is queued on every unit of the application at once.
+The --target flag may be used to queue the action on the application's
+leader only ("leader"), or on each unit in turn, waiting for the action
+on one unit to finish before queueing it on the next ("one-at-a-time").
+
+Params are validated according to the charm for the application, and may
+be specified by a key.key.key...=value format, as with 'juju run-action'.
+Params are parsed as YAML unless the --string-args flag is set.
+
+Runs missed while the controller is unavailable are not made up.
+
+Examples:
+
+    juju add-action-schedule nightly-backup mysql backup --cron "30 2 * * *"
+    juju add-action-schedule hourly-check mysql check --every 1h --target leader
+    juju add-action-schedule rolling-snapshot mysql snapshot --cron @weekly \
+        --target one-at-a-time outfile=/var/backups/db.tar.bz2
+
+See also:
+    action-schedules
+    pause-action-schedule
+    remove-action-schedule
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.cron, "cron", "", "Cron specification describing when to queue the action")
+	f.DurationVar(&c.interval, "every", 0, "Interval at which to queue the action")
+	f.StringVar(&c.target, "target", "all", `Units to queue the action on: "all", "leader" or "one-at-a-time"`)
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-action-schedule",
+		Args:    "<schedule name> <application> <action name> [key.key.key...=value]",
+		Purpose: "Queue an action for execution at regular intervals.",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addScheduleCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("schedule name, application and action name must be specified")
+	}
+	c.name = args[0]
+	if !names.IsValidApplication(args[1]) {
+		return errors.Errorf("invalid application name %q", args[1])
+	}
+	c.applicationTag = names.NewApplicationTag(args[1])
+	if !nameRule.MatchString(args[2]) {
+		return errors.Errorf("invalid action name %q", args[2])
+	}
+	c.actionName = args[2]
+	switch {
+	case c.cron == "" && c.interval == 0:
+		return errors.New("one of --cron or --every must be specified")
+	case c.cron != "" && c.interval != 0:
+		return errors.New("--cron and --every are mutually exclusive")
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	if err := addKeyValueArgs(c.args, c.parseStrings, actionParams); err != nil {
+		return err
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", conformantParams)
+	}
+
+	results, err := api.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Name:           c.name,
+			ApplicationTag: c.applicationTag.String(),
+			Action:         c.actionName,
+			Parameters:     typedConformantParams,
+			Cron:           c.cron,
+			Interval:       c.interval,
+			Target:         c.target,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -53,6 +53,10 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) Application() string {
+	return c.application
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -95,6 +99,42 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewPauseScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &pauseScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewResumeScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &resumeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowRolloutCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showRolloutCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
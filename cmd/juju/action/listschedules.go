@@ -0,0 +1,210 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	names []string
+	out   cmd.Output
+}
+
+const listSchedulesDoc = `
+List the action schedules in the model, or the named action schedules.
+
+The tabular output shows when each schedule next runs, and when it last
+ran. The yaml and json output also show the parameters of each schedule,
+and the history of its most recent runs, including the IDs of the actions
+queued by each run. The results of those actions may be seen using
+'juju show-action-output <ID>'.
+
+Examples:
+
+    juju action-schedules
+    juju action-schedules nightly-backup --format yaml
+
+See also:
+    add-action-schedule
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "action-schedules",
+		Args:    "[<schedule name>...]",
+		Purpose: "List action schedules.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	c.names = args
+	return nil
+}
+
+type scheduleOutput struct {
+	Application string                 `yaml:"application" json:"application"`
+	Action      string                 `yaml:"action" json:"action"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Cron        string                 `yaml:"cron,omitempty" json:"cron,omitempty"`
+	Every       string                 `yaml:"every,omitempty" json:"every,omitempty"`
+	Target      string                 `yaml:"target" json:"target"`
+	Paused      bool                   `yaml:"paused" json:"paused"`
+	Created     string                 `yaml:"created" json:"created"`
+	NextRun     string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	Runs        []scheduleRunOutput    `yaml:"runs,omitempty" json:"runs,omitempty"`
+}
+
+type scheduleRunOutput struct {
+	Run          int      `yaml:"run" json:"run"`
+	Started      string   `yaml:"started" json:"started"`
+	Actions      []string `yaml:"actions,omitempty" json:"actions,omitempty"`
+	PendingUnits []string `yaml:"pending-units,omitempty" json:"pending-units,omitempty"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules to display.")
+		return nil
+	}
+
+	schedules := make(map[string]scheduleOutput)
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		name, schedule, err := formatSchedule(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		schedules[name] = schedule
+	}
+	return c.out.Write(ctx, schedules)
+}
+
+func formatSchedule(details *params.ActionScheduleDetails) (string, scheduleOutput, error) {
+	appTag, err := names.ParseApplicationTag(details.ApplicationTag)
+	if err != nil {
+		return "", scheduleOutput{}, errors.Trace(err)
+	}
+	out := scheduleOutput{
+		Application: appTag.Id(),
+		Action:      details.Action,
+		Parameters:  details.Parameters,
+		Cron:        details.Cron,
+		Target:      details.Target,
+		Paused:      details.Paused,
+		Created:     formatScheduleTime(details.Created),
+		NextRun:     formatScheduleTime(details.NextRun),
+	}
+	if details.Interval != 0 {
+		out.Every = details.Interval.String()
+	}
+	for _, run := range details.Runs {
+		runOut := scheduleRunOutput{
+			Run:          run.Run,
+			Started:      formatScheduleTime(run.Started),
+			PendingUnits: run.PendingUnits,
+		}
+		for _, tag := range run.ActionTags {
+			actionTag, err := names.ParseActionTag(tag)
+			if err != nil {
+				return "", scheduleOutput{}, errors.Trace(err)
+			}
+			runOut.Actions = append(runOut.Actions, actionTag.Id())
+		}
+		out.Runs = append(out.Runs, runOut)
+	}
+	return details.Name, out, nil
+}
+
+// formatScheduleTime formats times in UTC, the time zone in which cron
+// specifications are evaluated.
+func formatScheduleTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return common.FormatTime(&t, true)
+}
+
+// printTabular prints the action schedules in tabular format.
+func (c *listSchedulesCommand) printTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		"Schedule", "Application", "Action", "When", "Target", "Status", "Next run", "Last run")
+	var scheduleNames []string
+	for name := range schedules {
+		scheduleNames = append(scheduleNames, name)
+	}
+	naturalsort.Sort(scheduleNames)
+	for _, name := range scheduleNames {
+		schedule := schedules[name]
+		when := schedule.Cron
+		if when == "" {
+			when = "every " + schedule.Every
+		}
+		status, nextRun := "active", schedule.NextRun
+		if schedule.Paused {
+			status, nextRun = "paused", ""
+		}
+		var lastRun string
+		if n := len(schedule.Runs); n > 0 {
+			lastRun = schedule.Runs[n-1].Started
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, schedule.Application, schedule.Action, when, schedule.Target, status, nextRun, lastRun)
+	}
+	tw.Flush()
+	return nil
+}
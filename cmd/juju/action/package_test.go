@@ -131,6 +131,14 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	addedSchedules     params.AddActionScheduleArgs
+	scheduleResults    []params.ActionScheduleResult
+	scheduleCall       string
+	scheduleNames      params.ActionScheduleNames
+	errorResults       []params.ErrorResult
+	addedRollouts      params.AddActionRolloutArgs
+	rolloutResults     []params.ActionRolloutResult
+	rolloutIds         params.ActionRolloutIds
 	apiErr             error
 }
 
@@ -213,3 +221,41 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) AddActionSchedules(args params.AddActionScheduleArgs) (params.ErrorResults, error) {
+	c.addedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionSchedules(args params.ActionScheduleNames) (params.ActionScheduleResults, error) {
+	c.scheduleNames = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) PauseActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("PauseActionSchedules", args)
+}
+
+func (c *fakeAPIClient) ResumeActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("ResumeActionSchedules", args)
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return c.updateActionSchedules("RemoveActionSchedules", args)
+}
+
+func (c *fakeAPIClient) updateActionSchedules(call string, args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.scheduleCall = call
+	c.scheduleNames = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddActionRollouts(args params.AddActionRolloutArgs) (params.ActionRolloutResults, error) {
+	c.addedRollouts = args
+	return params.ActionRolloutResults{Results: c.rolloutResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	c.rolloutIds = args
+	return params.ActionRolloutResults{Results: c.rolloutResults}, c.apiErr
+}
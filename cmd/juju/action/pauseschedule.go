@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewPauseScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&pauseScheduleCommand{})
+}
+
+// pauseScheduleCommand pauses action schedules.
+type pauseScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const pauseScheduleDoc = `
+Pause the named action schedules, so that they queue no actions until
+they are resumed. Actions already queued by the schedules are not
+affected.
+
+Examples:
+
+    juju pause-action-schedule nightly-backup
+
+See also:
+    action-schedules
+    resume-action-schedule
+`
+
+// Info is part of the cmd.Command interface.
+func (c *pauseScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "pause-action-schedule",
+		Args:    "<schedule name> [<schedule name>...]",
+		Purpose: "Pause action schedules.",
+		Doc:     pauseScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *pauseScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedules specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *pauseScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.PauseActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	return results.Combine()
+}
+
+func NewResumeScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&resumeScheduleCommand{})
+}
+
+// resumeScheduleCommand resumes paused action schedules.
+type resumeScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const resumeScheduleDoc = `
+Resume the named paused action schedules. Runs missed while a schedule
+was paused are not made up; the schedule next runs at the first
+scheduled time after it is resumed.
+
+Examples:
+
+    juju resume-action-schedule nightly-backup
+
+See also:
+    action-schedules
+    pause-action-schedule
+`
+
+// Info is part of the cmd.Command interface.
+func (c *resumeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-action-schedule",
+		Args:    "<schedule name> [<schedule name>...]",
+		Purpose: "Resume paused action schedules.",
+		Doc:     resumeScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *resumeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedules specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *resumeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ResumeActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	return results.Combine()
+}
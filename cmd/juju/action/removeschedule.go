@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const removeScheduleDoc = `
+Remove the named action schedules, along with the history of their runs.
+Actions already queued by the schedules are not affected.
+
+Examples:
+
+    juju remove-action-schedule nightly-backup
+
+See also:
+    action-schedules
+    add-action-schedule
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<schedule name> [<schedule name>...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedules specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	return results.Combine()
+}
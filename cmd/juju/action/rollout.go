@@ -0,0 +1,278 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// rolloutRunning is the status of an action rollout that may still
+// queue actions.
+const rolloutRunning = "running"
+
+// initRollout parses the arguments of a run-action command that rolls
+// an action out across the units of an application.
+func (c *runCommand) initRollout(args []string) error {
+	c.application = args[0]
+	c.actionName = args[1]
+	if !nameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[2:])
+	return err
+}
+
+// runRollout asks the controller to roll the action out across the
+// units of the application and, if requested, waits for the rollout's
+// actions to finish.
+func (c *runCommand) runRollout(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	results, err := api.AddActionRollouts(params.AddActionRolloutArgs{
+		Rollouts: []params.AddActionRolloutArg{{
+			ApplicationTag: names.NewApplicationTag(c.application).String(),
+			Action:         c.actionName,
+			Parameters:     actionParams,
+			MaxParallel:    c.maxParallel,
+			StopOnFailures: c.stopOnFailures,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	if results.Results[0].Error != nil {
+		return results.Results[0].Error
+	}
+	details := results.Results[0].Result
+	if details == nil {
+		return errors.Errorf("action rollout failed to start on %q", c.application)
+	}
+
+	if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 {
+		output := map[string]string{"Rollout queued with id": details.Id}
+		return c.out.Write(ctx, output)
+	}
+
+	var wait *time.Timer
+	if c.wait.d.Nanoseconds() <= 0 {
+		// Indefinite wait. Discard the tick.
+		wait = time.NewTimer(0 * time.Second)
+		_ = <-wait.C
+	} else {
+		wait = time.NewTimer(c.wait.d)
+	}
+	tick := time.NewTimer(2 * time.Second)
+	result, err := rolloutTimerLoop(api, details.Id, wait, tick)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatActionRollout(result))
+}
+
+// rolloutTimerLoop queries the given API until the action rollout
+// has finished and none of its actions are pending or running, or
+// until "wait" times out, using the "tick" timer to delay the API
+// queries.
+func rolloutTimerLoop(api APIClient, id string, wait, tick *time.Timer) (params.ActionRolloutDetails, error) {
+	for {
+		result, err := fetchActionRollout(api, id)
+		if err != nil {
+			return result, err
+		}
+		if rolloutFinished(result) {
+			return result, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case _ = <-wait.C:
+			return result, nil
+
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// rolloutFinished reports whether the action rollout will queue no
+// more actions, and all of the actions it has queued have finished.
+func rolloutFinished(details params.ActionRolloutDetails) bool {
+	if details.Status == rolloutRunning {
+		return false
+	}
+	for _, result := range details.Actions {
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+			return false
+		}
+	}
+	return true
+}
+
+// fetchActionRollout queries the given API for the details of the
+// action rollout with the given ID.
+func fetchActionRollout(api APIClient, id string) (params.ActionRolloutDetails, error) {
+	results, err := api.ActionRollouts(params.ActionRolloutIds{Ids: []string{id}})
+	if err != nil {
+		return params.ActionRolloutDetails{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.ActionRolloutDetails{}, errors.Errorf("expected one result for action rollout %q, got %d", id, len(results.Results))
+	}
+	if results.Results[0].Error != nil {
+		return params.ActionRolloutDetails{}, results.Results[0].Error
+	}
+	if results.Results[0].Result == nil {
+		return params.ActionRolloutDetails{}, errors.NotFoundf("action rollout %q", id)
+	}
+	return *results.Results[0].Result, nil
+}
+
+// formatActionRollout returns a summary of an action rollout suitable
+// for output: the number of actions with each status, and the result
+// of the action on each unit.
+func formatActionRollout(details params.ActionRolloutDetails) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":     details.Id,
+		"action": details.Action,
+		"status": details.Status,
+	}
+	if tag, err := names.ParseApplicationTag(details.ApplicationTag); err == nil {
+		out["application"] = tag.Id()
+	}
+	if !details.Created.IsZero() {
+		out["created"] = common.FormatTime(&details.Created, true)
+	}
+	if !details.Finished.IsZero() {
+		out["finished"] = common.FormatTime(&details.Finished, true)
+	}
+
+	summary := make(map[string]int)
+	units := make(map[string]interface{})
+	for _, result := range details.Actions {
+		if result.Action == nil {
+			continue
+		}
+		summary[result.Status]++
+		unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+		if err != nil {
+			continue
+		}
+		d := FormatActionResult(result)
+		if actionTag, err := names.ParseActionTag(result.Action.Tag); err == nil {
+			d["id"] = actionTag.Id()
+		}
+		units[unitTag.Id()] = d
+	}
+	if len(details.FailedUnits) > 0 {
+		summary["not-queued"] = len(details.FailedUnits)
+		out["not-queued"] = details.FailedUnits
+	}
+	if len(details.PendingUnits) > 0 {
+		summary["not-started"] = len(details.PendingUnits)
+		out["pending-units"] = details.PendingUnits
+	}
+	out["summary"] = summary
+	if len(units) > 0 {
+		out["units"] = units
+	}
+	return out
+}
+
+func NewShowRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&showRolloutCommand{})
+}
+
+// showRolloutCommand shows the progress of an action rollout.
+type showRolloutCommand struct {
+	ActionCommandBase
+	id   string
+	wait waitFlag
+	out  cmd.Output
+}
+
+const showRolloutDoc = `
+Show the progress of an action rollout started by 'juju run-action' with an
+application, including the number of its actions with each status and the
+result of the action on each unit.
+
+Examples:
+
+    juju show-action-rollout 3
+    juju show-action-rollout 3 --wait
+
+See also:
+    run-action
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showRolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(&c.wait, "wait", "Wait for the rollout to finish, with optional timeout")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showRolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-action-rollout",
+		Args:    "<rollout ID>",
+		Purpose: "Show the progress of an action rollout.",
+		Doc:     showRolloutDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showRolloutCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no action rollout ID specified")
+	case 1:
+		c.id = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showRolloutCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var wait *time.Timer
+	if c.wait.d.Nanoseconds() > 0 {
+		wait = time.NewTimer(c.wait.d)
+	} else {
+		// Without --wait, the zero timer fires straight away so the
+		// rollout is queried once.
+		wait = time.NewTimer(0 * time.Second)
+		if c.wait.forever {
+			// Indefinite wait. Discard the tick.
+			_ = <-wait.C
+		}
+	}
+	tick := time.NewTimer(2 * time.Second)
+	result, err := rolloutTimerLoop(api, c.id, wait, tick)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatActionRollout(result))
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+const otherActionId = "f47ac10b-58cc-4372-a567-0e02b2c3d480"
+
+type RolloutSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&RolloutSuite{})
+
+func (s *RolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *RolloutSuite) setRolloutResults(status string) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Result: &params.ActionRolloutDetails{
+			Id:             "0",
+			ApplicationTag: "application-mysql",
+			Action:         "backup",
+			MaxParallel:    2,
+			StopOnFailures: 1,
+			Status:         status,
+			Created:        time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC),
+			Finished:       time.Date(2018, 6, 1, 10, 5, 0, 0, time.UTC),
+			PendingUnits:   []string{"mysql/2"},
+			Actions: []params.ActionResult{{
+				Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+				Status: params.ActionCompleted,
+			}, {
+				Action:  &params.Action{Tag: "action-" + otherActionId, Receiver: "unit-mysql-1"},
+				Status:  params.ActionFailed,
+				Message: "boom",
+			}},
+		},
+	}}
+}
+
+const stoppedRolloutYAML = `
+action: backup
+application: mysql
+created: 2018-06-01 10:00:00Z
+finished: 2018-06-01 10:05:00Z
+id: "0"
+pending-units:
+- mysql/2
+status: stopped
+summary:
+  completed: 1
+  failed: 1
+  not-started: 1
+units:
+  mysql/0:
+    id: ` + validActionId + `
+    status: completed
+  mysql/1:
+    id: ` + otherActionId + `
+    message: boom
+    status: failed
+`
+
+func (s *RolloutSuite) TestInit(c *gc.C) {
+	wrappedCommand, command := action.NewRunCommandForTest(s.store)
+	err := cmdtesting.InitCommand(wrappedCommand, []string{
+		"-m", "admin", "mysql", "backup", "--max-parallel", "2", "--stop-on-failures", "1", "out=x",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.Application(), gc.Equals, "mysql")
+	c.Assert(command.UnitTags(), gc.HasLen, 0)
+	c.Assert(command.ActionName(), gc.Equals, "backup")
+	c.Assert(command.Args(), jc.DeepEquals, [][]string{{"out", "x"}})
+}
+
+func (s *RolloutSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql", "Backup"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"mysql", "backup", "--max-parallel", "-1"},
+		err:  "--max-parallel must not be negative",
+	}, {
+		args: []string{"mysql", "backup", "--stop-on-failures", "-1"},
+		err:  "--stop-on-failures must not be negative",
+	}, {
+		args: []string{validUnitId, "backup", "--max-parallel", "2"},
+		err:  "--max-parallel and --stop-on-failures require an application",
+	}, {
+		args: []string{"mysql", "backup", "out"},
+		err:  `argument "out" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		err := cmdtesting.InitCommand(wrappedCommand, append([]string{"-m", "admin"}, t.args...))
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *RolloutSuite) TestRunRollout(c *gc.C) {
+	s.setRolloutResults("running")
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql", "backup", "--max-parallel", "2", "--stop-on-failures", "1", "out=x",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.addedRollouts, jc.DeepEquals, params.AddActionRolloutArgs{
+		Rollouts: []params.AddActionRolloutArg{{
+			ApplicationTag: "application-mysql",
+			Action:         "backup",
+			Parameters:     map[string]interface{}{"out": "x"},
+			MaxParallel:    2,
+			StopOnFailures: 1,
+		}},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "Rollout queued with id: \"0\"\n")
+}
+
+func (s *RolloutSuite) TestRunRolloutWait(c *gc.C) {
+	s.setRolloutResults("stopped")
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.rolloutIds, jc.DeepEquals, params.ActionRolloutIds{Ids: []string{"0"}})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, stoppedRolloutYAML[1:])
+}
+
+func (s *RolloutSuite) TestRunRolloutError(c *gc.C) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Error: &params.Error{Message: `application "mysql" has no alive units`},
+	}}
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" has no alive units`)
+}
+
+func (s *RolloutSuite) TestShowRollout(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewShowRolloutCommandForTest(s.store), []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no action rollout ID specified")
+
+	s.setRolloutResults("stopped")
+	ctx, err := cmdtesting.RunCommand(c, action.NewShowRolloutCommandForTest(s.store), "-m", "admin", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.rolloutIds, jc.DeepEquals, params.ActionRolloutIds{Ids: []string{"0"}})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, stoppedRolloutYAML[1:])
+}
+
+func (s *RolloutSuite) TestShowRolloutNotFound(c *gc.C) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Error: &params.Error{Message: `action rollout "42" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewShowRolloutCommandForTest(s.store), "-m", "admin", "42")
+	c.Assert(err, gc.ErrorMatches, `action rollout "42" not found`)
+}
@@ -32,12 +32,16 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	ActionCommandBase
 	unitTags     []names.UnitTag
+	application  string
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
 	wait         waitFlag
 	out          cmd.Output
 	args         [][]string
+
+	maxParallel    int
+	stopOnFailures int
 }
 
 const runDoc = `
@@ -45,6 +49,14 @@ Queue an Action for execution on a given unit, with a given set of params.
 The Action ID is returned for use with 'juju show-action-output <ID>' or
 'juju show-action-status <ID>'.
 
+If an application is specified instead of units, the Action is rolled out
+across every unit of the application by the controller. At most
+--max-parallel of the Actions are queued or running at once, and the
+rollout stops queueing Actions once --stop-on-failures of them have failed.
+The rollout continues if the client disconnects; its ID is returned for use
+with 'juju show-action-rollout <ID>'. With --wait, a summary of the
+rollout's Actions is shown once they have all finished.
+
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -111,6 +123,9 @@ file:
   quality: high
 ...
 
+$ juju run-action mysql backup --max-parallel 2 --stop-on-failures 1
+Rollout queued with id: <ID>
+
 $ juju run-action sleeper/0 pause time=1000
 ...
 
@@ -126,19 +141,35 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Maximum number of units running the action at once, when an application is specified (0 for no limit)")
+	f.IntVar(&c.stopOnFailures, "stop-on-failures", 0, "Stop queueing the action after this many failures, when an application is specified (0 to never stop)")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "(<unit> [<unit> ...] | <application>) <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag(s), action name and action arguments.
+// Init gets the unit tag(s) or application, action name and action
+// arguments.
 func (c *runCommand) Init(args []string) error {
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must not be negative")
+	}
+	if c.stopOnFailures < 0 {
+		return errors.Errorf("--stop-on-failures must not be negative")
+	}
+	if len(args) >= 2 && !names.IsValidUnit(args[0]) && names.IsValidApplication(args[0]) {
+		return c.initRollout(args)
+	}
+	if c.maxParallel > 0 || c.stopOnFailures > 0 {
+		return errors.New("--max-parallel and --stop-on-failures require an application")
+	}
+
 	var unitNames []string
 	for idx, arg := range args {
 		if names.IsValidUnit(arg) {
@@ -162,21 +193,51 @@ func (c *runCommand) Init(args []string) error {
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
+	var err error
+	c.args, err = parseKeyValueArgs(args[len(unitNames)+1:])
+	return err
+}
+
+// parseKeyValueArgs parses action parameters specified on the command
+// line in key.key.key...=value format, returning a slice of the form
+// {..., [key, key, key, key, value], ...}.
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key...=value", arg)
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
 		}
 		keySlice := strings.Split(thisArg[0], ".")
 		// check each key for validity
 		for _, key := range keySlice {
 			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// addKeyValueArgs sets the parameters parsed by parseKeyValueArgs in
+// the target map. Values are parsed as YAML unless parseStrings is set.
+func addKeyValueArgs(args [][]string, parseStrings bool, target map[string]interface{}) error {
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return err
 			}
 		}
-		// c.args={..., [key, key, key, key, value]}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, target)
 	}
 	return nil
 }
@@ -214,21 +275,8 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		actionParams = betterParams
 	}
 
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
+	if err := addKeyValueArgs(c.args, c.parseStrings, actionParams); err != nil {
+		return err
 	}
 
 	conformantParams, err := common.ConformYAML(actionParams)
@@ -241,6 +289,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.application != "" {
+		return c.runRollout(ctx, api, typedConformantParams)
+	}
+
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
 		actions[i].Receiver = unitTag.String()
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) TestAddScheduleInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"backup", "mysql"},
+		err:  "schedule name, application and action name must be specified",
+	}, {
+		args: []string{"backup", "mysql/0", "snapshot", "--every", "1h"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"backup", "mysql", "Snapshot", "--every", "1h"},
+		err:  `invalid action name "Snapshot"`,
+	}, {
+		args: []string{"backup", "mysql", "snapshot"},
+		err:  "one of --cron or --every must be specified",
+	}, {
+		args: []string{"backup", "mysql", "snapshot", "--every", "1h", "--cron", "@daily"},
+		err:  "--cron and --every are mutually exclusive",
+	}, {
+		args: []string{"backup", "mysql", "snapshot", "--every", "1h", "outfile"},
+		err:  `argument "outfile" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := cmdtesting.InitCommand(action.NewAddScheduleCommandForTest(s.store), append([]string{"-m", "admin"}, t.args...))
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ScheduleSuite) TestAddSchedule(c *gc.C) {
+	s.client.errorResults = []params.ErrorResult{{}}
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"-m", "admin", "backup", "mysql", "snapshot", "--cron", "30 2 * * *",
+		"--target", "one-at-a-time", "outfile=out.tar.bz2", "level=5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.addedSchedules, jc.DeepEquals, params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Name:           "backup",
+			ApplicationTag: "application-mysql",
+			Action:         "snapshot",
+			Parameters:     map[string]interface{}{"outfile": "out.tar.bz2", "level": 5},
+			Cron:           "30 2 * * *",
+			Target:         "one-at-a-time",
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestAddScheduleError(c *gc.C) {
+	s.client.errorResults = []params.ErrorResult{{
+		Error: &params.Error{Message: `action schedule "backup" already exists`},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"-m", "admin", "backup", "mysql", "snapshot", "--every", "1h",
+	)
+	c.Assert(err, gc.ErrorMatches, `action schedule "backup" already exists`)
+	c.Assert(s.client.addedSchedules.Schedules[0].Interval, gc.Equals, time.Hour)
+	c.Assert(s.client.addedSchedules.Schedules[0].Target, gc.Equals, "all")
+}
+
+func (s *ScheduleSuite) setScheduleResults() {
+	started := time.Date(2018, 6, 2, 2, 30, 0, 0, time.UTC)
+	s.client.scheduleResults = []params.ActionScheduleResult{{
+		Result: &params.ActionScheduleDetails{
+			Name:           "backup",
+			ApplicationTag: "application-mysql",
+			Action:         "snapshot",
+			Parameters:     map[string]interface{}{"outfile": "out.tar.bz2"},
+			Cron:           "30 2 * * *",
+			Target:         "one-at-a-time",
+			Created:        time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC),
+			NextRun:        started.Add(24 * time.Hour),
+			Runs: []params.ActionScheduleRun{{
+				Run:          1,
+				Started:      started,
+				ActionTags:   []string{validActionTagString},
+				PendingUnits: []string{"mysql/1"},
+			}},
+		},
+	}, {
+		Result: &params.ActionScheduleDetails{
+			Name:           "check",
+			ApplicationTag: "application-mysql",
+			Action:         "check",
+			Interval:       time.Hour,
+			Target:         "leader",
+			Paused:         true,
+			Created:        time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC),
+			NextRun:        time.Date(2018, 6, 1, 11, 0, 0, 0, time.UTC),
+		},
+	}}
+}
+
+func (s *ScheduleSuite) TestListSchedulesTabular(c *gc.C) {
+	s.setScheduleResults()
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Schedule  Application  Action    When          Target         Status  Next run              Last run\n"+
+		"backup    mysql        snapshot  30 2 * * *    one-at-a-time  active  2018-06-03 02:30:00Z  2018-06-02 02:30:00Z\n"+
+		"check     mysql        check     every 1h0m0s  leader         paused                        \n",
+	)
+}
+
+func (s *ScheduleSuite) TestListSchedulesYAML(c *gc.C) {
+	s.setScheduleResults()
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store),
+		"-m", "admin", "--format", "yaml", "backup",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.scheduleNames, jc.DeepEquals, params.ActionScheduleNames{Names: []string{"backup"}})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+backup:
+  application: mysql
+  action: snapshot
+  parameters:
+    outfile: out.tar.bz2
+  cron: 30 2 * * *
+  target: one-at-a-time
+  paused: false
+  created: 2018-06-01 10:00:00Z
+  next-run: 2018-06-03 02:30:00Z
+  runs:
+  - run: 1
+    started: 2018-06-02 02:30:00Z
+    actions:
+    - `[1:]+validActionId+`
+    pending-units:
+    - mysql/1
+check:
+  application: mysql
+  action: check
+  every: 1h0m0s
+  target: leader
+  paused: true
+  created: 2018-06-01 10:00:00Z
+  next-run: 2018-06-01 11:00:00Z
+`)
+}
+
+func (s *ScheduleSuite) TestListSchedulesNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules to display.\n")
+}
+
+func (s *ScheduleSuite) TestListSchedulesError(c *gc.C) {
+	s.client.scheduleResults = []params.ActionScheduleResult{{
+		Error: &params.Error{Message: `action schedule "missing" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "missing")
+	c.Assert(err, gc.ErrorMatches, `action schedule "missing" not found`)
+}
+
+func (s *ScheduleSuite) TestPauseResumeRemoveSchedule(c *gc.C) {
+	for i, t := range []struct {
+		newCommand func() cmd.Command
+		call       string
+	}{{
+		newCommand: func() cmd.Command { return action.NewPauseScheduleCommandForTest(s.store) },
+		call:       "PauseActionSchedules",
+	}, {
+		newCommand: func() cmd.Command { return action.NewResumeScheduleCommandForTest(s.store) },
+		call:       "ResumeActionSchedules",
+	}, {
+		newCommand: func() cmd.Command { return action.NewRemoveScheduleCommandForTest(s.store) },
+		call:       "RemoveActionSchedules",
+	}} {
+		c.Logf("test %d: %s", i, t.call)
+		err := cmdtesting.InitCommand(t.newCommand(), []string{"-m", "admin"})
+		c.Check(err, gc.ErrorMatches, "no action schedules specified")
+
+		s.client.errorResults = []params.ErrorResult{{}, {
+			Error: &params.Error{Message: `action schedule "missing" not found`},
+		}}
+		_, err = cmdtesting.RunCommand(c, t.newCommand(), "-m", "admin", "backup", "missing")
+		c.Check(err, gc.ErrorMatches, `action schedule "missing" not found`)
+		c.Check(s.client.scheduleCall, gc.Equals, t.call)
+		c.Check(s.client.scheduleNames, jc.DeepEquals, params.ActionScheduleNames{
+			Names: []string{"backup", "missing"},
+		})
+	}
+}
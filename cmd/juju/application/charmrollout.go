@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+type charmRolloutAPI interface {
+	Close() error
+	BestAPIVersion() int
+	CharmRollout(string) (params.CharmRolloutDetails, error)
+	ResumeCharmRollout(string) error
+}
+
+// charmRolloutCommandBase holds what is common to the commands that
+// inspect and control rolling charm upgrades.
+type charmRolloutCommandBase struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+
+	newAPIFunc      func() (charmRolloutAPI, error)
+	applicationName string
+}
+
+func (c *charmRolloutCommandBase) init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *charmRolloutCommandBase) newAPI() (charmRolloutAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// NewShowCharmRolloutCommand returns a command which shows the progress
+// of an application's rolling charm upgrade.
+func NewShowCharmRolloutCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&showCharmRolloutCommand{})
+}
+
+// showCharmRolloutCommand shows the progress of a rolling charm upgrade.
+type showCharmRolloutCommand struct {
+	charmRolloutCommandBase
+	out cmd.Output
+}
+
+const showCharmRolloutDoc = `
+Show the progress of the most recent rolling upgrade of an application's
+charm, started by 'juju upgrade-charm' with --batch-size. The output shows the
+units the upgrade has been released to, the units still running the old charm
+and, if the upgrade was paused or rolled back, why.
+
+Examples:
+
+    juju show-charm-rollout mysql
+
+See also:
+    upgrade-charm
+    resume-charm-rollout
+`
+
+// Info implements cmd.Command.
+func (c *showCharmRolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-charm-rollout",
+		Args:    "<application>",
+		Purpose: "Show the progress of a rolling charm upgrade.",
+		Doc:     showCharmRolloutDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showCharmRolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *showCharmRolloutCommand) Init(args []string) error {
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *showCharmRolloutCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 9 {
+		return errors.New("rolling charm upgrades are not supported by this controller")
+	}
+	details, err := client.CharmRollout(c.applicationName)
+	if params.IsCodeNotFound(err) {
+		return errors.Errorf("application %q has no rolling charm upgrade", c.applicationName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatCharmRollout(details))
+}
+
+// formatCharmRollout returns a summary of a rolling charm upgrade
+// suitable for output.
+func formatCharmRollout(details params.CharmRolloutDetails) map[string]interface{} {
+	out := map[string]interface{}{
+		"from":         details.FromCharmURL,
+		"to":           details.ToCharmURL,
+		"batch-size":   details.BatchSize,
+		"ready-status": details.ReadyStatus,
+		"on-error":     details.OnError,
+		"status":       details.Status,
+	}
+	if tag, err := names.ParseApplicationTag(details.ApplicationTag); err == nil {
+		out["application"] = tag.Id()
+	}
+	if details.Message != "" {
+		out["message"] = details.Message
+	}
+	if !details.Started.IsZero() {
+		out["started"] = common.FormatTime(&details.Started, true)
+	}
+	if !details.Finished.IsZero() {
+		out["finished"] = common.FormatTime(&details.Finished, true)
+	}
+	if len(details.ReleasedUnits) > 0 {
+		out["released-units"] = details.ReleasedUnits
+	}
+	if len(details.PendingUnits) > 0 {
+		out["pending-units"] = details.PendingUnits
+	}
+	return out
+}
+
+// NewResumeCharmRolloutCommand returns a command which resumes an
+// application's paused rolling charm upgrade.
+func NewResumeCharmRolloutCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&resumeCharmRolloutCommand{})
+}
+
+// resumeCharmRolloutCommand resumes a paused rolling charm upgrade.
+type resumeCharmRolloutCommand struct {
+	charmRolloutCommandBase
+}
+
+const resumeCharmRolloutDoc = `
+Resume a rolling upgrade of an application's charm that was paused because a
+unit went into an error state. Resolve the error on the unit first; the
+upgrade continues once every unit it has been released to is ready.
+
+Examples:
+
+    juju resume-charm-rollout mysql
+
+See also:
+    upgrade-charm
+    show-charm-rollout
+`
+
+// Info implements cmd.Command.
+func (c *resumeCharmRolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-charm-rollout",
+		Args:    "<application>",
+		Purpose: "Resume a paused rolling charm upgrade.",
+		Doc:     resumeCharmRolloutDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *resumeCharmRolloutCommand) Init(args []string) error {
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *resumeCharmRolloutCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 9 {
+		return errors.New("rolling charm upgrades are not supported by this controller")
+	}
+	err = client.ResumeCharmRollout(c.applicationName)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type CharmRolloutSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockCharmRolloutAPI
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+type mockCharmRolloutAPI struct {
+	*testing.Stub
+	version int
+	details params.CharmRolloutDetails
+}
+
+func (s *mockCharmRolloutAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockCharmRolloutAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockCharmRolloutAPI) CharmRollout(application string) (params.CharmRolloutDetails, error) {
+	s.MethodCall(s, "CharmRollout", application)
+	return s.details, s.NextErr()
+}
+
+func (s *mockCharmRolloutAPI) ResumeCharmRollout(application string) error {
+	s.MethodCall(s, "ResumeCharmRollout", application)
+	return s.NextErr()
+}
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockCharmRolloutAPI{Stub: &testing.Stub{}, version: 9}
+}
+
+func (s *CharmRolloutSuite) store() jujuclient.ClientStore {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.IAAS,
+		}},
+	}
+	return store
+}
+
+func (s *CharmRolloutSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewShowCharmRolloutCommandForTest(s.mockAPI, s.store()), args...)
+}
+
+func (s *CharmRolloutSuite) runResume(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewResumeCharmRolloutCommandForTest(s.mockAPI, s.store()), args...)
+}
+
+func (s *CharmRolloutSuite) TestShow(c *gc.C) {
+	s.mockAPI.details = params.CharmRolloutDetails{
+		ApplicationTag: "application-mysql",
+		FromCharmURL:   "cs:mysql-1",
+		ToCharmURL:     "cs:mysql-2",
+		BatchSize:      2,
+		ReadyStatus:    "idle",
+		OnError:        "pause",
+		Status:         "paused",
+		Message:        "unit mysql/1 in error state",
+		Started:        time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC),
+		ReleasedUnits:  []string{"mysql/0", "mysql/1"},
+		PendingUnits:   []string{"mysql/2"},
+	}
+	ctx, err := s.runShow(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: mysql
+batch-size: 2
+from: cs:mysql-1
+message: unit mysql/1 in error state
+on-error: pause
+pending-units:
+- mysql/2
+ready-status: idle
+released-units:
+- mysql/0
+- mysql/1
+started: 2018-07-01 10:00:00Z
+status: paused
+to: cs:mysql-2
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"CharmRollout", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *CharmRolloutSuite) TestShowNotFound(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeNotFound, Message: "not found"})
+	_, err := s.runShow(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" has no rolling charm upgrade`)
+}
+
+func (s *CharmRolloutSuite) TestResume(c *gc.C) {
+	_, err := s.runResume(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"ResumeCharmRollout", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *CharmRolloutSuite) TestResumeError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runResume(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CharmRolloutSuite) TestInvalidArgs(c *gc.C) {
+	for _, run := range []func(*gc.C, ...string) (*cmd.Context, error){s.runShow, s.runResume} {
+		_, err := run(c)
+		c.Assert(err, gc.ErrorMatches, `no application specified`)
+		_, err = run(c, "invalid:name")
+		c.Assert(err, gc.ErrorMatches, `invalid application name "invalid:name"`)
+		_, err = run(c, "mysql", "extra")
+		c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	}
+}
+
+func (s *CharmRolloutSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 8
+	_, err := s.runShow(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "rolling charm upgrades are not supported by this controller")
+	_, err = s.runResume(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "rolling charm upgrades are not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close", "Close")
+}
+
+func (s *CharmRolloutSuite) TestWrongModel(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	_, err := cmdtesting.RunCommand(c, NewShowCharmRolloutCommandForTest(s.mockAPI, store), "mysql")
+	c.Assert(err, gc.ErrorMatches, `Juju command "show-charm-rollout" not supported on kubernetes models`)
+}
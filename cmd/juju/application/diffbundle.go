@@ -0,0 +1,547 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/storage"
+)
+
+const diffBundleDoc = `
+Bundle can be a local bundle file or directory, or the name of a bundle
+in the charm store. The bundle can be supplemented with overlays using
+--overlay, in the same way as for deploy.
+
+The model is exported as a bundle and compared with the bundle. The
+differences are shown for each application's charm, series, options,
+constraints, bindings, exposure, unit count and placement, and for the
+model's machines and relations. Values are shown for the bundle and the
+model side by side; an entity present on only one side is reported as
+missing from the other.
+
+Charms given without a revision in the bundle match any revision of the
+charm in the model. Local charms are compared by name.
+
+When --ignore-units is specified, unit counts, unit placement and
+machines are not compared. This is useful for bundles that describe
+only the shape of a deployment.
+
+Examples:
+
+    juju diff-bundle mediawiki-single
+    juju diff-bundle ./bundle.yaml --overlay ./overlay.yaml
+    juju diff-bundle ./bundle.yaml -m production --ignore-units
+
+See also:
+    deploy
+    export-bundle
+`
+
+// DiffBundleAPI specifies the API methods used by diff-bundle.
+type DiffBundleAPI interface {
+	ExportBundle() (string, error)
+	Close() error
+}
+
+// BundleResolver resolves and fetches bundles from the charm store.
+type BundleResolver interface {
+	ResolveWithChannel(*charm.URL) (*charm.URL, csparams.Channel, []string, error)
+	GetBundle(*charm.URL) (charm.Bundle, error)
+}
+
+// NewDiffBundleCommand returns a command which compares a bundle
+// with the model.
+func NewDiffBundleCommand() modelcmd.ModelCommand {
+	c := &diffBundleCommand{}
+	c.newAPIFunc = func() (DiffBundleAPI, error) {
+		apiRoot, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return bundle.NewClient(apiRoot), nil
+	}
+	c.newBundleResolverFunc = func() (BundleResolver, error) {
+		controllerAPIRoot, err := c.NewControllerAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer controllerAPIRoot.Close()
+		controllerCfg, err := controller.NewClient(controllerAPIRoot).ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bakeryClient, err := c.BakeryClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		csClient := newCharmStoreClient(bakeryClient, controllerCfg.CharmStoreURL()).WithChannel(c.channel)
+		return charmrepo.NewCharmStoreFromClient(csClient), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	newAPIFunc            func() (DiffBundleAPI, error)
+	newBundleResolverFunc func() (BundleResolver, error)
+
+	bundle      string
+	overlays    []string
+	channel     csparams.Channel
+	ignoreUnits bool
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or name>",
+		Purpose: "Compares a bundle with the model and reports any differences.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar((*string)(&c.channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.Var(cmd.NewAppendStringsValue(&c.overlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.BoolVar(&c.ignoreUnits, "ignore-units", false, "Ignore unit counts, unit placement and machines")
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	exported, err := client.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	model, err := charm.ReadBundleData(strings.NewReader(exported))
+	if err != nil {
+		return errors.Annotate(err, "reading exported model")
+	}
+
+	differ := bundleDiffer{ignoreUnits: c.ignoreUnits}
+	return c.out.Write(ctx, differ.diff(data, model))
+}
+
+// readBundle reads the bundle from a local file or directory, or
+// from the charm store, then applies any overlays and includes.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, error) {
+	var data *charm.BundleData
+	baseDir := ctx.Dir
+	path := ctx.AbsPath(c.bundle)
+	if bundleData, err := charmrepo.ReadBundleFile(path); err == nil {
+		data = bundleData
+		baseDir = filepath.Dir(path)
+	} else if b, _, pathErr := charmrepo.NewBundleAtPath(path); pathErr == nil {
+		data = b.Data()
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			baseDir = path
+		}
+	} else if _, statErr := os.Stat(path); statErr == nil {
+		// The path exists, so there's no point looking in the store.
+		return nil, errors.Annotatef(pathErr, "cannot read bundle %q", c.bundle)
+	}
+	if data == nil {
+		storeData, err := c.readStoreBundle(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data = storeData
+	}
+
+	if err := processBundleOverlay(data, c.overlays...); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := processBundleIncludes(baseDir, data); err != nil {
+		return nil, errors.Annotate(err, "unable to process includes")
+	}
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	verifyDevices := func(s string) error {
+		_, err := devices.ParseConstraints(s)
+		return err
+	}
+	if err := data.VerifyLocal(baseDir, verifyConstraints, verifyStorage, verifyDevices); err != nil {
+		return nil, errors.Annotate(err, "bundle is not valid")
+	}
+	resolveLocalCharms(baseDir, data)
+	return data, nil
+}
+
+func (c *diffBundleCommand) readStoreBundle(ctx *cmd.Context) (*charm.BundleData, error) {
+	url, err := charm.ParseURL(c.bundle)
+	if err != nil {
+		return nil, errors.Annotatef(err, "%q is neither a local bundle nor a charm store bundle", c.bundle)
+	}
+	resolver, err := c.newBundleResolverFunc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if url.Series == "" {
+		url.Series = "bundle"
+	}
+	resolved, _, _, err := resolver.ResolveWithChannel(url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resolved.Series != "bundle" {
+		return nil, errors.Errorf("%q is not a bundle", c.bundle)
+	}
+	b, err := resolver.GetBundle(resolved)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx.Infof("Located bundle %q", resolved)
+	return b.Data(), nil
+}
+
+// resolveLocalCharms replaces paths to local charms with local
+// charm URLs, as they would appear in the exported model.
+func resolveLocalCharms(baseDir string, data *charm.BundleData) {
+	for _, app := range data.Applications {
+		if !strings.HasPrefix(app.Charm, ".") && !filepath.IsAbs(app.Charm) {
+			continue
+		}
+		path := app.Charm
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		ch, err := charm.ReadCharm(path)
+		if err != nil {
+			// VerifyLocal has already checked the charm can be read,
+			// so this shouldn't happen; compare the path as given.
+			logger.Debugf("cannot read local charm %q: %v", path, err)
+			continue
+		}
+		app.Charm = "local:" + ch.Meta().Name
+	}
+}
+
+// Values missing from one side of the comparison.
+const (
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Series       *valueDiff                  `yaml:"series,omitempty" json:"series,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+type applicationDiff struct {
+	Missing     string                `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *valueDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *valueDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *valueDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Expose      *valueDiff            `yaml:"expose,omitempty" json:"expose,omitempty"`
+	NumUnits    *valueDiff            `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Placement   *valueDiff            `yaml:"to,omitempty" json:"to,omitempty"`
+	Options     map[string]*valueDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Bindings    map[string]*valueDiff `yaml:"bindings,omitempty" json:"bindings,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return reflect.DeepEqual(*d, applicationDiff{})
+}
+
+type machineDiff struct {
+	Missing     string     `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Series      *valueDiff `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *valueDiff `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+// valueDiff holds a value which differs between the bundle and
+// the model.
+type valueDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+// bundleDiffer compares bundle data with the bundle data
+// exported from a model.
+type bundleDiffer struct {
+	ignoreUnits bool
+}
+
+func (d bundleDiffer) diff(bundle, model *charm.BundleData) *bundleDiff {
+	result := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+		Machines:     make(map[string]*machineDiff),
+	}
+	if bundle.Series != "" && bundle.Series != model.Series {
+		result.Series = &valueDiff{Bundle: bundle.Series, Model: model.Series}
+	}
+
+	for _, name := range unionKeys(bundle.Applications, model.Applications) {
+		bundleApp, inBundle := bundle.Applications[name]
+		modelApp, inModel := model.Applications[name]
+		switch {
+		case !inBundle:
+			result.Applications[name] = &applicationDiff{Missing: missingFromBundle}
+		case !inModel:
+			result.Applications[name] = &applicationDiff{Missing: missingFromModel}
+		default:
+			if diff := d.diffApplication(bundleApp, modelApp); !diff.empty() {
+				result.Applications[name] = diff
+			}
+		}
+	}
+
+	if !d.ignoreUnits {
+		for _, id := range unionKeys(bundle.Machines, model.Machines) {
+			bundleMachine, inBundle := bundle.Machines[id]
+			modelMachine, inModel := model.Machines[id]
+			switch {
+			case !inBundle:
+				result.Machines[id] = &machineDiff{Missing: missingFromBundle}
+			case !inModel:
+				result.Machines[id] = &machineDiff{Missing: missingFromModel}
+			default:
+				if diff := diffMachine(bundleMachine, modelMachine); diff != nil {
+					result.Machines[id] = diff
+				}
+			}
+		}
+	}
+
+	result.Relations = diffRelations(bundle.Relations, model.Relations)
+	return result
+}
+
+func (d bundleDiffer) diffApplication(bundle, model *charm.ApplicationSpec) *applicationDiff {
+	result := &applicationDiff{}
+	if !charmMatches(bundle.Charm, model.Charm) {
+		result.Charm = &valueDiff{Bundle: bundle.Charm, Model: model.Charm}
+	}
+	if bundle.Series != "" && bundle.Series != model.Series {
+		result.Series = &valueDiff{Bundle: bundle.Series, Model: model.Series}
+	}
+	result.Constraints = diffConstraints(bundle.Constraints, model.Constraints)
+	if bundle.Expose != model.Expose {
+		result.Expose = &valueDiff{Bundle: bundle.Expose, Model: model.Expose}
+	}
+	if !d.ignoreUnits {
+		if bundle.NumUnits != model.NumUnits {
+			result.NumUnits = &valueDiff{Bundle: bundle.NumUnits, Model: model.NumUnits}
+		}
+		bundleTo, modelTo := strings.Join(bundle.To, ","), strings.Join(model.To, ",")
+		if bundleTo != modelTo {
+			result.Placement = &valueDiff{Bundle: bundleTo, Model: modelTo}
+		}
+	}
+
+	for _, key := range unionKeys(bundle.Options, model.Options) {
+		bundleValue, modelValue := bundle.Options[key], model.Options[key]
+		if !reflect.DeepEqual(bundleValue, modelValue) {
+			if result.Options == nil {
+				result.Options = make(map[string]*valueDiff)
+			}
+			result.Options[key] = &valueDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for _, key := range unionKeys(bundle.EndpointBindings, model.EndpointBindings) {
+		bundleValue, modelValue := bundle.EndpointBindings[key], model.EndpointBindings[key]
+		if bundleValue != modelValue {
+			if result.Bindings == nil {
+				result.Bindings = make(map[string]*valueDiff)
+			}
+			result.Bindings[key] = &valueDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	return result
+}
+
+func diffMachine(bundle, model *charm.MachineSpec) *machineDiff {
+	if bundle == nil {
+		bundle = &charm.MachineSpec{}
+	}
+	if model == nil {
+		model = &charm.MachineSpec{}
+	}
+	result := machineDiff{
+		Constraints: diffConstraints(bundle.Constraints, model.Constraints),
+	}
+	if bundle.Series != "" && bundle.Series != model.Series {
+		result.Series = &valueDiff{Bundle: bundle.Series, Model: model.Series}
+	}
+	if result == (machineDiff{}) {
+		return nil
+	}
+	return &result
+}
+
+// diffConstraints compares constraints by value, so that
+// "mem=4G cores=2" matches "cores=2 mem=4096M".
+func diffConstraints(bundle, model string) *valueDiff {
+	if bundle == model {
+		return nil
+	}
+	bundleCons, err1 := constraints.Parse(bundle)
+	modelCons, err2 := constraints.Parse(model)
+	if err1 == nil && err2 == nil && bundleCons.String() == modelCons.String() {
+		return nil
+	}
+	return &valueDiff{Bundle: bundle, Model: model}
+}
+
+// charmMatches reports whether the charm in the bundle matches the
+// charm deployed in the model. A bundle charm without a revision
+// matches any revision, and a bundle charm without a series matches
+// any series.
+func charmMatches(bundleCharm, modelCharm string) bool {
+	if bundleCharm == modelCharm {
+		return true
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Schema == "local" {
+		// Local charm revisions are assigned by the controller.
+		bundleURL.Revision = -1
+	}
+	if bundleURL.Revision == -1 {
+		modelURL = modelURL.WithRevision(-1)
+	}
+	if bundleURL.Series == "" {
+		modelURL.Series = ""
+	}
+	return bundleURL.String() == modelURL.String()
+}
+
+// diffRelations returns the relations which are only in the bundle
+// or only in the model, or nil if they are the same. Endpoints which
+// don't name a relation in the bundle match any relation of the
+// application in the model.
+func diffRelations(bundle, model [][]string) *relationsDiff {
+	remaining := make([][]string, len(model))
+	copy(remaining, model)
+
+	var bundleAdditions [][]string
+	for _, relation := range bundle {
+		found := -1
+		for i, candidate := range remaining {
+			if relationMatches(relation, candidate) {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			bundleAdditions = append(bundleAdditions, relation)
+			continue
+		}
+		remaining = append(remaining[:found], remaining[found+1:]...)
+	}
+	if len(bundleAdditions) == 0 && len(remaining) == 0 {
+		return nil
+	}
+	return &relationsDiff{
+		BundleAdditions: sortRelations(bundleAdditions),
+		ModelAdditions:  sortRelations(remaining),
+	}
+}
+
+func relationMatches(bundle, model []string) bool {
+	if len(bundle) != 2 || len(model) != 2 {
+		return false
+	}
+	return (endpointMatches(bundle[0], model[0]) && endpointMatches(bundle[1], model[1])) ||
+		(endpointMatches(bundle[0], model[1]) && endpointMatches(bundle[1], model[0]))
+}
+
+func endpointMatches(bundle, model string) bool {
+	bundleApp, bundleRelation := splitEndpoint(bundle)
+	modelApp, modelRelation := splitEndpoint(model)
+	if bundleApp != modelApp {
+		return false
+	}
+	return bundleRelation == "" || modelRelation == "" || bundleRelation == modelRelation
+}
+
+func splitEndpoint(endpoint string) (string, string) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func sortRelations(relations [][]string) [][]string {
+	sort.Slice(relations, func(i, j int) bool {
+		return strings.Join(relations[i], " ") < strings.Join(relations[j], " ")
+	})
+	return relations
+}
+
+// unionKeys returns the sorted keys of the two maps, which must be
+// maps with string keys.
+func unionKeys(a, b interface{}) []string {
+	keys := set.NewStrings()
+	for _, m := range []interface{}{a, b} {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			keys.Add(key.String())
+		}
+	}
+	return keys.SortedValues()
+}
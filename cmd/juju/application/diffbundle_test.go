@@ -0,0 +1,246 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type diffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeDiffBundleAPI
+	dir string
+}
+
+var _ = gc.Suite(&diffBundleSuite{})
+
+func (s *diffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeDiffBundleAPI{bundle: modelBundle}
+	s.dir = c.MkDir()
+}
+
+func (s *diffBundleSuite) runDiffBundle(c *gc.C, args ...string) (*cmdtesting.Context, error) {
+	command := &diffBundleCommand{
+		newAPIFunc: func() (DiffBundleAPI, error) {
+			return s.api, nil
+		},
+		newBundleResolverFunc: func() (BundleResolver, error) {
+			return nil, errors.New("charm store not available")
+		},
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return cmdtesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *diffBundleSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *diffBundleSuite) TestInitNoBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *diffBundleSuite) TestNoDifferences(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", modelBundle)
+	ctx, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *diffBundleSuite) TestDifferences(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 2
+    to: ["0", "1"]
+    constraints: mem=4G
+    options:
+      dataset-size: 20%
+  haproxy:
+    charm: cs:haproxy
+    num_units: 1
+machines:
+  "0": {}
+  "1": {}
+relations:
+- [haproxy, mysql]
+`)
+	ctx, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  mysql:
+    constraints:
+      bundle: mem=4G
+      model: mem=2G
+    num_units:
+      bundle: 2
+      model: 1
+    to:
+      bundle: 0,1
+      model: "0"
+    options:
+      dataset-size:
+        bundle: 20%
+        model: null
+  wordpress:
+    missing: bundle
+machines:
+  "1":
+    missing: model
+relations:
+  bundle-additions:
+  - - haproxy
+    - mysql
+  model-additions:
+  - - wordpress:db
+    - mysql:db
+`[1:])
+}
+
+func (s *diffBundleSuite) TestIgnoreUnits(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 3
+    constraints: mem=2048M
+  wordpress:
+    charm: cs:wordpress
+    expose: true
+relations:
+- [wordpress, "mysql:db"]
+`)
+	ctx, err := s.runDiffBundle(c, path, "--ignore-units")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *diffBundleSuite) TestOverlay(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", modelBundle)
+	overlay := s.writeFile(c, "overlay.yaml", `
+applications:
+  wordpress:
+    options:
+      blog-title: drift
+`)
+	ctx, err := s.runDiffBundle(c, path, "--overlay", overlay)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  wordpress:
+    options:
+      blog-title:
+        bundle: drift
+        model: null
+`[1:])
+}
+
+func (s *diffBundleSuite) TestExportError(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", modelBundle)
+	s.api.err = errors.New("boom")
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+}
+
+func (s *diffBundleSuite) TestCharmMatches(c *gc.C) {
+	for i, test := range []struct {
+		bundle, model string
+		matches       bool
+	}{
+		{"cs:mysql", "cs:xenial/mysql-58", true},
+		{"cs:mysql-58", "cs:xenial/mysql-58", true},
+		{"cs:mysql-57", "cs:xenial/mysql-58", false},
+		{"cs:trusty/mysql", "cs:xenial/mysql-58", false},
+		{"cs:postgresql", "cs:xenial/mysql-58", false},
+		{"local:mysql", "local:xenial/mysql-3", true},
+		{"local:mysql", "cs:xenial/mysql-3", false},
+	} {
+		c.Logf("test %d: %s vs %s", i, test.bundle, test.model)
+		c.Check(charmMatches(test.bundle, test.model), gc.Equals, test.matches)
+	}
+}
+
+func (s *diffBundleSuite) TestDiffRelations(c *gc.C) {
+	diff := diffRelations(
+		[][]string{{"a", "b:db"}, {"c:x", "d:y"}},
+		[][]string{{"b:db", "a:db"}, {"c:x", "d:z"}},
+	)
+	c.Assert(diff, jc.DeepEquals, &relationsDiff{
+		BundleAdditions: [][]string{{"c:x", "d:y"}},
+		ModelAdditions:  [][]string{{"c:x", "d:z"}},
+	})
+	c.Assert(diffRelations([][]string{{"a", "b"}}, [][]string{{"a:x", "b:y"}}), gc.IsNil)
+}
+
+func (s *diffBundleSuite) TestDiffMachines(c *gc.C) {
+	differ := bundleDiffer{}
+	diff := differ.diff(&charm.BundleData{
+		Machines: map[string]*charm.MachineSpec{
+			"0": {Series: "bionic", Constraints: "cores=2"},
+		},
+	}, &charm.BundleData{
+		Machines: map[string]*charm.MachineSpec{
+			"0": {Series: "xenial", Constraints: "cores=2"},
+			"1": {},
+		},
+	})
+	c.Assert(diff.Machines, jc.DeepEquals, map[string]*machineDiff{
+		"0": {Series: &valueDiff{Bundle: "bionic", Model: "xenial"}},
+		"1": {Missing: missingFromBundle},
+	})
+}
+
+const modelBundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 1
+    to: ["0"]
+    constraints: mem=2G
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 1
+    to: ["0"]
+    expose: true
+machines:
+  "0": {}
+relations:
+- [wordpress:db, mysql:db]
+`
+
+type fakeDiffBundleAPI struct {
+	bundle string
+	err    error
+}
+
+func (f *fakeDiffBundleAPI) ExportBundle() (string, error) {
+	return f.bundle, f.err
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	return nil
+}
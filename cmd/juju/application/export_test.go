@@ -139,3 +139,25 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewShowCharmRolloutCommandForTest returns a show-charm-rollout command
+// with the api provided as specified.
+func NewShowCharmRolloutCommandForTest(api charmRolloutAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showCharmRolloutCommand{}
+	cmd.newAPIFunc = func() (charmRolloutAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResumeCharmRolloutCommandForTest returns a resume-charm-rollout
+// command with the api provided as specified.
+func NewResumeCharmRolloutCommandForTest(api charmRolloutAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &resumeCharmRolloutCommand{}
+	cmd.newAPIFunc = func() (charmRolloutAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -125,6 +125,18 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// BatchSize, if non-zero, is the number of units to which the
+	// upgrade is released at a time.
+	BatchSize int
+
+	// ReadyStatus is the workload status that units must report before
+	// the upgrade is released to the next batch.
+	ReadyStatus string
+
+	// OnError is what to do when a unit goes into an error state
+	// during a rolling upgrade: "pause" or "rollback".
+	OnError string
 }
 
 const upgradeCharmDoc = `
@@ -198,6 +210,24 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+The --batch-size flag upgrades the application's units a few at a time. The
+upgrade is released to the next batch of units once every unit in the previous
+batch has upgraded, its agent is idle and its workload reports the status given
+by --ready-status (by default, "active"). Units added during the upgrade run the
+new charm immediately.
+
+  juju upgrade-charm foo --batch-size 2
+
+If a unit goes into an error state during a rolling upgrade, the upgrade is
+paused, leaving the remaining units on the old charm; it may be continued with
+"juju resume-charm-rollout". With --on-error rollback, the application is
+upgraded back to the old charm instead. The progress of a rolling upgrade is
+shown by "juju show-charm-rollout".
+
+  juju upgrade-charm foo --batch-size 2 --ready-status maintenance --on-error rollback
+
+--batch-size and --force-units are mutually exclusive.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -220,6 +250,9 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade this many units at a time")
+	f.StringVar(&c.ReadyStatus, "ready-status", "", "Workload status units must report before the next batch is upgraded (default active)")
+	f.StringVar(&c.OnError, "on-error", "", "What to do when a unit goes into an error state during a rolling upgrade: pause or rollback (default pause)")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -243,6 +276,24 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be a positive integer")
+	}
+	if c.BatchSize == 0 {
+		if c.ReadyStatus != "" {
+			return errors.Errorf("--ready-status requires --batch-size")
+		}
+		if c.OnError != "" {
+			return errors.Errorf("--on-error requires --batch-size")
+		}
+	} else if c.ForceUnits {
+		return errors.Errorf("--batch-size and --force-units are mutually exclusive")
+	}
+	switch c.OnError {
+	case "", "pause", "rollback":
+	default:
+		return errors.Errorf(`--on-error must be "pause" or "rollback", got %q`, c.OnError)
+	}
 	return nil
 }
 
@@ -270,6 +321,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			return errors.New(action + " at upgrade-charm time is not supported by " + suffix)
 		}
 	}
+	if c.BatchSize > 0 && apiRoot.BestFacadeVersion("Application") < 9 {
+		suffix := "this server"
+		if version, ok := apiRoot.ServerVersion(); ok {
+			suffix = fmt.Sprintf("server version %s", version)
+		}
+		return errors.New("rolling upgrades are not supported by " + suffix)
+	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
@@ -355,6 +413,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
+	if c.BatchSize > 0 {
+		cfg.Rollout = &params.CharmRolloutParams{
+			BatchSize:   c.BatchSize,
+			ReadyStatus: c.ReadyStatus,
+			OnError:     c.OnError,
+		}
+	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
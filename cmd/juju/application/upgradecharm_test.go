@@ -246,6 +246,32 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestRollout(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 9
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--ready-status", "maintenance", "--on-error", "rollback")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		Rollout: &params.CharmRolloutParams{
+			BatchSize:   2,
+			ReadyStatus: "maintenance",
+			OnError:     "rollback",
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRolloutMinFacadeVersion(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches,
+		"rolling upgrades are not supported by server version 1.2.3")
+	s.charmUpgradeClient.CheckNoCalls(c)
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -289,6 +315,32 @@ func (s *UpgradeCharmErrorsStateSuite) TestInvalidArgs(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
 }
 
+func (s *UpgradeCharmErrorsStateSuite) TestRolloutInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--batch-size", "-1"},
+		err:  "--batch-size must be a positive integer",
+	}, {
+		args: []string{"--ready-status", "maintenance"},
+		err:  "--ready-status requires --batch-size",
+	}, {
+		args: []string{"--on-error", "rollback"},
+		err:  "--on-error requires --batch-size",
+	}, {
+		args: []string{"--batch-size", "2", "--on-error", "explode"},
+		err:  `--on-error must be "pause" or "rollback", got "explode"`,
+	}, {
+		args: []string{"--batch-size", "2", "--force-units"},
+		err:  "--batch-size and --force-units are mutually exclusive",
+	}} {
+		c.Logf("test %d", i)
+		err := runUpgradeCharm(c, append([]string{"foo"}, test.args...)...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *UpgradeCharmErrorsStateSuite) TestInvalidApplication(c *gc.C) {
 	err := runUpgradeCharm(c, "phony")
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
@@ -34,6 +34,16 @@ Use --no-download to avoid getting a local copy of the backup downloaded
 at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
+Remote copies are kept in the controller's database unless the controller
+config "backup-storage" is set to "local" or "s3", in which case they are
+kept in the directory given by "backup-storage-path" or in the bucket given
+by "backup-s3-bucket", where they survive the loss of the controller.
+
+The controller can also back itself up on a schedule, by setting the
+controller config "backup-schedule" (eg "24h"). Old scheduled backups are
+removed according to "backup-retention-count" and "backup-retention-age";
+backups created with this command are never removed automatically.
+The outcome of the last scheduled backup is shown by 'juju show-controller'.
 
 Use --verbose to see extra information about backup.
 
@@ -15,6 +15,9 @@ import (
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+The backups are read from the storage configured by the controller
+config "backup-storage".
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -0,0 +1,375 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	auditlogapi "github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+)
+
+var usageAuditLogSummary = `
+Displays the audit log of a controller.`[1:]
+
+var usageAuditLogDetails = `
+When auditing is enabled, each controller machine records the commands
+run against it, along with the API calls made by each command, in its
+audit log. This command queries the audit logs of all the controller
+machines and shows the matching commands, each followed by the API
+calls it made, oldest first.
+
+The '--user' and '--model' options only show commands run by the given
+user or against the given model (by name or UUID).
+
+The '--call' option only shows the API calls made to the given facade,
+or to the given facade method when written as "Facade.Method".
+
+The '--since' and '--until' options limit the API calls to a time
+window, and accept the same values as they do for debug-log: either
+an absolute time, such as "2018-06-12 02:10:00", or a duration relative
+to now, such as "90m". Absolute times without a zone are taken to be
+in the local time zone, or UTC if '--utc' is specified.
+
+The '--errors-only' option only shows the API calls which failed.
+
+Only controller superusers may read the audit log.
+
+Examples:
+
+Show the commands run by mary in the last day:
+
+    juju audit-log --user mary --since 24h
+
+Show the applications deployed to the "prod" model, and by whom:
+
+    juju audit-log --model prod --call Application.Deploy
+
+Show all failed API calls as YAML:
+
+    juju audit-log --errors-only --format yaml
+
+See also:
+    controller-config
+    debug-log`
+
+func newAuditLogCommand() cmd.Command {
+	return newAuditLogCommandTZ(nil, time.Local)
+}
+
+func newAuditLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
+	command := &auditLogCommand{tz: tz}
+	command.openControllersFunc = command.openControllers
+	command.SetClientStore(store)
+	return modelcmd.WrapController(command)
+}
+
+// AuditLogAPI provides access to the audit log of a single controller
+// machine.
+type AuditLogAPI interface {
+	Query(filter auditlog.Filter) (string, []auditlog.Entry, error)
+	Close() error
+}
+
+// auditLogCommand queries the audit logs of the controller machines.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	// openControllersFunc returns an AuditLogAPI for each
+	// controller machine which can be reached.
+	openControllersFunc func(ctx *cmd.Context) ([]AuditLogAPI, error)
+
+	filter auditlog.Filter
+	call   string
+	since  string
+	until  string
+	utc    bool
+	tz     *time.Location
+}
+
+// Info implements Command.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: usageAuditLogSummary,
+		Doc:     usageAuditLogDetails,
+	}
+}
+
+// SetFlags implements Command.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+	f.StringVar(&c.filter.User, "user", "", "Only show commands run by this user")
+	f.StringVar(&c.filter.Model, "model", "", "Only show commands run against this model")
+	f.StringVar(&c.call, "call", "", "Only show API calls to this facade or facade method")
+	f.StringVar(&c.since, "since", "", "Only show API calls made since this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show API calls made until this time or duration ago")
+	f.BoolVar(&c.filter.ErrorsOnly, "errors-only", false, "Only show API calls which failed")
+	f.BoolVar(&c.utc, "utc", false, "Interpret and show times in UTC")
+}
+
+// Init implements Command.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.call != "" {
+		parts := strings.Split(c.call, ".")
+		if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+			return errors.Errorf("--call %q is not a facade or facade method", c.call)
+		}
+		c.filter.Facade = parts[0]
+		if len(parts) == 2 {
+			c.filter.Method = parts[1]
+		}
+	}
+	if c.utc {
+		c.tz = time.UTC
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) processTimeWindow(now time.Time) error {
+	var err error
+	if c.since != "" {
+		if c.filter.After, err = parseLogTime(c.since, now, c.tz); err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+	}
+	if c.until != "" {
+		if c.filter.Before, err = parseLogTime(c.until, now, c.tz); err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+	}
+	if !c.filter.Before.IsZero() && c.filter.Before.Before(c.filter.After) {
+		return errors.Errorf("--until %q is before --since %q", c.until, c.since)
+	}
+	return nil
+}
+
+// Run implements Command.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	if err := c.processTimeWindow(time.Now()); err != nil {
+		return errors.Trace(err)
+	}
+	apis, err := c.openControllersFunc(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		for _, client := range apis {
+			client.Close()
+		}
+	}()
+
+	// Each conversation is recorded by the controller machine the
+	// client was connected to, so merging the audit logs is just a
+	// matter of putting the conversations in order.
+	type machineEntry struct {
+		machineID string
+		when      time.Time
+		entry     auditlog.Entry
+	}
+	var merged []machineEntry
+	seen := make(map[string]bool)
+	for _, client := range apis {
+		machineID, entries, err := client.Query(c.filter)
+		if err != nil {
+			return errors.Annotate(err, "querying audit log")
+		}
+		if seen[machineID] {
+			continue
+		}
+		seen[machineID] = true
+		for _, entry := range entries {
+			when, _ := time.Parse(time.RFC3339, entry.Conversation.When)
+			merged = append(merged, machineEntry{machineID, when, entry})
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].when.Before(merged[j].when)
+	})
+	conversations := make([]auditConversation, len(merged))
+	for i, m := range merged {
+		conversations[i] = c.convertEntry(m.machineID, m.entry)
+	}
+	return c.out.Write(ctx, conversations)
+}
+
+// openControllers connects to each controller machine in turn, as
+// each one keeps its own audit log. Controller machines which can't
+// be reached are skipped with a warning, so that the audit log can
+// still be read while a controller machine is down.
+func (c *auditLogCommand) openControllers(ctx *cmd.Context) ([]AuditLogAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apis := []AuditLogAPI{auditlogapi.NewClient(root)}
+	for _, server := range root.APIHostPorts() {
+		addrs := network.HostPortsToStrings(server)
+		if containsString(addrs, root.Addr()) {
+			continue
+		}
+		conn, err := c.openControllerMachine(addrs)
+		if err != nil {
+			ctx.Warningf("cannot connect to controller machine at %s: %v", strings.Join(addrs, ", "), err)
+			continue
+		}
+		apis = append(apis, auditlogapi.NewClient(conn))
+	}
+	return apis, nil
+}
+
+// openControllerMachine opens an API connection to the controller
+// machine with the given addresses, in the same way as NewAPIRoot.
+func (c *auditLogCommand) openControllerMachine(addrs []string) (api.Connection, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	accountDetails, err := c.CurrentAccountDetails()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	// As in NewAPIRoot, external users log in with macaroons.
+	if accountDetails == nil || accountDetails.User == "" || !names.NewUserTag(accountDetails.User).IsLocal() {
+		accountDetails = &jujuclient.AccountDetails{}
+	}
+	args, err := c.NewAPIConnectionParams(c.ClientStore(), controllerName, "", accountDetails)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	openAPI := args.OpenAPI
+	args.OpenAPI = func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		info.Addrs = addrs
+		return openAPI(info, opts)
+	}
+	return juju.NewAPIConnection(args)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type auditConversation struct {
+	ControllerMachine string         `yaml:"controller-machine" json:"controller-machine"`
+	ConversationID    string         `yaml:"conversation-id" json:"conversation-id"`
+	When              string         `yaml:"when" json:"when"`
+	User              string         `yaml:"user" json:"user"`
+	Model             string         `yaml:"model" json:"model"`
+	ModelUUID         string         `yaml:"model-uuid" json:"model-uuid"`
+	Command           string         `yaml:"command" json:"command"`
+	Calls             []auditRequest `yaml:"calls,omitempty" json:"calls,omitempty"`
+}
+
+type auditRequest struct {
+	RequestID uint64       `yaml:"request-id" json:"request-id"`
+	When      string       `yaml:"when" json:"when"`
+	Facade    string       `yaml:"facade" json:"facade"`
+	Method    string       `yaml:"method" json:"method"`
+	Version   int          `yaml:"version" json:"version"`
+	Args      string       `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors    []auditError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func (c *auditLogCommand) convertEntry(machineID string, entry auditlog.Entry) auditConversation {
+	conv := entry.Conversation
+	result := auditConversation{
+		ControllerMachine: machineID,
+		ConversationID:    conv.ConversationID,
+		When:              c.formatTime(conv.When),
+		User:              conv.Who,
+		Model:             conv.ModelName,
+		ModelUUID:         conv.ModelUUID,
+		Command:           conv.What,
+	}
+	for _, req := range entry.Requests {
+		r := req.Request
+		request := auditRequest{
+			RequestID: r.RequestID,
+			When:      c.formatTime(r.When),
+			Facade:    r.Facade,
+			Method:    r.Method,
+			Version:   r.Version,
+			Args:      r.Args,
+		}
+		for _, err := range req.Errors {
+			if err != nil {
+				request.Errors = append(request.Errors, auditError{
+					Message: err.Message,
+					Code:    err.Code,
+				})
+			}
+		}
+		result.Calls = append(result.Calls, request)
+	}
+	return result
+}
+
+// formatTime converts an audit log timestamp to the command's time
+// zone, leaving it alone if it can't be parsed.
+func (c *auditLogCommand) formatTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.In(c.tz).Format(time.RFC3339)
+}
+
+// formatAuditLogTabular writes each command on a line of its own,
+// followed by the API calls it made.
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]auditConversation)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conversations, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Machine", "User", "Model", "Command/Call", "Error")
+	for _, conv := range conversations {
+		w.Println(conv.When, conv.ControllerMachine, conv.User, conv.Model, conv.Command, "")
+		for _, call := range conv.Calls {
+			var errs []string
+			for _, err := range call.Errors {
+				if err.Code != "" {
+					errs = append(errs, fmt.Sprintf("%s (%s)", err.Message, err.Code))
+				} else {
+					errs = append(errs, err.Message)
+				}
+			}
+			method := fmt.Sprintf("  %s.%s v%d", call.Facade, call.Method, call.Version)
+			w.Println(call.When, "", "", "", method, strings.Join(errs, "; "))
+		}
+	}
+	return tw.Flush()
+}
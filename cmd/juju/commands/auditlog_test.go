@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	apis []*fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apis = []*fakeAuditLogAPI{{
+		machineID: "0",
+		entries: []auditlog.Entry{{
+			Conversation: auditlog.Conversation{
+				Who:            "mary",
+				What:           "juju deploy mysql",
+				When:           "2018-10-01T09:00:00Z",
+				ModelName:      "prod",
+				ModelUUID:      "deadbeef",
+				ConversationID: "c1",
+			},
+			Requests: []auditlog.RequestEntry{{
+				Request: auditlog.Request{
+					RequestID: 1,
+					When:      "2018-10-01T09:00:01Z",
+					Facade:    "Application",
+					Method:    "Deploy",
+					Version:   8,
+				},
+				Errors: []*auditlog.Error{{Message: "no such charm", Code: "not found"}},
+			}},
+		}},
+	}, {
+		machineID: "1",
+		entries: []auditlog.Entry{{
+			Conversation: auditlog.Conversation{
+				Who:            "bob",
+				What:           "juju add-unit mysql",
+				When:           "2018-10-01T08:00:00Z",
+				ModelName:      "prod",
+				ModelUUID:      "deadbeef",
+				ConversationID: "c2",
+			},
+			Requests: []auditlog.RequestEntry{{
+				Request: auditlog.Request{
+					RequestID: 1,
+					When:      "2018-10-01T08:00:01Z",
+					Facade:    "Application",
+					Method:    "AddUnits",
+					Version:   8,
+				},
+			}},
+		}},
+	}}
+}
+
+func (s *AuditLogSuite) runAuditLog(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &auditLogCommand{
+		tz: time.UTC,
+		openControllersFunc: func(*cmd.Context) ([]AuditLogAPI, error) {
+			apis := make([]AuditLogAPI, len(s.apis))
+			for i, api := range s.apis {
+				apis[i] = api
+			}
+			return apis, nil
+		},
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *AuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.runAuditLog(c,
+		"--user", "mary",
+		"--model", "prod",
+		"--call", "Application.Deploy",
+		"--since", "2018-10-01 08:00:00",
+		"--until", "2018-10-01T10:00:00Z",
+		"--errors-only",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, api := range s.apis {
+		c.Check(api.filter, jc.DeepEquals, auditlog.Filter{
+			User:       "mary",
+			Model:      "prod",
+			Facade:     "Application",
+			Method:     "Deploy",
+			After:      time.Date(2018, 10, 1, 8, 0, 0, 0, time.UTC),
+			Before:     time.Date(2018, 10, 1, 10, 0, 0, 0, time.UTC),
+			ErrorsOnly: true,
+		})
+		c.Check(api.closed, jc.IsTrue)
+	}
+}
+
+func (s *AuditLogSuite) TestFacadeOnly(c *gc.C) {
+	_, err := s.runAuditLog(c, "--call", "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.apis[0].filter.Facade, gc.Equals, "Application")
+	c.Check(s.apis[0].filter.Method, gc.Equals, "")
+}
+
+func (s *AuditLogSuite) TestInvalidCall(c *gc.C) {
+	_, err := s.runAuditLog(c, "--call", "Application.Deploy.Now")
+	c.Assert(err, gc.ErrorMatches, `--call "Application.Deploy.Now" is not a facade or facade method`)
+}
+
+func (s *AuditLogSuite) TestInvalidTimeWindow(c *gc.C) {
+	_, err := s.runAuditLog(c, "--since", "1h", "--until", "2h")
+	c.Assert(err, gc.ErrorMatches, `--until "2h" is before --since "1h"`)
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	// Controller machine 0 is reachable at two addresses.
+	s.apis = append(s.apis, s.apis[0])
+	ctx, err := s.runAuditLog(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Machine  User  Model  Command/Call               Error
+2018-10-01T08:00:00Z  1        bob   prod   juju add-unit mysql        
+2018-10-01T08:00:01Z                          Application.AddUnits v8  
+2018-10-01T09:00:00Z  0        mary  prod   juju deploy mysql          
+2018-10-01T09:00:01Z                          Application.Deploy v8    no such charm (not found)
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	s.apis = s.apis[:1]
+	ctx, err := s.runAuditLog(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- controller-machine: "0"
+  conversation-id: c1
+  when: "2018-10-01T09:00:00Z"
+  user: mary
+  model: prod
+  model-uuid: deadbeef
+  command: juju deploy mysql
+  calls:
+  - request-id: 1
+    when: "2018-10-01T09:00:01Z"
+    facade: Application
+    method: Deploy
+    version: 8
+    errors:
+    - message: no such charm
+      code: not found
+`[1:])
+}
+
+type fakeAuditLogAPI struct {
+	machineID string
+	entries   []auditlog.Entry
+	filter    auditlog.Filter
+	closed    bool
+}
+
+func (f *fakeAuditLogAPI) Query(filter auditlog.Filter) (string, []auditlog.Entry, error) {
+	f.filter = filter
+	return f.machineID, f.entries, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.closed = true
+	return nil
+}
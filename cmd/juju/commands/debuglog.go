@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -39,6 +41,12 @@ Each log line is emitted in this format:
 
   <entity> <timestamp> <log-level> <module>:<line-no> <message>
 
+With '--format json' each message is instead emitted as a single line
+JSON object with the fields "timestamp", "entity", "level", "module",
+"location", "message" and "model-uuid". Timestamps are always in RFC3339
+format in UTC, so the '--color', '--date', '--location', '--ms' and
+'--utc' options have no effect.
+
 The "entity" is the source of the message: a machine or unit. The names for
 machines and units can be seen in the output of `[1:] + "`juju status`" + `.
 
@@ -49,13 +57,27 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--since' and '--until' options limit the messages to a time window.
+Each accepts either an absolute time, such as "2018-06-12 02:10:00" or
+"2018-06-12T02:10:00Z", or a duration relative to now, such as "90m" or
+"2h30m". Absolute times without a zone are taken to be in the local time
+zone, or UTC if '--utc' is specified. Selecting a time window implies
+'--replay' so that all matching messages in the window are shown.
+
+The '--message-regex' option only shows messages matching the given
+regular expression, written in the RE2 syntax of Go's regexp package
+(see https://golang.org/s/re2syntax); features outside it, such as
+lookahead and backreferences, are not supported. The filtering is done
+by the controller.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --since, --until and --message-regex selections are logically ANDed to
+  form the complete filter.
 
 Examples:
 
@@ -94,6 +116,21 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show the messages from unit mysql/0 logged between 02:10 and 02:25 that
+mention "connection refused":
+
+    juju debug-log --include mysql/0 \
+        --since "2018-06-12 02:10:00" --until "2018-06-12 02:25:00" \
+        --message-regex "connection refused"
+
+Show all messages from the last half hour and then exit:
+
+    juju debug-log --since 30m --no-tail
+
+Show all ERROR messages as JSON, one object per line:
+
+    juju debug-log --replay --no-tail --level ERROR --format json
+
 See also: 
     status
     ssh`
@@ -131,6 +168,11 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
+	since string
+	until string
+
+	outputFormat string
+
 	format string
 	tz     *time.Location
 }
@@ -151,10 +193,14 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time or duration ago")
+	f.StringVar(&c.params.MessageRegex, "message-regex", "", "Only show log messages matching this regular expression")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.StringVar(&c.outputFormat, "format", "text", "Specify output format (json|text)")
 
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
@@ -174,9 +220,22 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	switch c.outputFormat {
+	case "text", "json":
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q", c.outputFormat, "text", "json")
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Errorf("message regex %q is not valid: %v", c.params.MessageRegex, err)
+		}
+	}
+	if err := c.processTimeWindow(time.Now()); err != nil {
+		return errors.Trace(err)
+	}
 	if c.date {
 		c.format = "2006-01-02 15:04:05"
 	} else {
@@ -190,6 +249,60 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// timeWindowLayouts are the absolute time formats accepted by
+// --since and --until, tried in order.
+var timeWindowLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func (c *debugLogCommand) processTimeWindow(now time.Time) error {
+	var err error
+	if c.since != "" {
+		if c.params.StartTime, err = parseLogTime(c.since, now, c.tz); err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+	}
+	if c.until != "" {
+		if c.params.EndTime, err = parseLogTime(c.until, now, c.tz); err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+	}
+	if c.params.StartTime.IsZero() && c.params.EndTime.IsZero() {
+		return nil
+	}
+	if !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.Errorf("--until %q is before --since %q", c.until, c.since)
+	}
+	// A time window only makes sense if we start looking from the
+	// beginning of the log rather than the last few lines.
+	c.params.Replay = true
+	return nil
+}
+
+// parseLogTime parses value as either a duration before now or an
+// absolute time. Absolute times without a zone are interpreted in tz.
+func parseLogTime(value string, now time.Time, tz *time.Location) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	if tz == nil {
+		tz = time.Local
+	}
+	for _, layout := range timeWindowLayouts {
+		if t, err := time.ParseInLocation(layout, value, tz); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("%q is neither a duration nor a time", value)
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
@@ -260,6 +373,9 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.outputFormat == "json" {
+		return c.writeJSONRecords(ctx, messages)
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -275,6 +391,38 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// logRecordJSON is the serialisation of a log message used
+// by --format json.
+type logRecordJSON struct {
+	Timestamp time.Time `json:"timestamp"`
+	Entity    string    `json:"entity"`
+	Level     string    `json:"level"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+	ModelUUID string    `json:"model-uuid"`
+}
+
+func (c *debugLogCommand) writeJSONRecords(ctx *cmd.Context, messages <-chan common.LogMessage) error {
+	// The encoder writes each record followed by a newline,
+	// giving one JSON object per line.
+	encoder := json.NewEncoder(ctx.Stdout)
+	for msg := range messages {
+		if err := encoder.Encode(logRecordJSON{
+			Timestamp: msg.Timestamp.UTC(),
+			Entity:    msg.Entity,
+			Level:     msg.Severity,
+			Module:    msg.Module,
+			Location:  msg.Location,
+			Message:   msg.Message,
+			ModelUUID: msg.ModelUUID,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
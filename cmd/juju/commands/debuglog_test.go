@@ -104,6 +104,35 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--utc", "--since", "2018-06-12 02:10:00", "--until", "2018-06-12T02:25:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2018, 6, 12, 2, 10, 0, 0, time.UTC),
+				EndTime:   time.Date(2018, 6, 12, 2, 25, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--utc", "--since", "2018-06-12 02:25", "--until", "2018-06-12 02:10"},
+			errMatch: `--until "2018-06-12 02:10" is before --since "2018-06-12 02:25"`,
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither a duration nor a time`,
+		}, {
+			args:     []string{"--until", "-5m"},
+			errMatch: `invalid --until value: duration "-5m" must not be negative`,
+		}, {
+			args: []string{"--message-regex", "connection (refused|reset)"},
+			expected: common.DebugLogParams{
+				Backlog:      10,
+				MessageRegex: "connection (refused|reset)",
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
+		}, {
+			args:     []string{"--message-regex", "(unclosed"},
+			errMatch: `message regex "\(unclosed" is not valid: .*`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -142,6 +171,18 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestRelativeSince(c *gc.C) {
+	command := &debugLogCommand{}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	before := time.Now()
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "90m", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+	c.Check(command.params.StartTime, jc.TimeBetween(before.Add(-90*time.Minute), after.Add(-90*time.Minute)))
+	c.Check(command.params.EndTime, jc.TimeBetween(before.Add(-30*time.Minute), after.Add(-30*time.Minute)))
+	c.Check(command.params.Replay, jc.IsTrue)
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
@@ -184,6 +225,38 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestLogOutputJSON(c *gc.C) {
+	tz := time.FixedZone("test", 6*60*60)
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "test.module",
+				Location:  "somefile.go:123",
+				Message:   "this is the log output",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			}, {
+				Entity:    "unit-foo-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "ERROR",
+				Module:    "unit.foo/0.juju-log",
+				Location:  "hook.go:9",
+				Message:   "it \"broke\"",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			},
+		}}, nil
+	})
+	// The time display options are ignored when producing JSON.
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), tz),
+		"--format", "json", "--ms", "--date", "--location", "--color")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"timestamp":"2016-10-09T08:15:23.345Z","entity":"machine-0","level":"INFO","module":"test.module","location":"somefile.go:123","message":"this is the log output","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d"}`+"\n"+
+		`{"timestamp":"2016-10-09T08:15:24Z","entity":"unit-foo-0","level":"ERROR","module":"unit.foo/0.juju-log","location":"hook.go:9","message":"it \"broke\"","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d"}`+"\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
@@ -53,6 +53,9 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a secret or a new secret revision
+    secret-get               print secret values
+    secret-grant             grant access to a secret over a relation
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -97,6 +100,9 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
 	"status-get",
 	"status-set",
 	"storage-add",
@@ -40,18 +40,22 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/cmd/juju/waitfor"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/all"
+	"github.com/juju/juju/provider/plugin"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/utils/proxy"
 	jujuversion "github.com/juju/juju/version"
@@ -153,6 +157,11 @@ func (m main) Run(args []string) int {
 		return 2
 	}
 
+	pluginsDir := osenv.JujuXDGDataHomePath("provider-plugins")
+	if err := plugin.RegisterPlugins(environs.GlobalProviderRegistry(), pluginsDir); err != nil {
+		fmt.Fprintf(ctx.Stderr, "WARNING: cannot register provider plugins: %v\n", err)
+	}
+
 	if newInstall {
 		fmt.Fprintf(ctx.Stderr, "Since Juju %v is being run for the first time, downloading latest cloud information.\n", jujuversion.Current.Major)
 		updateCmd := cloud.NewUpdateCloudsCommand()
@@ -288,6 +297,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
 
+	// Secrets commands.
+	r.Register(secrets.NewListSecretsCommand())
+	r.Register(secrets.NewRotateSecretCommand())
+
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
@@ -298,6 +311,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(waitfor.NewWaitForCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -305,6 +319,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
 	r.Register(newDebugLogCommand(nil))
+	r.Register(newAuditLogCommand())
 	r.Register(newDebugHooksCommand(nil))
 
 	// Configuration commands.
@@ -313,6 +328,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSyncToolsCommand())
 	r.Register(newUpgradeJujuCommand(nil, nil))
 	r.Register(application.NewUpgradeCharmCommand())
+	r.Register(application.NewShowCharmRolloutCommand())
+	r.Register(application.NewResumeCharmRolloutCommand())
 	r.Register(application.NewUpdateSeriesCommand())
 	r.Register(application.NewSetSeriesCommand())
 
@@ -373,11 +390,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewShowCommand())
 
 	r.Register(newMigrateCommand())
+	r.Register(model.NewExportCommand())
+	r.Register(model.NewImportCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
 		r.Register(model.NewDumpDBCommand())
 	}
 	r.Register(model.NewExportBundleCommand())
+	r.Register(application.NewDiffBundleCommand())
 
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
@@ -385,6 +405,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewPauseScheduleCommand())
+	r.Register(action.NewResumeScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
+	r.Register(action.NewShowRolloutCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -411,7 +437,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewCreateStorageSnapshotCommandWithAPI())
+	r.Register(storage.NewListStorageSnapshotsCommandWithAPI())
+	r.Register(storage.NewRemoveStorageSnapshotCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -389,7 +389,9 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
+	"add-action-schedule",
 	"add-cloud",
 	"add-credential",
 	"add-k8s",
@@ -407,6 +409,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -424,6 +427,7 @@ var commandNames = []string{
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"debug-hooks",
@@ -432,6 +436,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -441,6 +446,7 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -453,8 +459,10 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -472,10 +480,12 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -490,12 +500,14 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"pause-action-schedule",
 	"payloads",
 	"plans",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -509,19 +521,25 @@ var commandNames = []string{
 	"remove-saas",
 	"remove-ssh-key",
 	"remove-storage",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
 	"resolved",
 	"resolve",
+	"resize-storage",
 	"resources",
 	"restore-backup",
+	"resume-action-schedule",
+	"resume-charm-rollout",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"rotate-secret",
 	"run",
 	"run-action",
 	"scale-application",
 	"scp",
+	"secrets",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
@@ -532,8 +550,10 @@ var commandNames = []string{
 	"set-series",
 	"set-wallet",
 	"show-action-output",
+	"show-action-rollout",
 	"show-action-status",
 	"show-backup",
+	"show-charm-rollout",
 	"show-cloud",
 	"show-controller",
 	"show-credential",
@@ -553,6 +573,7 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -571,6 +592,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
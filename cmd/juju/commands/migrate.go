@@ -4,14 +4,18 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -28,10 +32,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationPrechecks(spec controller.MigrationSpec) (coremigration.PrecheckReport, error)
 }
 
 const migrateDoc = `
@@ -57,6 +63,17 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the checks made on the source and target controllers
+before a migration starts are all run and every problem found is
+listed, but the model is not migrated. Blockers are problems that
+would stop the migration; warnings are worth reviewing but don't
+prevent it. The command fails if there are any blockers.
+
+Examples:
+
+    juju migrate mymodel othercontroller
+    juju migrate --dry-run mymodel othercontroller
+
 See also:
     login
     controllers
@@ -73,6 +90,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report any problems that would prevent the migration, without migrating the model")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -141,6 +164,13 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		report, err := api.MigrationPrechecks(*spec)
+		if err != nil {
+			return err
+		}
+		return c.writeReport(ctx, modelName, report)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +179,30 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// writeReport writes the outcome of the migration prechecks, failing
+// silently if any blockers were found.
+func (c *migrateCommand) writeReport(ctx *cmd.Context, modelName string, report coremigration.PrecheckReport) error {
+	if len(report.Blockers) == 0 {
+		fmt.Fprintf(ctx.Stdout, "Model %q can be migrated to controller %q\n", modelName, c.targetController)
+	} else {
+		fmt.Fprintf(ctx.Stdout, "Model %q can't be migrated to controller %q\n", modelName, c.targetController)
+		fmt.Fprintln(ctx.Stdout, "Blockers:")
+		for _, blocker := range report.Blockers {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", blocker)
+		}
+	}
+	if len(report.Warnings) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Warnings:")
+		for _, warning := range report.Warnings {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", warning)
+		}
+	}
+	if len(report.Blockers) > 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
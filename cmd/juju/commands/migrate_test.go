@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -219,6 +221,55 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	s.api.report = coremigration.PrecheckReport{
+		Warnings: []string{"target controller: user \"bob\" doesn't exist"},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model "model" can be migrated to controller "target"
+Warnings:
+  - target controller: user "bob" doesn't exist
+`[1:])
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlockers(c *gc.C) {
+	s.api.report = coremigration.PrecheckReport{
+		Blockers: []string{
+			"machine 0 is dying",
+			"target controller: upgrade in progress",
+		},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model "model" can't be migrated to controller "target"
+Blockers:
+  - machine 0 is dying
+  - target controller: upgrade in progress
+`[1:])
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+}
+
+func (s *MigrateSuite) TestDryRunError(c *gc.C) {
+	s.api.reportErr = errors.NotSupportedf("migration precheck reports on this controller version")
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "migration precheck reports on this controller version not supported")
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -236,14 +287,23 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen         *controller.MigrationSpec
+	migrationStarted bool
+	report           coremigration.PrecheckReport
+	reportErr        error
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
+	a.migrationStarted = true
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) (coremigration.PrecheckReport, error) {
+	a.specSeen = &spec
+	return a.report, a.reportErr
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -181,6 +181,10 @@ func (c *configCommand) getConfig(client controllerAPI, ctx *cmd.Context) error
 	if err != nil {
 		return err
 	}
+	// Credentials are never shown, even if the controller sends them.
+	for name := range controller.SecretConfigAttributes {
+		delete(attrs, name)
+	}
 
 	if c.key != "" {
 		if value, found := attrs[c.key]; found {
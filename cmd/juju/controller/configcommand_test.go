@@ -137,6 +137,22 @@ func (s *ConfigSuite) TestAllValuesJSON(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigSuite) TestCredentialsNotShown(c *gc.C) {
+	var api fakeControllerAPI
+	api.config = map[string]interface{}{
+		"controller-uuid":      "uuid",
+		"backup-s3-access-key": "access",
+		"backup-s3-secret-key": "secret",
+	}
+	context, err := s.runWithAPI(c, &api, "--format=json")
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	c.Assert(output, gc.Equals, `{"controller-uuid":"uuid"}`)
+
+	_, err = s.runWithAPI(c, &api, "backup-s3-secret-key")
+	c.Assert(err, gc.ErrorMatches, `key "backup-s3-secret-key" not found in "mallards" controller`)
+}
+
 func (s *ConfigSuite) TestNonexistentValue(c *gc.C) {
 	context, err := s.run(c, "courtney-barnett")
 	c.Assert(err, gc.ErrorMatches, `key "courtney-barnett" not found in "mallards" controller`)
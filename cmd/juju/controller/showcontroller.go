@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -27,7 +28,9 @@ Shows detailed information of a controller.`[1:]
 
 var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
-and user login details.
+and user login details. Controller administrators are also shown the
+schedule and outcome of the controller's scheduled backups, configured
+with the backup-schedule controller config.
 
 Examples:
     juju show-controller
@@ -88,6 +91,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	BackupStatus() (params.ControllerBackupStatus, error)
 	Close() error
 }
 
@@ -164,6 +168,14 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		}
 
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatusResults)
+		if access == string(permission.SuperuserAccess) {
+			backupStatus, err := client.BackupStatus()
+			if err == nil {
+				details.Backups = convertBackupStatusForShow(backupStatus)
+			} else if !errors.IsNotSupported(err) {
+				details.Errors = append(details.Errors, err.Error())
+			}
+		}
 		controllers[controllerName] = details
 		machineCount := 0
 		for _, r := range modelStatusResults {
@@ -234,6 +246,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the status of the controller's scheduled backups.
+	Backups *BackupDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -306,6 +321,44 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds details of a controller's scheduled backups to show.
+type BackupDetails struct {
+	// Schedule is the interval between scheduled backups.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// LastSuccess is when the last successful backup completed.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastFailure is when the last backup failed.
+	LastFailure string `yaml:"last-failure,omitempty" json:"last-failure,omitempty"`
+
+	// LastError is the error with which the last backup failed.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+// convertBackupStatusForShow returns the backup details to show, or
+// nil if backups have never been scheduled.
+func convertBackupStatusForShow(backupStatus params.ControllerBackupStatus) *BackupDetails {
+	details := BackupDetails{
+		Schedule:     backupStatus.Schedule,
+		LastBackupID: backupStatus.LastBackupID,
+		LastError:    backupStatus.LastError,
+	}
+	if backupStatus.LastSuccess != nil {
+		details.LastSuccess = backupStatus.LastSuccess.UTC().Format(time.RFC3339)
+	}
+	if backupStatus.LastFailure != nil {
+		details.LastFailure = backupStatus.LastFailure.UTC().Format(time.RFC3339)
+	}
+	if details == (BackupDetails{}) {
+		return nil
+	}
+	return &details
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -87,6 +89,61 @@ mallards:
 	s.assertShowController(c, "mallards")
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackupStatus(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+`
+	s.createTestClientStore(c)
+	lastSuccess := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	lastFailure := time.Date(2018, 10, 2, 2, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = params.ControllerBackupStatus{
+		Schedule:     "24h0m0s",
+		LastSuccess:  &lastSuccess,
+		LastBackupID: "20181001-020000.abc",
+		LastFailure:  &lastFailure,
+		LastError:    "disk full",
+	}
+
+	s.expectedOutput = `
+mallards:
+  details:
+    uuid: this-is-another-uuid
+    controller-uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+  models:
+    controller:
+      uuid: abc
+      model-uuid: abc
+      machine-count: 2
+      core-count: 4
+    my-model:
+      uuid: def
+      model-uuid: def
+      machine-count: 2
+      core-count: 4
+  current-model: admin/my-model
+  account:
+    user: admin
+    access: superuser
+  backups:
+    schedule: 24h0m0s
+    last-success: "2018-10-01T02:00:00Z"
+    last-backup-id: 20181001-020000.abc
+    last-failure: "2018-10-02T02:00:00Z"
+    last-error: disk full
+`[1:]
+
+	s.assertShowController(c, "mallards")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithPasswords(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -383,6 +440,7 @@ func (s *ShowControllerSuite) assertShowController(c *gc.C, args ...string) {
 type fakeController struct {
 	controllerName string
 	machines       map[string][]base.Machine
+	backupStatus   params.ControllerBackupStatus
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -422,6 +480,10 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 	return all, nil
 }
 
+func (c *fakeController) BackupStatus() (params.ControllerBackupStatus, error) {
+	return c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -140,3 +140,19 @@ func NewModelGetConstraintsCommandForTest() cmd.Command {
 }
 
 var GetBudgetAPIClient = &getBudgetAPIClient
+
+// NewExportCommandForTest returns an export-model command with the
+// apis provided as specified.
+func NewExportCommandForTest(modelAPI ExportModelAPI, binariesAPI ModelBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportCommand{modelAPI: modelAPI, binariesAPI: binariesAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportCommandForTest returns an import-model command with the api
+// provided as specified.
+func NewImportCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
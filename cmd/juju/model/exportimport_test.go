@@ -0,0 +1,349 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ExportImportSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store    *jujuclient.MemStore
+	exporter fakeExportClient
+	binaries fakeBinariesClient
+	importer fakeImportClient
+	archive  string
+}
+
+var _ = gc.Suite(&ExportImportSuite{})
+
+var (
+	exportedTimestamp = time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	exportedRevision  = params.SerializedModelResourceRevision{
+		Revision:  1,
+		Type:      "file",
+		Path:      "data.tgz",
+		Origin:    "upload",
+		Size:      4,
+		Timestamp: exportedTimestamp,
+	}
+	exportedModel = params.ExportedModel{
+		Info: params.MigrationModelInfo{
+			UUID:                   testing.ModelTag.Id(),
+			Name:                   "mymodel",
+			OwnerTag:               "user-admin",
+			AgentVersion:           version.MustParse("2.4.0"),
+			ControllerAgentVersion: version.MustParse("2.4.1"),
+		},
+		Model: params.SerializedModel{
+			Bytes:  []byte("model-uuid: " + testing.ModelTag.Id() + "\n"),
+			Charms: []string{"cs:xenial/mysql-10", "cs:xenial/mysql-2"},
+			Tools: []params.SerializedModelTools{{
+				Version: "2.4.0-xenial-amd64",
+				URI:     "/tools/2.4.0-xenial-amd64",
+			}},
+			Resources: []params.SerializedModelResource{{
+				Application:         "mysql",
+				Name:                "data",
+				ApplicationRevision: exportedRevision,
+				CharmStoreRevision:  exportedRevision,
+				UnitRevisions: map[string]params.SerializedModelResourceRevision{
+					"mysql/0": exportedRevision,
+				},
+			}, {
+				Application: "mysql",
+				Name:        "placeholder",
+				ApplicationRevision: params.SerializedModelResourceRevision{
+					Type:   "file",
+					Origin: "upload",
+				},
+				CharmStoreRevision: params.SerializedModelResourceRevision{
+					Type:   "file",
+					Origin: "store",
+				},
+			}},
+		},
+	}
+)
+
+func (s *ExportImportSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	s.exporter = fakeExportClient{exported: exportedModel}
+	s.binaries = fakeBinariesClient{blobs: map[string]string{
+		"cs:xenial/mysql-2":                  "mysql-2 charm",
+		"cs:xenial/mysql-10":                 "mysql-10 charm",
+		"/tools/2.4.0-xenial-amd64":          "agent binaries",
+		"/applications/mysql/resources/data": "data",
+	}}
+	s.importer = fakeImportClient{uploaded: make(map[string]string)}
+	s.archive = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+}
+
+func (s *ExportImportSuite) runExport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewExportCommandForTest(&s.exporter, &s.binaries, s.store), args...)
+}
+
+func (s *ExportImportSuite) runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewImportCommandForTest(&s.importer, s.store), args...)
+}
+
+func (s *ExportImportSuite) TestExportImport(c *gc.C) {
+	ctx, err := s.runExport(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `Exported model "admin/mymodel" to `+s.archive+"\n")
+	s.exporter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.binaries.CheckCallNames(c, "OpenCharm", "OpenCharm", "OpenURI", "OpenURI", "Close")
+
+	ctx, err = s.runImport(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `Imported model "mymodel" owned by "admin" into controller "testing"`+"\n")
+
+	uuid := testing.ModelTag.Id()
+	s.importer.CheckCallNames(c,
+		"Prechecks", "Import",
+		"UploadCharm", "UploadCharm", "UploadTools", "UploadResource", "SetUnitResource",
+		"Activate", "AdoptResources", "Close",
+	)
+	calls := s.importer.Calls()
+	c.Check(calls[0].Args, jc.DeepEquals, []interface{}{coremigration.ModelInfo{
+		UUID:                   uuid,
+		Name:                   "mymodel",
+		Owner:                  names.NewUserTag("admin"),
+		AgentVersion:           version.MustParse("2.4.0"),
+		ControllerAgentVersion: version.MustParse("2.4.1"),
+	}})
+	c.Check(calls[1].Args, jc.DeepEquals, []interface{}{exportedModel.Model.Bytes})
+	// Charms are uploaded in revision order.
+	c.Check(calls[2].Args, jc.DeepEquals, []interface{}{uuid, "cs:xenial/mysql-2"})
+	c.Check(calls[3].Args, jc.DeepEquals, []interface{}{uuid, "cs:xenial/mysql-10"})
+	c.Check(calls[4].Args, jc.DeepEquals, []interface{}{uuid, version.MustParseBinary("2.4.0-xenial-amd64")})
+	c.Check(calls[5].Args, jc.DeepEquals, []interface{}{uuid, "mysql", "data"})
+	c.Check(calls[6].Args, jc.DeepEquals, []interface{}{uuid, "mysql/0", "data"})
+	c.Check(s.importer.uploaded, jc.DeepEquals, map[string]string{
+		"cs:xenial/mysql-2":  "mysql-2 charm",
+		"cs:xenial/mysql-10": "mysql-10 charm",
+		"2.4.0-xenial-amd64": "agent binaries",
+		"mysql/data":         "data",
+	})
+}
+
+func (s *ExportImportSuite) TestExportArchiveExists(c *gc.C) {
+	err := ioutil.WriteFile(s.archive, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runExport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `archive file ".*mymodel.tar.gz" already exists`)
+	data, err := ioutil.ReadFile(s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "precious")
+}
+
+func (s *ExportImportSuite) TestExportRemovesArchiveOnError(c *gc.C) {
+	delete(s.binaries.blobs, "/tools/2.4.0-xenial-amd64")
+	_, err := s.runExport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `cannot open agent binaries 2.4.0-xenial-amd64: /tools/2.4.0-xenial-amd64 not found`)
+	_, err = ioutil.ReadFile(s.archive)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportImportSuite) TestExportNotSupported(c *gc.C) {
+	s.exporter.SetErrors(errors.NotSupportedf("exporting models"))
+	_, err := s.runExport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `exporting model: exporting models not supported`)
+}
+
+func (s *ExportImportSuite) TestImportAbortsOnUploadError(c *gc.C) {
+	_, err := s.runExport(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.importer.SetErrors(nil, nil, errors.New("boom"))
+	_, err = s.runImport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `cannot import model: cannot upload charm cs:xenial/mysql-2: boom`)
+	s.importer.CheckCallNames(c, "Prechecks", "Import", "UploadCharm", "Abort", "Close")
+}
+
+func (s *ExportImportSuite) TestImportPrechecksFail(c *gc.C) {
+	_, err := s.runExport(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.importer.SetErrors(errors.New(`model named "mymodel" already exists`))
+	_, err = s.runImport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `cannot import model: model named "mymodel" already exists`)
+	s.importer.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ExportImportSuite) TestImportInvalidArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.archive, []byte("not an archive"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runImport(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, `reading model archive ".*mymodel.tar.gz": .*`)
+	s.importer.CheckNoCalls(c)
+}
+
+func (s *ExportImportSuite) TestInvalidArgs(c *gc.C) {
+	_, err := s.runExport(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	_, err = s.runExport(c, "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+	_, err = s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	_, err = s.runImport(c, "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+type fakeExportClient struct {
+	gitjujutesting.Stub
+	exported params.ExportedModel
+}
+
+func (f *fakeExportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportClient) ExportModel(model names.ModelTag) (params.ExportedModel, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.exported, f.NextErr()
+}
+
+type fakeBinariesClient struct {
+	gitjujutesting.Stub
+	blobs map[string]string
+}
+
+func (f *fakeBinariesClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeBinariesClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return f.open(curl.String())
+}
+
+func (f *fakeBinariesClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri, query)
+	return f.open(uri)
+}
+
+func (f *fakeBinariesClient) open(name string) (io.ReadCloser, error) {
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	blob, ok := f.blobs[name]
+	if !ok {
+		return nil, errors.NotFoundf("%s", name)
+	}
+	return ioutil.NopCloser(strings.NewReader(blob)), nil
+}
+
+type fakeImportClient struct {
+	gitjujutesting.Stub
+	uploaded map[string]string
+}
+
+func (f *fakeImportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", bytes)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) UploadCharm(modelUUID string, curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String())
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return curl, f.read(curl.String(), r)
+}
+
+func (f *fakeImportClient) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, series ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return nil, f.read(vers.String(), r)
+}
+
+func (f *fakeImportClient) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.ApplicationID, res.Name)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	return f.read(res.ApplicationID+"/"+res.Name, r)
+}
+
+func (f *fakeImportClient) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) read(name string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	f.uploaded[name] = string(data)
+	return err
+}
@@ -0,0 +1,227 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportCommand returns a fully constructed export-model command.
+func NewExportCommand() cmd.Command {
+	return modelcmd.Wrap(&exportCommand{})
+}
+
+type exportCommand struct {
+	modelcmd.ModelCommandBase
+	modelAPI    ExportModelAPI
+	binariesAPI ModelBinariesAPI
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes the model, along with the charms, agent binaries and resources it
+uses, to a single archive file. The archive can be imported into another
+controller with 'juju import-model', for example to recreate a model after
+rebuilding a controller that has no route to the original one.
+
+The model keeps running while it is exported, so changes made to it during
+the export may not be captured.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m mymodel mymodel.tar.gz
+
+See also:
+    import-model
+    dump-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Exports a model to an archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *exportCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) (params.ExportedModel, error)
+}
+
+// ModelBinariesAPI specifies the used function calls for downloading
+// a model's charms, agent binaries and resources.
+type ModelBinariesAPI interface {
+	Close() error
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+}
+
+func (c *exportCommand) getModelAPI() (ExportModelAPI, error) {
+	if c.modelAPI != nil {
+		return c.modelAPI, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+func (c *exportCommand) getBinariesAPI() (ModelBinariesAPI, error) {
+	if c.binariesAPI != nil {
+		return c.binariesAPI, nil
+	}
+	return c.ModelCommandBase.NewAPIClient()
+}
+
+// Run implements Command.
+func (c *exportCommand) Run(ctx *cmd.Context) (err error) {
+	modelName, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	modelClient, err := c.getModelAPI()
+	if err != nil {
+		return err
+	}
+	defer modelClient.Close()
+
+	exported, err := modelClient.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+
+	binariesClient, err := c.getBinariesAPI()
+	if err != nil {
+		return err
+	}
+	defer binariesClient.Close()
+
+	filename := ctx.AbsPath(c.filename)
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return errors.Errorf("archive file %q already exists", c.filename)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+
+	w := newArchiveWriter(f)
+	if err := writeModelArchive(w, exported, binariesClient); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Exported model %q to %s", modelName, c.filename)
+	return nil
+}
+
+// writeModelArchive writes the exported model to the archive, fetching
+// the binaries it uses from the controller.
+func writeModelArchive(w *archiveWriter, exported params.ExportedModel, client ModelBinariesAPI) error {
+	metadata := archiveMetadata{
+		Format:    archiveFormat,
+		Info:      exported.Info,
+		Charms:    exported.Model.Charms,
+		Resources: exported.Model.Resources,
+	}
+	// The tools URIs are replaced with their paths in the archive.
+	for _, tools := range exported.Model.Tools {
+		metadata.Tools = append(metadata.Tools, params.SerializedModelTools{
+			Version: tools.Version,
+			URI:     toolsArchivePath(tools.Version),
+		})
+	}
+	metadataBytes, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writeBytes(archiveMetadataFile, metadataBytes); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writeBytes(archiveModelFile, exported.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, curlStr := range exported.Model.Charms {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := client.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", curl)
+		}
+		err = w.writeStream(charmArchivePath(curlStr), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, tools := range exported.Model.Tools {
+		reader, err := client.OpenURI(tools.URI, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open agent binaries %s", tools.Version)
+		}
+		err = w.writeStream(toolsArchivePath(tools.Version), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, res := range exported.Model.Resources {
+		if isPlaceholder(res.ApplicationRevision) {
+			// Placeholders have no content to archive; they are
+			// recreated from the model description on import.
+			continue
+		}
+		uri := fmt.Sprintf("/applications/%s/resources/%s", res.Application, res.Name)
+		reader, err := client.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open resource %s/%s", res.Application, res.Name)
+		}
+		err = w.writeStream(resourceArchivePath(res.Application, res.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportCommand returns a fully constructed import-model command.
+func NewImportCommand() cmd.Command {
+	return modelcmd.WrapController(&importCommand{})
+}
+
+type importCommand struct {
+	modelcmd.ControllerCommandBase
+	api ImportModelAPI
+
+	filename string
+}
+
+const importModelHelpDoc = `
+Recreates a model from an archive written by 'juju export-model', along with
+the charms, agent binaries and resources it uses. The model keeps its name,
+owner and UUID, so neither may already be in use on the controller.
+
+Importing a model records it in the controller; the model's machines and
+agents are not changed. They must be able to reach the controller the model
+is imported into, and the model must no longer be running on the controller
+it was exported from.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c mycontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+    models
+`
+
+// Info implements Command.
+func (c *importCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Imports a model from an archive file.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *importCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(string) error
+	Activate(string) error
+	AdoptResources(string) error
+	UploadCharm(string, *charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(string, io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+	UploadResource(string, resource.Resource, io.ReadSeeker) error
+	SetUnitResource(string, string, resource.Resource) error
+}
+
+type importModelAPI struct {
+	*migrationtarget.Client
+	io.Closer
+}
+
+func (c *importCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return importModelAPI{migrationtarget.NewClient(root), root}, nil
+}
+
+// Run implements Command.
+func (c *importCommand) Run(ctx *cmd.Context) error {
+	archive, err := openModelArchive(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	info := archive.metadata.Info
+	owner, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return errors.Annotate(err, "reading model archive")
+	}
+	serialized, err := common.ConvertSerializedModel(params.SerializedModel{
+		Bytes:     archive.model,
+		Charms:    archive.metadata.Charms,
+		Tools:     archive.metadata.Tools,
+		Resources: archive.metadata.Resources,
+	})
+	if err != nil {
+		return errors.Annotate(err, "reading model archive")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.Prechecks(coremigration.ModelInfo{
+		UUID:                   info.UUID,
+		Name:                   info.Name,
+		Owner:                  owner,
+		AgentVersion:           info.AgentVersion,
+		ControllerAgentVersion: info.ControllerAgentVersion,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+
+	if err := client.Import(serialized.Bytes); err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+	if err := uploadModelBinaries(client, info.UUID, archive, serialized); err != nil {
+		if abortErr := client.Abort(info.UUID); abortErr != nil {
+			logger.Errorf("cannot remove partially imported model: %v", abortErr)
+		}
+		return errors.Annotate(err, "cannot import model")
+	}
+	if err := client.Activate(info.UUID); err != nil {
+		return errors.Annotate(err, "cannot activate imported model")
+	}
+	if err := client.AdoptResources(info.UUID); err != nil {
+		ctx.Warningf("cannot adopt the model's cloud resources: %v", err)
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Imported model %q owned by %q into controller %q", info.Name, owner.Id(), controllerName)
+	return nil
+}
+
+// uploadModelBinaries sends the charms, agent binaries and resources
+// held in the archive to the controller.
+func uploadModelBinaries(client ImportModelAPI, modelUUID string, archive *modelArchive, serialized coremigration.SerializedModel) error {
+	// Charms are uploaded in ascending charm URL order so that charm
+	// revisions end up the same as they were in the exported model.
+	charms := append([]string(nil), serialized.Charms...)
+	naturalsort.Sort(charms)
+	for _, curlStr := range charms {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		f, err := archive.OpenCharm(curl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		usedCurl, err := client.UploadCharm(modelUUID, curl, f)
+		f.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload charm %s", curl)
+		} else if usedCurl.String() != curl.String() {
+			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
+		}
+	}
+
+	for vers, uri := range serialized.Tools {
+		f, err := archive.OpenTools(uri)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = client.UploadTools(modelUUID, f, vers)
+		f.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload agent binaries %s", vers)
+		}
+	}
+
+	for _, res := range serialized.Resources {
+		// Placeholder resources are created by the import itself.
+		if rev := res.ApplicationRevision; !rev.IsPlaceholder() {
+			f, err := archive.OpenResource(rev.ApplicationID, rev.Name)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = client.UploadResource(modelUUID, rev, f)
+			f.Close()
+			if err != nil {
+				return errors.Annotatef(err, "cannot upload resource %s/%s", rev.ApplicationID, rev.Name)
+			}
+		}
+		for unitName, unitRev := range res.UnitRevisions {
+			if err := client.SetUnitResource(modelUUID, unitName, unitRev); err != nil {
+				return errors.Annotate(err, "cannot set unit resource")
+			}
+		}
+	}
+	return nil
+}
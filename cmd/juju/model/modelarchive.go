@@ -0,0 +1,255 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// A model archive is a gzipped tarball holding everything needed to
+// recreate a model on another controller:
+//
+//     metadata.json                 model details and binary index
+//     model.yaml                    serialized model description
+//     charms/<charm URL>            charm archives
+//     tools/<binary version>.tgz    agent binaries
+//     resources/<app>/<name>        application resources
+//
+// Charm URLs are query escaped to make them safe file names.
+const (
+	archiveFormat       = 1
+	archiveMetadataFile = "metadata.json"
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// archiveMetadata describes the contents of a model archive.
+type archiveMetadata struct {
+	Format    int                              `json:"format"`
+	Info      params.MigrationModelInfo        `json:"info"`
+	Charms    []string                         `json:"charms"`
+	Tools     []params.SerializedModelTools    `json:"tools"`
+	Resources []params.SerializedModelResource `json:"resources"`
+}
+
+func charmArchivePath(curl string) string {
+	return path.Join(archiveCharmsDir, url.QueryEscape(curl))
+}
+
+func toolsArchivePath(vers string) string {
+	return path.Join(archiveToolsDir, vers+".tgz")
+}
+
+func resourceArchivePath(application, name string) string {
+	return path.Join(archiveResourcesDir, application, name)
+}
+
+// isPlaceholder reports whether the resource revision has no content
+// uploaded for it, mirroring resource.Resource.IsPlaceholder.
+func isPlaceholder(rev params.SerializedModelResourceRevision) bool {
+	return rev.Timestamp.IsZero()
+}
+
+// archiveWriter writes the files of a model archive.
+type archiveWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	gz := gzip.NewWriter(w)
+	return &archiveWriter{
+		gz: gz,
+		tw: tar.NewWriter(gz),
+	}
+}
+
+// writeBytes adds a file with the given content to the archive.
+func (w *archiveWriter) writeBytes(name string, data []byte) error {
+	if err := w.writeHeader(name, int64(len(data))); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := w.tw.Write(data)
+	return errors.Annotatef(err, "writing %s", name)
+}
+
+// writeStream adds a file with the content read from r to the archive.
+// The content is spooled through a temporary file as the size must be
+// known before it can be written.
+func (w *archiveWriter) writeStream(name string, r io.Reader) error {
+	tempFile, err := ioutil.TempFile("", "juju-export-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	size, err := io.Copy(tempFile, r)
+	if err != nil {
+		return errors.Annotatef(err, "reading %s", name)
+	}
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writeHeader(name, size); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(w.tw, tempFile)
+	return errors.Annotatef(err, "writing %s", name)
+}
+
+func (w *archiveWriter) writeHeader(name string, size int64) error {
+	return errors.Annotatef(w.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}), "writing %s", name)
+}
+
+// Close flushes the archive. It does not close the underlying writer.
+func (w *archiveWriter) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.gz.Close())
+}
+
+// modelArchive provides access to the contents of a model archive,
+// which is unpacked into a temporary directory when opened.
+type modelArchive struct {
+	dir      string
+	metadata archiveMetadata
+	model    []byte
+}
+
+// openModelArchive unpacks and validates the model archive at the
+// given path. The returned archive must be closed to remove the
+// unpacked files.
+func openModelArchive(filename string) (_ *modelArchive, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+
+	dir, err := ioutil.TempDir("", "juju-import-model")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive := &modelArchive{dir: dir}
+	defer func() {
+		if err != nil {
+			archive.Close()
+		}
+	}()
+	if err := unpackArchive(f, dir); err != nil {
+		return nil, errors.Annotatef(err, "reading model archive %q", filename)
+	}
+
+	metadata, err := ioutil.ReadFile(filepath.Join(dir, archiveMetadataFile))
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading model archive %q", filename)
+	}
+	if err := json.Unmarshal(metadata, &archive.metadata); err != nil {
+		return nil, errors.Annotatef(err, "reading model archive %q", filename)
+	}
+	if archive.metadata.Format != archiveFormat {
+		return nil, errors.NotSupportedf("model archive format %d", archive.metadata.Format)
+	}
+	archive.model, err = ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading model archive %q", filename)
+	}
+	return archive, nil
+}
+
+func unpackArchive(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.NotValidf("archive path %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := writeFile(target, tr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func writeFile(filename string, r io.Reader) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+func (a *modelArchive) open(name string) (*os.File, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%s in model archive", name)
+	}
+	return f, errors.Trace(err)
+}
+
+// OpenCharm returns the archived charm with the given URL.
+func (a *modelArchive) OpenCharm(curl *charm.URL) (*os.File, error) {
+	return a.open(charmArchivePath(curl.String()))
+}
+
+// OpenTools returns the archived agent binaries at the given path.
+func (a *modelArchive) OpenTools(uri string) (*os.File, error) {
+	return a.open(uri)
+}
+
+// OpenResource returns the archived content of an application's
+// resource.
+func (a *modelArchive) OpenResource(application, name string) (*os.File, error) {
+	return a.open(resourceArchivePath(application, name))
+}
+
+// Close removes the unpacked archive.
+func (a *modelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/output"
+)
+
+type secretInfo struct {
+	Id          string            `yaml:"id" json:"id"`
+	Application string            `yaml:"application" json:"application"`
+	Revision    int               `yaml:"revision" json:"revision"`
+	Created     string            `yaml:"created" json:"created"`
+	Updated     string            `yaml:"updated" json:"updated"`
+	Grants      []secretGrantInfo `yaml:"grants,omitempty" json:"grants,omitempty"`
+}
+
+type secretGrantInfo struct {
+	Relation    string `yaml:"relation" json:"relation"`
+	Application string `yaml:"application" json:"application"`
+	Unit        string `yaml:"unit,omitempty" json:"unit,omitempty"`
+}
+
+type secretInfos []secretInfo
+
+func (o secretInfos) Len() int      { return len(o) }
+func (o secretInfos) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+func (o secretInfos) Less(i, j int) bool {
+	return o[i].Id < o[j].Id
+}
+
+func formatListTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	formatSecretsTabular(writer, secretInfos(secrets))
+	return nil
+}
+
+// formatSecretsTabular returns a tabular summary of secrets.
+func formatSecretsTabular(writer io.Writer, secrets secretInfos) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	sort.Sort(secrets)
+
+	w.Println("ID", "Revision", "Updated", "Granted to")
+	for _, secret := range secrets {
+		var grantees []string
+		for _, g := range secret.Grants {
+			grantee := g.Application
+			if g.Unit != "" {
+				grantee = g.Unit
+			}
+			grantees = append(grantees, grantee)
+		}
+		w.Println(secret.Id, secret.Revision, secret.Updated, strings.Join(grantees, ","))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var listSecretsHelpSummary = `
+Lists the secrets in a model.`[1:]
+
+var listSecretsHelpDetails = `
+Lists the secrets stored by the applications in a model, along with
+the relations over which each secret has been granted. Secret values
+are never displayed.
+
+Examples:
+    juju list-secrets
+    juju secrets --format yaml
+
+See also:
+    rotate-secret`
+
+// NewListSecretsCommand returns a command to list secrets.
+func NewListSecretsCommand() cmd.Command {
+	cmd := &listSecretsCommand{}
+	cmd.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	isoTime bool
+
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-secrets",
+		Purpose: listSecretsHelpSummary,
+		Doc:     listSecretsHelpDetails,
+		Aliases: []string{"secrets"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) (err error) {
+	return cmd.CheckEmpty(args)
+}
+
+// ListSecretsAPI defines the API methods that the list secrets command uses.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.SecretDetails, error)
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.ListSecrets()
+	if err != nil {
+		return err
+	}
+
+	list := make([]secretInfo, len(results))
+	for i, r := range results {
+		list[i] = secretInfo{
+			Id:          r.Id,
+			Application: r.Application,
+			Revision:    r.Revision,
+			Created:     common.FormatTime(&r.Created, c.isoTime),
+			Updated:     common.FormatTime(&r.Updated, c.isoTime),
+		}
+		for _, g := range r.Grants {
+			list[i].Grants = append(list[i].Grants, secretGrantInfo{
+				Relation:    g.RelationKey,
+				Application: g.Application,
+				Unit:        g.Unit,
+			})
+		}
+	}
+	return c.out.Write(ctx, list)
+}
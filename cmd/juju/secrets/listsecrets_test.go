@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockListAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockListAPI{
+		secrets: []params.SecretDetails{{
+			Id:          "wordpress/api-key",
+			Application: "wordpress",
+			Revision:    1,
+			Created:     time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			Id:          "mysql/password",
+			Application: "mysql",
+			Revision:    2,
+			Created:     time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+			Grants: []params.SecretGrantDetails{{
+				RelationKey: "wordpress:db mysql:server",
+				Application: "wordpress",
+			}, {
+				RelationKey: "mediawiki:db mysql:server",
+				Application: "mediawiki",
+				Unit:        "mediawiki/0",
+			}},
+		}},
+	}
+}
+
+func (s *ListSuite) TestInitArgs(c *gc.C) {
+	_, err := s.runList(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                 Revision  Updated               Granted to
+mysql/password     2         2018-01-02 00:00:00Z  wordpress,mediawiki/0
+wordpress/api-key  1         2018-01-01 00:00:00Z  
+
+`[1:])
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: wordpress/api-key
+  application: wordpress
+  revision: 1
+  created: 2018-01-01 00:00:00Z
+  updated: 2018-01-01 00:00:00Z
+- id: mysql/password
+  application: mysql
+  revision: 2
+  created: 2018-01-01 00:00:00Z
+  updated: 2018-01-02 00:00:00Z
+  grants:
+  - relation: wordpress:db mysql:server
+    application: wordpress
+  - relation: mediawiki:db mysql:server
+    application: mediawiki
+    unit: mediawiki/0
+`[1:])
+}
+
+func (s *ListSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.mockAPI), args...)
+}
+
+type mockListAPI struct {
+	secrets []params.SecretDetails
+	err     error
+}
+
+func (s *mockListAPI) Close() error {
+	return nil
+}
+
+func (s *mockListAPI) ListSecrets() ([]params.SecretDetails, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.secrets, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var rotateSecretHelpSummary = `
+Rotates a secret.`[1:]
+
+var rotateSecretHelpDetails = `
+Adds a new revision of a secret, holding the specified values. Units
+reading the secret will see the new revision the next time they run
+secret-get; earlier revisions remain available by revision number.
+
+Examples:
+    juju rotate-secret mysql/password root=sup3rs3cret
+
+See also:
+    list-secrets`
+
+// NewRotateSecretCommand returns a command to rotate a secret.
+func NewRotateSecretCommand() cmd.Command {
+	cmd := &rotateSecretCommand{}
+	cmd.newAPIFunc = func() (RotateSecretAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type rotateSecretCommand struct {
+	modelcmd.ModelCommandBase
+	id     string
+	values map[string]string
+
+	newAPIFunc func() (RotateSecretAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *rotateSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-secret",
+		Args:    "<secret-id> <key>=<value> [...]",
+		Purpose: rotateSecretHelpSummary,
+		Doc:     rotateSecretHelpDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *rotateSecretCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	c.id = args[0]
+	if len(args) == 1 {
+		return errors.New("no secret values specified")
+	}
+	c.values, err = keyvalues.Parse(args[1:], false)
+	return err
+}
+
+// RotateSecretAPI defines the API methods that the rotate secret command uses.
+type RotateSecretAPI interface {
+	Close() error
+	RotateSecret(id string, values map[string]string) error
+}
+
+// Run implements cmd.Command.
+func (c *rotateSecretCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.RotateSecret(c.id, c.values)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type RotateSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockRotateAPI
+}
+
+var _ = gc.Suite(&RotateSuite{})
+
+func (s *RotateSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockRotateAPI{}
+}
+
+func (s *RotateSuite) TestInitMissingId(c *gc.C) {
+	_, err := s.runRotate(c)
+	c.Assert(err, gc.ErrorMatches, "no secret ID specified")
+}
+
+func (s *RotateSuite) TestInitMissingValues(c *gc.C) {
+	_, err := s.runRotate(c, "mysql/password")
+	c.Assert(err, gc.ErrorMatches, "no secret values specified")
+}
+
+func (s *RotateSuite) TestInitInvalidValues(c *gc.C) {
+	_, err := s.runRotate(c, "mysql/password", "root")
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "root"`)
+}
+
+func (s *RotateSuite) TestRotate(c *gc.C) {
+	_, err := s.runRotate(c, "mysql/password", "root=foo", "admin=bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.id, gc.Equals, "mysql/password")
+	c.Assert(s.mockAPI.values, jc.DeepEquals, map[string]string{
+		"root":  "foo",
+		"admin": "bar",
+	})
+}
+
+func (s *RotateSuite) TestRotateError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runRotate(c, "mysql/password", "root=foo")
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *RotateSuite) runRotate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewRotateSecretCommandForTest(s.mockAPI), args...)
+}
+
+type mockRotateAPI struct {
+	id     string
+	values map[string]string
+	err    error
+}
+
+func (s *mockRotateAPI) Close() error {
+	return nil
+}
+
+func (s *mockRotateAPI) RotateSecret(id string, values map[string]string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.id = id
+	s.values = values
+	return nil
+}
@@ -103,6 +103,7 @@ type applicationStatus struct {
 	CharmVersion     string                `json:"charm-version,omitempty" yaml:"charm-version,omitempty"`
 	CanUpgradeTo     string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
 	Scale            *int                  `json:"scale,omitempty" yaml:"scale,omitempty"`
+	CurrentScale     *int                  `json:"current-scale,omitempty" yaml:"current-scale,omitempty"`
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
@@ -218,8 +219,7 @@ func (s *formattedStatus) applicationScale(name string) (string, bool) {
 	app := s.Applications[name]
 	match := func(u unitStatus) {
 		desiredUnitCount += 1
-		switch u.JujuStatusInfo.Current {
-		case status.Executing, status.Idle, status.Running:
+		if u.available() {
 			currentUnitCount += 1
 		}
 	}
@@ -248,6 +248,16 @@ func (s *formattedStatus) applicationScale(name string) (string, bool) {
 	return fmt.Sprintf("%d/%d", currentUnitCount, desiredUnitCount), true
 }
 
+// available reports whether the unit is active and available,
+// that is whether its agent is idle, executing or running.
+func (u unitStatus) available() bool {
+	switch u.JujuStatusInfo.Current {
+	case status.Executing, status.Idle, status.Running:
+		return true
+	}
+	return false
+}
+
 type statusInfoContents struct {
 	Err     error         `json:"-" yaml:",omitempty"`
 	Current status.Status `json:"current,omitempty" yaml:"current,omitempty"`
@@ -229,6 +229,17 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 			meterStatuses:   application.MeterStatuses,
 		})
 	}
+	if application.Scale != nil {
+		// Report how many of the desired units are available,
+		// as the scale may be changed by an autoscaler.
+		current := 0
+		for _, u := range out.Units {
+			if u.available() {
+				current++
+			}
+		}
+		out.CurrentScale = &current
+	}
 
 	return out
 }
@@ -8,7 +8,9 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -31,6 +33,7 @@ type statusAPI interface {
 func NewStatusCommand() cmd.Command {
 	return modelcmd.Wrap(&statusCommand{
 		relationsFlagProvidedF: func() bool { return false },
+		clock:                  clock.WallClock,
 	})
 }
 
@@ -48,6 +51,15 @@ type statusCommand struct {
 
 	// relationsFlagProvidedF indicates whether 'relations' option was provided by the user.
 	relationsFlagProvidedF func() bool
+
+	// watch, if non-zero, is the minimum interval between redraws
+	// when continuously watching the status.
+	watch time.Duration
+	clock clock.Clock
+
+	// relationsIgnoredShown records whether the user has been told
+	// that --relations is ignored, so that it is only said once.
+	relationsIgnoredShown bool
 }
 
 var usageSummary = `
@@ -89,11 +101,17 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other 
 formats.
 
+The --watch option keeps a single connection to the controller open and
+redraws the status whenever the model changes, at most once per the given
+interval. When writing to a terminal, lines which changed since the previous
+redraw are highlighted. Interrupt the command to stop watching.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
+    juju show-status --watch 5s
 
 See also:
     machines
@@ -118,6 +136,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
+	f.DurationVar(&c.watch, "watch", 0, "Redraw the status as the model changes, at most once per this interval")
 
 	c.relationsFlagProvidedF = func() bool {
 		provided := false
@@ -144,6 +163,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch < 0 {
+		return errors.NotValidf("negative --watch interval %v", c.watch)
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -169,34 +191,15 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
-	if err != nil {
-		if status == nil {
-			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
-		}
-		// Display any error, but continue to print status if some was returned
-		fmt.Fprintf(ctx.Stderr, "%v\n", err)
-	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+	if c.watch > 0 {
+		return c.runWatch(ctx, apiclient)
 	}
 
-	controllerName, err := c.ControllerName()
+	status, err := c.getStatus(ctx, apiclient)
 	if err != nil {
 		return errors.Trace(err)
 	}
-
-	showRelations := true
-	if c.out.Name() != "tabular" {
-		if c.relationsFlagProvidedF() {
-			// For non-tabular formats this is redundant and needs to be mentioned to the user.
-			ctx.Infof("provided --relations option is ignored")
-		}
-	} else {
-		showRelations = c.relations
-	}
-	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
-	formatted, err := formatter.format()
+	formatted, err := c.formatStatus(ctx, status)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -226,6 +229,45 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// getStatus fetches the full status from the API. Partial failures
+// are reported on ctx.Stderr; only a complete failure is returned
+// as an error.
+func (c *statusCommand) getStatus(ctx *cmd.Context, apiclient statusAPI) (*params.FullStatus, error) {
+	status, err := apiclient.Status(c.patterns)
+	if err != nil {
+		if status == nil {
+			// Status call completely failed, there is nothing to report
+			return nil, errors.Trace(err)
+		}
+		// Display any error, but continue to print status if some was returned
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	} else if status == nil {
+		return nil, errors.Errorf("unable to obtain the current status")
+	}
+	return status, nil
+}
+
+// formatStatus converts the status into the value written by c.out.
+func (c *statusCommand) formatStatus(ctx *cmd.Context, status *params.FullStatus) (interface{}, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	showRelations := true
+	if c.out.Name() != "tabular" {
+		if c.relationsFlagProvidedF() && !c.relationsIgnoredShown {
+			// For non-tabular formats this is redundant and needs to be mentioned to the user.
+			ctx.Infof("provided --relations option is ignored")
+			c.relationsIgnoredShown = true
+		}
+	} else {
+		showRelations = c.relations
+	}
+	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
+	return formatter.format()
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
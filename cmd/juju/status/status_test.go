@@ -4866,6 +4866,42 @@ foo/1  active    running     10.0.0.1  80/TCP
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatCAASScale(c *gc.C) {
+	scale := 3
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Type: "caas",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: "cs:foo-1",
+				Scale: &scale,
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						AgentStatus: params.DetailedStatus{Status: "allocating"},
+					},
+					"foo/1": {
+						AgentStatus: params.DetailedStatus{Status: "running"},
+					},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := formatted.Applications["foo"]
+	c.Assert(app.Scale, gc.NotNil)
+	c.Assert(*app.Scale, gc.Equals, 3)
+	c.Assert(app.CurrentScale, gc.NotNil)
+	c.Assert(*app.CurrentScale, gc.Equals, 1)
+
+	out, err := goyaml.Marshal(app)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), jc.Contains, "\nscale: 3\ncurrent-scale: 1\n")
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -0,0 +1,199 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// clearScreen moves the cursor to the top left of the terminal
+// and clears it, ready for the next frame.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// changedLine is used to highlight lines which differ from
+// the previous frame.
+var changedLine = ansiterm.Styles(ansiterm.Reverse)
+
+// allWatcher is the part of *api.AllWatcher used to drive --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// watchAllAPI is implemented by status API clients that
+// are able to watch the whole model for changes.
+type watchAllAPI interface {
+	WatchAll() (*api.AllWatcher, error)
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(watchAllAPI)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd())
+}
+
+// runWatch writes the status, then redraws it each time the model
+// changes, using the same API connection throughout. Redraws happen
+// at most once per c.watch, and only if the output has changed.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	terminal := isTerminal(ctx.Stdout)
+	if terminal {
+		c.color = true
+	}
+	writer := ansiterm.NewWriter(ctx.Stdout)
+	writer.SetColorCapable(terminal || c.color)
+
+	// The first call to Next returns the current state of the model,
+	// which the initial frame already reflects, so only subsequent
+	// deltas are interesting; having one pending redraw is enough
+	// however many deltas arrive in the meantime.
+	changed := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		for first := true; ; first = false {
+			if _, err := watcher.Next(); err != nil {
+				watchErr <- err
+				return
+			}
+			if first {
+				continue
+			}
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var previous []string
+	draw := func() error {
+		frame, err := c.renderStatus(ctx, apiclient)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		lines := strings.SplitAfter(frame, "\n")
+		if previous != nil && equalLines(lines, previous) {
+			return nil
+		}
+		if terminal {
+			fmt.Fprint(writer, clearScreen)
+		}
+		writeFrame(writer, lines, previous)
+		previous = lines
+		return nil
+	}
+	if err := draw(); err != nil {
+		return errors.Trace(err)
+	}
+
+	var redraw <-chan time.Time
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case <-changed:
+			if redraw == nil {
+				redraw = c.clock.After(c.watch)
+			}
+		case <-redraw:
+			redraw = nil
+			if err := draw(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// renderStatus fetches the status and returns it as it would be
+// written by c.out.
+func (c *statusCommand) renderStatus(ctx *cmd.Context, apiclient statusAPI) (string, error) {
+	status, err := c.getStatus(ctx, apiclient)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	formatted, err := c.formatStatus(ctx, status)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	frameCtx := *ctx
+	frameCtx.Stdout = &buf
+	if err := c.out.Write(&frameCtx, formatted); err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
+
+// writeFrame writes lines to w, highlighting those which did not
+// appear in the previous frame. Nothing is highlighted in the
+// first frame.
+func writeFrame(w *ansiterm.Writer, lines, previous []string) {
+	seen := make(map[string]int)
+	for _, line := range previous {
+		seen[line]++
+	}
+	for _, line := range lines {
+		if previous == nil || seen[line] > 0 {
+			seen[line]--
+			fmt.Fprint(w, line)
+			continue
+		}
+		text := strings.TrimSuffix(line, "\n")
+		changedLine.Fprintf(w, "%s", text)
+		if len(text) < len(line) {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func (s *WatchSuite) TestWatchRedrawsOnlyOnChange(c *gc.C) {
+	client := &fakeWatchStatusAPI{statuses: make(chan *params.FullStatus)}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	watcher := &fakeAllWatcher{deltas: make(chan []multiwatcher.Delta)}
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	clk := testclock.NewClock(time.Now())
+	statusCmd := &statusCommand{
+		relationsFlagProvidedF: func() bool { return false },
+		clock:                  clk,
+	}
+	statusCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	command := modelcmd.Wrap(statusCmd)
+	err := cmdtesting.InitCommand(command, []string{"--watch", "5s", "--format", "oneline"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := cmdtesting.Context(c)
+	done := make(chan error)
+	go func() {
+		done <- command.Run(ctx)
+	}()
+
+	// Each send blocks until the command asks for the status.
+	client.statuses <- watchTestStatus("maintenance")
+	// The initial deltas describe the model as already drawn.
+	watcher.deltas <- nil
+	// A change results in a single redraw after the interval.
+	watcher.deltas <- nil
+	err = clk.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	client.statuses <- watchTestStatus("active")
+	// This change leaves the output the same, so nothing is drawn.
+	watcher.deltas <- nil
+	err = clk.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	client.statuses <- watchTestStatus("active")
+
+	close(watcher.deltas)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, "watching model: watcher stopped")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"\n- foo/0: 10.0.0.1 (agent:idle, workload:maintenance)\n"+
+		"\n- foo/0: 10.0.0.1 (agent:idle, workload:active)\n")
+}
+
+func (s *WatchSuite) TestWatchNegativeInterval(c *gc.C) {
+	command := &statusCommand{relationsFlagProvidedF: func() bool { return false }}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--watch", "-5s"})
+	c.Assert(err, gc.ErrorMatches, "negative --watch interval -5s not valid")
+}
+
+func (s *WatchSuite) TestWriteFrameHighlightsChanges(c *gc.C) {
+	var buf bytes.Buffer
+	w := ansiterm.NewWriter(&buf)
+	w.SetColorCapable(true)
+	writeFrame(w, []string{"same\n", "new\n"}, []string{"same\n", "old\n"})
+
+	var expected bytes.Buffer
+	ew := ansiterm.NewWriter(&expected)
+	ew.SetColorCapable(true)
+	expected.WriteString("same\n")
+	changedLine.Fprintf(ew, "%s", "new")
+	expected.WriteString("\n")
+	c.Assert(buf.String(), gc.Equals, expected.String())
+}
+
+func (s *WatchSuite) TestWriteFrameFirstFrame(c *gc.C) {
+	var buf bytes.Buffer
+	w := ansiterm.NewWriter(&buf)
+	w.SetColorCapable(true)
+	writeFrame(w, []string{"one\n", "two\n"}, nil)
+	c.Assert(buf.String(), gc.Equals, "one\ntwo\n")
+}
+
+func watchTestStatus(workload string) *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "test",
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						PublicAddress:  "10.0.0.1",
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+						WorkloadStatus: params.DetailedStatus{Status: workload},
+					},
+				},
+			},
+		},
+	}
+}
+
+type fakeWatchStatusAPI struct {
+	statuses chan *params.FullStatus
+}
+
+func (a *fakeWatchStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return <-a.statuses, nil
+}
+
+func (a *fakeWatchStatusAPI) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas chan []multiwatcher.Delta
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas, ok := <-w.deltas
+	if !ok {
+		return nil, errors.New("watcher stopped")
+	}
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	return nil
+}
@@ -75,3 +75,31 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewResizeStorageCommandForTest(new NewStorageResizerCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCreateStorageSnapshotCommandForTest(new func() (StorageSnapshotCreatorCloser, error), store jujuclient.ClientStore) cmd.Command {
+	cmd := &createStorageSnapshotCommand{}
+	cmd.SetClientStore(store)
+	cmd.newAPIFunc = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListStorageSnapshotsCommandForTest(new func() (StorageSnapshotListerCloser, error), store jujuclient.ClientStore) cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.SetClientStore(store)
+	cmd.newAPIFunc = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveStorageSnapshotCommandForTest(new func() (StorageSnapshotRemoverCloser, error), store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.SetClientStore(store)
+	cmd.newAPIFunc = new
+	return modelcmd.Wrap(cmd)
+}
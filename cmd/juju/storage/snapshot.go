@@ -0,0 +1,356 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewCreateStorageSnapshotCommandWithAPI returns a command
+// used to take snapshots of storage instances.
+func NewCreateStorageSnapshotCommandWithAPI() cmd.Command {
+	cmd := &createStorageSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotCreatorCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createStorageSnapshotCommandDoc = `
+Takes a snapshot of the volume backing each of the specified storage
+instances. Specify the storage IDs, as output by "juju storage".
+
+The snapshots are taken by the storage provider, if it supports them.
+Use "juju storage-snapshots" to see when each snapshot has been taken.
+A snapshot may be restored to new storage by specifying its ID in the
+storage constraints passed to "juju add-storage" or "juju deploy".
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+    juju add-storage postgresql/1 pgdata=snapshot:0/0
+`
+
+	createStorageSnapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+// createStorageSnapshotCommand takes snapshots of storage instances.
+type createStorageSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotCreatorCloser, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *createStorageSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createStorageSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Takes snapshots of storage.",
+		Doc:     createStorageSnapshotCommandDoc,
+		Args:    createStorageSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *createStorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshot(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "create storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("creating snapshot %s of %s", result.Result.Id, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotCreatorCloser defines the API methods that the
+// create-storage-snapshot command uses.
+type StorageSnapshotCreatorCloser interface {
+	Close() error
+	CreateSnapshot(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error)
+}
+
+// NewListStorageSnapshotsCommandWithAPI returns a command
+// used to list storage snapshots.
+func NewListStorageSnapshotsCommandWithAPI() cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotListerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listStorageSnapshotsCommandDoc = `
+Lists the volume snapshots in the model. A snapshot with no provider ID
+has been requested, but not yet taken by the storage provider.
+`
+
+// listStorageSnapshotsCommand lists storage snapshots.
+type listStorageSnapshotsCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotListerCloser, error)
+	out        cmd.Output
+}
+
+// SnapshotInfo defines the serialization behaviour of the volume
+// snapshot information.
+type SnapshotInfo struct {
+	Volume     string `yaml:"volume" json:"volume"`
+	Storage    string `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Pool       string `yaml:"pool" json:"pool"`
+	Life       string `yaml:"life" json:"life"`
+	Created    string `yaml:"created" json:"created"`
+	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Size       uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+// Init implements Command.Init.
+func (c *listStorageSnapshotsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Info implements Command.Info.
+func (c *listStorageSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     listStorageSnapshotsCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listStorageSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listStorageSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	snapshots := make(map[string]SnapshotInfo)
+	for _, result := range results {
+		if result.Error != nil {
+			ctx.Warningf("%s", result.Error)
+			continue
+		}
+		snapshots[result.Result.Id] = formatSnapshotInfo(*result.Result)
+	}
+	if len(snapshots) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	return c.out.Write(ctx, snapshots)
+}
+
+func formatSnapshotInfo(details params.VolumeSnapshotDetails) SnapshotInfo {
+	info := SnapshotInfo{
+		Pool:    details.Pool,
+		Life:    string(details.Life),
+		Created: details.Created.UTC().Format(time.RFC3339),
+	}
+	if tag, err := names.ParseVolumeTag(details.VolumeTag); err == nil {
+		info.Volume = tag.Id()
+	}
+	if tag, err := names.ParseStorageTag(details.StorageTag); err == nil {
+		info.Storage = tag.Id()
+	}
+	if details.Info != nil {
+		info.ProviderId = details.Info.SnapshotId
+		info.Size = details.Info.Size
+	}
+	return info
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Volume", "Storage", "Pool", "Provider id", "Size", "Created")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := snapshots[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Volume, info.Storage, info.Pool, info.ProviderId, size, info.Created)
+	}
+	return tw.Flush()
+}
+
+// StorageSnapshotListerCloser defines the API methods that the
+// storage-snapshots command uses.
+type StorageSnapshotListerCloser interface {
+	Close() error
+	ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error)
+}
+
+// NewRemoveStorageSnapshotCommandWithAPI returns a command
+// used to remove storage snapshots.
+func NewRemoveStorageSnapshotCommandWithAPI() cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotRemoverCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	removeStorageSnapshotCommandDoc = `
+Removes the specified volume snapshots from the model, destroying them
+in the storage provider. Specify the snapshot IDs, as output by
+"juju storage-snapshots". A snapshot cannot be removed while storage is
+being restored from it.
+
+Examples:
+    juju remove-storage-snapshot 0/0 0/1
+`
+
+	removeStorageSnapshotCommandArgs = `<snapshot> [<snapshot> ...]`
+)
+
+// removeStorageSnapshotCommand removes storage snapshots.
+type removeStorageSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc  func() (StorageSnapshotRemoverCloser, error)
+	snapshotIds []string
+}
+
+// Init implements Command.Init.
+func (c *removeStorageSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	for _, id := range args {
+		// Snapshot IDs are formed in the same way as volume IDs.
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("snapshot ID %q", id)
+		}
+	}
+	c.snapshotIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeStorageSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "Removes storage snapshots.",
+		Doc:     removeStorageSnapshotCommandDoc,
+		Args:    removeStorageSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeStorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSnapshots(c.snapshotIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s: %s", c.snapshotIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %s", c.snapshotIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotRemoverCloser defines the API methods that the
+// remove-storage-snapshot command uses.
+type StorageSnapshotRemoverCloser interface {
+	Close() error
+	RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error)
+}
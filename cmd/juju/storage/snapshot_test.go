@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type StorageSnapshotSuite struct {
+	testing.IsolationSuite
+	fake fakeStorageSnapshotAPI
+}
+
+var _ = gc.Suite(&StorageSnapshotSuite{})
+
+func (s *StorageSnapshotSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fake = fakeStorageSnapshotAPI{}
+}
+
+func (s *StorageSnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	s.fake.createResults = []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0/0"}},
+		{Error: &params.Error{Message: "volume \"0/1\" not provisioned"}},
+	}
+	command := storage.NewCreateStorageSnapshotCommandForTest(s.fake.newCreator, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "foo/0", "foo/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.fake.CheckCallNames(c, "NewAPI", "CreateSnapshot", "Close")
+	s.fake.CheckCall(c, 1, "CreateSnapshot", []string{"foo/0", "foo/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+creating snapshot 0/0 of foo/0
+failed to snapshot foo/1: volume "0/1" not provisioned
+`[1:])
+}
+
+func (s *StorageSnapshotSuite) TestCreateSnapshotUnauthorizedError(c *gc.C) {
+	s.fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	command := storage.NewCreateStorageSnapshotCommandForTest(s.fake.newCreator, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to create storage snapshots.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *StorageSnapshotSuite) TestCreateSnapshotInitErrors(c *gc.C) {
+	command := storage.NewCreateStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+	command = storage.NewCreateStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err = cmdtesting.RunCommand(c, command, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *StorageSnapshotSuite) setListResults() {
+	s.fake.listResults = []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:        "1",
+			VolumeTag: "volume-1",
+			Pool:      "ebs",
+			Life:      params.Alive,
+			Created:   time.Date(2018, 10, 16, 10, 0, 0, 0, time.UTC),
+		},
+	}, {
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0/0",
+			VolumeTag:  "volume-0-0",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Life:       params.Alive,
+			Created:    time.Date(2018, 10, 16, 9, 30, 0, 0, time.UTC),
+			Info: &params.VolumeSnapshotInfo{
+				SnapshotId: "snap-0",
+				Size:       1024,
+			},
+		},
+	}}
+}
+
+func (s *StorageSnapshotSuite) TestListSnapshotsTabular(c *gc.C) {
+	s.setListResults()
+	command := storage.NewListStorageSnapshotsCommandForTest(s.fake.newLister, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "NewAPI", "ListSnapshots", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Volume  Storage  Pool  Provider id  Size    Created
+0/0       0/0     data/0   loop  snap-0       1.0GiB  2018-10-16T09:30:00Z
+1         1                ebs                        2018-10-16T10:00:00Z
+`[1:])
+}
+
+func (s *StorageSnapshotSuite) TestListSnapshotsJSON(c *gc.C) {
+	s.setListResults()
+	command := storage.NewListStorageSnapshotsCommandForTest(s.fake.newLister, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"0/0":{"volume":"0/0","storage":"data/0","pool":"loop","life":"alive","created":"2018-10-16T09:30:00Z","provider-id":"snap-0","size":1024},"1":{"volume":"1","pool":"ebs","life":"alive","created":"2018-10-16T10:00:00Z"}}
+`)
+}
+
+func (s *StorageSnapshotSuite) TestListSnapshotsNone(c *gc.C) {
+	command := storage.NewListStorageSnapshotsCommandForTest(s.fake.newLister, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *StorageSnapshotSuite) TestRemoveSnapshots(c *gc.C) {
+	s.fake.removeResults = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "snapshot is being restored to volume 0/2"}},
+	}
+	command := storage.NewRemoveStorageSnapshotCommandForTest(s.fake.newRemover, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "0/0", "0/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.fake.CheckCallNames(c, "NewAPI", "RemoveSnapshots", "Close")
+	s.fake.CheckCall(c, 1, "RemoveSnapshots", []string{"0/0", "0/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0/0
+failed to remove snapshot 0/1: snapshot is being restored to volume 0/2
+`[1:])
+}
+
+func (s *StorageSnapshotSuite) TestRemoveSnapshotsInitErrors(c *gc.C) {
+	command := storage.NewRemoveStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot ID")
+	command = storage.NewRemoveStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err = cmdtesting.RunCommand(c, command, "foo/0")
+	c.Assert(err, gc.ErrorMatches, `snapshot ID "foo/0" not valid`)
+}
+
+type fakeStorageSnapshotAPI struct {
+	testing.Stub
+	createResults []params.VolumeSnapshotDetailsResult
+	listResults   []params.VolumeSnapshotDetailsResult
+	removeResults []params.ErrorResult
+}
+
+func (f *fakeStorageSnapshotAPI) newCreator() (storage.StorageSnapshotCreatorCloser, error) {
+	f.MethodCall(f, "NewAPI")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) newLister() (storage.StorageSnapshotListerCloser, error) {
+	f.MethodCall(f, "NewAPI")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) newRemover() (storage.StorageSnapshotRemoverCloser, error) {
+	f.MethodCall(f, "NewAPI")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) CreateSnapshot(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	f.MethodCall(f, "CreateSnapshot", storageIds)
+	return f.createResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	f.MethodCall(f, "ListSnapshots")
+	return f.listResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotAPI) RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RemoveSnapshots", snapshotIds)
+	return f.removeResults, f.NextErr()
+}
@@ -0,0 +1,297 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Entity kinds that can be waited for.
+const (
+	kindApplication = "application"
+	kindUnit        = "unit"
+	kindMachine     = "machine"
+	kindModel       = "model"
+)
+
+// kindAttributes holds the attributes that queries may refer to
+// for each kind of entity.
+var kindAttributes = map[string]set.Strings{
+	kindApplication: set.NewStrings("name", "life", "status", "exposed", "unit-count"),
+	kindUnit: set.NewStrings(
+		"name", "application", "machine", "workload-status",
+		"workload-message", "agent-status", "leader",
+	),
+	kindMachine: set.NewStrings("id", "life", "status", "instance-status", "unit-count"),
+	kindModel:   set.NewStrings("name", "life", "status", "application-count", "machine-count"),
+}
+
+// childKinds holds the kind of entity that "all" and "any" range
+// over for each kind of entity.
+var childKinds = map[string]string{
+	kindModel:       kindApplication,
+	kindApplication: kindUnit,
+	kindMachine:     kindUnit,
+}
+
+// defaultQueries are used when no --query is given.
+var defaultQueries = map[string]string{
+	kindApplication: `unit-count > 0 && all(workload-status == "active" && agent-status == "idle")`,
+	kindUnit:        `workload-status == "active" && agent-status == "idle"`,
+	kindMachine:     `status == "started"`,
+	kindModel:       `all(unit-count > 0 && all(workload-status == "active" && agent-status == "idle"))`,
+}
+
+// entityStore holds the model's entities as reported by an
+// AllWatcher.
+type entityStore struct {
+	model        *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+
+	// leaders maps application names to the name of their
+	// leader unit. Leadership isn't reported by the AllWatcher,
+	// so it is filled in from the full status.
+	leaders map[string]string
+}
+
+func newEntityStore() *entityStore {
+	return &entityStore{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+		leaders:      make(map[string]string),
+	}
+}
+
+// apply updates the store with the supplied deltas.
+func (s *entityStore) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if delta.Removed {
+				s.model = nil
+			} else {
+				s.model = info
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, info.Name)
+			} else {
+				s.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(s.units, info.Name)
+			} else {
+				s.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(s.machines, info.Id)
+			} else {
+				s.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// setLeaders records the application leaders found in status.
+func (s *entityStore) setLeaders(status *params.FullStatus) {
+	leaders := make(map[string]string)
+	for appName, app := range status.Applications {
+		for unitName, unit := range app.Units {
+			if unit.Leader {
+				leaders[appName] = unitName
+			}
+		}
+	}
+	s.leaders = leaders
+}
+
+// scope returns the named entity of the given kind, or nil if the
+// entity is not in the model.
+func (s *entityStore) scope(kind, name string) scope {
+	switch kind {
+	case kindApplication:
+		if info, ok := s.applications[name]; ok {
+			return applicationScope{s, info}
+		}
+	case kindUnit:
+		if info, ok := s.units[name]; ok {
+			return unitScope{s, info}
+		}
+	case kindMachine:
+		if info, ok := s.machines[name]; ok {
+			return machineScope{s, info}
+		}
+	case kindModel:
+		if s.model != nil && s.model.Name == name {
+			return modelScope{s, s.model}
+		}
+	}
+	return nil
+}
+
+func (s *entityStore) unitsWhere(match func(*multiwatcher.UnitInfo) bool) []scope {
+	var names []string
+	for name, info := range s.units {
+		if match(info) {
+			names = append(names, name)
+		}
+	}
+	names = naturalsort.Sort(names)
+	result := make([]scope, len(names))
+	for i, name := range names {
+		result[i] = unitScope{s, s.units[name]}
+	}
+	return result
+}
+
+type applicationScope struct {
+	store *entityStore
+	info  *multiwatcher.ApplicationInfo
+}
+
+func (a applicationScope) attribute(name string) interface{} {
+	switch name {
+	case "name":
+		return a.info.Name
+	case "life":
+		return string(a.info.Life)
+	case "status":
+		return string(a.info.Status.Current)
+	case "exposed":
+		return a.info.Exposed
+	case "unit-count":
+		return len(a.children())
+	}
+	return nil
+}
+
+func (a applicationScope) children() []scope {
+	return a.store.unitsWhere(func(unit *multiwatcher.UnitInfo) bool {
+		return unit.Application == a.info.Name
+	})
+}
+
+func (a applicationScope) String() string {
+	return fmt.Sprintf("application %q: life %s, status %s", a.info.Name, a.info.Life, a.info.Status.Current)
+}
+
+type unitScope struct {
+	store *entityStore
+	info  *multiwatcher.UnitInfo
+}
+
+func (u unitScope) attribute(name string) interface{} {
+	switch name {
+	case "name":
+		return u.info.Name
+	case "application":
+		return u.info.Application
+	case "machine":
+		return u.info.MachineId
+	case "workload-status":
+		return string(u.info.WorkloadStatus.Current)
+	case "workload-message":
+		return u.info.WorkloadStatus.Message
+	case "agent-status":
+		return string(u.info.AgentStatus.Current)
+	case "leader":
+		return u.store.leaders[u.info.Application] == u.info.Name
+	}
+	return nil
+}
+
+func (u unitScope) children() []scope {
+	return nil
+}
+
+func (u unitScope) String() string {
+	description := fmt.Sprintf("unit %q: workload %s, agent %s",
+		u.info.Name, u.info.WorkloadStatus.Current, u.info.AgentStatus.Current)
+	if message := u.info.WorkloadStatus.Message; message != "" {
+		description += fmt.Sprintf(" (%s)", message)
+	}
+	return description
+}
+
+type machineScope struct {
+	store *entityStore
+	info  *multiwatcher.MachineInfo
+}
+
+func (m machineScope) attribute(name string) interface{} {
+	switch name {
+	case "id":
+		return m.info.Id
+	case "life":
+		return string(m.info.Life)
+	case "status":
+		return string(m.info.AgentStatus.Current)
+	case "instance-status":
+		return string(m.info.InstanceStatus.Current)
+	case "unit-count":
+		return len(m.children())
+	}
+	return nil
+}
+
+func (m machineScope) children() []scope {
+	return m.store.unitsWhere(func(unit *multiwatcher.UnitInfo) bool {
+		return unit.MachineId == m.info.Id
+	})
+}
+
+func (m machineScope) String() string {
+	return fmt.Sprintf("machine %q: life %s, status %s, instance %s",
+		m.info.Id, m.info.Life, m.info.AgentStatus.Current, m.info.InstanceStatus.Current)
+}
+
+type modelScope struct {
+	store *entityStore
+	info  *multiwatcher.ModelInfo
+}
+
+func (m modelScope) attribute(name string) interface{} {
+	switch name {
+	case "name":
+		return m.info.Name
+	case "life":
+		return string(m.info.Life)
+	case "status":
+		return string(m.info.Status.Current)
+	case "application-count":
+		return len(m.store.applications)
+	case "machine-count":
+		return len(m.store.machines)
+	}
+	return nil
+}
+
+func (m modelScope) children() []scope {
+	var names []string
+	for name := range m.store.applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]scope, len(names))
+	for i, name := range names {
+		result[i] = applicationScope{m.store, m.store.applications[name]}
+	}
+	return result
+}
+
+func (m modelScope) String() string {
+	return fmt.Sprintf("model %q: life %s, status %s", m.info.Name, m.info.Life, m.info.Status.Current)
+}
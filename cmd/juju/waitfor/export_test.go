@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var (
+	ParseQuery     = parseQuery
+	NewEntityStore = newEntityStore
+)
+
+type EntityStore = entityStore
+
+// NewWaitForCommandForTest returns a wait-for command which uses
+// the supplied API and clock.
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &waitForCommand{
+		newAPIFunc: func() (WaitForAPI, error) { return api, nil },
+		clock:      clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipModelFlags)
+}
+
+// Eval evaluates the query against the named entity in the store.
+func Eval(q *query, kind string, store *entityStore, name string) (bool, error) {
+	if err := q.check(kind); err != nil {
+		return false, err
+	}
+	return q.eval(store.scope(kind, name))
+}
+
+// Explain explains why the named entity doesn't satisfy the query.
+func Explain(q *query, kind string, store *entityStore, name string) []string {
+	return q.explain(store.scope(kind, name))
+}
+
+// Apply applies deltas to the store.
+var Apply = (*entityStore).apply
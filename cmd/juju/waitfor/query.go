@@ -0,0 +1,525 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// query is a parsed wait-for query expression.
+//
+// The grammar is:
+//
+//	or      := and { "||" and }
+//	and     := not { "&&" not }
+//	not     := "!" not | compare
+//	compare := primary [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) primary ]
+//	primary := ident | ("all" | "any") "(" or ")" | string | number |
+//	           "true" | "false" | "(" or ")"
+//
+// Identifiers name attributes of the entity being evaluated. The
+// functions "all" and "any" evaluate their argument against each of
+// the entity's children, such as the units of an application.
+type query struct {
+	source string
+	root   expr
+}
+
+type expr interface {
+	String() string
+}
+
+type identExpr struct {
+	name string
+}
+
+func (e identExpr) String() string { return e.name }
+
+type literalExpr struct {
+	value interface{}
+}
+
+func (e literalExpr) String() string {
+	if s, ok := e.value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(e.value)
+}
+
+type notExpr struct {
+	x expr
+}
+
+func (e notExpr) String() string { return "!" + e.x.String() }
+
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e binaryExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.left, e.op, e.right)
+}
+
+type callExpr struct {
+	fn  string
+	arg expr
+}
+
+func (e callExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.fn, e.arg)
+}
+
+// parseQuery parses the query source.
+func parseQuery(source string) (*query, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected %s at offset %d", tok, tok.pos)
+	}
+	return &query{source: source, root: root}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.value)
+}
+
+// operators lists the recognised operators, longest first so
+// that "<=" is matched in preference to "<".
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")"}
+
+func isIdentRune(r rune) bool {
+	return r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i+1 : end]), i})
+			i = end + 1
+		case unicode.IsDigit(r):
+			end := i
+			for end < len(runes) && unicode.IsDigit(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[i:end]), i})
+			i = end
+		case unicode.IsLetter(r):
+			end := i
+			for end < len(runes) && isIdentRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[i:end]), i})
+			i = end
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{tokenOp, op, i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, errors.Errorf("unexpected character %q at offset %d", r, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) acceptOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokenOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.value == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expectOp(op string) error {
+	if _, ok := p.acceptOp(op); !ok {
+		tok := p.peek()
+		return errors.Errorf("expected %q, found %s at offset %d", op, tok, tok.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "||", left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if _, ok := p.acceptOp("!"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.acceptOp("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return binaryExpr{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return literalExpr{tok.value}, nil
+	case tokenNumber:
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, errors.Errorf("invalid number %q at offset %d", tok.value, tok.pos)
+		}
+		return literalExpr{n}, nil
+	case tokenIdent:
+		switch tok.value {
+		case "true":
+			return literalExpr{true}, nil
+		case "false":
+			return literalExpr{false}, nil
+		case "all", "any":
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return callExpr{fn: tok.value, arg: arg}, nil
+		}
+		return identExpr{tok.value}, nil
+	case tokenOp:
+		if tok.value == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, errors.Errorf("unexpected %s at offset %d", tok, tok.pos)
+}
+
+// check verifies that every identifier in the query is an attribute
+// of the entity kind it will be evaluated against.
+func (q *query) check(kind string) error {
+	return checkExpr(q.root, kind)
+}
+
+func checkExpr(e expr, kind string) error {
+	switch e := e.(type) {
+	case identExpr:
+		if !kindAttributes[kind].Contains(e.name) {
+			return errors.Errorf("%s has no attribute %q, expected one of %s",
+				kind, e.name, strings.Join(kindAttributes[kind].SortedValues(), ", "))
+		}
+	case notExpr:
+		return checkExpr(e.x, kind)
+	case binaryExpr:
+		if err := checkExpr(e.left, kind); err != nil {
+			return err
+		}
+		return checkExpr(e.right, kind)
+	case callExpr:
+		child, ok := childKinds[kind]
+		if !ok {
+			return errors.Errorf("%s(...) cannot be used with a %s", e.fn, kind)
+		}
+		return checkExpr(e.arg, child)
+	}
+	return nil
+}
+
+// uses reports whether the query refers to the named attribute.
+func (q *query) uses(name string) bool {
+	var uses func(expr) bool
+	uses = func(e expr) bool {
+		switch e := e.(type) {
+		case identExpr:
+			return e.name == name
+		case notExpr:
+			return uses(e.x)
+		case binaryExpr:
+			return uses(e.left) || uses(e.right)
+		case callExpr:
+			return uses(e.arg)
+		}
+		return false
+	}
+	return uses(q.root)
+}
+
+// scope is an entity against which a query is evaluated.
+type scope interface {
+	// attribute returns the value of the named attribute,
+	// which is a string, int or bool.
+	attribute(name string) interface{}
+
+	// children returns the scopes that "all" and "any" range over.
+	children() []scope
+
+	// String describes the entity and its current state.
+	String() string
+}
+
+// eval evaluates the query against s.
+func (q *query) eval(s scope) (bool, error) {
+	v, err := evalExpr(q.root, s)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, errors.Errorf("query %q does not evaluate to true or false", q.source)
+	}
+	return b, nil
+}
+
+func evalBool(e expr, s scope) (bool, error) {
+	v, err := evalExpr(e, s)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, errors.Errorf("%s is not true or false", e)
+	}
+	return b, nil
+}
+
+func evalExpr(e expr, s scope) (interface{}, error) {
+	switch e := e.(type) {
+	case literalExpr:
+		return e.value, nil
+	case identExpr:
+		return s.attribute(e.name), nil
+	case notExpr:
+		b, err := evalBool(e.x, s)
+		return !b, err
+	case callExpr:
+		for _, child := range s.children() {
+			b, err := evalBool(e.arg, child)
+			if err != nil {
+				return nil, err
+			}
+			if e.fn == "any" && b {
+				return true, nil
+			}
+			if e.fn == "all" && !b {
+				return false, nil
+			}
+		}
+		return e.fn == "all", nil
+	case binaryExpr:
+		switch e.op {
+		case "&&", "||":
+			left, err := evalBool(e.left, s)
+			if err != nil {
+				return nil, err
+			}
+			if left == (e.op == "||") {
+				return left, nil
+			}
+			return evalBool(e.right, s)
+		}
+		left, err := evalExpr(e.left, s)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evalExpr(e.right, s)
+		if err != nil {
+			return nil, err
+		}
+		return compare(e, left, right)
+	}
+	return nil, errors.Errorf("unexpected expression %s", e)
+}
+
+func compare(e binaryExpr, left, right interface{}) (bool, error) {
+	if fmt.Sprintf("%T", left) != fmt.Sprintf("%T", right) {
+		return false, errors.Errorf("cannot compare %#v with %#v in %s", left, right, e)
+	}
+	switch e.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	}
+	l, lok := left.(int)
+	r, rok := right.(int)
+	if !lok || !rok {
+		return false, errors.Errorf("cannot use %q with non-numeric values in %s", e.op, e)
+	}
+	switch e.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	}
+	return false, errors.Errorf("unknown operator %q", e.op)
+}
+
+// explain returns descriptions of the entities that stop the query
+// being satisfied by s. Where the query ranges over children, the
+// offending children are described rather than s itself.
+func (q *query) explain(s scope) []string {
+	seen := set.NewStrings()
+	var result []string
+	for _, description := range explainExpr(q.root, s) {
+		if !seen.Contains(description) {
+			seen.Add(description)
+			result = append(result, description)
+		}
+	}
+	if len(result) == 0 {
+		result = []string{s.String()}
+	}
+	return result
+}
+
+func explainExpr(e expr, s scope) []string {
+	if ok, err := evalBool(e, s); err == nil && ok {
+		return nil
+	}
+	switch e := e.(type) {
+	case binaryExpr:
+		switch e.op {
+		case "&&", "||":
+			return append(explainExpr(e.left, s), explainExpr(e.right, s)...)
+		}
+	case callExpr:
+		var result []string
+		for _, child := range s.children() {
+			result = append(result, explainExpr(e.arg, child)...)
+		}
+		if len(result) > 0 || e.fn == "any" {
+			return result
+		}
+	}
+	return []string{s.String()}
+}
@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type QuerySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		query    string
+		errMatch string
+	}{{
+		query:    `workload-status ==`,
+		errMatch: `unexpected end of query at offset 18`,
+	}, {
+		query:    `workload-status == "active`,
+		errMatch: `unterminated string at offset 19`,
+	}, {
+		query:    `(leader`,
+		errMatch: `expected "\)", found end of query at offset 7`,
+	}, {
+		query:    `all leader`,
+		errMatch: `expected "\(", found "leader" at offset 4`,
+	}, {
+		query:    `leader leader`,
+		errMatch: `unexpected "leader" at offset 7`,
+	}, {
+		query:    `leader = true`,
+		errMatch: `unexpected character '=' at offset 7`,
+	}} {
+		c.Logf("test %d: %s", i, test.query)
+		_, err := waitfor.ParseQuery(test.query)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
+func (s *QuerySuite) TestEval(c *gc.C) {
+	store := newTestStore()
+	for i, test := range []struct {
+		kind     string
+		name     string
+		query    string
+		expected bool
+		errMatch string
+	}{{
+		kind:     "unit",
+		name:     "mysql/0",
+		query:    `workload-status == "active" && agent-status == "idle"`,
+		expected: true,
+	}, {
+		kind:     "unit",
+		name:     "mysql/1",
+		query:    `workload-status == "active" && agent-status == "idle"`,
+		expected: false,
+	}, {
+		kind:     "unit",
+		name:     "mysql/1",
+		query:    `!(workload-status == "active") || machine == "0"`,
+		expected: true,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `unit-count >= 2 && any(workload-status == "active")`,
+		expected: true,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `all(workload-status == "active")`,
+		expected: false,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `life == "alive" && !exposed`,
+		expected: true,
+	}, {
+		kind:     "machine",
+		name:     "1",
+		query:    `status == "started" && unit-count == 1`,
+		expected: true,
+	}, {
+		kind:     "model",
+		name:     "default",
+		query:    `application-count == 1 && all(unit-count < 3)`,
+		expected: true,
+	}, {
+		kind:     "unit",
+		name:     "mysql/0",
+		query:    `workload-status`,
+		errMatch: `query "workload-status" does not evaluate to true or false`,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `unit-count == "2"`,
+		errMatch: `cannot compare 2 with "2" in \(unit-count == "2"\)`,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `name > "a"`,
+		errMatch: `cannot use ">" with non-numeric values in \(name > "a"\)`,
+	}, {
+		kind:     "application",
+		name:     "mysql",
+		query:    `workload-status == "active"`,
+		errMatch: `application has no attribute "workload-status", expected one of .*`,
+	}, {
+		kind:     "unit",
+		name:     "mysql/0",
+		query:    `all(leader)`,
+		errMatch: `all\(...\) cannot be used with a unit`,
+	}} {
+		c.Logf("test %d: %s %s %s", i, test.kind, test.name, test.query)
+		q, err := waitfor.ParseQuery(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := waitfor.Eval(q, test.kind, store, test.name)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.expected)
+	}
+}
+
+func (s *QuerySuite) TestExplain(c *gc.C) {
+	store := newTestStore()
+	q, err := waitfor.ParseQuery(`unit-count > 0 && all(workload-status == "active" && agent-status == "idle")`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(waitfor.Explain(q, "application", store, "mysql"), jc.DeepEquals, []string{
+		`unit "mysql/1": workload maintenance, agent executing (installing packages)`,
+	})
+}
+
+func newTestStore() *waitfor.EntityStore {
+	store := waitfor.NewEntityStore()
+	waitfor.Apply(store, []multiwatcher.Delta{{
+		Entity: &multiwatcher.ModelInfo{
+			Name:   "default",
+			Life:   "alive",
+			Status: multiwatcher.StatusInfo{Current: status.Available},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0",
+			Life:        "alive",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "1",
+			Life:        "alive",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+		},
+	}, {
+		Entity: &multiwatcher.ApplicationInfo{
+			Name: "mysql",
+			Life: "alive",
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "mysql/1",
+			Application: "mysql",
+			MachineId:   "1",
+			WorkloadStatus: multiwatcher.StatusInfo{
+				Current: status.Maintenance,
+				Message: "installing packages",
+			},
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Executing},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "mysql/2",
+			Application: "mysql",
+		},
+	}, {
+		Removed: true,
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "mysql/2",
+			Application: "mysql",
+		},
+	}})
+	return store
+}
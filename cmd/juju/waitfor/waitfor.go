@@ -0,0 +1,299 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// defaultTimeout is how long to wait for the query to be
+// satisfied when --timeout isn't given.
+const defaultTimeout = 10 * time.Minute
+
+const waitForDoc = `
+Waits until the given application, unit, machine or model satisfies a
+query, then exits. If the query is not satisfied before the timeout
+expires the command fails, listing the entities which did not reach the
+required state.
+
+The query is an expression over the attributes of the entity:
+
+  application: name, life, status, exposed, unit-count
+  unit:        name, application, machine, workload-status,
+               workload-message, agent-status, leader
+  machine:     id, life, status, instance-status, unit-count
+  model:       name, life, status, application-count, machine-count
+
+Attributes may be compared with strings, numbers, true or false using
+==, !=, <, <=, > and >=, and comparisons may be combined with &&, ||, !
+and parentheses. The functions all(...) and any(...) evaluate their
+argument against the units of an application or machine, or against
+the applications of a model.
+
+When no query is given, applications and units wait for their units to
+be active and idle, machines wait to be started, and models wait for
+all of their applications to have active and idle units.
+
+Waiting for a model watches the named model itself rather than the
+current model. Use -m <controller>: to wait for a model on a controller
+other than the current one.
+
+Changes to the model are watched for, so the query is evaluated as soon
+as anything changes rather than polling.
+
+Examples:
+
+    juju wait-for application mysql
+    juju wait-for unit mysql/0 --query 'workload-status == "blocked"'
+    juju wait-for application mysql --timeout 30m \
+        --query 'unit-count >= 3 && any(leader && workload-status == "active")'
+    juju wait-for machine 0 --query 'instance-status == "running"'
+    juju wait-for model default
+    juju wait-for model default -m prod:
+
+See also:
+    status
+    show-status-log
+`
+
+// WaitForAPI describes the API methods used by the wait-for command.
+type WaitForAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+// AllWatcher describes the methods used to receive model deltas.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// NewWaitForCommand returns a command which waits for an entity
+// to reach a given state.
+func NewWaitForCommand() cmd.Command {
+	c := &waitForCommand{clock: clock.WallClock}
+	c.newAPIFunc = func() (WaitForAPI, error) {
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return clientAdapter{client}, nil
+	}
+	// The command defines its own model flags, as for models
+	// they select the controller of the model waited for.
+	return modelcmd.Wrap(c, modelcmd.WrapSkipModelFlags)
+}
+
+// clientAdapter adapts an *api.Client to the WaitForAPI interface.
+type clientAdapter struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (c clientAdapter) WatchAll() (AllWatcher, error) {
+	watcher, err := c.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc func() (WaitForAPI, error)
+	clock      clock.Clock
+
+	modelFlag   string
+	kind        string
+	name        string
+	querySource string
+	query       *query
+	timeout     time.Duration
+}
+
+// Info implements cmd.Command.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "(application|unit|machine|model) <name>",
+		Purpose: "Waits for an entity to reach a given state.",
+		Doc:     waitForDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.modelFlag, "m", "", "Model to operate in. Accepts [<controller name>:]<model name>")
+	f.StringVar(&c.modelFlag, "model", "", "")
+	f.StringVar(&c.querySource, "query", "", "Expression which the entity must satisfy")
+	f.DurationVar(&c.timeout, "timeout", defaultTimeout, "How long to wait before failing")
+}
+
+// Init implements cmd.Command.
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected an entity kind and name")
+	}
+	c.kind, c.name = args[0], args[1]
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return errors.Trace(err)
+	}
+	switch c.kind {
+	case kindApplication:
+		if !names.IsValidApplication(c.name) {
+			return errors.NotValidf("application name %q", c.name)
+		}
+	case kindUnit:
+		if !names.IsValidUnit(c.name) {
+			return errors.NotValidf("unit name %q", c.name)
+		}
+	case kindMachine:
+		if !names.IsValidMachine(c.name) {
+			return errors.NotValidf("machine id %q", c.name)
+		}
+	case kindModel:
+		if !names.IsValidModelName(c.name) {
+			return errors.NotValidf("model name %q", c.name)
+		}
+	default:
+		return errors.Errorf("entity kind %q is not one of %s",
+			c.kind, strings.Join([]string{kindApplication, kindUnit, kindMachine, kindModel}, ", "))
+	}
+	if err := c.initModelName(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.timeout <= 0 {
+		return errors.NotValidf("timeout %v", c.timeout)
+	}
+	source := c.querySource
+	if source == "" {
+		source = defaultQueries[c.kind]
+	}
+	q, err := parseQuery(source)
+	if err != nil {
+		return errors.Annotatef(err, "invalid query %q", source)
+	}
+	if err := q.check(c.kind); err != nil {
+		return errors.Annotatef(err, "invalid query %q", source)
+	}
+	c.query = q
+	return nil
+}
+
+// initModelName sets the model to connect to. Models are waited for
+// by watching the named model itself, rather than the current one,
+// on the controller selected with the model flag.
+func (c *waitForCommand) initModelName() error {
+	if c.kind != kindModel {
+		return errors.Trace(c.SetModelName(c.modelFlag, true))
+	}
+	controllerName, modelName := modelcmd.SplitModelName(c.modelFlag)
+	if modelName != "" && modelName != c.name {
+		return errors.Errorf(
+			"model %q selected with -m is not model %q; use -m <controller>: to select only the controller",
+			modelName, c.name,
+		)
+	}
+	qualifiedName := c.name
+	if controllerName != "" {
+		qualifiedName = modelcmd.JoinModelName(controllerName, c.name)
+	}
+	return errors.Trace(c.SetModelName(qualifiedName, false))
+}
+
+// Run implements cmd.Command.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	deltas := make(chan []multiwatcher.Delta)
+	watchErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			d, err := watcher.Next()
+			if err != nil {
+				watchErr <- err
+				return
+			}
+			select {
+			case deltas <- d:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	store := newEntityStore()
+	usesLeader := c.query.uses("leader")
+	timeout := c.clock.After(c.timeout)
+	for {
+		select {
+		case d := <-deltas:
+			store.apply(d)
+			if usesLeader {
+				// Leadership isn't part of the deltas, so it's
+				// refreshed whenever anything changes.
+				status, err := client.Status(nil)
+				if err != nil {
+					return errors.Annotate(err, "getting unit leadership")
+				}
+				store.setLeaders(status)
+			}
+			s := store.scope(c.kind, c.name)
+			if s == nil {
+				continue
+			}
+			ok, err := c.query.eval(s)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if ok {
+				ctx.Infof("%s %q satisfied query %q", c.kind, c.name, c.query.source)
+				return nil
+			}
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case <-timeout:
+			return c.timeoutError(store)
+		}
+	}
+}
+
+func (c *waitForCommand) timeoutError(store *entityStore) error {
+	var pending []string
+	if s := store.scope(c.kind, c.name); s != nil {
+		pending = c.query.explain(s)
+	} else {
+		pending = []string{fmt.Sprintf("%s %q not found", c.kind, c.name)}
+	}
+	return errors.Errorf("timed out after %v waiting for %s %q to satisfy query %q; not converged:\n  %s",
+		c.timeout, c.kind, c.name, c.query.source, strings.Join(pending, "\n  "))
+}
@@ -0,0 +1,268 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeWaitForAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeWaitForAPI{
+		watcher: &fakeAllWatcher{deltas: make(chan []multiwatcher.Delta)},
+	}
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *WaitForSuite) runWaitFor(c *gc.C, args ...string) (*cmdtesting.Context, chan error) {
+	ctx := cmdtesting.Context(c)
+	command := waitfor.NewWaitForCommandForTest(s.api, s.clock, jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(command, args)
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Run(ctx)
+	}()
+	return ctx, done
+}
+
+func (s *WaitForSuite) waitDone(c *gc.C, done chan error) error {
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	return nil
+}
+
+func (s *WaitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		args:     []string{"application"},
+		errMatch: `expected an entity kind and name`,
+	}, {
+		args:     []string{"relation", "foo"},
+		errMatch: `entity kind "relation" is not one of application, unit, machine, model`,
+	}, {
+		args:     []string{"unit", "mysql"},
+		errMatch: `unit name "mysql" not valid`,
+	}, {
+		args:     []string{"machine", "0", "extra"},
+		errMatch: `unrecognized args: \["extra"\]`,
+	}, {
+		args:     []string{"application", "mysql", "--timeout", "0s"},
+		errMatch: `timeout 0s not valid`,
+	}, {
+		args:     []string{"application", "mysql", "--query", `leader`},
+		errMatch: `invalid query "leader": application has no attribute "leader", expected one of .*`,
+	}, {
+		args:     []string{"unit", "mysql/0", "--query", `leader &&`},
+		errMatch: `invalid query "leader &&": unexpected end of query at offset 9`,
+	}, {
+		args:     []string{"model", "other", "-m", "arthur:sword"},
+		errMatch: `model "sword" selected with -m is not model "other"; use -m <controller>: to select only the controller`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := waitfor.NewWaitForCommandForTest(s.api, s.clock, jujuclienttesting.MinimalStore())
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
+func (s *WaitForSuite) TestWaitsForDefaultQuery(c *gc.C) {
+	ctx, done := s.runWaitFor(c, "application", "mysql")
+
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		{Entity: unitInfo("mysql/0", status.Maintenance, status.Executing)},
+	}
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: unitInfo("mysql/0", status.Active, status.Idle)},
+	}
+
+	err := s.waitDone(c, done)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `application "mysql" satisfied query .*\n`)
+	c.Assert(s.api.statusCalls, gc.Equals, 0)
+}
+
+func (s *WaitForSuite) TestLeaderFromStatus(c *gc.C) {
+	s.api.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {},
+					"mysql/1": {Leader: true},
+				},
+			},
+		},
+	}
+	_, done := s.runWaitFor(c, "unit", "mysql/1", "--query", "leader")
+
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: unitInfo("mysql/0", status.Active, status.Idle)},
+		{Entity: unitInfo("mysql/1", status.Active, status.Idle)},
+	}
+
+	err := s.waitDone(c, done)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.statusCalls, gc.Equals, 1)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	_, done := s.runWaitFor(c, "application", "mysql", "--timeout", "5m")
+
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		{Entity: unitInfo("mysql/0", status.Active, status.Idle)},
+		{Entity: unitInfo("mysql/1", status.Blocked, status.Idle)},
+	}
+	// Once the next batch is accepted, the first has been applied.
+	s.api.watcher.deltas <- nil
+	err := s.clock.WaitAdvance(5*time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.waitDone(c, done)
+	c.Assert(err, gc.ErrorMatches, `timed out after 5m0s waiting for application "mysql" to satisfy query ".*"; not converged:
+  unit "mysql/1": workload blocked, agent idle`)
+}
+
+func (s *WaitForSuite) TestTimeoutNotFound(c *gc.C) {
+	_, done := s.runWaitFor(c, "machine", "3", "--timeout", "1m")
+
+	err := s.clock.WaitAdvance(time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.waitDone(c, done)
+	c.Assert(err, gc.ErrorMatches, `timed out after 1m0s waiting for machine "3" to satisfy query ".*"; not converged:
+  machine "3" not found`)
+}
+
+func (s *WaitForSuite) TestWaitsForNamedModel(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	command := waitfor.NewWaitForCommandForTest(s.api, s.clock, jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(command, []string{"model", "other", "--query", `life == "alive"`})
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Run(ctx)
+	}()
+
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: &multiwatcher.ModelInfo{Name: "other", Life: multiwatcher.Life("alive")}},
+	}
+	err = s.waitDone(c, done)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The command connects to the named model, not the current one.
+	modelName, err := modelcmd.InnerCommand(command).(modelcmd.ModelCommand).ModelName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "other")
+}
+
+func (s *WaitForSuite) TestWaitsForNamedModelOnController(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	store.Controllers["lancelot"] = jujuclient.ControllerDetails{}
+	ctx := cmdtesting.Context(c)
+	command := waitfor.NewWaitForCommandForTest(s.api, s.clock, store)
+	err := cmdtesting.InitCommand(command, []string{"model", "other", "-m", "lancelot:", "--query", `life == "alive"`})
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Run(ctx)
+	}()
+
+	s.api.watcher.deltas <- []multiwatcher.Delta{
+		{Entity: &multiwatcher.ModelInfo{Name: "other", Life: multiwatcher.Life("alive")}},
+	}
+	err = s.waitDone(c, done)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The command connects to the named model on the
+	// controller selected with -m, not the current one.
+	inner := modelcmd.InnerCommand(command).(modelcmd.ModelCommand)
+	controllerName, err := inner.ControllerName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerName, gc.Equals, "lancelot")
+	modelName, err := inner.ModelName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "other")
+}
+
+func (s *WaitForSuite) TestWatcherError(c *gc.C) {
+	_, done := s.runWaitFor(c, "machine", "0")
+	close(s.api.watcher.deltas)
+	err := s.waitDone(c, done)
+	c.Assert(err, gc.ErrorMatches, `watching model: watcher stopped`)
+}
+
+func unitInfo(name string, workload, agent status.Status) *multiwatcher.UnitInfo {
+	return &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    "mysql",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}
+}
+
+type fakeWaitForAPI struct {
+	watcher     *fakeAllWatcher
+	status      *params.FullStatus
+	statusCalls int
+}
+
+func (f *fakeWaitForAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.statusCalls++
+	return f.status, nil
+}
+
+func (f *fakeWaitForAPI) WatchAll() (waitfor.AllWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas chan []multiwatcher.Delta
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas, ok := <-w.deltas
+	if !ok {
+		return nil, errors.New("watcher stopped")
+	}
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	return nil
+}
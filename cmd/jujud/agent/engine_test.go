@@ -33,8 +33,10 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
+		"charm-rollout",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"firewaller",
 		"instance-poller",
@@ -53,7 +55,9 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"charm-revision-updater",
+		"charm-rollout",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -51,6 +51,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/watcher"
@@ -104,6 +105,10 @@ var (
 	machineManifolds   = machine.Manifolds
 )
 
+// auditLogBufferSize is the number of audit log records held in
+// memory while waiting to be forwarded to the log forwarding target.
+const auditLogBufferSize = 10000
+
 // Variable to override in tests, default is true
 var ProductionMongoWriteConcern = true
 
@@ -577,6 +582,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			LogPruneInterval:                  5 * time.Minute,
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
+			AuditLogBuffer:                    auditlog.NewBuffer(auditLogBufferSize),
 			SetStatePool:                      statePoolReporter.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
@@ -790,6 +796,7 @@ func (a *MachineAgent) openStateForUpgrade() (*state.StatePool, error) {
 		ControllerModelTag: agentConfig.Model(),
 		MongoSession:       session,
 		NewPolicy:          stateenvirons.GetNewPolicyFunc(),
+		SecretsKey:         controllerSecretsKey(agentConfig),
 		// state.InitDatabase is idempotent and needs to be called just
 		// prior to performing any upgrades since a new Juju binary may
 		// declare new indices or explicit collections.
@@ -950,6 +957,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		SecretsKey:             controllerSecretsKey(agentConfig),
 	})
 	return ctlr, nil
 }
@@ -1076,6 +1084,17 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 	return nil
 }
 
+// controllerSecretsKey returns the key material from which the keys
+// used to encrypt model secrets are derived. It is the same on every
+// controller machine, and is held only in the agent configuration.
+func controllerSecretsKey(agentConfig agent.Config) []byte {
+	info, ok := agentConfig.StateServingInfo()
+	if !ok || info.SecretsKey == "" {
+		return nil
+	}
+	return []byte(info.SecretsKey)
+}
+
 func openStatePool(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
@@ -1098,6 +1117,7 @@ func openStatePool(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		SecretsKey:             controllerSecretsKey(agentConfig),
 	})
 	if err != nil {
 		return nil, nil, err
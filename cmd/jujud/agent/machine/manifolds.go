@@ -28,6 +28,7 @@ import (
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/raftlease"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -60,6 +62,8 @@ import (
 	"github.com/juju/juju/worker/httpserver"
 	"github.com/juju/juju/worker/identityfilewriter"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
@@ -230,6 +234,11 @@ type ManifoldsConfig struct {
 	// supports network spaces.
 	ControllerSupportsSpaces func(*state.State) (bool, error)
 
+	// AuditLogBuffer holds the audit log records written by the
+	// controller until they are forwarded to the log forwarding
+	// target.
+	AuditLogBuffer *auditlog.Buffer
+
 	// MachineLock is a central source for acquiring the machine lock.
 	// This is used by a number of workers to ensure serialisation of actions
 	// across the machine.
@@ -282,6 +291,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 
 	leaseFSM := raftlease.NewFSM()
 
+	// Assign the audit log buffer only when there is one, so that a
+	// nil buffer doesn't become a non-nil interface value.
+	var auditForwardTo auditlog.AuditLog
+	var auditSource logforwarder.AuditSource
+	if config.AuditLogBuffer != nil {
+		auditForwardTo = config.AuditLogBuffer
+		auditSource = config.AuditLogBuffer
+	}
+
 	manifolds := dependency.Manifolds{
 		// The agent manifold references the enclosing agent, and is the
 		// foundation stone on which most other manifolds ultimately depend.
@@ -702,6 +720,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				NewWorker:  backupscheduler.NewWorker,
+				NewBackups: backupscheduler.NewStateBackups,
+			},
+		))),
+
 		httpServerName: httpserver.Manifold(httpserver.ManifoldConfig{
 			AgentName:             agentName,
 			CertWatcherName:       certificateWatcherName,
@@ -761,6 +789,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName: agentName,
 			StateName: stateName,
 			NewWorker: auditconfigupdater.New,
+			ForwardTo: auditForwardTo,
+		})),
+
+		auditLogForwarderName: ifController(logforwarder.AuditManifold(logforwarder.AuditManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Source:        auditSource,
+			OpenSink:      sinks.Open,
+			NewWorker:     logforwarder.NewAuditWorker,
 		})),
 
 		raftEnabledName: ifController(featureflag.Manifold(featureflag.ManifoldConfig{
@@ -960,12 +998,14 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
 	peergrouperName               = "peer-grouper"
 	restoreWatcherName            = "restore-watcher"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	auditLogForwarderName         = "audit-log-forwarder"
 	leaseManagerName              = "lease-manager"
 
 	upgradeSeriesEnabledName = "upgrade-series-enabled"
@@ -56,6 +56,8 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
+		"backup-scheduler",
 		"central-hub",
 		"certificate-updater",
 		"certificate-watcher",
@@ -134,6 +136,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"certificate-updater",
 		"certificate-watcher",
 		"central-hub",
@@ -192,11 +195,13 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	controllerWorkers := set.NewStrings(
 		"certificate-watcher",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"is-primary-controller-flag",
 		"raft-enabled-flag",
 		"lease-manager",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"log-pruner",
 		"transaction-pruner",
@@ -319,6 +324,31 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"audit-log-forwarder": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher"},
+
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
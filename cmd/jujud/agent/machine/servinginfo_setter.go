@@ -79,6 +79,9 @@ func ServingInfoSetterManifold(config ServingInfoSetterConfig) dependency.Manifo
 					// apiState.
 					info.Cert = existing.Cert
 					info.PrivateKey = existing.PrivateKey
+					if info.SecretsKey == "" {
+						info.SecretsKey = existing.SecretsKey
+					}
 				}
 				config.SetStateServingInfo(info)
 				return nil
@@ -117,6 +117,7 @@ func (s *ServingInfoSetterSuite) startManifold(c *gc.C, a coreagent.Agent, mockA
 					Cert:       "cert",
 					PrivateKey: "key",
 					APIPort:    mockAPIPort,
+					SecretsKey: "secrets key",
 				}
 			default:
 				c.Fatalf("not sure how to handle: %q", request)
@@ -145,6 +146,7 @@ func (s *ServingInfoSetterSuite) TestJobManageEnviron(c *gc.C) {
 	c.Assert(a.conf.ssi.APIPort, gc.Equals, mockAPIPort)
 	c.Assert(a.conf.ssi.Cert, gc.Equals, "cert")
 	c.Assert(a.conf.ssi.PrivateKey, gc.Equals, "key")
+	c.Assert(a.conf.ssi.SecretsKey, gc.Equals, "secrets key")
 }
 
 func (s *ServingInfoSetterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C) {
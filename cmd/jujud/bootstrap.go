@@ -5,6 +5,8 @@ package main
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -224,8 +226,15 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	if !ok {
 		return fmt.Errorf("bootstrap machine config has no state serving info")
 	}
+	// Generate the key material for encrypting model secrets. It
+	// is kept only in the agent config, never in the database.
+	secretsKey, err := generateSecretsKey()
+	if err != nil {
+		return err
+	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+	info.SecretsKey = secretsKey
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		mmprof, err := mongo.NewMemoryProfile(args.ControllerConfig.MongoMemoryProfile())
@@ -545,3 +554,13 @@ func storeImageMetadataInState(st *state.State, env environs.Environ, source str
 	}
 	return nil
 }
+
+// generateSecretsKey generates the pseudo-random key material from
+// which the keys used to encrypt model secrets are derived.
+func generateSecretsKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Annotate(err, "cannot read random secrets key")
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
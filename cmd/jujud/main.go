@@ -28,10 +28,12 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	components "github.com/juju/juju/component/all"
 	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/sockets"
 	// Import the providers.
 	_ "github.com/juju/juju/provider/all"
+	"github.com/juju/juju/provider/plugin"
 	"github.com/juju/juju/upgrades"
 	"github.com/juju/juju/utils/proxy"
 	"github.com/juju/juju/worker/logsender"
@@ -153,6 +155,11 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 		return 1, errors.Trace(err)
 	}
 
+	pluginsDir := filepath.Join(cmdutil.DataDir, "provider-plugins")
+	if err := plugin.RegisterPlugins(environs.GlobalProviderRegistry(), pluginsDir); err != nil {
+		logger.Errorf("cannot register provider plugins: %v", err)
+	}
+
 	jujud := jujucmd.NewSuperCommand(cmd.SuperCommandParams{
 		Name: "jujud",
 		Doc:  jujudDoc,
@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// juju-provider-dummy serves the dummy provider as a provider plugin.
+// It is the reference implementation of a provider plugin, and is
+// intended for testing only.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/juju/environs"
+	// Register the dummy provider.
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/provider/plugin"
+)
+
+func main() {
+	if err := serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serve() error {
+	p, err := environs.Provider("dummy")
+	if err != nil {
+		return err
+	}
+	provider, ok := p.(environs.CloudEnvironProvider)
+	if !ok {
+		return fmt.Errorf("dummy provider does not implement CloudEnvironProvider")
+	}
+	return plugin.Serve("dummy", provider)
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -192,6 +193,66 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// BackupStorage is the kind of storage used for backup archives
+	// kept by the controller: one of "controller", "local" or "s3".
+	BackupStorage = "backup-storage"
+
+	// BackupStoragePath is the directory on each controller machine
+	// in which backup archives are kept when BackupStorage is "local".
+	BackupStoragePath = "backup-storage-path"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// used when BackupStorage is "s3", eg "https://s3.amazonaws.com".
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the S3-compatible object store.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket in which backup archives are kept.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the S3-compatible object store. It may be set but is never
+	// returned by the API.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the S3-compatible object store. It may be set but is never
+	// returned by the API.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupSchedule is the interval at which the controller backs
+	// itself up, eg "24h". Scheduled backups are disabled when it is
+	// empty or zero.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionCount is the number of scheduled backups to
+	// keep when pruning after a scheduled backup (or 0 to keep any
+	// number). Backups created by hand are never pruned.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the age beyond which scheduled backups
+	// are removed when pruning after a scheduled backup, eg "720h"
+	// (or empty or zero to keep backups of any age).
+	BackupRetentionAge = "backup-retention-age"
+)
+
+const (
+	// BackupStorageController keeps backup archives in the
+	// controller's database.
+	BackupStorageController = "controller"
+
+	// BackupStorageLocal keeps backup archives in a directory on
+	// the controller machines, which may be a network mount.
+	BackupStorageLocal = "local"
+
+	// BackupStorageS3 keeps backup archives in an S3-compatible
+	// object store.
+	BackupStorageS3 = "s3"
+
+	// DefaultBackupS3Region is the default for BackupS3Region.
+	DefaultBackupS3Region = "us-east-1"
 )
 
 var (
@@ -225,6 +286,16 @@ var (
 		CAASOperatorImagePath,
 		Features,
 		MeteringURL,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupSchedule,
+		BackupRetentionCount,
+		BackupRetentionAge,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -240,6 +311,25 @@ var (
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		Features,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupSchedule,
+		BackupRetentionCount,
+		BackupRetentionAge,
+	)
+
+	// SecretConfigAttributes contains the controller config attributes
+	// which hold credentials. They are only read by the controller
+	// itself, and are removed from the config returned to clients
+	// and agents.
+	SecretConfigAttributes = set.NewStrings(
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -542,6 +632,71 @@ func (c Config) MeteringURL() string {
 	return url
 }
 
+// BackupStorage returns the kind of storage used for backup archives.
+func (c Config) BackupStorage() string {
+	if value := c.asString(BackupStorage); value != "" {
+		return value
+	}
+	return BackupStorageController
+}
+
+// BackupStoragePath returns the directory in which backup archives
+// are kept when BackupStorage is "local".
+func (c Config) BackupStoragePath() string {
+	return c.asString(BackupStoragePath)
+}
+
+// BackupS3Endpoint returns the URL of the object store in which backup
+// archives are kept when BackupStorage is "s3".
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the backup object store.
+func (c Config) BackupS3Region() string {
+	if value := c.asString(BackupS3Region); value != "" {
+		return value
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3Bucket returns the bucket in which backup archives are kept.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key for the backup object store.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key for the backup object store.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// BackupSchedule returns the interval at which the controller backs
+// itself up, or 0 if scheduled backups are disabled.
+func (c Config) BackupSchedule() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupSchedule))
+	return val
+}
+
+// BackupRetentionCount returns the number of scheduled backups to keep,
+// or 0 to keep any number.
+func (c Config) BackupRetentionCount() int {
+	return c.intOrDefault(BackupRetentionCount, 0)
+}
+
+// BackupRetentionAge returns the age beyond which scheduled backups are
+// removed, or 0 to keep backups of any age.
+func (c Config) BackupRetentionAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupRetentionAge))
+	return val
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -649,6 +804,57 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateBackupStorage(); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, key := range []string{BackupSchedule, BackupRetentionAge} {
+		if v, ok := c[key].(string); ok && v != "" {
+			if d, err := time.ParseDuration(v); err != nil {
+				return errors.Annotatef(err, "invalid %s in configuration", key)
+			} else if d < 0 {
+				return errors.NotValidf("negative %s %q", key, v)
+			}
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok {
+		if v < 0 {
+			return errors.Errorf("invalid backup retention count: should be a number of backups (or 0 to keep all), got %d", v)
+		}
+	}
+
+	return nil
+}
+
+func (c Config) validateBackupStorage() error {
+	switch c.BackupStorage() {
+	case BackupStorageController:
+	case BackupStorageLocal:
+		path := c.BackupStoragePath()
+		if path == "" {
+			return errors.Errorf("%s must be set when %s is %q", BackupStoragePath, BackupStorage, BackupStorageLocal)
+		}
+		if !filepath.IsAbs(path) {
+			return errors.NotValidf("%s %q (must be an absolute path)", BackupStoragePath, path)
+		}
+	case BackupStorageS3:
+		for _, key := range []string{BackupS3Endpoint, BackupS3Bucket, BackupS3AccessKey, BackupS3SecretKey} {
+			if c.asString(key) == "" {
+				return errors.Errorf("%s must be set when %s is %q", key, BackupStorage, BackupStorageS3)
+			}
+		}
+		u, err := url.Parse(c.BackupS3Endpoint())
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupS3Endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("%s %q (must be an http or https URL)", BackupS3Endpoint, c.BackupS3Endpoint())
+		}
+	default:
+		return errors.Errorf("%s: expected one of %q, %q or %q, got %q",
+			BackupStorage, BackupStorageController, BackupStorageLocal, BackupStorageS3, c.BackupStorage())
+	}
 	return nil
 }
 
@@ -729,6 +935,16 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
 	MeteringURL:             schema.String(),
+	BackupStorage:           schema.String(),
+	BackupStoragePath:       schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3Bucket:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
+	BackupSchedule:          schema.String(),
+	BackupRetentionCount:    schema.ForceInt(),
+	BackupRetentionAge:      schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -755,4 +971,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
 	MeteringURL:             romulus.DefaultAPIRoot,
+	BackupStorage:           schema.Omit,
+	BackupStoragePath:       schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3Bucket:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
+	BackupSchedule:          schema.Omit,
+	BackupRetentionCount:    schema.Omit,
+	BackupRetentionAge:      schema.Omit,
 })
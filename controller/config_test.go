@@ -230,6 +230,87 @@ var validateTests = []struct {
 		controller.CAASOperatorImagePath: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "unknown backup storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "tape",
+	},
+	expectError: `backup-storage: expected one of "controller", "local" or "s3", got "tape"`,
+}, {
+	about: "local backup storage without path",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "local",
+	},
+	expectError: `backup-storage-path must be set when backup-storage is "local"`,
+}, {
+	about: "local backup storage with relative path",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "local",
+		controller.BackupStoragePath: "backups",
+	},
+	expectError: `backup-storage-path "backups" \(must be an absolute path\) not valid`,
+}, {
+	about: "local backup storage",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "local",
+		controller.BackupStoragePath: "/srv/backups",
+	},
+}, {
+	about: "s3 backup storage without bucket",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `backup-s3-bucket must be set when backup-storage is "s3"`,
+}, {
+	about: "s3 backup storage with bad endpoint",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `backup-s3-endpoint "s3.example.com" \(must be an http or https URL\) not valid`,
+}, {
+	about: "s3 backup storage",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "http://10.0.0.1:9000",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "daily",
+	},
+	expectError: `invalid backup-schedule in configuration: time: invalid duration .*`,
+}, {
+	about: "negative backup retention age",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetentionAge: "-24h",
+	},
+	expectError: `negative backup-retention-age "-24h" not valid`,
+}, {
+	about: "negative backup retention count",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupRetentionCount: -1,
+	},
+	expectError: `invalid backup retention count: should be a number of backups \(or 0 to keep all\), got -1`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -504,3 +585,56 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
+
+func (s *ConfigSuite) TestBackupStorageDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, controller.BackupStorageController)
+	c.Check(cfg.BackupStoragePath(), gc.Equals, "")
+	c.Check(cfg.BackupS3Region(), gc.Equals, controller.DefaultBackupS3Region)
+	c.Check(cfg.BackupSchedule(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestBackupScheduleValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupSchedule:       "24h",
+			controller.BackupRetentionCount: 7,
+			controller.BackupRetentionAge:   "720h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, 24*time.Hour)
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 7)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, 720*time.Hour)
+}
+
+func (s *ConfigSuite) TestBackupStorageValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupStorage:     "s3",
+			controller.BackupS3Endpoint:  "https://s3.example.com",
+			controller.BackupS3Region:    "eu-west-2",
+			controller.BackupS3Bucket:    "backups",
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, controller.BackupStorageS3)
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, "https://s3.example.com")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "eu-west-2")
+	c.Check(cfg.BackupS3Bucket(), gc.Equals, "backups")
+	c.Check(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Check(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+}
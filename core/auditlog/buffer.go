@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// Buffer is an AuditLog which keeps the records written to it in
+// memory until they are taken, so that they can be forwarded
+// elsewhere. It holds a limited number of records, dropping the
+// oldest when full, so that a slow or missing reader never holds up
+// API requests.
+type Buffer struct {
+	mu      sync.Mutex
+	size    int
+	records []Record
+	dropped int
+	ready   chan struct{}
+}
+
+// NewBuffer returns a Buffer holding at most size records.
+func NewBuffer(size int) *Buffer {
+	return &Buffer{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// AddConversation implements AuditLog.
+func (b *Buffer) AddConversation(c Conversation) error {
+	b.add(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (b *Buffer) AddRequest(r Request) error {
+	b.add(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (b *Buffer) AddResponse(r ResponseErrors) error {
+	b.add(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog. The buffer is unaffected, since it may
+// be shared by several audit logs.
+func (b *Buffer) Close() error {
+	return nil
+}
+
+func (b *Buffer) add(record Record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.records) >= b.size {
+		b.records = b.records[1:]
+		b.dropped++
+	}
+	b.records = append(b.records, record)
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready returns a channel which receives a value when there are
+// records to be taken.
+func (b *Buffer) Ready() <-chan struct{} {
+	return b.ready
+}
+
+// Take empties the buffer, returning the records it held, oldest
+// first, and the number of records dropped since the last call.
+func (b *Buffer) Take() ([]Record, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	records, dropped := b.records, b.dropped
+	b.records, b.dropped = nil, 0
+	return records, dropped
+}
+
+// NewMultiLog returns an AuditLog which writes each record to all of
+// the given audit logs.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return multiLog(logs)
+}
+
+type multiLog []AuditLog
+
+// AddConversation implements AuditLog.
+func (m multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error { return log.AddConversation(c) })
+}
+
+// AddRequest implements AuditLog.
+func (m multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error { return log.AddRequest(r) })
+}
+
+// AddResponse implements AuditLog.
+func (m multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error { return log.AddResponse(r) })
+}
+
+// Close implements AuditLog.
+func (m multiLog) Close() error {
+	return m.each(AuditLog.Close)
+}
+
+// each calls f for every log, even if an earlier one fails, and
+// returns the first error.
+func (m multiLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range m {
+		if err := f(log); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type BufferSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BufferSuite{})
+
+func (s *BufferSuite) TestTake(c *gc.C) {
+	buffer := auditlog.NewBuffer(10)
+	select {
+	case <-buffer.Ready():
+		c.Fatalf("unexpectedly ready")
+	default:
+	}
+
+	conversation := auditlog.Conversation{Who: "mary", ConversationID: "c1"}
+	request := auditlog.Request{ConversationID: "c1", RequestID: 1}
+	response := auditlog.ResponseErrors{ConversationID: "c1", RequestID: 1}
+	c.Assert(buffer.AddConversation(conversation), jc.ErrorIsNil)
+	c.Assert(buffer.AddRequest(request), jc.ErrorIsNil)
+	c.Assert(buffer.AddResponse(response), jc.ErrorIsNil)
+
+	select {
+	case <-buffer.Ready():
+	default:
+		c.Fatalf("not ready")
+	}
+	records, dropped := buffer.Take()
+	c.Assert(dropped, gc.Equals, 0)
+	c.Assert(records, jc.DeepEquals, []auditlog.Record{
+		{Conversation: &conversation},
+		{Request: &request},
+		{Errors: &response},
+	})
+
+	records, dropped = buffer.Take()
+	c.Assert(records, gc.HasLen, 0)
+	c.Assert(dropped, gc.Equals, 0)
+}
+
+func (s *BufferSuite) TestDropsOldest(c *gc.C) {
+	buffer := auditlog.NewBuffer(2)
+	for _, id := range []string{"c1", "c2", "c3"} {
+		err := buffer.AddConversation(auditlog.Conversation{ConversationID: id})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	records, dropped := buffer.Take()
+	c.Assert(dropped, gc.Equals, 1)
+	c.Assert(records, gc.HasLen, 2)
+	c.Assert(records[0].Conversation.ConversationID, gc.Equals, "c2")
+	c.Assert(records[1].Conversation.ConversationID, gc.Equals, "c3")
+}
+
+func (s *BufferSuite) TestMultiLog(c *gc.C) {
+	var log1, log2 fakeLog
+	log2.stub.SetErrors(errors.New("disk full"))
+	multi := auditlog.NewMultiLog(&log1, &log2)
+
+	err := multi.AddConversation(auditlog.Conversation{ConversationID: "c1"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	err = multi.AddRequest(auditlog.Request{ConversationID: "c1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = multi.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	log1.stub.CheckCallNames(c, "AddConversation", "AddRequest", "Close")
+	log2.stub.CheckCallNames(c, "AddConversation", "AddRequest", "Close")
+}
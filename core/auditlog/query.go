@@ -0,0 +1,253 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Filter selects the entries returned by ReadLog. Zero-valued fields
+// match everything.
+type Filter struct {
+	// User matches the name of the user who made a conversation.
+	User string
+
+	// Model matches the name or UUID of the model a conversation was
+	// with.
+	Model string
+
+	// Facade and Method match the requests made in a conversation.
+	Facade string
+	Method string
+
+	// After and Before limit requests (and conversations without
+	// matching requests) to a time window.
+	After  time.Time
+	Before time.Time
+
+	// ErrorsOnly matches requests which got an error response.
+	ErrorsOnly bool
+}
+
+// matchesRequests returns whether the filter selects particular
+// requests, rather than conversations.
+func (f Filter) matchesRequests() bool {
+	return f.Facade != "" || f.Method != "" || f.ErrorsOnly
+}
+
+func (f Filter) matchesConversation(c Conversation) bool {
+	if f.User != "" && f.User != c.Who {
+		return false
+	}
+	if f.Model != "" && f.Model != c.ModelName && f.Model != c.ModelUUID {
+		return false
+	}
+	return true
+}
+
+func (f Filter) matchesRequest(r Request) bool {
+	if f.Facade != "" && f.Facade != r.Facade {
+		return false
+	}
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	return f.matchesTime(r.When)
+}
+
+func (f Filter) matchesTime(when string) bool {
+	if f.After.IsZero() && f.Before.IsZero() {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return false
+	}
+	if !f.After.IsZero() && t.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && t.After(f.Before) {
+		return false
+	}
+	return true
+}
+
+// Entry is a conversation from the audit log, with those of its
+// requests which match a filter.
+type Entry struct {
+	Conversation Conversation
+	Requests     []RequestEntry
+}
+
+// RequestEntry is a request from the audit log, with any errors sent
+// back in response to it.
+type RequestEntry struct {
+	Request Request
+	Errors  []*Error
+}
+
+// hasError returns whether any error was sent back in response to
+// the request.
+func (r RequestEntry) hasError() bool {
+	for _, err := range r.Errors {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadLog reads the audit log written by NewLogFile to the given
+// directory, including any rotated log files, and returns the entries
+// matching the filter, oldest first. Requests whose conversation has
+// been rotated out of the log are not returned.
+func ReadLog(logDir string, filter Filter) ([]Entry, error) {
+	paths, err := logFiles(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &logReader{
+		filter:   filter,
+		byID:     make(map[string]*Entry),
+		requests: make(map[requestKey]requestIndex),
+	}
+	for _, path := range paths {
+		if err := r.readFile(path); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", path)
+		}
+	}
+	return r.result(), nil
+}
+
+// logFiles returns the paths of the audit log files in logDir, oldest
+// first. Rotated log files are named with the time they were rotated,
+// and may be compressed.
+func logFiles(logDir string) ([]string, error) {
+	rotated, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(rotated)
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		return append(rotated, current), nil
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return rotated, nil
+}
+
+type requestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+// requestIndex locates a request within its entry.
+type requestIndex struct {
+	entry *Entry
+	index int
+}
+
+type logReader struct {
+	filter   Filter
+	order    []*Entry
+	byID     map[string]*Entry
+	requests map[requestKey]requestIndex
+}
+
+func (r *logReader) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+
+	var source io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		source = gz
+	}
+
+	scanner := bufio.NewScanner(source)
+	// Requests may include their arguments, which can be large.
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Warningf("skipping unreadable audit log record in %s: %v", path, err)
+			continue
+		}
+		r.add(record)
+	}
+	return errors.Trace(scanner.Err())
+}
+
+func (r *logReader) add(record Record) {
+	switch {
+	case record.Conversation != nil:
+		c := *record.Conversation
+		if !r.filter.matchesConversation(c) {
+			return
+		}
+		entry := &Entry{Conversation: c}
+		r.order = append(r.order, entry)
+		r.byID[c.ConversationID] = entry
+	case record.Request != nil:
+		req := *record.Request
+		entry, ok := r.byID[req.ConversationID]
+		if !ok || !r.filter.matchesRequest(req) {
+			return
+		}
+		r.requests[requestKey{req.ConversationID, req.RequestID}] = requestIndex{
+			entry: entry,
+			index: len(entry.Requests),
+		}
+		entry.Requests = append(entry.Requests, RequestEntry{Request: req})
+	case record.Errors != nil:
+		key := requestKey{record.Errors.ConversationID, record.Errors.RequestID}
+		if i, ok := r.requests[key]; ok {
+			req := &i.entry.Requests[i.index]
+			req.Errors = append(req.Errors, record.Errors.Errors...)
+		}
+	}
+}
+
+func (r *logReader) result() []Entry {
+	var result []Entry
+	for _, entry := range r.order {
+		requests := entry.Requests
+		if r.filter.ErrorsOnly {
+			requests = nil
+			for _, req := range entry.Requests {
+				if req.hasError() {
+					requests = append(requests, req)
+				}
+			}
+		}
+		if len(requests) == 0 {
+			if r.filter.matchesRequests() || !r.filter.matchesTime(entry.Conversation.When) {
+				continue
+			}
+		}
+		result = append(result, Entry{
+			Conversation: entry.Conversation,
+			Requests:     requests,
+		})
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+
+	// The oldest conversation has been rotated out to a compressed
+	// file, along with its first request.
+	s.writeRotated(c, "audit-2018-10-01T10-00-00.000.log.gz",
+		auditlog.Record{Conversation: &auditlog.Conversation{
+			Who:            "mary",
+			What:           "juju deploy",
+			When:           "2018-10-01T09:00:00Z",
+			ModelName:      "default",
+			ModelUUID:      "deadbeef",
+			ConversationID: "c1",
+			ConnectionID:   "1",
+		}},
+		auditlog.Record{Request: &auditlog.Request{
+			ConversationID: "c1",
+			ConnectionID:   "1",
+			RequestID:      1,
+			When:           "2018-10-01T09:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        6,
+		}},
+	)
+
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	defer logFile.Close()
+	c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-10-01T09:00:02Z",
+		Errors:         []*auditlog.Error{{Message: "no such charm", Code: "not found"}},
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      2,
+		When:           "2018-10-01T09:00:03Z",
+		Facade:         "Application",
+		Method:         "AddRelation",
+		Version:        6,
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      2,
+		When:           "2018-10-01T09:00:04Z",
+		Errors:         []*auditlog.Error{nil},
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who:            "bob",
+		What:           "juju remove-unit",
+		When:           "2018-10-02T09:00:00Z",
+		ModelName:      "prod",
+		ModelUUID:      "cafebabe",
+		ConversationID: "c2",
+		ConnectionID:   "2",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c2",
+		ConnectionID:   "2",
+		RequestID:      1,
+		When:           "2018-10-02T09:00:01Z",
+		Facade:         "Application",
+		Method:         "DestroyUnit",
+		Version:        6,
+	}), jc.ErrorIsNil)
+	// A request whose conversation has been lost is ignored.
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c0",
+		ConnectionID:   "0",
+		RequestID:      7,
+		When:           "2018-10-02T09:00:02Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	}), jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) writeRotated(c *gc.C, name string, records ...auditlog.Record) {
+	f, err := os.Create(filepath.Join(s.dir, name))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	encoder := json.NewEncoder(gz)
+	for _, record := range records {
+		c.Assert(encoder.Encode(record), jc.ErrorIsNil)
+	}
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) query(c *gc.C, filter auditlog.Filter) []auditlog.Entry {
+	entries, err := auditlog.ReadLog(s.dir, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	return entries
+}
+
+func methods(entry auditlog.Entry) []string {
+	var result []string
+	for _, req := range entry.Requests {
+		result = append(result, req.Request.Facade+"."+req.Request.Method)
+	}
+	return result
+}
+
+func (s *QuerySuite) TestAll(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{})
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Conversation.ConversationID, gc.Equals, "c1")
+	c.Check(methods(entries[0]), jc.DeepEquals, []string{"Application.Deploy", "Application.AddRelation"})
+	c.Check(entries[0].Requests[0].Errors, jc.DeepEquals, []*auditlog.Error{
+		{Message: "no such charm", Code: "not found"},
+	})
+	c.Check(entries[1].Conversation.ConversationID, gc.Equals, "c2")
+	c.Check(methods(entries[1]), jc.DeepEquals, []string{"Application.DestroyUnit"})
+}
+
+func (s *QuerySuite) TestUser(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{User: "bob"})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Conversation.ConversationID, gc.Equals, "c2")
+}
+
+func (s *QuerySuite) TestModel(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{Model: "default"})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Conversation.ConversationID, gc.Equals, "c1")
+
+	entries = s.query(c, auditlog.Filter{Model: "cafebabe"})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Conversation.ConversationID, gc.Equals, "c2")
+}
+
+func (s *QuerySuite) TestFacadeMethod(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{Facade: "Application", Method: "AddRelation"})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(methods(entries[0]), jc.DeepEquals, []string{"Application.AddRelation"})
+}
+
+func (s *QuerySuite) TestTimeWindow(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{
+		After:  time.Date(2018, 10, 1, 9, 0, 2, 0, time.UTC),
+		Before: time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC),
+	})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(methods(entries[0]), jc.DeepEquals, []string{"Application.AddRelation"})
+}
+
+func (s *QuerySuite) TestErrorsOnly(c *gc.C) {
+	entries := s.query(c, auditlog.Filter{ErrorsOnly: true})
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(methods(entries[0]), jc.DeepEquals, []string{"Application.Deploy"})
+}
+
+func (s *QuerySuite) TestNoLog(c *gc.C) {
+	entries, err := auditlog.ReadLog(c.MkDir(), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedule specifications, and
+// computes the times at which they fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxYears is the number of years into the future that Next will
+// search for a matching time before giving up. A specification such
+// as "0 0 31 2 *" never matches.
+const maxYears = 5
+
+// shortcuts holds the supported predefined specifications.
+var shortcuts = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+}
+
+type bounds struct {
+	name     string
+	min, max uint
+}
+
+var (
+	minuteBounds = bounds{"minute", 0, 59}
+	hourBounds   = bounds{"hour", 0, 23}
+	domBounds    = bounds{"day of month", 1, 31}
+	monthBounds  = bounds{"month", 1, 12}
+	dowBounds    = bounds{"day of week", 0, 7}
+)
+
+// Schedule is a parsed cron specification. The fields of a schedule
+// are bit sets, where bit n is set if the value n matches.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar and dowStar record whether the day of month and day
+	// of week fields were unrestricted. If both fields are
+	// restricted, a day matches if either of them matches.
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a standard five-field cron specification
+// ("minute hour day-of-month month day-of-week"). Each field may be
+// "*", a number, a range ("1-5"), or a comma-separated list of
+// those, and each may be followed by a step ("*/15"). The
+// shortcuts @hourly, @daily, @midnight, @weekly, @monthly and
+// @yearly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	expanded := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if expanded, ok = shortcuts[spec]; !ok {
+			return nil, errors.NotValidf("cron shortcut %q", spec)
+		}
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("cron specification %q (expected 5 fields, got %d)", spec, len(fields))
+	}
+	s := &Schedule{
+		spec:    spec,
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	for i, f := range []struct {
+		bits   *uint64
+		bounds bounds
+	}{
+		{&s.minute, minuteBounds},
+		{&s.hour, hourBounds},
+		{&s.dom, domBounds},
+		{&s.month, monthBounds},
+		{&s.dow, dowBounds},
+	} {
+		bits, err := parseField(fields[i], f.bounds)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cron specification %q", spec)
+		}
+		*f.bits = bits
+	}
+	// Sunday may be specified as either 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField parses a comma-separated list of ranges into a bit set.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parseRange(part, b)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+// parseRange parses a single range, with optional step, into a
+// bit set.
+func parseRange(part string, b bounds) (uint64, error) {
+	rangePart, step := part, uint(1)
+	if i := strings.Index(part, "/"); i >= 0 {
+		n, err := strconv.ParseUint(part[i+1:], 10, 8)
+		if err != nil || n == 0 {
+			return 0, errors.NotValidf("%s step %q", b.name, part[i+1:])
+		}
+		rangePart, step = part[:i], uint(n)
+	}
+	var lo, hi uint
+	switch {
+	case rangePart == "*":
+		lo, hi = b.min, b.max
+	case strings.Contains(rangePart, "-"):
+		i := strings.Index(rangePart, "-")
+		var err error
+		if lo, err = parseValue(rangePart[:i], b); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if hi, err = parseValue(rangePart[i+1:], b); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if lo > hi {
+			return 0, errors.NotValidf("%s range %q", b.name, rangePart)
+		}
+	default:
+		v, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		lo, hi = v, v
+		if step != 1 {
+			hi = b.max
+		}
+	}
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || uint(n) < b.min || uint(n) > b.max {
+		return 0, errors.NotValidf("%s %q", b.name, s)
+	}
+	return uint(n), nil
+}
+
+// String returns the specification from which the schedule was
+// parsed.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t, truncated to the minute, at
+// which the schedule fires. Times are evaluated in t's location. If
+// the schedule never fires, the zero time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+var nextTests = []struct {
+	spec   string
+	from   string
+	expect string
+}{{
+	spec:   "* * * * *",
+	from:   "2018-06-01T10:20:30Z",
+	expect: "2018-06-01T10:21:00Z",
+}, {
+	spec:   "30 2 * * *",
+	from:   "2018-06-01T10:20:00Z",
+	expect: "2018-06-02T02:30:00Z",
+}, {
+	spec:   "30 2 * * *",
+	from:   "2018-06-02T02:30:00Z",
+	expect: "2018-06-03T02:30:00Z",
+}, {
+	spec:   "*/15 * * * *",
+	from:   "2018-06-01T10:20:00Z",
+	expect: "2018-06-01T10:30:00Z",
+}, {
+	spec:   "0 9-17/4 * * *",
+	from:   "2018-06-01T14:00:00Z",
+	expect: "2018-06-01T17:00:00Z",
+}, {
+	spec:   "0 0 * * 1,3",
+	from:   "2018-06-01T00:00:00Z", // Friday
+	expect: "2018-06-04T00:00:00Z",
+}, {
+	spec:   "0 0 1 * *",
+	from:   "2018-12-15T00:00:00Z",
+	expect: "2019-01-01T00:00:00Z",
+}, {
+	spec:   "0 0 29 2 *",
+	from:   "2018-03-01T00:00:00Z",
+	expect: "2020-02-29T00:00:00Z",
+}, {
+	// Either day field may match if both are restricted.
+	spec:   "0 0 15 * 0",
+	from:   "2018-06-01T00:00:00Z",
+	expect: "2018-06-03T00:00:00Z",
+}, {
+	spec:   "0 0 * * 7",
+	from:   "2018-06-01T00:00:00Z",
+	expect: "2018-06-03T00:00:00Z",
+}, {
+	spec:   "@weekly",
+	from:   "2018-06-01T00:00:00Z",
+	expect: "2018-06-03T00:00:00Z",
+}, {
+	spec:   "0 0 31 2 *",
+	from:   "2018-06-01T00:00:00Z",
+	expect: "0001-01-01T00:00:00Z",
+}}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	for i, test := range nextTests {
+		c.Logf("test %d: %q from %s", i, test.spec, test.from)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		from, err := time.Parse(time.RFC3339, test.from)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(from).Format(time.RFC3339), gc.Equals, test.expect)
+	}
+}
+
+func (s *CronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.String(), gc.Equals, "@daily")
+}
+
+var parseErrorTests = []struct {
+	spec string
+	err  string
+}{{
+	spec: "* * * *",
+	err:  `cron specification "\* \* \* \*" \(expected 5 fields, got 4\) not valid`,
+}, {
+	spec: "@fortnightly",
+	err:  `cron shortcut "@fortnightly" not valid`,
+}, {
+	spec: "60 * * * *",
+	err:  `cron specification "60 \* \* \* \*": minute "60" not valid`,
+}, {
+	spec: "* * 0 * *",
+	err:  `cron specification "\* \* 0 \* \*": day of month "0" not valid`,
+}, {
+	spec: "* 5-2 * * *",
+	err:  `cron specification "\* 5-2 \* \* \*": hour range "5-2" not valid`,
+}, {
+	spec: "*/0 * * * *",
+	err:  `cron specification "\*/0 \* \* \* \*": minute step "0" not valid`,
+}, {
+	spec: "* * * jan *",
+	err:  `cron specification "\* \* \* jan \*": month "jan" not valid`,
+}}
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -68,6 +68,13 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// CloudName, CloudRegion and Users are only used when reporting
+	// on migration prechecks. They may be empty when the model info
+	// comes from an older controller.
+	CloudName   string
+	CloudRegion string
+	Users       []names.UserTag
 }
 
 func (i *ModelInfo) Validate() error {
@@ -85,3 +92,15 @@ func (i *ModelInfo) Validate() error {
 	}
 	return nil
 }
+
+// PrecheckReport holds the outcome of running every migration
+// precheck, rather than stopping at the first failure.
+type PrecheckReport struct {
+	// Blockers holds the problems that would prevent the model from
+	// being migrated.
+	Blockers []string
+
+	// Warnings holds conditions that don't prevent a migration but
+	// may need attention before or after it.
+	Warnings []string
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 	"github.com/juju/juju/network"
 )
 
@@ -74,6 +75,19 @@ const (
 	// ProvisionerHarvestModeKey stores the key for this setting.
 	ProvisionerHarvestModeKey = "provisioner-harvest-mode"
 
+	// ProvisionerRetryCountKey is the number of times the provisioner
+	// retries starting an instance before giving up.
+	ProvisionerRetryCountKey = "provisioner-retry-count"
+
+	// ProvisionerRetryDelayKey is how long the provisioner waits before
+	// the first retry of starting an instance, eg "10s". The delay
+	// doubles with each subsequent retry.
+	ProvisionerRetryDelayKey = "provisioner-retry-delay"
+
+	// ProvisionerRetryMaxDelayKey is the longest the provisioner waits
+	// between retries of starting an instance, eg "5m".
+	ProvisionerRetryMaxDelayKey = "provisioner-retry-max-delay"
+
 	// AgentStreamKey stores the key for this setting.
 	AgentStreamKey = "agent-stream"
 
@@ -188,6 +202,23 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdWebhookURL sets the https URL to which log records are
+	// POSTed. If set, log records are forwarded there instead of to
+	// the syslog server.
+	LogFwdWebhookURL = "webhook-url"
+
+	// LogFwdWebhookCACert sets the certificate of the CA that signed
+	// the webhook server certificate.
+	LogFwdWebhookCACert = "webhook-ca-cert"
+
+	// LogFwdWebhookClientCert sets the client certificate for webhook
+	// forwarding.
+	LogFwdWebhookClientCert = "webhook-client-cert"
+
+	// LogFwdWebhookClientKey sets the client key for webhook
+	// forwarding.
+	LogFwdWebhookClientKey = "webhook-client-key"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -589,7 +620,11 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if whCfg, ok := cfg.LogFwdWebhook(); ok {
+		if err := whCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid webhook forwarding config")
+		}
+	} else if lfCfg, ok := cfg.LogFwdSyslog(); ok {
 		if err := lfCfg.Validate(); err != nil {
 			return errors.Annotate(err, "invalid syslog forwarding config")
 		}
@@ -628,6 +663,22 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[ProvisionerRetryCountKey].(int); ok && v < 0 {
+		return errors.Errorf("provisioner retry count %d cannot be negative", v)
+	}
+
+	retryDelay, err := cfg.durationAttr(ProvisionerRetryDelayKey)
+	if err != nil {
+		return errors.Annotate(err, "invalid provisioner retry delay in model configuration")
+	}
+	retryMaxDelay, err := cfg.durationAttr(ProvisionerRetryMaxDelayKey)
+	if err != nil {
+		return errors.Annotate(err, "invalid provisioner retry max delay in model configuration")
+	}
+	if retryDelay > 0 && retryMaxDelay > 0 && retryMaxDelay < retryDelay {
+		return errors.Errorf("provisioner retry max delay %v cannot be less than retry delay %v", retryMaxDelay, retryDelay)
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -1068,6 +1119,26 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdWebhook returns the webhook forwarding config. It is only
+// returned if a webhook URL is set, in which case it takes the place
+// of the syslog forwarding config.
+func (c *Config) LogFwdWebhook() (*webhook.RawConfig, bool) {
+	url, _ := c.defined[LogFwdWebhookURL].(string)
+	if url == "" {
+		return nil, false
+	}
+	whCfg := webhook.RawConfig{
+		URL: url,
+	}
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		whCfg.Enabled = s.(bool)
+	}
+	whCfg.CACert, _ = c.defined[LogFwdWebhookCACert].(string)
+	whCfg.ClientCert, _ = c.defined[LogFwdWebhookClientCert].(string)
+	whCfg.ClientKey, _ = c.defined[LogFwdWebhookClientKey].(string)
+	return &whCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1206,6 +1277,48 @@ func (c *Config) ImageStream() string {
 	return "released"
 }
 
+// ProvisionerRetryCount returns the number of times the provisioner
+// retries starting an instance, and whether it is set.
+func (c *Config) ProvisionerRetryCount() (int, bool) {
+	v, ok := c.defined[ProvisionerRetryCountKey].(int)
+	return v, ok
+}
+
+// ProvisionerRetryDelay returns how long the provisioner waits before
+// first retrying to start an instance, and whether it is set.
+func (c *Config) ProvisionerRetryDelay() (time.Duration, bool) {
+	_, ok := c.defined[ProvisionerRetryDelayKey].(string)
+	// Value has already been validated.
+	val, _ := c.durationAttr(ProvisionerRetryDelayKey)
+	return val, ok
+}
+
+// ProvisionerRetryMaxDelay returns the longest the provisioner waits
+// between retries to start an instance, and whether it is set.
+func (c *Config) ProvisionerRetryMaxDelay() (time.Duration, bool) {
+	_, ok := c.defined[ProvisionerRetryMaxDelayKey].(string)
+	// Value has already been validated.
+	val, _ := c.durationAttr(ProvisionerRetryMaxDelayKey)
+	return val, ok
+}
+
+// durationAttr returns the duration stored in the named attribute,
+// or zero if it is not set.
+func (c *Config) durationAttr(name string) (time.Duration, error) {
+	v, ok := c.defined[name].(string)
+	if !ok || v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if d < 0 {
+		return 0, errors.Errorf("%s %q cannot be negative", name, v)
+	}
+	return d, nil
+}
+
 // AgentStream returns the simplestreams stream
 // used to identify which tools to use when
 // when bootstrapping or upgrading an environment.
@@ -1443,6 +1556,11 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
 
+	LogFwdWebhookURL:        schema.Omit,
+	LogFwdWebhookCACert:     schema.Omit,
+	LogFwdWebhookClientCert: schema.Omit,
+	LogFwdWebhookClientKey:  schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey:      schema.Omit,
@@ -1451,6 +1569,9 @@ var alwaysOptional = schema.Defaults{
 	"firewall-mode":              schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
+	ProvisionerRetryCountKey:     schema.Omit,
+	ProvisionerRetryDelayKey:     schema.Omit,
+	ProvisionerRetryMaxDelayKey:  schema.Omit,
 	HTTPProxyKey:                 schema.Omit,
 	HTTPSProxyKey:                schema.Omit,
 	FTPProxyKey:                  schema.Omit,
@@ -1862,6 +1983,21 @@ global or per instance security groups.`,
 		Values:      []interface{}{"all", "none", "unknown", "destroyed"},
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerRetryCountKey: {
+		Description: "The number of times to retry starting an instance before giving up (default 10)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryDelayKey: {
+		Description: "How long to wait before first retrying to start an instance, in human-readable time format; the delay doubles with each retry (default 10s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryMaxDelayKey: {
+		Description: "The longest to wait between retries to start an instance, in human-readable time format (default 1m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"proxy-ssh": {
 		// default: true
 		Description: `Whether SSH commands should be proxied through the API server`,
@@ -1874,7 +2010,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding, to syslog or a webhook, is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1898,6 +2034,26 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdWebhookURL: {
+		Description: `The https URL to which log records are POSTed as JSON lines, instead of being sent to syslog.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdWebhookCACert: {
+		Description: `The certificate of the CA that signed the webhook server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdWebhookClientCert: {
+		Description: `The webhook client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdWebhookClientKey: {
+		Description: `The webhook client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
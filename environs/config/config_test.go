@@ -489,6 +489,28 @@ var configTests = []configTest{
 			"syslog-client-key":  testing.CAKey,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing CA certificate: asn1: syntax error: data truncated`,
+	}, {
+		about:       "negative provisioner retry count",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-count": -1,
+		}),
+		err: `provisioner retry count -1 cannot be negative`,
+	}, {
+		about:       "invalid provisioner retry delay",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-delay": "soon",
+		}),
+		err: `invalid provisioner retry delay in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "provisioner retry max delay less than delay",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-delay":     "1m",
+			"provisioner-retry-max-delay": "10s",
+		}),
+		err: `provisioner retry max delay 10s cannot be less than retry delay 1m0s`,
 	}, {
 		about:       "invalid syslog cert",
 		useDefaults: config.UseDefaults,
@@ -552,6 +574,28 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid webhook config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"logforward-enabled":  true,
+			"webhook-url":         "https://logs.example.com/ingest",
+			"webhook-ca-cert":     testing.CACert,
+			"webhook-client-cert": testing.ServerCert,
+			"webhook-client-key":  testing.ServerKey,
+		}),
+	}, {
+		about:       "Invalid webhook url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"webhook-url":        "http://logs.example.com/ingest",
+		}),
+		err: `invalid webhook forwarding config: non-https URL "http://logs.example.com/ingest" not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -673,6 +717,18 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	whCfg, hasWebhookCfg := cfg.LogFwdWebhook()
+	if v, _ := test.attrs["webhook-url"].(string); v != "" {
+		c.Assert(hasWebhookCfg, jc.IsTrue)
+		c.Assert(whCfg.URL, gc.Equals, v)
+		c.Check(whCfg.Enabled, gc.Equals, test.attrs["logforward-enabled"] == true)
+		c.Check(whCfg.CACert, gc.Equals, test.attrs["webhook-ca-cert"])
+		c.Check(whCfg.ClientCert, gc.Equals, test.attrs["webhook-client-cert"])
+		c.Check(whCfg.ClientKey, gc.Equals, test.attrs["webhook-client-key"])
+	} else {
+		c.Assert(hasWebhookCfg, jc.IsFalse)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -1318,6 +1374,33 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestProvisionerRetryConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.ProvisionerRetryMaxDelay()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestProvisionerRetryConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"provisioner-retry-count":     3,
+		"provisioner-retry-delay":     "30s",
+		"provisioner-retry-max-delay": "10m",
+	})
+	count, ok := cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(count, gc.Equals, 3)
+	delay, ok := cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(delay, gc.Equals, 30*time.Second)
+	maxDelay, ok := cfg.ProvisionerRetryMaxDelay()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(maxDelay, gc.Equals, 10*time.Minute)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
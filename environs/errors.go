@@ -41,3 +41,101 @@ func IsAvailabilityZoneIndependent(err error) bool {
 	}
 	return false
 }
+
+// ErrorKind classifies an error returned by a provider, so that Juju
+// can decide whether, and how, to retry the failed operation.
+type ErrorKind string
+
+const (
+	// ErrorKindUnknown is the kind of errors that have not been
+	// classified by the provider.
+	ErrorKindUnknown ErrorKind = ""
+
+	// ErrorKindQuota is the kind of errors caused by exceeding a
+	// quota or limit on the cloud account. Retrying will not help
+	// until the quota is raised, or resources are released.
+	ErrorKindQuota ErrorKind = "quota"
+
+	// ErrorKindCapacity is the kind of errors caused by the cloud,
+	// or one of its availability zones, having insufficient capacity
+	// to satisfy the request.
+	ErrorKindCapacity ErrorKind = "capacity"
+
+	// ErrorKindAuth is the kind of errors caused by the cloud
+	// credential being invalid, or lacking the necessary permissions.
+	ErrorKindAuth ErrorKind = "auth"
+
+	// ErrorKindTransient is the kind of errors that are expected to
+	// clear up by themselves, such as rate limiting or a temporary
+	// outage of the cloud API.
+	ErrorKindTransient ErrorKind = "transient"
+)
+
+// ClassifiedError provides an interface for compute providers to
+// indicate the kind of an error.
+type ClassifiedError interface {
+	error
+
+	// ErrorKind returns the kind of the error.
+	ErrorKind() ErrorKind
+}
+
+// ClassifyError returns the kind of the given error, or its cause.
+// If the error does not implement ClassifiedError, ErrorKindUnknown
+// is returned.
+func ClassifyError(err error) ErrorKind {
+	if err, ok := errors.Cause(err).(ClassifiedError); ok {
+		return err.ErrorKind()
+	}
+	return ErrorKindUnknown
+}
+
+// QuotaExceededError wraps the given error such that ClassifyError
+// returns ErrorKindQuota. Quotas apply to the cloud account as a
+// whole, so the error is also independent of availability zones.
+func QuotaExceededError(err error) error {
+	return classifyError(err, ErrorKindQuota, true)
+}
+
+// CapacityError wraps the given error such that ClassifyError returns
+// ErrorKindCapacity. The error is assumed to be specific to the
+// availability zone in which the operation was attempted.
+func CapacityError(err error) error {
+	return classifyError(err, ErrorKindCapacity, false)
+}
+
+// TransientError wraps the given error such that ClassifyError returns
+// ErrorKindTransient. The operation should be retried as it was, so
+// the error is independent of availability zones.
+func TransientError(err error) error {
+	return classifyError(err, ErrorKindTransient, true)
+}
+
+func classifyError(err error, kind ErrorKind, zoneIndependent bool) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, &classifiedError{
+		error:           err,
+		kind:            kind,
+		zoneIndependent: zoneIndependent,
+	})
+	wrapped.(*errors.Err).SetLocation(2)
+	return wrapped
+}
+
+type classifiedError struct {
+	error
+	kind            ErrorKind
+	zoneIndependent bool
+}
+
+// ErrorKind is part of the ClassifiedError interface.
+func (e *classifiedError) ErrorKind() ErrorKind {
+	return e.kind
+}
+
+// AvailabilityZoneIndependent is part of the AvailabilityZoneError interface.
+func (e *classifiedError) AvailabilityZoneIndependent() bool {
+	return e.zoneIndependent
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type errorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (*errorsSuite) TestClassifyError(c *gc.C) {
+	for i, test := range []struct {
+		wrap            func(error) error
+		kind            environs.ErrorKind
+		zoneIndependent bool
+	}{{
+		wrap:            environs.QuotaExceededError,
+		kind:            environs.ErrorKindQuota,
+		zoneIndependent: true,
+	}, {
+		wrap: environs.CapacityError,
+		kind: environs.ErrorKindCapacity,
+	}, {
+		wrap:            environs.TransientError,
+		kind:            environs.ErrorKindTransient,
+		zoneIndependent: true,
+	}} {
+		c.Logf("test %d: %s", i, test.kind)
+		err := test.wrap(errors.New("foo"))
+		c.Check(err, gc.ErrorMatches, "foo")
+		c.Check(environs.ClassifyError(err), gc.Equals, test.kind)
+		c.Check(environs.IsAvailabilityZoneIndependent(err), gc.Equals, test.zoneIndependent)
+
+		annotated := errors.Annotate(err, "bar")
+		c.Check(annotated, gc.ErrorMatches, "bar: foo")
+		c.Check(environs.ClassifyError(annotated), gc.Equals, test.kind)
+	}
+}
+
+func (*errorsSuite) TestClassifyErrorUnknown(c *gc.C) {
+	c.Assert(environs.ClassifyError(errors.New("foo")), gc.Equals, environs.ErrorKindUnknown)
+	c.Assert(environs.ClassifyError(nil), gc.Equals, environs.ErrorKindUnknown)
+	c.Assert(environs.QuotaExceededError(nil), jc.ErrorIsNil)
+}
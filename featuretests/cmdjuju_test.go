@@ -164,6 +164,28 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-autoscaling-max-units:
+    description: the most units the application is autoscaled to; setting this enables
+      autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscaling-metric:
+    description: the name of a metric recorded with add-metric to autoscale on
+    source: unset
+    type: string
+  kubernetes-autoscaling-metric-target:
+    description: the average value per unit of the charm's metric to autoscale to
+    source: unset
+    type: string
+  kubernetes-autoscaling-min-units:
+    description: the fewest units the application is autoscaled to
+    source: unset
+    type: int
+  kubernetes-autoscaling-target-cpu:
+    description: the average CPU utilisation of the pods to autoscale to, as a percentage
+      of their CPU request
+    source: unset
+    type: int
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
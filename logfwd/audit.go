@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+// These are the recognized kinds of audited activity.
+const (
+	AuditConversation AuditKind = "conversation"
+	AuditRequest      AuditKind = "request"
+	AuditErrors       AuditKind = "errors"
+)
+
+// AuditKind identifies the kind of activity described by an
+// AuditRecord.
+type AuditKind string
+
+// AuditRecord holds the detail of an audited API activity: the start
+// of a conversation with the API, a request made in a conversation, or
+// the errors in the response to a request. Only the fields relevant to
+// the Kind are set.
+type AuditRecord struct {
+	// Kind is the kind of activity audited.
+	Kind AuditKind
+
+	// ConversationID and ConnectionID identify the conversation
+	// and the API connection it is part of.
+	ConversationID string
+	ConnectionID   string
+
+	// Who, What, ModelName and ModelUUID describe a conversation:
+	// the user, the command they ran, and the model they used.
+	Who       string
+	What      string
+	ModelName string
+	ModelUUID string
+
+	// RequestID identifies the request made, or responded to.
+	RequestID uint64
+
+	// Facade, Method, Version and Args describe a request.
+	Facade  string
+	Method  string
+	Version int
+	Args    string
+
+	// Errors holds the errors in a response.
+	Errors []AuditError
+}
+
+// AuditError holds the details of an error returned by the API.
+type AuditError struct {
+	Message string
+	Code    string
+}
@@ -33,6 +33,11 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Audit holds the detail of the audited activity this record
+	// describes, if any. Senders may use it to include the full
+	// detail of the activity.
+	Audit *AuditRecord
 }
 
 // Validate ensures that the record is correct.
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -144,17 +145,7 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 					Value: rfc5424.StructuredDataParamValue(rec.Origin.ModelUUID),
 				}},
 			},
-			&sdelements.Private{
-				Name: "log",
-				PEN:  sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
-				Data: []rfc5424.StructuredDataParam{{
-					Name:  "module",
-					Value: rfc5424.StructuredDataParamValue(rec.Location.Module),
-				}, {
-					Name:  "source",
-					Value: rfc5424.StructuredDataParamValue(fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)),
-				}},
-			},
+			recordElement(rec),
 		},
 		Msg: rec.Message,
 	}
@@ -177,3 +168,62 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// recordElement returns the structured data element describing the
+// record itself: the audited activity for audit records, or the
+// source location for everything else.
+func recordElement(rec logfwd.Record) rfc5424.StructuredDataElement {
+	pen := sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber)
+	if rec.Audit != nil {
+		return &sdelements.Private{
+			Name: "audit",
+			PEN:  pen,
+			Data: auditParams(*rec.Audit),
+		}
+	}
+	return &sdelements.Private{
+		Name: "log",
+		PEN:  pen,
+		Data: []rfc5424.StructuredDataParam{{
+			Name:  "module",
+			Value: rfc5424.StructuredDataParamValue(rec.Location.Module),
+		}, {
+			Name:  "source",
+			Value: rfc5424.StructuredDataParamValue(fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)),
+		}},
+	}
+}
+
+func auditParams(rec logfwd.AuditRecord) []rfc5424.StructuredDataParam {
+	var params []rfc5424.StructuredDataParam
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		params = append(params, rfc5424.StructuredDataParam{
+			Name:  rfc5424.StructuredDataName(name),
+			Value: rfc5424.StructuredDataParamValue(value),
+		})
+	}
+	add("conversation-id", rec.ConversationID)
+	add("connection-id", rec.ConnectionID)
+	switch rec.Kind {
+	case logfwd.AuditConversation:
+		add("who", rec.Who)
+		add("what", rec.What)
+		add("model-name", rec.ModelName)
+	case logfwd.AuditRequest:
+		add("request-id", strconv.FormatUint(rec.RequestID, 10))
+		add("facade", rec.Facade)
+		add("method", rec.Method)
+		add("version", strconv.Itoa(rec.Version))
+		add("args", rec.Args)
+	case logfwd.AuditErrors:
+		add("request-id", strconv.FormatUint(rec.RequestID, 10))
+		for _, e := range rec.Errors {
+			add("error", e.Message)
+			add("error-code", e.Code)
+		}
+	}
+	return params
+}
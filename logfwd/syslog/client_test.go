@@ -148,6 +148,81 @@ func (s *ClientSuite) TestSendLogFull(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestSendAudit(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	origin := logfwd.OriginForMachineAgent(tag, cID, mID, ver)
+	origin.Type = logfwd.OriginTypeUser
+	origin.Name = "mary"
+	records := []logfwd.Record{{
+		Origin:    origin,
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.INFO,
+		Message:   "mary called Application.Deploy v6",
+		Audit: &logfwd.AuditRecord{
+			Kind:           logfwd.AuditRequest,
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      25,
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        6,
+			Args:           `{"applications":[]}`,
+		},
+	}, {
+		Origin:    origin,
+		Timestamp: time.Unix(12346, 0),
+		Level:     loggo.ERROR,
+		Message:   "request 25 by mary failed: oops (unauthorized access)",
+		Audit: &logfwd.AuditRecord{
+			Kind:           logfwd.AuditErrors,
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      25,
+			Errors: []logfwd.AuditError{{
+				Message: "oops",
+				Code:    "unauthorized access",
+			}},
+		},
+	}}
+	client := syslog.Client{Sender: s.sender}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send", "Send")
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityInformational)
+	c.Check(msg.StructuredData[2], jc.DeepEquals, &sdelements.Private{
+		Name: "audit",
+		PEN:  28978,
+		Data: []rfc5424.StructuredDataParam{
+			{Name: "conversation-id", Value: "0123456789abcdef"},
+			{Name: "connection-id", Value: "AC1"},
+			{Name: "request-id", Value: "25"},
+			{Name: "facade", Value: "Application"},
+			{Name: "method", Value: "Deploy"},
+			{Name: "version", Value: "6"},
+			{Name: "args", Value: `{"applications":[]}`},
+		},
+	})
+	msg = s.stub.Calls()[1].Args[0].(rfc5424.Message)
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityError)
+	c.Check(msg.StructuredData[2], jc.DeepEquals, &sdelements.Private{
+		Name: "audit",
+		PEN:  28978,
+		Data: []rfc5424.StructuredDataParam{
+			{Name: "conversation-id", Value: "0123456789abcdef"},
+			{Name: "connection-id", Value: "AC1"},
+			{Name: "request-id", Value: "25"},
+			{Name: "error", Value: "oops"},
+			{Name: "error-code", Value: "unauthorized access"},
+		},
+	})
+}
+
 func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	tag := names.NewMachineTag("99")
 	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
@@ -0,0 +1,418 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.webhook")
+
+// contentType is the media type of the request bodies: one JSON
+// object per line.
+const contentType = "application/x-ndjson"
+
+// These are the defaults used by Open.
+const (
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	DefaultMaxBuffered   = 10000
+	DefaultRetryDelay    = time.Second
+	DefaultMaxRetryDelay = time.Minute
+	DefaultTimeout       = 30 * time.Second
+)
+
+// Doer sends HTTP requests. It is implemented by *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// ClientConfig holds the information needed to create a Client.
+type ClientConfig struct {
+	// URL is where batches of records are POSTed.
+	URL string
+
+	// HTTPClient sends the requests.
+	HTTPClient Doer
+
+	// Clock is used to wait between batches and retries.
+	Clock clock.Clock
+
+	// BatchSize is the maximum number of records sent in one request.
+	BatchSize int
+
+	// FlushInterval is how long the client waits after records
+	// arrive for more to join them in the same batch.
+	FlushInterval time.Duration
+
+	// MaxBuffered is the maximum number of records held waiting to
+	// be sent. When it is reached, the oldest records are dropped.
+	MaxBuffered int
+
+	// RetryDelay is how long the client waits before trying a failed
+	// request again. The delay doubles after every failure, up to
+	// MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to create a
+// Client.
+func (config ClientConfig) Validate() error {
+	if config.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.MaxBuffered < config.BatchSize {
+		return errors.NotValidf("MaxBuffered smaller than BatchSize")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay smaller than RetryDelay")
+	}
+	return nil
+}
+
+// Client forwards log records to an HTTP endpoint. Records passed to
+// Send are buffered and POSTed in batches from a background goroutine,
+// which retries failed requests until they succeed or the client is
+// closed. Send never waits for the network, so callers which need to
+// know when records have been delivered should use NotifySent.
+type Client struct {
+	tomb   tomb.Tomb
+	config ClientConfig
+	ready  chan struct{}
+
+	mu      sync.Mutex
+	pending []logfwd.Record
+	dropped int
+	sent    func([]logfwd.Record)
+}
+
+// Open returns a client which forwards log records to the endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	client, err := NewClient(ClientConfig{
+		URL: cfg.URL,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: DefaultTimeout,
+		},
+		Clock:         clock.WallClock,
+		BatchSize:     DefaultBatchSize,
+		FlushInterval: DefaultFlushInterval,
+		MaxBuffered:   DefaultMaxBuffered,
+		RetryDelay:    DefaultRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+	})
+	return client, errors.Trace(err)
+}
+
+// NewClient returns a client configured as specified.
+func NewClient(config ClientConfig) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &Client{
+		config: config,
+		ready:  make(chan struct{}, 1),
+	}
+	client.tomb.Go(client.loop)
+	return client, nil
+}
+
+// Send queues the records to be sent to the remote endpoint.
+func (client *Client) Send(records []logfwd.Record) error {
+	select {
+	case <-client.tomb.Dying():
+		return errors.New("webhook client closed")
+	default:
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.pending = append(client.pending, records...)
+	if excess := len(client.pending) - client.config.MaxBuffered; excess > 0 {
+		client.pending = client.pending[excess:]
+		client.dropped += excess
+	}
+	select {
+	case client.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// NotifySent arranges for f to be called with each batch of records
+// once the endpoint has accepted it with a 2xx response. It is called
+// from the client's goroutine, in the order the records were sent.
+func (client *Client) NotifySent(f func([]logfwd.Record)) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.sent = f
+}
+
+// Close stops the client, making one final attempt to send any
+// buffered records.
+func (client *Client) Close() error {
+	client.tomb.Kill(nil)
+	return errors.Trace(client.tomb.Wait())
+}
+
+func (client *Client) loop() error {
+	for {
+		select {
+		case <-client.tomb.Dying():
+			client.flush(nil)
+			return nil
+		case <-client.ready:
+		}
+
+		// Give more records a chance to arrive, so they can be
+		// sent together.
+		if client.config.FlushInterval > 0 && client.buffered() < client.config.BatchSize {
+			select {
+			case <-client.tomb.Dying():
+			case <-client.config.Clock.After(client.config.FlushInterval):
+			}
+		}
+
+		for {
+			batch := client.take()
+			if len(batch) == 0 {
+				break
+			}
+			if !client.sendWithRetry(batch) {
+				client.flush(batch)
+				return nil
+			}
+		}
+	}
+}
+
+func (client *Client) buffered() int {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return len(client.pending)
+}
+
+// take removes the next batch of records from the buffer.
+func (client *Client) take() []logfwd.Record {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.dropped > 0 {
+		logger.Warningf("dropped %d log records waiting to be sent to %s", client.dropped, client.config.URL)
+		client.dropped = 0
+	}
+	n := len(client.pending)
+	if n > client.config.BatchSize {
+		n = client.config.BatchSize
+	}
+	batch := client.pending[:n:n]
+	client.pending = client.pending[n:]
+	return batch
+}
+
+// sendWithRetry sends the batch, retrying with increasing delays until
+// it succeeds. It returns false if the client is closed first.
+func (client *Client) sendWithRetry(batch []logfwd.Record) bool {
+	delay := client.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := client.send(batch)
+		if err == nil {
+			client.notifySent(batch)
+			return true
+		}
+		logger.Warningf("sending %d log records to %s (attempt %d): %v", len(batch), client.config.URL, attempt, err)
+		select {
+		case <-client.tomb.Dying():
+			return false
+		case <-client.config.Clock.After(delay):
+		}
+		delay *= 2
+		if delay > client.config.MaxRetryDelay {
+			delay = client.config.MaxRetryDelay
+		}
+	}
+}
+
+// flush makes a single attempt to send the in-flight batch, if any,
+// followed by the buffered records.
+func (client *Client) flush(batch []logfwd.Record) {
+	if len(batch) == 0 {
+		batch = client.take()
+	}
+	for len(batch) > 0 {
+		if err := client.send(batch); err != nil {
+			logger.Errorf("discarding %d log records: %v", len(batch)+client.buffered(), err)
+			return
+		}
+		client.notifySent(batch)
+		batch = client.take()
+	}
+}
+
+// notifySent reports the delivery of the batch
+// to the function passed to NotifySent, if any.
+func (client *Client) notifySent(batch []logfwd.Record) {
+	client.mu.Lock()
+	sent := client.sent
+	client.mu.Unlock()
+	if sent != nil {
+		sent(batch)
+	}
+}
+
+func (client *Client) send(batch []logfwd.Record) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, rec := range batch {
+		if err := encoder.Encode(newRecordJSON(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest("POST", client.config.URL, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return nil
+}
+
+// recordJSON is the serialisation of a log record sent to the
+// endpoint.
+type recordJSON struct {
+	ID        int64      `json:"id"`
+	Timestamp time.Time  `json:"timestamp"`
+	Level     string     `json:"level"`
+	Origin    originJSON `json:"origin"`
+	Module    string     `json:"module,omitempty"`
+	Location  string     `json:"location,omitempty"`
+	Message   string     `json:"message"`
+	Audit     *auditJSON `json:"audit,omitempty"`
+}
+
+// auditJSON is the serialisation of the detail of
+// an audited activity.
+type auditJSON struct {
+	Kind           string           `json:"kind"`
+	ConversationID string           `json:"conversation-id,omitempty"`
+	ConnectionID   string           `json:"connection-id,omitempty"`
+	Who            string           `json:"who,omitempty"`
+	What           string           `json:"what,omitempty"`
+	ModelName      string           `json:"model-name,omitempty"`
+	ModelUUID      string           `json:"model-uuid,omitempty"`
+	RequestID      uint64           `json:"request-id,omitempty"`
+	Facade         string           `json:"facade,omitempty"`
+	Method         string           `json:"method,omitempty"`
+	Version        int              `json:"version,omitempty"`
+	Args           string           `json:"args,omitempty"`
+	Errors         []auditErrorJSON `json:"errors,omitempty"`
+}
+
+type auditErrorJSON struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+type originJSON struct {
+	ControllerUUID  string `json:"controller-uuid"`
+	ModelUUID       string `json:"model-uuid"`
+	Hostname        string `json:"hostname,omitempty"`
+	Type            string `json:"type"`
+	Name            string `json:"name,omitempty"`
+	Software        string `json:"software"`
+	SoftwareVersion string `json:"software-version"`
+}
+
+func newRecordJSON(rec logfwd.Record) recordJSON {
+	return recordJSON{
+		ID:        rec.ID,
+		Timestamp: rec.Timestamp.UTC(),
+		Level:     rec.Level.String(),
+		Origin: originJSON{
+			ControllerUUID:  rec.Origin.ControllerUUID,
+			ModelUUID:       rec.Origin.ModelUUID,
+			Hostname:        rec.Origin.Hostname,
+			Type:            rec.Origin.Type.String(),
+			Name:            rec.Origin.Name,
+			Software:        rec.Origin.Software.Name,
+			SoftwareVersion: rec.Origin.Software.Version.String(),
+		},
+		Module:   rec.Location.Module,
+		Location: rec.Location.String(),
+		Message:  rec.Message,
+		Audit:    newAuditJSON(rec.Audit),
+	}
+}
+
+func newAuditJSON(rec *logfwd.AuditRecord) *auditJSON {
+	if rec == nil {
+		return nil
+	}
+	result := &auditJSON{
+		Kind:           string(rec.Kind),
+		ConversationID: rec.ConversationID,
+		ConnectionID:   rec.ConnectionID,
+		Who:            rec.Who,
+		What:           rec.What,
+		ModelName:      rec.ModelName,
+		ModelUUID:      rec.ModelUUID,
+		RequestID:      rec.RequestID,
+		Facade:         rec.Facade,
+		Method:         rec.Method,
+		Version:        rec.Version,
+		Args:           rec.Args,
+	}
+	for _, e := range rec.Errors {
+		result.Errors = append(result.Errors, auditErrorJSON{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
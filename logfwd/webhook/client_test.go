@@ -0,0 +1,282 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/webhook"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer  *fakeDoer
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.doer = &fakeDoer{
+		requests: make(chan *http.Request, 10),
+		bodies:   make(chan string, 10),
+	}
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *ClientSuite) newClient(c *gc.C, batchSize, maxBuffered int) *webhook.Client {
+	client, err := webhook.NewClient(webhook.ClientConfig{
+		URL:           "https://logs.example.com/ingest",
+		HTTPClient:    s.doer,
+		Clock:         s.clock,
+		BatchSize:     batchSize,
+		MaxBuffered:   maxBuffered,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func (s *ClientSuite) nextBody(c *gc.C) string {
+	var body string
+	select {
+	case body = <-s.doer.bodies:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request")
+	}
+	return body
+}
+
+func record(id int64, message string) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.OriginForMachineAgent(
+			names.NewMachineTag("99"),
+			"9f484882-2f18-4fd2-967d-db9663db7bea",
+			"deadbeef-2f18-4fd2-967d-db9663db7bea",
+			version.MustParse("1.2.3"),
+		),
+		Timestamp: time.Date(2018, 10, 1, 9, 0, 0, 0, time.UTC),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: message,
+	}
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.newClient(c, 2, 10)
+	err := client.Send([]logfwd.Record{record(1, "one"), record(2, "two"), record(3, "three")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.nextBody(c), gc.Equals, `
+{"id":1,"timestamp":"2018-10-01T09:00:00Z","level":"ERROR","origin":{"controller-uuid":"9f484882-2f18-4fd2-967d-db9663db7bea","model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea","hostname":"machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea","type":"machine","name":"99","software":"jujud-machine-agent","software-version":"1.2.3"},"module":"juju.x.y","location":"x/y/spam.go:42","message":"one"}
+{"id":2,"timestamp":"2018-10-01T09:00:00Z","level":"ERROR","origin":{"controller-uuid":"9f484882-2f18-4fd2-967d-db9663db7bea","model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea","hostname":"machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea","type":"machine","name":"99","software":"jujud-machine-agent","software-version":"1.2.3"},"module":"juju.x.y","location":"x/y/spam.go:42","message":"two"}
+`[1:])
+	c.Check(s.nextBody(c), jc.Contains, `"message":"three"`)
+
+	req := <-s.doer.requests
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.String(), gc.Equals, "https://logs.example.com/ingest")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+}
+
+func (s *ClientSuite) TestSendAudit(c *gc.C) {
+	client := s.newClient(c, 10, 10)
+	rec := record(1, "mary called Application.Deploy v6")
+	rec.Audit = &logfwd.AuditRecord{
+		Kind:           logfwd.AuditRequest,
+		ConversationID: "c1",
+		RequestID:      1,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	}
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.nextBody(c), jc.Contains,
+		`"audit":{"kind":"request","conversation-id":"c1","request-id":1,"facade":"Application","method":"Deploy","version":6}`)
+}
+
+func (s *ClientSuite) TestRetry(c *gc.C) {
+	s.doer.stub.SetErrors(errors.New("connection refused"))
+	client := s.newClient(c, 10, 10)
+	err := client.Send([]logfwd.Record{record(1, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	first := s.nextBody(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Check(s.nextBody(c), gc.Equals, first)
+}
+
+func (s *ClientSuite) TestErrorStatusRetried(c *gc.C) {
+	s.doer.status = http.StatusServiceUnavailable
+	client := s.newClient(c, 10, 10)
+	err := client.Send([]logfwd.Record{record(1, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.nextBody(c)
+	s.doer.setStatus(http.StatusOK)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.nextBody(c)
+}
+
+func (s *ClientSuite) TestNotifySentAfterAccepted(c *gc.C) {
+	s.doer.status = http.StatusServiceUnavailable
+	client := s.newClient(c, 10, 10)
+	sent := make(chan []logfwd.Record, 10)
+	client.NotifySent(func(records []logfwd.Record) {
+		sent <- records
+	})
+	err := client.Send([]logfwd.Record{record(1, "one"), record(2, "two")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Records rejected by the endpoint are not reported as sent.
+	s.nextBody(c)
+	select {
+	case <-sent:
+		c.Fatalf("records reported sent before they were accepted")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.doer.setStatus(http.StatusOK)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.nextBody(c)
+	select {
+	case records := <-sent:
+		c.Assert(records, gc.HasLen, 2)
+		c.Check(records[1].ID, gc.Equals, int64(2))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for records to be reported sent")
+	}
+}
+
+func (s *ClientSuite) TestBufferBounded(c *gc.C) {
+	s.doer.stub.SetErrors(errors.New("connection refused"))
+	client := s.newClient(c, 2, 2)
+	err := client.Send([]logfwd.Record{record(1, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+	s.nextBody(c)
+
+	// While the first record is waiting to be retried, more arrive
+	// than can be buffered, so the oldest of them is dropped.
+	err = client.Send([]logfwd.Record{record(2, "two"), record(3, "three"), record(4, "four")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Check(s.nextBody(c), jc.Contains, `"message":"one"`)
+
+	body := s.nextBody(c)
+	c.Check(strings.Count(body, "\n"), gc.Equals, 2)
+	c.Check(body, jc.Contains, `"message":"three"`)
+	c.Check(body, jc.Contains, `"message":"four"`)
+}
+
+func (s *ClientSuite) TestCloseFlushes(c *gc.C) {
+	client, err := webhook.NewClient(webhook.ClientConfig{
+		URL:           "https://logs.example.com/ingest",
+		HTTPClient:    s.doer,
+		Clock:         s.clock,
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		MaxBuffered:   10,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send([]logfwd.Record{record(1, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.nextBody(c), jc.Contains, `"message":"one"`)
+
+	err = client.Send([]logfwd.Record{record(2, "two")})
+	c.Assert(err, gc.ErrorMatches, "webhook client closed")
+}
+
+func (s *ClientSuite) TestCloseFlushesBatchBeingRetried(c *gc.C) {
+	s.doer.stub.SetErrors(errors.New("connection refused"))
+	client := s.newClient(c, 10, 10)
+	err := client.Send([]logfwd.Record{record(1, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.nextBody(c), jc.Contains, `"message":"one"`)
+
+	// The client is closed while the batch waits to be retried,
+	// so the final attempt includes it.
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.nextBody(c), jc.Contains, `"message":"one"`)
+}
+
+func (s *ClientSuite) TestOpenInvalid(c *gc.C) {
+	_, err := webhook.Open(webhook.RawConfig{
+		Enabled: true,
+		URL:     "http://logs.example.com/ingest",
+	})
+	c.Assert(err, gc.ErrorMatches, `non-https URL .* not valid`)
+}
+
+type fakeDoer struct {
+	stub     testing.Stub
+	requests chan *http.Request
+	bodies   chan string
+
+	mu     sync.Mutex
+	status int
+}
+
+func (d *fakeDoer) setStatus(status int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status = status
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.stub.AddCall("Do", req)
+	err = d.stub.NextErr()
+	d.mu.Lock()
+	status := d.status
+	d.mu.Unlock()
+	d.requests <- req
+	d.bodies <- string(body)
+	if err != nil {
+		return nil, err
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(&bytes.Buffer{}),
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// RawConfig holds the raw configuration data for forwarding log
+// records to an HTTP endpoint.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the https URL to which batches of log records are POSTed.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate. If it is not set, the
+	// system's root CAs are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to
+	// present to the server. It is optional, but must be set if
+	// ClientKey is.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) for
+	// ClientCert.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.NotValidf("non-https URL %q", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(caCert)
+		tlsConfig.RootCAs = rootCAs
+	}
+	return tlsConfig, nil
+}
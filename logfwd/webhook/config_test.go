@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/webhook"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := webhook.RawConfig{
+		Enabled:    true,
+		URL:        "https://logs.example.com:8443/ingest",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutTLS(c *gc.C) {
+	cfg := webhook.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg webhook.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := webhook.RawConfig{Enabled: true}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNotHTTPS(c *gc.C) {
+	cfg := webhook.RawConfig{
+		Enabled: true,
+		URL:     "http://logs.example.com/ingest",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `non-https URL "http://logs.example.com/ingest" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL:    "https://logs.example.com/ingest",
+		CACert: "abc",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingKey(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL:        "https://logs.example.com/ingest",
+		ClientCert: coretesting.ServerCert,
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: (crypto/)?tls: .*`)
+}
@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -34,7 +36,10 @@ type PrecheckBackend interface {
 	AllRelations() ([]PrecheckRelation, error)
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+	Cloud(name string) (cloud.Cloud, error)
+	UserExists(tag names.UserTag) (bool, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	UnmigratableEntities() ([]string, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -72,6 +77,7 @@ type PrecheckApplication interface {
 	Name() string
 	Life() state.Life
 	CharmURL() (*charm.URL, bool)
+	CharmUploaded() (bool, error)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
 }
@@ -119,7 +125,25 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
+	ctx := precheckContext{backend: backend, presence: modelPresence}
+	return errors.Trace(ctx.sourcePrecheck(controllerPresence))
+}
+
+// SourcePrecheckReport runs all of the source controller prechecks
+// without stopping at the first failure, and returns every problem
+// found. Errors are only returned when the checks can't be run.
+func SourcePrecheckReport(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) (coremigration.PrecheckReport, error) {
+	var report coremigration.PrecheckReport
+	ctx := precheckContext{backend: backend, presence: modelPresence, report: &report}
+	err := ctx.sourcePrecheck(controllerPresence)
+	return report, errors.Trace(err)
+}
+
+func (ctx *precheckContext) sourcePrecheck(controllerPresence ModelPresence) error {
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -137,18 +161,34 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
-	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
+	if entities, err := ctx.backend.UnmigratableEntities(); err != nil {
+		return errors.Annotate(err, "checking for unmigratable entities")
+	} else if len(entities) > 0 {
+		err := errors.Errorf("model has %s, which migrations don't support", strings.Join(entities, ", "))
+		if err := ctx.fail(err); err != nil {
+			return err
+		}
+	}
+
+	if cleanupNeeded, err := ctx.backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.fail(errors.New("cleanup needed")); err != nil {
+			return err
+		}
 	}
 
 	// Check the source controller.
-	controllerBackend, err := backend.ControllerBackend()
+	controllerBackend, err := ctx.backend.ControllerBackend()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerCtx := precheckContext{
+		backend:  controllerBackend,
+		presence: controllerPresence,
+		report:   ctx.report,
+		label:    "source controller",
+	}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
@@ -158,6 +198,42 @@ func SourcePrecheck(
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+
+	// report is nil when the prechecks stop at the first failure.
+	// Otherwise failures and warnings are added to it, along with
+	// the results of some extra checks for problems that a
+	// migration would only hit after it has started.
+	report *coremigration.PrecheckReport
+
+	// label is prefixed to the failures and warnings added to the
+	// report.
+	label string
+}
+
+// fail handles a failed precheck. When a report is being made the
+// failure is added to it and nil is returned so that the remaining
+// checks still run. Otherwise the failure is returned.
+func (ctx *precheckContext) fail(err error) error {
+	if ctx.report == nil {
+		return err
+	}
+	if ctx.label != "" {
+		err = errors.Annotate(err, ctx.label)
+	}
+	ctx.report.Blockers = append(ctx.report.Blockers, err.Error())
+	return nil
+}
+
+// warn adds a warning to the report, if one is being made.
+func (ctx *precheckContext) warn(format string, args ...interface{}) {
+	if ctx.report == nil {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if ctx.label != "" {
+		msg = ctx.label + ": " + msg
+	}
+	ctx.report.Warnings = append(ctx.report.Warnings, msg)
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -166,18 +242,25 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.fail(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		err := ctx.fail(errors.New("model is being imported as part of another migration"))
+		if err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredential(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
-		if err != nil {
+		if errors.IsNotFound(err) && ctx.report != nil {
+			return ctx.fail(errors.Errorf("model credential %q not found", credTag.Id()))
+		} else if err != nil {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			return ctx.fail(errors.New("model has revoked credentials"))
 		}
 	}
 	return nil
@@ -187,6 +270,26 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	ctx := precheckContext{backend: backend, presence: presence}
+	return errors.Trace(ctx.targetPrecheck(pool, modelInfo))
+}
+
+// TargetPrecheckReport runs all of the target controller prechecks
+// without stopping at the first failure, and returns every problem
+// found. Errors are only returned when the checks can't be run.
+func TargetPrecheckReport(
+	backend PrecheckBackend,
+	pool Pool,
+	modelInfo coremigration.ModelInfo,
+	presence ModelPresence,
+) (coremigration.PrecheckReport, error) {
+	var report coremigration.PrecheckReport
+	ctx := precheckContext{backend: backend, presence: presence, report: &report}
+	err := ctx.targetPrecheck(pool, modelInfo)
+	return report, errors.Trace(err)
+}
+
+func (ctx *precheckContext) targetPrecheck(pool Pool, modelInfo coremigration.ModelInfo) error {
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -198,34 +301,41 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	// window can upset the migrationmaster worker.
 	//
 	// See also https://lpad.tv/1611391
-	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
+	if migrating, err := ctx.backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := ctx.fail(errors.New("model is being migrated out of target controller")); err != nil {
+			return err
+		}
 	}
 
-	controllerVersion, err := backend.AgentVersion()
+	controllerVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		err := ctx.fail(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
+		if err != nil {
+			return err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		err := ctx.fail(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion))
+		if err != nil {
+			return err
+		}
 	}
 
-	controllerCtx := precheckContext{backend, presence}
-	if err := controllerCtx.checkController(); err != nil {
+	if err := ctx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
 
 	// Check for conflicts with existing models
-	modelUUIDs, err := backend.AllModelUUIDs()
+	modelUUIDs, err := ctx.backend.AllModelUUIDs()
 	if err != nil {
 		return errors.Annotate(err, "retrieving models")
 	}
@@ -240,16 +350,61 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			err := ctx.fail(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
+			if err != nil {
+				return err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := ctx.fail(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return err
+			}
 		}
 	}
 
+	if ctx.report != nil {
+		return errors.Trace(ctx.checkModelRequirements(modelInfo))
+	}
 	return nil
 }
 
+// checkModelRequirements reports on the cloud and users the model
+// needs from the target controller. These aren't checked before a
+// migration starts, so are only included in reports.
+func (ctx *precheckContext) checkModelRequirements(modelInfo coremigration.ModelInfo) error {
+	if modelInfo.CloudName != "" {
+		modelCloud, err := ctx.backend.Cloud(modelInfo.CloudName)
+		if errors.IsNotFound(err) {
+			ctx.fail(errors.Errorf("cloud %q not found", modelInfo.CloudName))
+		} else if err != nil {
+			return errors.Annotatef(err, "retrieving cloud %q", modelInfo.CloudName)
+		} else if modelInfo.CloudRegion != "" && !hasRegion(modelCloud, modelInfo.CloudRegion) {
+			ctx.fail(errors.Errorf("cloud %q has no region %q", modelInfo.CloudName, modelInfo.CloudRegion))
+		}
+	}
+
+	for _, user := range modelInfo.Users {
+		if !user.IsLocal() {
+			continue
+		}
+		if exists, err := ctx.backend.UserExists(user); err != nil {
+			return errors.Annotatef(err, "retrieving user %q", user.Id())
+		} else if !exists {
+			ctx.warn("user %q has access to the model but doesn't exist on the target controller", user.Id())
+		}
+	}
+	return nil
+}
+
+func hasRegion(c cloud.Cloud, name string) bool {
+	for _, region := range c.Regions {
+		if region.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -273,13 +428,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.fail(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.fail(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -298,29 +457,38 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := ctx.fail(errors.Errorf("machine %s is %s", machine.Id(), machine.Life())); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			if err := ctx.fail(newStatusError("machine %s not running", machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			err := ctx.fail(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status))
+			if err != nil {
+				return err
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			if err := ctx.fail(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)); err != nil {
+				return err
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -340,7 +508,26 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.fail(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
+		}
+		if ctx.report != nil {
+			// The charm is only fetched from the controller once
+			// the migration has started.
+			if uploaded, err := app.CharmUploaded(); err != nil {
+				return nil, errors.Annotatef(err, "retrieving charm for %s", app.Name())
+			} else if !uploaded {
+				curl, _ := app.CharmURL()
+				ctx.fail(errors.Errorf("charm %s for application %s is not available", curl, app.Name()))
+			}
+			// Pending resources are left behind by failed deploys
+			// and aren't exported.
+			if pending, err := ctx.backend.ListPendingResources(app.Name()); err != nil {
+				return nil, errors.Annotatef(err, "retrieving pending resources for %s", app.Name())
+			} else if len(pending) > 0 {
+				ctx.warn("application %s has %d pending resources which won't be migrated", app.Name(), len(pending))
+			}
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -357,27 +544,33 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.fail(errors.Errorf("application %s is below its minimum units threshold", app.Name())); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := ctx.fail(errors.Errorf("unit %s is %s", unit.Name(), unit.Life())); err != nil {
+				return err
+			}
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
 			return errors.Trace(err)
 		}
 
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 			return errors.Trace(err)
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := ctx.fail(errors.Errorf("unit %s is upgrading", unit.Name())); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -394,20 +587,20 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.fail(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return ctx.fail(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
 	return nil
 }
@@ -438,6 +631,11 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 			return errors.Annotatef(err, "checking whether relation %s is cross-model", rel)
 		}
 		if crossModel {
+			// Models with remote applications are rejected when
+			// they are imported into the target controller.
+			if ctx.report != nil {
+				ctx.fail(errors.Errorf("relation %s is cross-model, which migrations don't support", rel))
+			}
 			continue
 		}
 		for _, ep := range rel.Endpoints() {
@@ -458,7 +656,9 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					if err := ctx.fail(errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)); err != nil {
+						return err
+					}
 				}
 			}
 		}
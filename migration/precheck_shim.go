@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -111,6 +112,19 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, nil
 }
 
+// UserExists implements PrecheckBackend.
+func (s *precheckShim) UserExists(tag names.UserTag) (bool, error) {
+	_, err := s.State.User(tag)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if _, ok := errors.Cause(err).(state.DeletedUserError); ok {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
@@ -138,6 +152,15 @@ type precheckAppShim struct {
 	*state.Application
 }
 
+// CharmUploaded implements PrecheckApplication.
+func (s *precheckAppShim) CharmUploaded() (bool, error) {
+	ch, _, err := s.Application.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return ch.IsUploaded(), nil
+}
+
 // AllUnits implements PrecheckApplication.
 func (s *precheckAppShim) AllUnits() ([]PrecheckUnit, error) {
 	units, err := s.Application.AllUnits()
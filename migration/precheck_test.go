@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -93,6 +94,20 @@ func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is being imported as part of another migration")
 }
 
+func (*SourcePrecheckSuite) TestUnmigratableEntities(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unmigratable = []string{"volume snapshots (2)"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has volume snapshots \(2\), which migrations don't support`)
+}
+
+func (*SourcePrecheckSuite) TestUnmigratableEntitiesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unmigratableErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking for unmigratable entities: boom")
+}
+
 func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.cleanupErr = errors.New("boom")
@@ -401,6 +416,87 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, coremigration.PrecheckReport{})
+}
+
+func (*SourcePrecheckSuite) TestReportCollectsAllBlockers(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.model.life = state.Dying
+	backend.cleanupNeeded = true
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:     "spanner",
+			charmURL: "cs:spanner-3",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "spanner/0", charmURL: "cs:spanner-3", agentStatus: status.Failed},
+				&fakeUnit{name: "spanner/1", charmURL: "cs:spanner-2"},
+			},
+		},
+	}
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is dying",
+		"unit spanner/0 not idle or executing (failed)",
+		"unit spanner/1 is upgrading",
+		"cleanup needed",
+		"source controller: upgrade in progress",
+	})
+	c.Assert(report.Warnings, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestReportExtraChecks(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[0].(*fakeApp).charmPending = true
+	backend.pendingResources = []resource.Resource{
+		resourcetesting.NewResource(c, nil, "blob", "foo", "body").Resource,
+	}
+	backend.relations = []migration.PrecheckRelation{&fakeRelation{
+		key:        "foo:db remote-mysql:db",
+		crossModel: true,
+		endpoints: []state.Endpoint{
+			{ApplicationName: "foo"},
+			{ApplicationName: "remote-mysql"},
+		},
+	}}
+	backend.controllerBackend = newHappyBackend()
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, coremigration.PrecheckReport{
+		Blockers: []string{
+			"charm cs:foo-1 for application foo is not available",
+			"relation foo:db remote-mysql:db is cross-model, which migrations don't support",
+		},
+		Warnings: []string{
+			"application foo has 1 pending resources which won't be migrated",
+			"application bar has 1 pending resources which won't be migrated",
+		},
+	})
+}
+
+func (*SourcePrecheckSuite) TestReportMissingCredential(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.credential = "cloud/owner/cred"
+	backend.credentialsErr = errors.NotFoundf("credential")
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{`model credential "cloud/owner/cred" not found`})
+}
+
+func (*SourcePrecheckSuite) TestReportError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -628,6 +724,63 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestReportCollectsAllBlockers(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID},
+			&fakeModel{uuid: "uuid", name: modelName, owner: modelOwner},
+		},
+	}
+	backend := newBackendWithRebootingMachine()
+	backend.models = pool.uuids()
+	backend.migrationActive = true
+	backend.isUpgrading = true
+	s.modelInfo.AgentVersion.Patch++
+	report, err := migration.TargetPrecheckReport(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 is scheduled to reboot",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+	c.Assert(report.Warnings, gc.HasLen, 0)
+}
+
+func (s *TargetPrecheckSuite) TestReportModelRequirements(c *gc.C) {
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"dummy": {Name: "dummy", Regions: []cloud.Region{{Name: "east"}}},
+	}
+	backend.users = []string{"owner"}
+	s.modelInfo.CloudName = "dummy"
+	s.modelInfo.CloudRegion = "west"
+	s.modelInfo.Users = []names.UserTag{
+		modelOwner,
+		names.NewUserTag("bob"),
+		names.NewUserTag("fred@external"),
+	}
+	report, err := migration.TargetPrecheckReport(backend, nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, coremigration.PrecheckReport{
+		Blockers: []string{`cloud "dummy" has no region "west"`},
+		Warnings: []string{`user "bob" has access to the model but doesn't exist on the target controller`},
+	})
+
+	// The model requirements are only checked for reports.
+	err = migration.TargetPrecheck(backend, nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestReportMissingCloud(c *gc.C) {
+	s.modelInfo.CloudName = "dummy"
+	report, err := migration.TargetPrecheckReport(newHappyBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{`cloud "dummy" not found`})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -763,6 +916,12 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	unmigratable    []string
+	unmigratableErr error
+
+	clouds map[string]cloud.Cloud
+	users  []string
+
 	controllerBackend *fakeBackend
 }
 
@@ -794,6 +953,22 @@ func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (state.Crede
 	return b.credentials, b.credentialsErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := b.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (b *fakeBackend) UserExists(tag names.UserTag) (bool, error) {
+	for _, user := range b.users {
+		if user == tag.Id() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
 	return b.machines, b.allMachinesErr
 }
@@ -810,6 +985,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) UnmigratableEntities() ([]string, error) {
+	return b.unmigratable, b.unmigratableErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -934,11 +1113,12 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name         string
+	life         state.Life
+	charmURL     string
+	charmPending bool
+	units        []migration.PrecheckUnit
+	minunits     int
 }
 
 func (a *fakeApp) Name() string {
@@ -957,6 +1137,10 @@ func (a *fakeApp) CharmURL() (*charm.URL, bool) {
 	return charm.MustParseURL(url), false
 }
 
+func (a *fakeApp) CharmUploaded() (bool, error) {
+	return !a.charmPending, nil
+}
+
 func (a *fakeApp) AllUnits() ([]migration.PrecheckUnit, error) {
 	return a.units, nil
 }
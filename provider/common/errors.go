@@ -8,6 +8,8 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
 )
 
 // ZoneIndependentError wraps the given error such that it
@@ -38,6 +40,11 @@ type credentialNotValid struct {
 	error
 }
 
+// ErrorKind is part of the environs.ClassifiedError interface.
+func (*credentialNotValid) ErrorKind() environs.ErrorKind {
+	return environs.ErrorKindAuth
+}
+
 // CredentialNotValid returns an error which wraps err and satisfies
 // IsCredentialNotValid().
 func CredentialNotValid(err error) error {
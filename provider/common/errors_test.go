@@ -62,3 +62,9 @@ func (s *ErrorsSuite) TestInvalidCredentialf(c *gc.C) {
 	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
 	c.Assert(err, gc.ErrorMatches, "bar: foo")
 }
+
+func (s *ErrorsSuite) TestInvalidCredentialErrorKind(c *gc.C) {
+	err := common.CredentialNotValid(errors.New("foo"))
+	c.Assert(environs.ClassifyError(err), gc.Equals, environs.ErrorKindAuth)
+	c.Assert(environs.ClassifyError(errors.Annotate(err, "bar")), gc.Equals, environs.ErrorKindAuth)
+}
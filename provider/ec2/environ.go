@@ -601,6 +601,9 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
 	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
 	if err != nil {
+		if classified, ok := classifyRunInstancesError(err); ok {
+			return nil, errors.Annotate(classified, "cannot run instances")
+		}
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
 		}
@@ -1979,6 +1982,22 @@ func isSubnetConstrainedError(err error) bool {
 	return false
 }
 
+// classifyRunInstancesError wraps errors from RunInstances that
+// indicate exceeded quotas, insufficient capacity or throttling, so
+// that the provisioner can decide whether and how to retry. It
+// reports whether the error was classified.
+func classifyRunInstancesError(err error) (error, bool) {
+	switch ec2ErrCode(err) {
+	case "InsufficientInstanceCapacity":
+		return environs.CapacityError(err), true
+	case "InstanceLimitExceeded", "VcpuLimitExceeded":
+		return environs.QuotaExceededError(err), true
+	case "RequestLimitExceeded", "InternalError", "Unavailable":
+		return environs.TransientError(err), true
+	}
+	return err, false
+}
+
 // If the err is of type *ec2.Error, ec2ErrCode returns
 // its code, otherwise it returns the empty string.
 func ec2ErrCode(err error) string {
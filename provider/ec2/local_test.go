@@ -1127,6 +1127,32 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(errors.Details(err), jc.Contains, runInstancesError.Message)
 }
 
+func (t *localServerSuite) TestStartInstanceClassifiesErrors(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	for i, test := range []struct {
+		code string
+		kind environs.ErrorKind
+	}{
+		{"InsufficientInstanceCapacity", environs.ErrorKindCapacity},
+		{"InstanceLimitExceeded", environs.ErrorKindQuota},
+		{"RequestLimitExceeded", environs.ErrorKindTransient},
+		{"Blocked", environs.ErrorKindUnknown},
+	} {
+		c.Logf("test %d: %s", i, test.code)
+		t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+			return nil, &amzec2.Error{Code: test.code, Message: "blah blah"}
+		})
+		_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", environs.StartInstanceParams{
+			ControllerUUID:   t.ControllerUUID,
+			StatusCallback:   fakeCallback,
+			AvailabilityZone: "test-available",
+		})
+		c.Check(err, gc.ErrorMatches, ".*blah blah.*")
+		c.Check(environs.ClassifyError(err), gc.Equals, test.kind)
+	}
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets and
@@ -0,0 +1,177 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"bytes"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// Connector returns a new connection to a provider plugin.
+type Connector func() (io.ReadWriteCloser, error)
+
+// CommandConnector returns a Connector that starts the provider
+// plugin executable at the given path, and talks to it over its
+// stdin and stdout. Anything the plugin writes to stderr is logged.
+func CommandConnector(path string) Connector {
+	return func() (io.ReadWriteCloser, error) {
+		return startCommand(path)
+	}
+}
+
+func startCommand(path string) (io.ReadWriteCloser, error) {
+	cmd := exec.Command(path)
+	cmd.Stderr = &logWriter{
+		logger: loggo.GetLogger("juju.provider.plugin." + filepath.Base(path)),
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Annotatef(err, "starting provider plugin %q", path)
+	}
+	return &commandConn{
+		ReadCloser: stdout,
+		stdin:      stdin,
+		cmd:        cmd,
+	}, nil
+}
+
+// commandConn is a connection to a provider plugin process.
+type commandConn struct {
+	io.ReadCloser
+	stdin io.WriteCloser
+	cmd   *exec.Cmd
+}
+
+// Write is part of the io.Writer interface.
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Close closes the plugin's stdin, which the plugin must treat
+// as a request to exit, and waits for the plugin to do so.
+func (c *commandConn) Close() error {
+	if err := c.stdin.Close(); err != nil {
+		logger.Debugf("closing provider plugin stdin: %v", err)
+	}
+	return c.cmd.Wait()
+}
+
+// logWriter is an io.Writer that logs each line written to it.
+type logWriter struct {
+	logger loggo.Logger
+	buf    []byte
+}
+
+// Write is part of the io.Writer interface.
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logger.Infof("%s", w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// client makes calls to a provider plugin, connecting to it on
+// first use and again after the connection is lost.
+type client struct {
+	providerType string
+	connect      Connector
+
+	mu              sync.Mutex
+	rpcClient       *rpc.Client
+	providerVersion int
+}
+
+// call calls the named plugin method, connecting to the plugin
+// if necessary.
+func (c *client) call(method string, args, result interface{}) error {
+	rpcClient, err := c.client()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rpcClient.Call(method, args, result)
+	if _, ok := err.(rpc.ServerError); err != nil && !ok {
+		// The connection to the plugin has failed, most likely
+		// because it has exited; start it again on the next call.
+		c.reset(rpcClient)
+	}
+	return errors.Annotatef(err, "calling %s on provider plugin %q", method, c.providerType)
+}
+
+// version returns the provider version reported by the plugin.
+func (c *client) version() (int, error) {
+	if _, err := c.client(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.providerVersion, nil
+}
+
+func (c *client) client() (*rpc.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rpcClient != nil {
+		return c.rpcClient, nil
+	}
+	conn, err := c.connect()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rpcClient := rpc.NewClientWithCodec(jsonrpc.NewClientCodec(conn))
+	if err := c.handshake(rpcClient); err != nil {
+		rpcClient.Close()
+		return nil, errors.Annotatef(err, "connecting to provider plugin %q", c.providerType)
+	}
+	c.rpcClient = rpcClient
+	return rpcClient, nil
+}
+
+func (c *client) handshake(rpcClient *rpc.Client) error {
+	var result HandshakeResult
+	args := HandshakeArgs{ProtocolVersion: ProtocolVersion}
+	if err := rpcClient.Call("Provider.Handshake", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return fromError(result.Error)
+	}
+	if result.ProtocolVersion != ProtocolVersion {
+		return errors.NotSupportedf("protocol version %d", result.ProtocolVersion)
+	}
+	if result.ProviderType != c.providerType {
+		return errors.Errorf("plugin provides %q, not %q", result.ProviderType, c.providerType)
+	}
+	c.providerVersion = result.ProviderVersion
+	return nil
+}
+
+func (c *client) reset(rpcClient *rpc.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rpcClient == rpcClient {
+		c.rpcClient = nil
+		rpcClient.Close()
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	coretools "github.com/juju/juju/tools"
+)
+
+// toError converts an error returned by a provider to
+// its wire representation.
+func toError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var code string
+	switch cause := errors.Cause(err); {
+	case cause == environs.ErrNoInstances:
+		code = CodeNoInstances
+	case cause == environs.ErrPartialInstances:
+		code = CodePartialInstances
+	case cause == environs.ErrNotBootstrapped:
+		code = CodeNotBootstrapped
+	case common.IsCredentialNotValid(err):
+		code = CodeCredentialNotValid
+	case environs.IsAvailabilityZoneIndependent(err):
+		code = CodeZoneIndependent
+	case errors.IsNotFound(err):
+		code = CodeNotFound
+	case errors.IsNotSupported(err):
+		code = CodeNotSupported
+	case errors.IsNotImplemented(err):
+		code = CodeNotImplemented
+	case errors.IsNotValid(err):
+		code = CodeNotValid
+	}
+	return &Error{Message: err.Error(), Code: code}
+}
+
+// fromError converts an error returned by a plugin to an error
+// that satisfies the checks Juju makes of provider errors.
+func fromError(e *Error) error {
+	if e == nil {
+		return nil
+	}
+	switch e.Code {
+	case CodeNoInstances:
+		return environs.ErrNoInstances
+	case CodePartialInstances:
+		return environs.ErrPartialInstances
+	case CodeNotBootstrapped:
+		return environs.ErrNotBootstrapped
+	case CodeCredentialNotValid:
+		return common.CredentialNotValid(errors.New(e.Message))
+	case CodeZoneIndependent:
+		return common.ZoneIndependentError(errors.New(e.Message))
+	case CodeNotFound:
+		return errors.NewNotFound(nil, e.Message)
+	case CodeNotSupported:
+		return errors.NewNotSupported(nil, e.Message)
+	case CodeNotImplemented:
+		return errors.NewNotImplemented(nil, e.Message)
+	case CodeNotValid:
+		return errors.NewNotValid(nil, e.Message)
+	}
+	return errors.New(e.Message)
+}
+
+// fromCallError is like fromError, but also invalidates the
+// credential used by the call if the plugin reported that it
+// is not valid.
+func fromCallError(ctx context.ProviderCallContext, e *Error) error {
+	err := fromError(e)
+	if common.IsCredentialNotValid(err) {
+		if invalidateErr := ctx.InvalidateCredential(e.Message); invalidateErr != nil {
+			logger.Errorf("could not invalidate credential: %v", invalidateErr)
+		}
+	}
+	return err
+}
+
+func toCredential(cred cloud.Credential) Credential {
+	return Credential{
+		AuthType:   string(cred.AuthType()),
+		Attributes: cred.Attributes(),
+		Label:      cred.Label,
+	}
+}
+
+func fromCredential(cred Credential) cloud.Credential {
+	result := cloud.NewCredential(cloud.AuthType(cred.AuthType), cred.Attributes)
+	result.Label = cred.Label
+	return result
+}
+
+func toCloudSpec(spec environs.CloudSpec) CloudSpec {
+	result := CloudSpec{
+		Type:             spec.Type,
+		Name:             spec.Name,
+		Region:           spec.Region,
+		Endpoint:         spec.Endpoint,
+		IdentityEndpoint: spec.IdentityEndpoint,
+		StorageEndpoint:  spec.StorageEndpoint,
+		CACertificates:   spec.CACertificates,
+	}
+	if spec.Credential != nil {
+		cred := toCredential(*spec.Credential)
+		result.Credential = &cred
+	}
+	return result
+}
+
+func fromCloudSpec(spec CloudSpec) environs.CloudSpec {
+	result := environs.CloudSpec{
+		Type:             spec.Type,
+		Name:             spec.Name,
+		Region:           spec.Region,
+		Endpoint:         spec.Endpoint,
+		IdentityEndpoint: spec.IdentityEndpoint,
+		StorageEndpoint:  spec.StorageEndpoint,
+		CACertificates:   spec.CACertificates,
+	}
+	if spec.Credential != nil {
+		cred := fromCredential(*spec.Credential)
+		result.Credential = &cred
+	}
+	return result
+}
+
+func toConfigAttrs(cfg *config.Config) ConfigAttrs {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.AllAttrs()
+}
+
+func fromConfigAttrs(attrs ConfigAttrs) (*config.Config, error) {
+	if attrs == nil {
+		return nil, nil
+	}
+	cfg, err := config.New(config.NoDefaults, attrs)
+	return cfg, errors.Trace(err)
+}
+
+func toTools(list coretools.List) []Tools {
+	result := make([]Tools, len(list))
+	for i, tools := range list {
+		result[i] = Tools{
+			Version: tools.Version.String(),
+			URL:     tools.URL,
+			SHA256:  tools.SHA256,
+			Size:    tools.Size,
+		}
+	}
+	return result
+}
+
+func fromTools(list []Tools) (coretools.List, error) {
+	result := make(coretools.List, len(list))
+	for i, tools := range list {
+		v, err := version.ParseBinary(tools.Version)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = &coretools.Tools{
+			Version: v,
+			URL:     tools.URL,
+			SHA256:  tools.SHA256,
+			Size:    tools.Size,
+		}
+	}
+	return result, nil
+}
+
+func toAddress(addr network.Address) Address {
+	return Address{
+		Value:     addr.Value,
+		Type:      string(addr.Type),
+		Scope:     string(addr.Scope),
+		SpaceName: string(addr.SpaceName),
+	}
+}
+
+func fromAddress(addr Address) network.Address {
+	return network.Address{
+		Value:     addr.Value,
+		Type:      network.AddressType(addr.Type),
+		Scope:     network.Scope(addr.Scope),
+		SpaceName: network.SpaceName(addr.SpaceName),
+	}
+}
+
+func toAddresses(addrs []network.Address) []Address {
+	if len(addrs) == 0 {
+		return nil
+	}
+	result := make([]Address, len(addrs))
+	for i, addr := range addrs {
+		result[i] = toAddress(addr)
+	}
+	return result
+}
+
+func fromAddresses(addrs []Address) []network.Address {
+	if len(addrs) == 0 {
+		return nil
+	}
+	result := make([]network.Address, len(addrs))
+	for i, addr := range addrs {
+		result[i] = fromAddress(addr)
+	}
+	return result
+}
+
+func toSubnet(subnet network.SubnetInfo) Subnet {
+	return Subnet{
+		CIDR:              subnet.CIDR,
+		ProviderId:        string(subnet.ProviderId),
+		ProviderNetworkId: string(subnet.ProviderNetworkId),
+		SpaceProviderId:   string(subnet.SpaceProviderId),
+		VLANTag:           subnet.VLANTag,
+		AvailabilityZones: subnet.AvailabilityZones,
+	}
+}
+
+func fromSubnet(subnet Subnet) network.SubnetInfo {
+	return network.SubnetInfo{
+		CIDR:              subnet.CIDR,
+		ProviderId:        network.Id(subnet.ProviderId),
+		ProviderNetworkId: network.Id(subnet.ProviderNetworkId),
+		SpaceProviderId:   network.Id(subnet.SpaceProviderId),
+		VLANTag:           subnet.VLANTag,
+		AvailabilityZones: subnet.AvailabilityZones,
+	}
+}
+
+func toSubnets(subnets []network.SubnetInfo) []Subnet {
+	result := make([]Subnet, len(subnets))
+	for i, subnet := range subnets {
+		result[i] = toSubnet(subnet)
+	}
+	return result
+}
+
+func fromSubnets(subnets []Subnet) []network.SubnetInfo {
+	result := make([]network.SubnetInfo, len(subnets))
+	for i, subnet := range subnets {
+		result[i] = fromSubnet(subnet)
+	}
+	return result
+}
+
+func toNetworkInterface(iface network.InterfaceInfo) NetworkInterface {
+	result := NetworkInterface{
+		DeviceIndex:         iface.DeviceIndex,
+		MACAddress:          iface.MACAddress,
+		CIDR:                iface.CIDR,
+		ProviderId:          string(iface.ProviderId),
+		ProviderSubnetId:    string(iface.ProviderSubnetId),
+		ProviderNetworkId:   string(iface.ProviderNetworkId),
+		ProviderSpaceId:     string(iface.ProviderSpaceId),
+		ProviderVLANId:      string(iface.ProviderVLANId),
+		ProviderAddressId:   string(iface.ProviderAddressId),
+		AvailabilityZones:   iface.AvailabilityZones,
+		VLANTag:             iface.VLANTag,
+		InterfaceName:       iface.InterfaceName,
+		ParentInterfaceName: iface.ParentInterfaceName,
+		InterfaceType:       string(iface.InterfaceType),
+		Disabled:            iface.Disabled,
+		NoAutoStart:         iface.NoAutoStart,
+		ConfigType:          string(iface.ConfigType),
+		DNSServers:          toAddresses(iface.DNSServers),
+		MTU:                 iface.MTU,
+		DNSSearchDomains:    iface.DNSSearchDomains,
+		IsDefaultGateway:    iface.IsDefaultGateway,
+	}
+	if iface.Address.Value != "" {
+		addr := toAddress(iface.Address)
+		result.Address = &addr
+	}
+	if iface.GatewayAddress.Value != "" {
+		addr := toAddress(iface.GatewayAddress)
+		result.GatewayAddress = &addr
+	}
+	for _, route := range iface.Routes {
+		result.Routes = append(result.Routes, Route{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		})
+	}
+	return result
+}
+
+func fromNetworkInterface(iface NetworkInterface) network.InterfaceInfo {
+	result := network.InterfaceInfo{
+		DeviceIndex:         iface.DeviceIndex,
+		MACAddress:          iface.MACAddress,
+		CIDR:                iface.CIDR,
+		ProviderId:          network.Id(iface.ProviderId),
+		ProviderSubnetId:    network.Id(iface.ProviderSubnetId),
+		ProviderNetworkId:   network.Id(iface.ProviderNetworkId),
+		ProviderSpaceId:     network.Id(iface.ProviderSpaceId),
+		ProviderVLANId:      network.Id(iface.ProviderVLANId),
+		ProviderAddressId:   network.Id(iface.ProviderAddressId),
+		AvailabilityZones:   iface.AvailabilityZones,
+		VLANTag:             iface.VLANTag,
+		InterfaceName:       iface.InterfaceName,
+		ParentInterfaceName: iface.ParentInterfaceName,
+		InterfaceType:       network.InterfaceType(iface.InterfaceType),
+		Disabled:            iface.Disabled,
+		NoAutoStart:         iface.NoAutoStart,
+		ConfigType:          network.InterfaceConfigType(iface.ConfigType),
+		DNSServers:          fromAddresses(iface.DNSServers),
+		MTU:                 iface.MTU,
+		DNSSearchDomains:    iface.DNSSearchDomains,
+		IsDefaultGateway:    iface.IsDefaultGateway,
+	}
+	if iface.Address != nil {
+		result.Address = fromAddress(*iface.Address)
+	}
+	if iface.GatewayAddress != nil {
+		result.GatewayAddress = fromAddress(*iface.GatewayAddress)
+	}
+	for _, route := range iface.Routes {
+		result.Routes = append(result.Routes, network.Route{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		})
+	}
+	return result
+}
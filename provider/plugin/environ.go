@@ -0,0 +1,437 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
+	coretools "github.com/juju/juju/tools"
+)
+
+// environ is an environs.Environ that delegates to a provider plugin.
+type environ struct {
+	// Plugins do not yet provide storage providers.
+	storage.StaticProviderRegistry
+
+	provider *environProvider
+	cloud    environs.CloudSpec
+
+	mu  sync.Mutex
+	cfg *config.Config
+}
+
+var _ environs.Environ = (*environ)(nil)
+var _ environs.Networking = (*environ)(nil)
+
+func (e *environ) params() EnvironParams {
+	return EnvironParams{
+		Cloud:  toCloudSpec(e.cloud),
+		Config: toConfigAttrs(e.Config()),
+	}
+}
+
+func (e *environ) call(ctx context.ProviderCallContext, method string, args interface{}) error {
+	var result ErrorResult
+	if err := e.provider.client.call(method, args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return fromCallError(ctx, result.Error)
+}
+
+// Provider is part of the Environ interface.
+func (e *environ) Provider() environs.EnvironProvider {
+	return e.provider
+}
+
+// Config is part of the Environ interface.
+func (e *environ) Config() *config.Config {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.cfg
+}
+
+// SetConfig is part of the Environ interface.
+func (e *environ) SetConfig(cfg *config.Config) error {
+	valid, err := e.provider.Validate(cfg, e.Config())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cfg = valid
+	return nil
+}
+
+// PrepareForBootstrap is part of the Environ interface.
+func (e *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	var result ErrorResult
+	if err := e.provider.client.call("Environ.PrepareForBootstrap", PrepareForBootstrapArgs{
+		Environ:           e.params(),
+		VerifyCredentials: ctx.ShouldVerifyCredentials(),
+	}, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return fromError(result.Error)
+}
+
+// Bootstrap is part of the Environ interface.
+func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, e, callCtx, args)
+}
+
+// Create is part of the Environ interface.
+func (e *environ) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	return e.call(ctx, "Environ.Create", ControllerArgs{
+		Environ:        e.params(),
+		ControllerUUID: args.ControllerUUID,
+	})
+}
+
+// Destroy is part of the Environ interface.
+func (e *environ) Destroy(ctx context.ProviderCallContext) error {
+	return e.call(ctx, "Environ.Destroy", e.params())
+}
+
+// DestroyController is part of the Environ interface.
+func (e *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	return e.call(ctx, "Environ.DestroyController", ControllerArgs{
+		Environ:        e.params(),
+		ControllerUUID: controllerUUID,
+	})
+}
+
+// AdoptResources is part of the Environ interface.
+func (e *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	return e.call(ctx, "Environ.AdoptResources", AdoptResourcesArgs{
+		Environ:        e.params(),
+		ControllerUUID: controllerUUID,
+		SourceVersion:  fromVersion.String(),
+	})
+}
+
+// PrecheckInstance is part of the InstancePrechecker interface.
+func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	return e.call(ctx, "Environ.PrecheckInstance", PrecheckInstanceArgs{
+		Environ:     e.params(),
+		Series:      args.Series,
+		Constraints: args.Constraints,
+		Placement:   args.Placement,
+	})
+}
+
+// ConstraintsValidator is part of the Environ interface.
+func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	return &validator{env: e, ctx: ctx}, nil
+}
+
+// InstanceTypes is part of the InstanceTypesFetcher interface.
+func (e *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	result := instances.InstanceTypesWithCostMetadata{}
+	return result, errors.NotSupportedf("InstanceTypes")
+}
+
+// composeUserData renders the user data for an instance. It is a
+// variable so that tests need not construct a complete instance config.
+var composeUserData = func(icfg *instancecfg.InstanceConfig) ([]byte, error) {
+	return providerinit.ComposeUserData(icfg, nil, pluginRenderer{})
+}
+
+// pluginRenderer renders plain cloud-init user data, leaving any
+// compression or encoding to the plugin.
+type pluginRenderer struct{}
+
+// Render is part of the renderers.ProviderRenderer interface.
+func (pluginRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	case jujuos.Windows:
+		return renderers.RenderYAML(cfg, renderers.WinEmbedInScript)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS: %s", os.String())
+	}
+}
+
+// StartInstance is part of the InstanceBroker interface. The user data
+// is rendered here, so plugins need not understand the instance config.
+func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	icfg := args.InstanceConfig
+	tools, err := args.Tools.Match(coretools.Filter{Arch: instanceArch(args)})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := icfg.SetTools(tools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(icfg, e.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	userData, err := composeUserData(icfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot make user data")
+	}
+
+	startArgs := StartInstanceArgs{
+		Environ:          e.params(),
+		ControllerUUID:   args.ControllerUUID,
+		MachineId:        icfg.MachineId,
+		MachineNonce:     icfg.MachineNonce,
+		Series:           icfg.Series,
+		Constraints:      args.Constraints,
+		Tools:            toTools(tools),
+		Placement:        args.Placement,
+		AvailabilityZone: args.AvailabilityZone,
+		UserData:         userData,
+	}
+	for _, job := range icfg.Jobs {
+		startArgs.Jobs = append(startArgs.Jobs, string(job))
+	}
+	if len(args.SubnetsToZones) > 0 {
+		startArgs.SubnetsToZones = make(map[string][]string)
+		for id, zones := range args.SubnetsToZones {
+			startArgs.SubnetsToZones[string(id)] = zones
+		}
+	}
+	var result StartInstanceResult
+	if err := e.provider.client.call("Environ.StartInstance", startArgs, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	if result.Instance == nil {
+		return nil, errors.New("provider plugin did not return an instance")
+	}
+	return &environs.StartInstanceResult{
+		Instance: &pluginInstance{*result.Instance},
+		Hardware: result.Hardware,
+	}, nil
+}
+
+// instanceArch returns the architecture of the agent binaries that
+// the instance will run, preferring amd64 if there is a choice.
+func instanceArch(args environs.StartInstanceParams) string {
+	if args.Constraints.HasArch() {
+		return *args.Constraints.Arch
+	}
+	arches := args.Tools.Arches()
+	for _, a := range arches {
+		if a == arch.AMD64 {
+			return a
+		}
+	}
+	if len(arches) > 0 {
+		return arches[0]
+	}
+	return ""
+}
+
+// StopInstances is part of the InstanceBroker interface.
+func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	args := InstanceIdsArgs{Environ: e.params()}
+	for _, id := range ids {
+		args.Ids = append(args.Ids, string(id))
+	}
+	return e.call(ctx, "Environ.StopInstances", args)
+}
+
+// MaintainInstance is part of the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// AllInstances is part of the InstanceBroker interface.
+func (e *environ) AllInstances(ctx context.ProviderCallContext) ([]instance.Instance, error) {
+	return e.instances(ctx, "Environ.AllInstances", e.params())
+}
+
+// Instances is part of the Environ interface.
+func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := InstanceIdsArgs{Environ: e.params()}
+	for _, id := range ids {
+		args.Ids = append(args.Ids, string(id))
+	}
+	return e.instances(ctx, "Environ.Instances", args)
+}
+
+func (e *environ) instances(ctx context.ProviderCallContext, method string, args interface{}) ([]instance.Instance, error) {
+	var result InstancesResult
+	if err := e.provider.client.call(method, args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	err := fromCallError(ctx, result.Error)
+	if err != nil && err != environs.ErrPartialInstances {
+		return nil, err
+	}
+	insts := make([]instance.Instance, len(result.Instances))
+	for i, inst := range result.Instances {
+		if inst != nil {
+			insts[i] = &pluginInstance{*inst}
+		}
+	}
+	return insts, err
+}
+
+// ControllerInstances is part of the Environ interface.
+func (e *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	var result InstanceIdsResult
+	if err := e.provider.client.call("Environ.ControllerInstances", ControllerArgs{
+		Environ:        e.params(),
+		ControllerUUID: controllerUUID,
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	ids := make([]instance.Id, len(result.Ids))
+	for i, id := range result.Ids {
+		ids[i] = instance.Id(id)
+	}
+	return ids, nil
+}
+
+// Subnets is part of the Networking interface.
+func (e *environ) Subnets(ctx context.ProviderCallContext, inst instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	args := SubnetsArgs{
+		Environ:    e.params(),
+		InstanceId: string(inst),
+	}
+	for _, id := range subnetIds {
+		args.SubnetIds = append(args.SubnetIds, string(id))
+	}
+	var result SubnetsResult
+	if err := e.provider.client.call("Environ.Subnets", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	return fromSubnets(result.Subnets), nil
+}
+
+// SuperSubnets is part of the Networking interface.
+func (e *environ) SuperSubnets(ctx context.ProviderCallContext) ([]string, error) {
+	var result StringsResult
+	if err := e.provider.client.call("Environ.SuperSubnets", e.params(), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	return result.Result, nil
+}
+
+// NetworkInterfaces is part of the Networking interface.
+func (e *environ) NetworkInterfaces(ctx context.ProviderCallContext, instId instance.Id) ([]network.InterfaceInfo, error) {
+	var result NetworkInterfacesResult
+	if err := e.provider.client.call("Environ.NetworkInterfaces", InstanceArgs{
+		Environ:    e.params(),
+		InstanceId: string(instId),
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	interfaces := make([]network.InterfaceInfo, len(result.Interfaces))
+	for i, iface := range result.Interfaces {
+		interfaces[i] = fromNetworkInterface(iface)
+	}
+	return interfaces, nil
+}
+
+func (e *environ) supports(ctx context.ProviderCallContext, method string) (bool, error) {
+	var result BoolResult
+	if err := e.provider.client.call(method, e.params(), &result); err != nil {
+		return false, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return false, fromCallError(ctx, result.Error)
+	}
+	return result.Result, nil
+}
+
+// SupportsSpaces is part of the Networking interface.
+func (e *environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error) {
+	return e.supports(ctx, "Environ.SupportsSpaces")
+}
+
+// SupportsSpaceDiscovery is part of the Networking interface.
+func (e *environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
+	return e.supports(ctx, "Environ.SupportsSpaceDiscovery")
+}
+
+// SupportsContainerAddresses is part of the Networking interface.
+// Plugins cannot yet allocate container addresses.
+func (e *environ) SupportsContainerAddresses(ctx context.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// Spaces is part of the Networking interface.
+func (e *environ) Spaces(ctx context.ProviderCallContext) ([]network.SpaceInfo, error) {
+	var result SpacesResult
+	if err := e.provider.client.call("Environ.Spaces", e.params(), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(ctx, result.Error)
+	}
+	spaces := make([]network.SpaceInfo, len(result.Spaces))
+	for i, space := range result.Spaces {
+		spaces[i] = network.SpaceInfo{
+			Name:       space.Name,
+			ProviderId: network.Id(space.ProviderId),
+			Subnets:    fromSubnets(space.Subnets),
+		}
+	}
+	return spaces, nil
+}
+
+// ProviderSpaceInfo is part of the Networking interface.
+func (*environ) ProviderSpaceInfo(ctx context.ProviderCallContext, space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the Networking interface.
+func (*environ) AreSpacesRoutable(ctx context.ProviderCallContext, space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// AllocateContainerAddresses is part of the Networking interface.
+func (*environ) AllocateContainerAddresses(ctx context.ProviderCallContext, hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses is part of the Networking interface.
+func (*environ) ReleaseContainerAddresses(ctx context.ProviderCallContext, interfaces []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses is part of the Networking interface.
+func (*environ) SSHAddresses(ctx context.ProviderCallContext, addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
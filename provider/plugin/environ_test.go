@@ -0,0 +1,240 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin_test
+
+import (
+	"io"
+	"net"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/provider/plugin"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environSuite struct {
+	coretesting.BaseSuite
+
+	conns    []io.Closer
+	provider environs.CloudEnvironProvider
+	callCtx  context.ProviderCallContext
+	userData []byte
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.conns = nil
+	s.userData = nil
+	s.PatchValue(plugin.ComposeUserData, func(icfg *instancecfg.InstanceConfig) ([]byte, error) {
+		s.userData = []byte("user-data for " + icfg.MachineId)
+		return s.userData, nil
+	})
+	s.provider = plugin.NewProvider("dummy", s.connect)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *environSuite) TearDownTest(c *gc.C) {
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	dummy.Reset(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+// connect serves the dummy provider in-process, over a pipe.
+func (s *environSuite) connect() (io.ReadWriteCloser, error) {
+	p, err := environs.Provider("dummy")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, server := net.Pipe()
+	go plugin.ServeConn("dummy", p.(environs.CloudEnvironProvider), server)
+	s.conns = append(s.conns, client)
+	return client, nil
+}
+
+func (s *environSuite) config(c *gc.C) *config.Config {
+	attrs := dummy.SampleConfig().Merge(coretesting.Attrs{
+		// Only one dummy controller may be prepared per process.
+		"controller": false,
+	})
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *environSuite) open(c *gc.C) environs.Environ {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  dummy.SampleCloudSpec(),
+		Config: s.config(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
+
+func (s *environSuite) prepare(c *gc.C) environs.Environ {
+	env := s.open(c)
+	err := env.PrepareForBootstrap(envtesting.BootstrapContext(c))
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
+
+func (s *environSuite) startInstance(c *gc.C, env environs.Environ, machineId string) *environs.StartInstanceResult {
+	apiInfo := &api.Info{
+		Tag:      names.NewMachineTag(machineId),
+		ModelTag: coretesting.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		coretesting.ControllerTag, machineId, "fake-nonce", "released", "xenial", apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := env.StartInstance(s.callCtx, environs.StartInstanceParams{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		InstanceConfig: icfg,
+		Tools: coretools.List{{
+			Version: version.MustParseBinary("2.5.0-xenial-amd64"),
+			URL:     "https://example.com/tools.tgz",
+			SHA256:  "1234",
+			Size:    10,
+		}},
+		AvailabilityZone: "zone1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *environSuite) TestHandshakeProviderTypeMismatch(c *gc.C) {
+	s.provider = plugin.NewProvider("foo", s.connect)
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  dummy.SampleCloudSpec(),
+		Config: s.config(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `connecting to provider plugin "foo": plugin provides "dummy", not "foo"`)
+}
+
+func (s *environSuite) TestOpenInvalidConfig(c *gc.C) {
+	cfg, err := s.config(c).Apply(map[string]interface{}{"broken": "Open"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Open(environs.OpenParams{
+		Cloud:  dummy.SampleCloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, gc.ErrorMatches, "dummy.Open is broken")
+}
+
+func (s *environSuite) TestValidate(c *gc.C) {
+	cfg := s.config(c)
+	valid, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid.UUID(), gc.Equals, cfg.UUID())
+	c.Assert(valid.AllAttrs()["secret"], gc.Equals, "pork")
+}
+
+func (s *environSuite) TestCredentialSchemas(c *gc.C) {
+	p, err := environs.Provider("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provider.CredentialSchemas(), jc.DeepEquals, p.CredentialSchemas())
+}
+
+func (s *environSuite) TestNotBootstrapped(c *gc.C) {
+	env := s.prepare(c)
+	_, err := env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environSuite) TestStartInstance(c *gc.C) {
+	env := s.prepare(c)
+	result := s.startInstance(c, env, "1")
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("only-0"))
+	c.Assert(result.Hardware, gc.NotNil)
+	c.Assert(*result.Hardware.Arch, gc.Equals, "amd64")
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "zone1")
+	c.Assert(string(s.userData), gc.Equals, "user-data for 1")
+
+	addrs, err := result.Instance.Addresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 3)
+	c.Assert(addrs[0].Value, gc.Equals, "only-0.dns")
+}
+
+func (s *environSuite) TestInstances(c *gc.C) {
+	env := s.prepare(c)
+	inst0 := s.startInstance(c, env, "1").Instance
+	inst1 := s.startInstance(c, env, "2").Instance
+
+	all, err := env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+
+	insts, err := env.Instances(s.callCtx, []instance.Id{inst1.Id(), "missing", inst0.Id()})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 3)
+	c.Assert(insts[0].Id(), gc.Equals, inst1.Id())
+	c.Assert(insts[1], gc.IsNil)
+	c.Assert(insts[2].Id(), gc.Equals, inst0.Id())
+
+	_, err = env.Instances(s.callCtx, []instance.Id{"missing"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+
+	err = env.StopInstances(s.callCtx, inst0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	all, err = env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, inst1.Id())
+}
+
+func (s *environSuite) TestConstraintsValidator(c *gc.C) {
+	env := s.open(c)
+	validator, err := env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("cpu-power=10 mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power"})
+
+	_, err = validator.Validate(constraints.MustParse("arch=s390x"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=s390x\n.*`)
+
+	// Changes to the validator are applied by the plugin.
+	validator.UpdateVocabulary(constraints.Arch, []string{"s390x"})
+	_, err = validator.Validate(constraints.MustParse("arch=s390x"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	merged, err := validator.Merge(constraints.MustParse("mem=4G"), constraints.MustParse("instance-type=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(merged, jc.DeepEquals, constraints.MustParse("instance-type=foo"))
+}
+
+func (s *environSuite) TestPluginRestarted(c *gc.C) {
+	env := s.prepare(c)
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	c.Assert(s.conns, gc.HasLen, 1)
+
+	// The first call after the plugin goes away fails, and the
+	// next call starts the plugin again.
+	_, err := env.AllInstances(s.callCtx)
+	c.Assert(err, gc.ErrorMatches, `calling Environ.AllInstances on provider plugin "dummy": .*`)
+	_, err = env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.conns, gc.HasLen, 2)
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// pluginInstance is an instance.Instance describing an instance as
+// it was when returned by a provider plugin.
+type pluginInstance struct {
+	inst Instance
+}
+
+var _ instance.Instance = (*pluginInstance)(nil)
+
+// Id is part of the instance.Instance interface.
+func (inst *pluginInstance) Id() instance.Id {
+	return instance.Id(inst.inst.Id)
+}
+
+// Status is part of the instance.Instance interface.
+func (inst *pluginInstance) Status(ctx context.ProviderCallContext) instance.InstanceStatus {
+	jujuStatus := status.Status(inst.inst.Status)
+	if !jujuStatus.KnownInstanceStatus() {
+		jujuStatus = status.Unknown
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: inst.inst.Message,
+	}
+}
+
+// Addresses is part of the instance.Instance interface.
+func (inst *pluginInstance) Addresses(ctx context.ProviderCallContext) ([]network.Address, error) {
+	return fromAddresses(inst.inst.Addresses), nil
+}
+
+// validator is a constraints.Validator that delegates to a provider
+// plugin. Changes made to the validator are recorded and sent with
+// each call, so that the plugin can apply them to its own validator.
+type validator struct {
+	env    *environ
+	ctx    context.ProviderCallContext
+	params ConstraintsValidatorParams
+}
+
+var _ constraints.Validator = (*validator)(nil)
+
+// RegisterConflicts is part of the constraints.Validator interface.
+func (v *validator) RegisterConflicts(reds, blues []string) {
+	v.params.Conflicts = append(v.params.Conflicts, ConstraintsConflict{
+		Reds:  reds,
+		Blues: blues,
+	})
+}
+
+// RegisterUnsupported is part of the constraints.Validator interface.
+func (v *validator) RegisterUnsupported(unsupported []string) {
+	v.params.Unsupported = append([]string(nil), unsupported...)
+}
+
+// RegisterVocabulary is part of the constraints.Validator interface.
+func (v *validator) RegisterVocabulary(attributeName string, allowedValues interface{}) {
+	v.params.Vocabulary = append(v.params.Vocabulary, ConstraintsVocabulary{
+		Attribute: attributeName,
+		Values:    vocabularyValues(allowedValues),
+	})
+}
+
+// UpdateVocabulary is part of the constraints.Validator interface.
+func (v *validator) UpdateVocabulary(attributeName string, newValues interface{}) {
+	v.params.Vocabulary = append(v.params.Vocabulary, ConstraintsVocabulary{
+		Attribute: attributeName,
+		Values:    vocabularyValues(newValues),
+		Update:    true,
+	})
+}
+
+func vocabularyValues(values interface{}) []interface{} {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		logger.Warningf("ignoring constraints vocabulary %v of type %T", values, values)
+		return nil
+	}
+	result := make([]interface{}, v.Len())
+	for i := range result {
+		result[i] = v.Index(i).Interface()
+	}
+	return result
+}
+
+func (v *validator) validatorParams() ConstraintsValidatorParams {
+	params := v.params
+	params.Environ = v.env.params()
+	return params
+}
+
+// Validate is part of the constraints.Validator interface.
+func (v *validator) Validate(cons constraints.Value) ([]string, error) {
+	var result ValidateConstraintsResult
+	if err := v.env.provider.client.call("Environ.ValidateConstraints", ValidateConstraintsArgs{
+		Validator:   v.validatorParams(),
+		Constraints: cons,
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromCallError(v.ctx, result.Error)
+	}
+	return result.Unsupported, nil
+}
+
+// Merge is part of the constraints.Validator interface.
+func (v *validator) Merge(consFallback, cons constraints.Value) (constraints.Value, error) {
+	var result MergeConstraintsResult
+	if err := v.env.provider.client.call("Environ.MergeConstraints", MergeConstraintsArgs{
+		Validator:   v.validatorParams(),
+		Fallback:    consFallback,
+		Constraints: cons,
+	}, &result); err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return constraints.Value{}, fromCallError(v.ctx, result.Error)
+	}
+	return result.Constraints, nil
+}
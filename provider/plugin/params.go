@@ -0,0 +1,450 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// The types in this file define the wire format of the provider plugin
+// protocol. They must only be changed in a backwards compatible manner,
+// or else ProtocolVersion must be bumped.
+
+// Error codes carried by Error, identifying the errors that Juju
+// treats specially.
+const (
+	CodeNotFound           = "not found"
+	CodeNotSupported       = "not supported"
+	CodeNotImplemented     = "not implemented"
+	CodeNotValid           = "not valid"
+	CodeNoInstances        = "no instances"
+	CodePartialInstances   = "partial instances"
+	CodeNotBootstrapped    = "not bootstrapped"
+	CodeZoneIndependent    = "zone independent"
+	CodeCredentialNotValid = "credential not valid"
+)
+
+// Error is the error returned by a plugin call.
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// ErrorResult holds the result of a plugin call that returns
+// nothing but an error.
+type ErrorResult struct {
+	Error *Error `json:"error,omitempty"`
+}
+
+// HandshakeArgs holds the arguments for Provider.Handshake, which
+// is the first call made on each connection to a plugin.
+type HandshakeArgs struct {
+	ProtocolVersion int `json:"protocol-version"`
+}
+
+// HandshakeResult holds the result of Provider.Handshake.
+type HandshakeResult struct {
+	ProtocolVersion int    `json:"protocol-version"`
+	ProviderType    string `json:"provider-type"`
+	ProviderVersion int    `json:"provider-version"`
+	Error           *Error `json:"error,omitempty"`
+}
+
+// ConfigAttrs holds model config attributes. Whole numbers are
+// decoded as ints, rather than as float64 values, so that the
+// attributes can be validated by the config schema.
+type ConfigAttrs map[string]interface{}
+
+// UnmarshalJSON is part of the json.Unmarshaler interface.
+func (a *ConfigAttrs) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var attrs map[string]interface{}
+	if err := decoder.Decode(&attrs); err != nil {
+		return errors.Trace(err)
+	}
+	for k, v := range attrs {
+		attrs[k] = coerceNumbers(v)
+	}
+	*a = attrs
+	return nil
+}
+
+func coerceNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, elem := range v {
+			v[k] = coerceNumbers(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = coerceNumbers(elem)
+		}
+	}
+	return v
+}
+
+// ConfigResult holds a model config returned by a plugin.
+type ConfigResult struct {
+	Config ConfigAttrs `json:"config,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// ValidateConfigArgs holds the arguments for Provider.Validate.
+type ValidateConfigArgs struct {
+	Config ConfigAttrs `json:"config"`
+	Old    ConfigAttrs `json:"old,omitempty"`
+}
+
+// CloudSchemaResult holds the result of Provider.CloudSchema. Schema
+// is a JSON schema document, or null if the provider does not support
+// custom clouds.
+type CloudSchemaResult struct {
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// PingArgs holds the arguments for Provider.Ping.
+type PingArgs struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// Credential describes a cloud credential.
+type Credential struct {
+	AuthType   string            `json:"auth-type"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Label      string            `json:"label,omitempty"`
+}
+
+// CloudCredential describes the credentials detected for a cloud.
+type CloudCredential struct {
+	DefaultCredential string                `json:"default-credential,omitempty"`
+	DefaultRegion     string                `json:"default-region,omitempty"`
+	AuthCredentials   map[string]Credential `json:"auth-credentials,omitempty"`
+}
+
+// CredentialAttr describes an attribute of a credential schema.
+type CredentialAttr struct {
+	Name           string        `json:"name"`
+	Description    string        `json:"description,omitempty"`
+	Hidden         bool          `json:"hidden,omitempty"`
+	FileAttr       string        `json:"file-attr,omitempty"`
+	FilePath       bool          `json:"file-path,omitempty"`
+	ExpandFilePath bool          `json:"expand-file-path,omitempty"`
+	Optional       bool          `json:"optional,omitempty"`
+	Options        []interface{} `json:"options,omitempty"`
+}
+
+// CredentialSchemasResult holds the result of
+// Provider.CredentialSchemas, keyed by auth-type.
+type CredentialSchemasResult struct {
+	Schemas map[string][]CredentialAttr `json:"schemas"`
+	Error   *Error                      `json:"error,omitempty"`
+}
+
+// CloudCredentialResult holds the result of Provider.DetectCredentials.
+type CloudCredentialResult struct {
+	Credential *CloudCredential `json:"credential,omitempty"`
+	Error      *Error           `json:"error,omitempty"`
+}
+
+// FinalizeCredentialArgs holds the arguments for
+// Provider.FinalizeCredential.
+type FinalizeCredentialArgs struct {
+	Credential            Credential `json:"credential"`
+	CloudEndpoint         string     `json:"cloud-endpoint,omitempty"`
+	CloudStorageEndpoint  string     `json:"cloud-storage-endpoint,omitempty"`
+	CloudIdentityEndpoint string     `json:"cloud-identity-endpoint,omitempty"`
+}
+
+// CredentialResult holds the result of Provider.FinalizeCredential.
+type CredentialResult struct {
+	Credential *Credential `json:"credential,omitempty"`
+	Error      *Error      `json:"error,omitempty"`
+}
+
+// CloudSpec describes the cloud that an environ is opened against.
+type CloudSpec struct {
+	Type             string      `json:"type"`
+	Name             string      `json:"name"`
+	Region           string      `json:"region,omitempty"`
+	Endpoint         string      `json:"endpoint,omitempty"`
+	IdentityEndpoint string      `json:"identity-endpoint,omitempty"`
+	StorageEndpoint  string      `json:"storage-endpoint,omitempty"`
+	Credential       *Credential `json:"credential,omitempty"`
+	CACertificates   []string    `json:"ca-certificates,omitempty"`
+}
+
+// EnvironParams identifies an environ. The protocol is stateless, so
+// every environ call carries the cloud spec and model config that the
+// environ is opened with.
+type EnvironParams struct {
+	Cloud  CloudSpec   `json:"cloud"`
+	Config ConfigAttrs `json:"config"`
+}
+
+// PrepareForBootstrapArgs holds the arguments for
+// Environ.PrepareForBootstrap.
+type PrepareForBootstrapArgs struct {
+	Environ           EnvironParams `json:"environ"`
+	VerifyCredentials bool          `json:"verify-credentials"`
+}
+
+// ControllerArgs holds the arguments for environ calls that
+// operate on behalf of a controller.
+type ControllerArgs struct {
+	Environ        EnvironParams `json:"environ"`
+	ControllerUUID string        `json:"controller-uuid"`
+}
+
+// AdoptResourcesArgs holds the arguments for Environ.AdoptResources.
+type AdoptResourcesArgs struct {
+	Environ        EnvironParams `json:"environ"`
+	ControllerUUID string        `json:"controller-uuid"`
+	SourceVersion  string        `json:"source-version"`
+}
+
+// PrecheckInstanceArgs holds the arguments for Environ.PrecheckInstance.
+type PrecheckInstanceArgs struct {
+	Environ     EnvironParams     `json:"environ"`
+	Series      string            `json:"series"`
+	Constraints constraints.Value `json:"constraints"`
+	Placement   string            `json:"placement,omitempty"`
+}
+
+// ConstraintsConflict describes two lists of constraint attributes
+// that conflict with each other.
+type ConstraintsConflict struct {
+	Reds  []string `json:"reds"`
+	Blues []string `json:"blues"`
+}
+
+// ConstraintsVocabulary describes the values allowed for a constraint
+// attribute. If Update is true, the values are merged with those
+// already allowed rather than replacing them.
+type ConstraintsVocabulary struct {
+	Attribute string        `json:"attribute"`
+	Values    []interface{} `json:"values"`
+	Update    bool          `json:"update,omitempty"`
+}
+
+// ConstraintsValidatorParams identifies the environ whose constraints
+// validator is used, along with the changes Juju has made to it. If
+// Unsupported is not empty, it replaces the attributes that the
+// validator considers unsupported.
+type ConstraintsValidatorParams struct {
+	Environ     EnvironParams           `json:"environ"`
+	Unsupported []string                `json:"unsupported,omitempty"`
+	Conflicts   []ConstraintsConflict   `json:"conflicts,omitempty"`
+	Vocabulary  []ConstraintsVocabulary `json:"vocabulary,omitempty"`
+}
+
+// ValidateConstraintsArgs holds the arguments for
+// Environ.ValidateConstraints.
+type ValidateConstraintsArgs struct {
+	Validator   ConstraintsValidatorParams `json:"validator"`
+	Constraints constraints.Value          `json:"constraints"`
+}
+
+// ValidateConstraintsResult holds the result of
+// Environ.ValidateConstraints.
+type ValidateConstraintsResult struct {
+	Unsupported []string `json:"unsupported,omitempty"`
+	Error       *Error   `json:"error,omitempty"`
+}
+
+// MergeConstraintsArgs holds the arguments for Environ.MergeConstraints.
+type MergeConstraintsArgs struct {
+	Validator   ConstraintsValidatorParams `json:"validator"`
+	Fallback    constraints.Value          `json:"fallback"`
+	Constraints constraints.Value          `json:"constraints"`
+}
+
+// MergeConstraintsResult holds the result of Environ.MergeConstraints.
+type MergeConstraintsResult struct {
+	Constraints constraints.Value `json:"constraints"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// Tools describes agent binaries that an instance may use.
+type Tools struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+}
+
+// StartInstanceArgs holds the arguments for Environ.StartInstance.
+//
+// UserData holds the cloud-init user data that the instance must be
+// started with; it is rendered by Juju, and is neither compressed nor
+// encoded for any particular cloud.
+type StartInstanceArgs struct {
+	Environ          EnvironParams       `json:"environ"`
+	ControllerUUID   string              `json:"controller-uuid"`
+	MachineId        string              `json:"machine-id"`
+	MachineNonce     string              `json:"machine-nonce"`
+	Jobs             []string            `json:"jobs"`
+	Series           string              `json:"series"`
+	Constraints      constraints.Value   `json:"constraints"`
+	Tools            []Tools             `json:"tools"`
+	Placement        string              `json:"placement,omitempty"`
+	AvailabilityZone string              `json:"availability-zone,omitempty"`
+	SubnetsToZones   map[string][]string `json:"subnets-to-zones,omitempty"`
+	UserData         []byte              `json:"user-data"`
+}
+
+// Address describes a network address of an instance.
+type Address struct {
+	Value     string `json:"value"`
+	Type      string `json:"type"`
+	Scope     string `json:"scope"`
+	SpaceName string `json:"space-name,omitempty"`
+}
+
+// Instance describes a cloud instance at the time it was returned.
+type Instance struct {
+	Id        string    `json:"id"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	Addresses []Address `json:"addresses,omitempty"`
+}
+
+// StartInstanceResult holds the result of Environ.StartInstance.
+type StartInstanceResult struct {
+	Instance *Instance                         `json:"instance,omitempty"`
+	Hardware *instance.HardwareCharacteristics `json:"hardware,omitempty"`
+	Error    *Error                            `json:"error,omitempty"`
+}
+
+// InstanceIdsArgs holds the arguments for environ calls that operate
+// on a number of instances.
+type InstanceIdsArgs struct {
+	Environ EnvironParams `json:"environ"`
+	Ids     []string      `json:"ids"`
+}
+
+// InstanceIdsResult holds the result of Environ.ControllerInstances.
+type InstanceIdsResult struct {
+	Ids   []string `json:"ids"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// InstancesResult holds the result of Environ.Instances and
+// Environ.AllInstances. When some of the requested instances
+// are not found, their entries are null and Error has the code
+// CodePartialInstances.
+type InstancesResult struct {
+	Instances []*Instance `json:"instances"`
+	Error     *Error      `json:"error,omitempty"`
+}
+
+// BoolResult holds the result of a plugin call that returns a bool.
+type BoolResult struct {
+	Result bool   `json:"result"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// StringsResult holds the result of a plugin call that returns
+// a list of strings.
+type StringsResult struct {
+	Result []string `json:"result"`
+	Error  *Error   `json:"error,omitempty"`
+}
+
+// Subnet describes a subnet known to the provider.
+type Subnet struct {
+	CIDR              string   `json:"cidr"`
+	ProviderId        string   `json:"provider-id,omitempty"`
+	ProviderNetworkId string   `json:"provider-network-id,omitempty"`
+	SpaceProviderId   string   `json:"space-provider-id,omitempty"`
+	VLANTag           int      `json:"vlan-tag,omitempty"`
+	AvailabilityZones []string `json:"availability-zones,omitempty"`
+}
+
+// SubnetsArgs holds the arguments for Environ.Subnets.
+type SubnetsArgs struct {
+	Environ    EnvironParams `json:"environ"`
+	InstanceId string        `json:"instance-id,omitempty"`
+	SubnetIds  []string      `json:"subnet-ids,omitempty"`
+}
+
+// SubnetsResult holds the result of Environ.Subnets.
+type SubnetsResult struct {
+	Subnets []Subnet `json:"subnets"`
+	Error   *Error   `json:"error,omitempty"`
+}
+
+// Space describes a network space known to the provider.
+type Space struct {
+	Name       string   `json:"name"`
+	ProviderId string   `json:"provider-id,omitempty"`
+	Subnets    []Subnet `json:"subnets,omitempty"`
+}
+
+// SpacesResult holds the result of Environ.Spaces.
+type SpacesResult struct {
+	Spaces []Space `json:"spaces"`
+	Error  *Error  `json:"error,omitempty"`
+}
+
+// InstanceArgs holds the arguments for environ calls that operate
+// on a single instance.
+type InstanceArgs struct {
+	Environ    EnvironParams `json:"environ"`
+	InstanceId string        `json:"instance-id"`
+}
+
+// Route describes a static network route.
+type Route struct {
+	DestinationCIDR string `json:"destination-cidr"`
+	GatewayIP       string `json:"gateway-ip"`
+	Metric          int    `json:"metric"`
+}
+
+// NetworkInterface describes a network interface of an instance.
+type NetworkInterface struct {
+	DeviceIndex         int       `json:"device-index"`
+	MACAddress          string    `json:"mac-address,omitempty"`
+	CIDR                string    `json:"cidr,omitempty"`
+	ProviderId          string    `json:"provider-id,omitempty"`
+	ProviderSubnetId    string    `json:"provider-subnet-id,omitempty"`
+	ProviderNetworkId   string    `json:"provider-network-id,omitempty"`
+	ProviderSpaceId     string    `json:"provider-space-id,omitempty"`
+	ProviderVLANId      string    `json:"provider-vlan-id,omitempty"`
+	ProviderAddressId   string    `json:"provider-address-id,omitempty"`
+	AvailabilityZones   []string  `json:"availability-zones,omitempty"`
+	VLANTag             int       `json:"vlan-tag,omitempty"`
+	InterfaceName       string    `json:"interface-name,omitempty"`
+	ParentInterfaceName string    `json:"parent-interface-name,omitempty"`
+	InterfaceType       string    `json:"interface-type,omitempty"`
+	Disabled            bool      `json:"disabled,omitempty"`
+	NoAutoStart         bool      `json:"no-auto-start,omitempty"`
+	ConfigType          string    `json:"config-type,omitempty"`
+	Address             *Address  `json:"address,omitempty"`
+	DNSServers          []Address `json:"dns-servers,omitempty"`
+	MTU                 int       `json:"mtu,omitempty"`
+	DNSSearchDomains    []string  `json:"dns-search-domains,omitempty"`
+	GatewayAddress      *Address  `json:"gateway-address,omitempty"`
+	Routes              []Route   `json:"routes,omitempty"`
+	IsDefaultGateway    bool      `json:"is-default-gateway,omitempty"`
+}
+
+// NetworkInterfacesResult holds the result of Environ.NetworkInterfaces.
+type NetworkInterfacesResult struct {
+	Interfaces []NetworkInterface `json:"interfaces"`
+	Error      *Error             `json:"error,omitempty"`
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package plugin implements environ providers that run out of process,
+// as provider plugins.
+//
+// A provider plugin is an executable named "juju-provider-<type>",
+// where <type> is the provider type it implements, placed in a plugins
+// directory. The plugin serves JSON-RPC (as implemented by the standard
+// library's net/rpc/jsonrpc package) over its stdin and stdout, and
+// writes any logging to stderr. It must exit when stdin is closed.
+// Plugins written in Go should call Serve with an existing
+// environs.CloudEnvironProvider; see cmd/plugins/juju-provider-dummy.
+//
+// The first call on each connection must be Provider.Handshake, which
+// agrees the protocol version. The protocol is stateless: each Environ
+// call carries the cloud spec and model config of the environ it
+// operates on, so plugins may be restarted at any time.
+//
+// Version 1 of the protocol covers the environ provider, the environ
+// lifecycle, instance management, constraints validation and
+// networking. Firewalling, storage, instance types and container
+// address allocation are not supported. Instance user data is rendered
+// by Juju and passed to the plugin.
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/environs"
+)
+
+var logger = loggo.GetLogger("juju.provider.plugin")
+
+const (
+	// ProtocolVersion is the version of the provider plugin
+	// protocol implemented by this package.
+	ProtocolVersion = 1
+
+	// ExecutablePrefix is the prefix of provider plugin executable
+	// names. The remainder of the name is the provider type.
+	ExecutablePrefix = "juju-provider-"
+)
+
+// Discover returns the paths of the provider plugins in dir, keyed by
+// provider type. It is not an error for dir not to exist.
+func Discover(dir string) (map[string]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading provider plugins directory")
+	}
+	plugins := make(map[string]string)
+	for _, info := range infos {
+		name := info.Name()
+		if !strings.HasPrefix(name, ExecutablePrefix) || info.IsDir() {
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			name = strings.TrimSuffix(name, ".exe")
+		} else if info.Mode()&0111 == 0 {
+			logger.Debugf("ignoring non-executable provider plugin %q", name)
+			continue
+		}
+		providerType := strings.TrimPrefix(name, ExecutablePrefix)
+		if providerType == "" {
+			continue
+		}
+		plugins[providerType] = filepath.Join(dir, info.Name())
+	}
+	return plugins, nil
+}
+
+// RegisterPlugins registers the provider plugins in dir with the given
+// registry. Plugins for provider types that are already registered are
+// ignored, so plugins cannot replace the providers built into Juju.
+func RegisterPlugins(registry environs.ProviderRegistry, dir string) error {
+	plugins, err := Discover(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for providerType, path := range plugins {
+		if _, err := registry.Provider(providerType); err == nil {
+			logger.Warningf("ignoring provider plugin %q: provider %q already registered", path, providerType)
+			continue
+		}
+		logger.Debugf("registering provider plugin %q for provider %q", path, providerType)
+		if err := registry.RegisterProvider(NewProvider(providerType, CommandConnector(path)), providerType); err != nil {
+			return errors.Annotatef(err, "registering provider plugin %q", path)
+		}
+	}
+	return nil
+}
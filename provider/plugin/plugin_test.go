@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/plugin"
+)
+
+type pluginSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&pluginSuite{})
+
+func (s *pluginSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS == "windows" {
+		c.Skip("provider plugins are not checked for an executable bit on windows")
+	}
+	s.dir = c.MkDir()
+	s.writeFile(c, "juju-provider-foo", 0755)
+	s.writeFile(c, "juju-provider-bar", 0755)
+	s.writeFile(c, "juju-provider-notexec", 0644)
+	s.writeFile(c, "juju-metadata", 0755)
+	err := os.Mkdir(filepath.Join(s.dir, "juju-provider-dir"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *pluginSuite) writeFile(c *gc.C, name string, mode os.FileMode) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, name), []byte("#!/bin/sh\n"), mode)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *pluginSuite) TestDiscover(c *gc.C) {
+	plugins, err := plugin.Discover(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plugins, jc.DeepEquals, map[string]string{
+		"foo": filepath.Join(s.dir, "juju-provider-foo"),
+		"bar": filepath.Join(s.dir, "juju-provider-bar"),
+	})
+}
+
+func (s *pluginSuite) TestDiscoverMissingDir(c *gc.C) {
+	plugins, err := plugin.Discover(filepath.Join(s.dir, "missing"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plugins, gc.HasLen, 0)
+}
+
+func (s *pluginSuite) TestRegisterPlugins(c *gc.C) {
+	registry := &fakeRegistry{providers: map[string]environs.EnvironProvider{
+		"foo": nil,
+	}}
+	err := plugin.RegisterPlugins(registry, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	// The plugin for the built in provider "foo" is ignored.
+	c.Assert(registry.registered, jc.DeepEquals, []string{"bar"})
+	c.Assert(registry.providers["foo"], gc.IsNil)
+	c.Assert(registry.providers["bar"], gc.NotNil)
+}
+
+func (s *pluginSuite) TestRegisterPluginsError(c *gc.C) {
+	registry := &fakeRegistry{
+		providers: map[string]environs.EnvironProvider{},
+		err:       errors.New("boom"),
+	}
+	err := plugin.RegisterPlugins(registry, s.dir)
+	c.Assert(err, gc.ErrorMatches, `registering provider plugin ".*juju-provider-.*": boom`)
+}
+
+type fakeRegistry struct {
+	environs.ProviderRegistry
+	providers  map[string]environs.EnvironProvider
+	registered []string
+	err        error
+}
+
+func (r *fakeRegistry) RegisterProvider(p environs.EnvironProvider, providerType string, aliases ...string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.providers[providerType] = p
+	r.registered = append(r.registered, providerType)
+	return nil
+}
+
+func (r *fakeRegistry) Provider(providerType string) (environs.EnvironProvider, error) {
+	p, ok := r.providers[providerType]
+	if !ok {
+		return nil, errors.NotFoundf("provider %q", providerType)
+	}
+	return p, nil
+}
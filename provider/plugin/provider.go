@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+// NewProvider returns an environ provider for the given provider type
+// that delegates to the provider plugin reached by connect. The plugin
+// is not started until the provider is first used.
+func NewProvider(providerType string, connect Connector) environs.CloudEnvironProvider {
+	return &environProvider{
+		client: &client{
+			providerType: providerType,
+			connect:      connect,
+		},
+	}
+}
+
+type environProvider struct {
+	client *client
+}
+
+var _ environs.CloudEnvironProvider = (*environProvider)(nil)
+
+// Version is part of the EnvironProvider interface.
+func (p *environProvider) Version() int {
+	version, err := p.client.version()
+	if err != nil {
+		logger.Errorf("getting provider version: %v", err)
+	}
+	return version
+}
+
+// CloudSchema is part of the EnvironProvider interface.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	var result CloudSchemaResult
+	if err := p.client.call("Provider.CloudSchema", struct{}{}, &result); err != nil {
+		logger.Errorf("getting cloud schema: %v", err)
+		return nil
+	}
+	if result.Error != nil {
+		logger.Errorf("getting cloud schema: %v", fromError(result.Error))
+		return nil
+	}
+	if len(result.Schema) == 0 || string(result.Schema) == "null" {
+		return nil
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(result.Schema, &schema); err != nil {
+		logger.Errorf("decoding cloud schema: %v", err)
+		return nil
+	}
+	return &schema
+}
+
+// Ping is part of the EnvironProvider interface.
+func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	var result ErrorResult
+	if err := p.client.call("Provider.Ping", PingArgs{Endpoint: endpoint}, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return fromCallError(ctx, result.Error)
+}
+
+// PrepareConfig is part of the EnvironProvider interface.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	var result ConfigResult
+	if err := p.client.call("Provider.PrepareConfig", EnvironParams{
+		Cloud:  toCloudSpec(args.Cloud),
+		Config: toConfigAttrs(args.Config),
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromError(result.Error)
+	}
+	return fromConfigAttrs(result.Config)
+}
+
+// Validate is part of the config.Validator interface.
+func (p *environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	var result ConfigResult
+	if err := p.client.call("Provider.Validate", ValidateConfigArgs{
+		Config: toConfigAttrs(cfg),
+		Old:    toConfigAttrs(old),
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromError(result.Error)
+	}
+	return fromConfigAttrs(result.Config)
+}
+
+// CredentialSchemas is part of the ProviderCredentials interface.
+func (p *environProvider) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	var result CredentialSchemasResult
+	if err := p.client.call("Provider.CredentialSchemas", struct{}{}, &result); err != nil {
+		logger.Errorf("getting credential schemas: %v", err)
+		return nil
+	}
+	if result.Error != nil {
+		logger.Errorf("getting credential schemas: %v", fromError(result.Error))
+		return nil
+	}
+	schemas := make(map[cloud.AuthType]cloud.CredentialSchema)
+	for authType, attrs := range result.Schemas {
+		schema := make(cloud.CredentialSchema, len(attrs))
+		for i, attr := range attrs {
+			schema[i] = cloud.NamedCredentialAttr{
+				Name: attr.Name,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    attr.Description,
+					Hidden:         attr.Hidden,
+					FileAttr:       attr.FileAttr,
+					FilePath:       attr.FilePath,
+					ExpandFilePath: attr.ExpandFilePath,
+					Optional:       attr.Optional,
+					Options:        attr.Options,
+				},
+			}
+		}
+		schemas[cloud.AuthType(authType)] = schema
+	}
+	return schemas
+}
+
+// DetectCredentials is part of the ProviderCredentials interface.
+func (p *environProvider) DetectCredentials() (*cloud.CloudCredential, error) {
+	var result CloudCredentialResult
+	if err := p.client.call("Provider.DetectCredentials", struct{}{}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromError(result.Error)
+	}
+	if result.Credential == nil {
+		return nil, errors.NotFoundf("credentials")
+	}
+	detected := &cloud.CloudCredential{
+		DefaultCredential: result.Credential.DefaultCredential,
+		DefaultRegion:     result.Credential.DefaultRegion,
+		AuthCredentials:   make(map[string]cloud.Credential),
+	}
+	for name, cred := range result.Credential.AuthCredentials {
+		detected.AuthCredentials[name] = fromCredential(cred)
+	}
+	return detected, nil
+}
+
+// FinalizeCredential is part of the ProviderCredentials interface.
+// Plugins cannot interact with the user, so ctx is not used.
+func (p *environProvider) FinalizeCredential(
+	ctx environs.FinalizeCredentialContext,
+	args environs.FinalizeCredentialParams,
+) (*cloud.Credential, error) {
+	var result CredentialResult
+	if err := p.client.call("Provider.FinalizeCredential", FinalizeCredentialArgs{
+		Credential:            toCredential(args.Credential),
+		CloudEndpoint:         args.CloudEndpoint,
+		CloudStorageEndpoint:  args.CloudStorageEndpoint,
+		CloudIdentityEndpoint: args.CloudIdentityEndpoint,
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromError(result.Error)
+	}
+	if result.Credential == nil {
+		return nil, nil
+	}
+	cred := fromCredential(*result.Credential)
+	return &cred, nil
+}
+
+// Open is part of the CloudEnvironProvider interface. The plugin
+// is asked to open the environ, so that it may reject an invalid
+// cloud spec or config, but it need not retain it.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	env := &environ{
+		provider: p,
+		cloud:    args.Cloud,
+		cfg:      args.Config,
+	}
+	var result ErrorResult
+	if err := p.client.call("Provider.Open", env.params(), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, fromError(result.Error)
+	}
+	return env, nil
+}
@@ -0,0 +1,645 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// UserDataStarter may be implemented by an Environ served by Serve,
+// to start instances with the user data rendered by Juju.
+//
+// Environs that do not implement UserDataStarter have StartInstance
+// called with an instance config holding only the machine ID, nonce,
+// series, jobs and agent binaries, from which working user data cannot
+// be rendered. That is enough only for providers, such as dummy, that
+// do not run the instances they start.
+type UserDataStarter interface {
+	StartInstanceWithUserData(
+		ctx context.ProviderCallContext,
+		args environs.StartInstanceParams,
+		userData []byte,
+	) (*environs.StartInstanceResult, error)
+}
+
+// Serve serves the given provider as a provider plugin over stdin
+// and stdout, returning when stdin is closed. It is intended to be
+// called from the main function of a plugin executable.
+func Serve(providerType string, provider environs.CloudEnvironProvider) error {
+	stdout := os.Stdout
+	// Anything else written to stdout would corrupt the protocol
+	// stream, so send it to stderr, where it will be logged.
+	os.Stdout = os.Stderr
+	return ServeConn(providerType, provider, &stdioConn{
+		Reader: os.Stdin,
+		Writer: stdout,
+	})
+}
+
+type stdioConn struct {
+	io.Reader
+	io.Writer
+}
+
+// Close is part of the io.Closer interface.
+func (*stdioConn) Close() error {
+	return nil
+}
+
+// ServeConn serves the given provider as a provider plugin over
+// the given connection, returning when the connection is closed.
+func ServeConn(providerType string, provider environs.CloudEnvironProvider, conn io.ReadWriteCloser) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName("Provider", &providerServer{
+		providerType: providerType,
+		provider:     provider,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if err := server.RegisterName("Environ", &environServer{
+		provider: provider,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	return nil
+}
+
+// callContext is the context.ProviderCallContext passed to the served
+// provider, recording whether the provider invalidated the credential.
+type callContext struct {
+	invalidReason string
+}
+
+// InvalidateCredential is part of the context.ProviderCallContext interface.
+func (c *callContext) InvalidateCredential(reason string) error {
+	c.invalidReason = reason
+	return nil
+}
+
+// toError converts an error returned by a call made with the context
+// to its wire representation.
+func (c *callContext) toError(err error) *Error {
+	result := toError(err)
+	if result != nil && c.invalidReason != "" {
+		result.Code = CodeCredentialNotValid
+	}
+	return result
+}
+
+// pluginContext is the environs.BootstrapContext passed to the served
+// provider. Plugins cannot interact with the user, and stdout carries
+// the protocol stream, so all output is sent to stderr.
+type pluginContext struct {
+	verifyCredentials bool
+}
+
+func (*pluginContext) GetStdin() io.Reader                         { return bytes.NewReader(nil) }
+func (*pluginContext) GetStdout() io.Writer                        { return os.Stderr }
+func (*pluginContext) GetStderr() io.Writer                        { return os.Stderr }
+func (*pluginContext) Infof(format string, args ...interface{})    { logger.Infof(format, args...) }
+func (*pluginContext) Verbosef(format string, args ...interface{}) { logger.Debugf(format, args...) }
+func (*pluginContext) InterruptNotify(chan<- os.Signal)            {}
+func (*pluginContext) StopInterruptNotify(chan<- os.Signal)        {}
+func (c *pluginContext) ShouldVerifyCredentials() bool             { return c.verifyCredentials }
+
+// providerServer serves the Provider methods of the protocol.
+type providerServer struct {
+	providerType string
+	provider     environs.CloudEnvironProvider
+}
+
+// Handshake returns the protocol version and provider type served.
+func (s *providerServer) Handshake(args HandshakeArgs, result *HandshakeResult) error {
+	result.ProtocolVersion = ProtocolVersion
+	result.ProviderType = s.providerType
+	result.ProviderVersion = s.provider.Version()
+	if args.ProtocolVersion != ProtocolVersion {
+		result.Error = toError(errors.NotSupportedf("protocol version %d", args.ProtocolVersion))
+	}
+	return nil
+}
+
+// CloudSchema returns the provider's cloud schema.
+func (s *providerServer) CloudSchema(_ struct{}, result *CloudSchemaResult) error {
+	schema := s.provider.CloudSchema()
+	if schema == nil {
+		return nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	result.Schema = data
+	return nil
+}
+
+// Ping tests the connection to the cloud.
+func (s *providerServer) Ping(args PingArgs, result *ErrorResult) error {
+	ctx := &callContext{}
+	result.Error = ctx.toError(s.provider.Ping(ctx, args.Endpoint))
+	return nil
+}
+
+// PrepareConfig prepares the configuration for a new model.
+func (s *providerServer) PrepareConfig(args EnvironParams, result *ConfigResult) error {
+	cfg, err := fromConfigAttrs(args.Config)
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	cfg, err = s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Cloud:  fromCloudSpec(args.Cloud),
+		Config: cfg,
+	})
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	result.Config = toConfigAttrs(cfg)
+	return nil
+}
+
+// Validate validates a model config, and changes to it.
+func (s *providerServer) Validate(args ValidateConfigArgs, result *ConfigResult) error {
+	cfg, err := fromConfigAttrs(args.Config)
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	old, err := fromConfigAttrs(args.Old)
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	valid, err := s.provider.Validate(cfg, old)
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	result.Config = toConfigAttrs(valid)
+	return nil
+}
+
+// CredentialSchemas returns the provider's credential schemas.
+func (s *providerServer) CredentialSchemas(_ struct{}, result *CredentialSchemasResult) error {
+	result.Schemas = make(map[string][]CredentialAttr)
+	for authType, schema := range s.provider.CredentialSchemas() {
+		attrs := make([]CredentialAttr, len(schema))
+		for i, attr := range schema {
+			attrs[i] = CredentialAttr{
+				Name:           attr.Name,
+				Description:    attr.Description,
+				Hidden:         attr.Hidden,
+				FileAttr:       attr.FileAttr,
+				FilePath:       attr.FilePath,
+				ExpandFilePath: attr.ExpandFilePath,
+				Optional:       attr.Optional,
+				Options:        attr.Options,
+			}
+		}
+		result.Schemas[string(authType)] = attrs
+	}
+	return nil
+}
+
+// DetectCredentials detects credentials for the cloud.
+func (s *providerServer) DetectCredentials(_ struct{}, result *CloudCredentialResult) error {
+	detected, err := s.provider.DetectCredentials()
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	result.Credential = &CloudCredential{
+		DefaultCredential: detected.DefaultCredential,
+		DefaultRegion:     detected.DefaultRegion,
+		AuthCredentials:   make(map[string]Credential),
+	}
+	for name, cred := range detected.AuthCredentials {
+		result.Credential.AuthCredentials[name] = toCredential(cred)
+	}
+	return nil
+}
+
+// FinalizeCredential finalizes a credential for use.
+func (s *providerServer) FinalizeCredential(args FinalizeCredentialArgs, result *CredentialResult) error {
+	cred, err := s.provider.FinalizeCredential(&pluginContext{}, environs.FinalizeCredentialParams{
+		Credential:            fromCredential(args.Credential),
+		CloudEndpoint:         args.CloudEndpoint,
+		CloudStorageEndpoint:  args.CloudStorageEndpoint,
+		CloudIdentityEndpoint: args.CloudIdentityEndpoint,
+	})
+	if err != nil {
+		result.Error = toError(err)
+		return nil
+	}
+	if cred != nil {
+		finalized := toCredential(*cred)
+		result.Credential = &finalized
+	}
+	return nil
+}
+
+// Open checks that an environ can be opened with the given
+// cloud spec and model config.
+func (s *providerServer) Open(args EnvironParams, result *ErrorResult) error {
+	_, err := openEnviron(s.provider, args)
+	result.Error = toError(err)
+	return nil
+}
+
+func openEnviron(provider environs.CloudEnvironProvider, args EnvironParams) (environs.Environ, error) {
+	cfg, err := fromConfigAttrs(args.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return provider.Open(environs.OpenParams{
+		Cloud:  fromCloudSpec(args.Cloud),
+		Config: cfg,
+	})
+}
+
+// environServer serves the Environ methods of the protocol. The
+// environ is opened afresh for each call.
+type environServer struct {
+	provider environs.CloudEnvironProvider
+}
+
+// environCall opens the environ identified by args and calls f with
+// it, returning the wire representation of any error.
+func (s *environServer) environCall(args EnvironParams, f func(*callContext, environs.Environ) error) *Error {
+	env, err := openEnviron(s.provider, args)
+	if err != nil {
+		return toError(err)
+	}
+	ctx := &callContext{}
+	return ctx.toError(f(ctx, env))
+}
+
+// networkingCall is like environCall, for environs that
+// implement environs.Networking.
+func (s *environServer) networkingCall(args EnvironParams, f func(*callContext, environs.Networking) error) *Error {
+	return s.environCall(args, func(ctx *callContext, env environs.Environ) error {
+		netEnv, ok := environs.SupportsNetworking(env)
+		if !ok {
+			return errors.NotSupportedf("networking")
+		}
+		return f(ctx, netEnv)
+	})
+}
+
+// PrepareForBootstrap prepares the environ for bootstrapping.
+func (s *environServer) PrepareForBootstrap(args PrepareForBootstrapArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(_ *callContext, env environs.Environ) error {
+		return env.PrepareForBootstrap(&pluginContext{verifyCredentials: args.VerifyCredentials})
+	})
+	return nil
+}
+
+// Create creates the environ for a new hosted model.
+func (s *environServer) Create(args ControllerArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		return env.Create(ctx, environs.CreateParams{ControllerUUID: args.ControllerUUID})
+	})
+	return nil
+}
+
+// Destroy destroys the environ.
+func (s *environServer) Destroy(args EnvironParams, result *ErrorResult) error {
+	result.Error = s.environCall(args, func(ctx *callContext, env environs.Environ) error {
+		return env.Destroy(ctx)
+	})
+	return nil
+}
+
+// DestroyController destroys the controller's environ, and
+// any resources relating to its hosted models.
+func (s *environServer) DestroyController(args ControllerArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		return env.DestroyController(ctx, args.ControllerUUID)
+	})
+	return nil
+}
+
+// AdoptResources adopts the environ's resources into a new controller.
+func (s *environServer) AdoptResources(args AdoptResourcesArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		sourceVersion, err := version.Parse(args.SourceVersion)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return env.AdoptResources(ctx, args.ControllerUUID, sourceVersion)
+	})
+	return nil
+}
+
+// PrecheckInstance checks that an instance could be started.
+func (s *environServer) PrecheckInstance(args PrecheckInstanceArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		return env.PrecheckInstance(ctx, environs.PrecheckInstanceParams{
+			Series:      args.Series,
+			Constraints: args.Constraints,
+			Placement:   args.Placement,
+		})
+	})
+	return nil
+}
+
+func constraintsValidator(ctx *callContext, env environs.Environ, args ConstraintsValidatorParams) (constraints.Validator, error) {
+	validator, err := env.ConstraintsValidator(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(args.Unsupported) > 0 {
+		validator.RegisterUnsupported(args.Unsupported)
+	}
+	for _, conflict := range args.Conflicts {
+		validator.RegisterConflicts(conflict.Reds, conflict.Blues)
+	}
+	for _, vocab := range args.Vocabulary {
+		if vocab.Update {
+			validator.UpdateVocabulary(vocab.Attribute, vocab.Values)
+		} else {
+			validator.RegisterVocabulary(vocab.Attribute, vocab.Values)
+		}
+	}
+	return validator, nil
+}
+
+// ValidateConstraints validates constraints with the environ's
+// constraints validator.
+func (s *environServer) ValidateConstraints(args ValidateConstraintsArgs, result *ValidateConstraintsResult) error {
+	result.Error = s.environCall(args.Validator.Environ, func(ctx *callContext, env environs.Environ) error {
+		validator, err := constraintsValidator(ctx, env, args.Validator)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Unsupported, err = validator.Validate(args.Constraints)
+		return err
+	})
+	return nil
+}
+
+// MergeConstraints merges constraints with the environ's
+// constraints validator.
+func (s *environServer) MergeConstraints(args MergeConstraintsArgs, result *MergeConstraintsResult) error {
+	result.Error = s.environCall(args.Validator.Environ, func(ctx *callContext, env environs.Environ) error {
+		validator, err := constraintsValidator(ctx, env, args.Validator)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Constraints, err = validator.Merge(args.Fallback, args.Constraints)
+		return err
+	})
+	return nil
+}
+
+// StartInstance starts an instance.
+func (s *environServer) StartInstance(args StartInstanceArgs, result *StartInstanceResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		tools, err := fromTools(args.Tools)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		icfg := &instancecfg.InstanceConfig{
+			MachineId:    args.MachineId,
+			MachineNonce: args.MachineNonce,
+			Series:       args.Series,
+			APIInfo: &api.Info{
+				Tag:      names.NewMachineTag(args.MachineId),
+				ModelTag: names.NewModelTag(env.Config().UUID()),
+			},
+		}
+		for _, job := range args.Jobs {
+			icfg.Jobs = append(icfg.Jobs, multiwatcher.MachineJob(job))
+		}
+		if err := icfg.SetTools(tools); err != nil {
+			return errors.Trace(err)
+		}
+		startArgs := environs.StartInstanceParams{
+			ControllerUUID:   args.ControllerUUID,
+			Constraints:      args.Constraints,
+			Tools:            tools,
+			InstanceConfig:   icfg,
+			Placement:        args.Placement,
+			AvailabilityZone: args.AvailabilityZone,
+		}
+		if len(args.SubnetsToZones) > 0 {
+			startArgs.SubnetsToZones = make(map[network.Id][]string)
+			for id, zones := range args.SubnetsToZones {
+				startArgs.SubnetsToZones[network.Id(id)] = zones
+			}
+		}
+		var started *environs.StartInstanceResult
+		if starter, ok := env.(UserDataStarter); ok {
+			started, err = starter.StartInstanceWithUserData(ctx, startArgs, args.UserData)
+		} else {
+			started, err = env.StartInstance(ctx, startArgs)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Instance, err = toInstance(ctx, started.Instance)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Hardware = started.Hardware
+		return nil
+	})
+	return nil
+}
+
+func toInstance(ctx context.ProviderCallContext, inst instance.Instance) (*Instance, error) {
+	addrs, err := inst.Addresses(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := inst.Status(ctx)
+	return &Instance{
+		Id:        string(inst.Id()),
+		Status:    string(status.Status),
+		Message:   status.Message,
+		Addresses: toAddresses(addrs),
+	}, nil
+}
+
+// toInstances converts instances to their wire representation,
+// leaving null entries for missing instances.
+func toInstances(ctx context.ProviderCallContext, insts []instance.Instance) ([]*Instance, error) {
+	result := make([]*Instance, len(insts))
+	for i, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		var err error
+		if result[i], err = toInstance(ctx, inst); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// StopInstances stops instances.
+func (s *environServer) StopInstances(args InstanceIdsArgs, result *ErrorResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		ids := make([]instance.Id, len(args.Ids))
+		for i, id := range args.Ids {
+			ids[i] = instance.Id(id)
+		}
+		return env.StopInstances(ctx, ids...)
+	})
+	return nil
+}
+
+// AllInstances returns all of the environ's instances.
+func (s *environServer) AllInstances(args EnvironParams, result *InstancesResult) error {
+	result.Error = s.environCall(args, func(ctx *callContext, env environs.Environ) error {
+		insts, err := env.AllInstances(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Instances, err = toInstances(ctx, insts)
+		return errors.Trace(err)
+	})
+	return nil
+}
+
+// Instances returns the instances with the given ids.
+func (s *environServer) Instances(args InstanceIdsArgs, result *InstancesResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		ids := make([]instance.Id, len(args.Ids))
+		for i, id := range args.Ids {
+			ids[i] = instance.Id(id)
+		}
+		insts, err := env.Instances(ctx, ids)
+		if err != nil && err != environs.ErrPartialInstances {
+			return err
+		}
+		var convertErr error
+		if result.Instances, convertErr = toInstances(ctx, insts); convertErr != nil {
+			return errors.Trace(convertErr)
+		}
+		return err
+	})
+	return nil
+}
+
+// ControllerInstances returns the ids of the controller's instances.
+func (s *environServer) ControllerInstances(args ControllerArgs, result *InstanceIdsResult) error {
+	result.Error = s.environCall(args.Environ, func(ctx *callContext, env environs.Environ) error {
+		ids, err := env.ControllerInstances(ctx, args.ControllerUUID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			result.Ids = append(result.Ids, string(id))
+		}
+		return nil
+	})
+	return nil
+}
+
+// Subnets returns the subnets known to the provider.
+func (s *environServer) Subnets(args SubnetsArgs, result *SubnetsResult) error {
+	result.Error = s.networkingCall(args.Environ, func(ctx *callContext, env environs.Networking) error {
+		subnetIds := make([]network.Id, len(args.SubnetIds))
+		for i, id := range args.SubnetIds {
+			subnetIds[i] = network.Id(id)
+		}
+		subnets, err := env.Subnets(ctx, instance.Id(args.InstanceId), subnetIds)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Subnets = toSubnets(subnets)
+		return nil
+	})
+	return nil
+}
+
+// SuperSubnets returns the subnets that contain the environ's subnets.
+func (s *environServer) SuperSubnets(args EnvironParams, result *StringsResult) error {
+	result.Error = s.networkingCall(args, func(ctx *callContext, env environs.Networking) (err error) {
+		result.Result, err = env.SuperSubnets(ctx)
+		return errors.Trace(err)
+	})
+	return nil
+}
+
+// NetworkInterfaces returns the network interfaces of an instance.
+func (s *environServer) NetworkInterfaces(args InstanceArgs, result *NetworkInterfacesResult) error {
+	result.Error = s.networkingCall(args.Environ, func(ctx *callContext, env environs.Networking) error {
+		interfaces, err := env.NetworkInterfaces(ctx, instance.Id(args.InstanceId))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Interfaces = make([]NetworkInterface, len(interfaces))
+		for i, iface := range interfaces {
+			result.Interfaces[i] = toNetworkInterface(iface)
+		}
+		return nil
+	})
+	return nil
+}
+
+// SupportsSpaces reports whether the environ supports spaces.
+func (s *environServer) SupportsSpaces(args EnvironParams, result *BoolResult) error {
+	result.Error = s.environCall(args, func(ctx *callContext, env environs.Environ) (err error) {
+		if netEnv, ok := environs.SupportsNetworking(env); ok {
+			result.Result, err = netEnv.SupportsSpaces(ctx)
+		}
+		return errors.Trace(err)
+	})
+	return nil
+}
+
+// SupportsSpaceDiscovery reports whether the environ supports
+// discovering spaces from the provider.
+func (s *environServer) SupportsSpaceDiscovery(args EnvironParams, result *BoolResult) error {
+	result.Error = s.environCall(args, func(ctx *callContext, env environs.Environ) (err error) {
+		if netEnv, ok := environs.SupportsNetworking(env); ok {
+			result.Result, err = netEnv.SupportsSpaceDiscovery(ctx)
+		}
+		return errors.Trace(err)
+	})
+	return nil
+}
+
+// Spaces returns the spaces known to the provider.
+func (s *environServer) Spaces(args EnvironParams, result *SpacesResult) error {
+	result.Error = s.networkingCall(args, func(ctx *callContext, env environs.Networking) error {
+		spaces, err := env.Spaces(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Spaces = make([]Space, len(spaces))
+		for i, space := range spaces {
+			result.Spaces[i] = Space{
+				Name:       space.Name,
+				ProviderId: string(space.ProviderId),
+				Subnets:    toSubnets(space.Subnets),
+			}
+		}
+		return nil
+	})
+	return nil
+}
@@ -285,6 +285,35 @@ func newActionDoc(mb modelBackend, receiverTag names.Tag, actionName string, par
 		}, nil
 }
 
+// enqueueActionOps returns the document of a new action, and the
+// operations required to enqueue it on the receiver, which must not
+// be dead.
+func enqueueActionOps(st *State, receiver names.Tag, actionName string, payload map[string]interface{}) (actionDoc, []txn.Op, error) {
+	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+	return doc, []txn.Op{{
+		C:      receiverCollectionName,
+		Id:     receiverId,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}}, nil
+}
+
 var ensureActionMarker = ensureSuffixFn(actionMarker)
 
 // Action returns an Action by Id, which is a UUID.
@@ -380,27 +409,11 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload)
+	doc, ops, err := enqueueActionOps(m.st, receiver, actionName, payload)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	ops := []txn.Op{{
-		C:      receiverCollectionName,
-		Id:     receiverId,
-		Assert: notDeadDoc,
-	}, {
-		C:      actionsC,
-		Id:     doc.DocId,
-		Assert: txn.DocMissing,
-		Insert: doc,
-	}, {
-		C:      actionNotificationsC,
-		Id:     ndoc.DocId,
-		Assert: txn.DocMissing,
-		Insert: ndoc,
-	}}
-
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
 			return nil, err
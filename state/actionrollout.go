@@ -0,0 +1,396 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ActionRolloutStatus describes the progress of an action rollout.
+type ActionRolloutStatus string
+
+const (
+	// ActionRolloutRunning indicates that the rollout is still
+	// enqueueing actions, or waiting for enqueued actions to finish.
+	ActionRolloutRunning ActionRolloutStatus = "running"
+
+	// ActionRolloutCompleted indicates that the action has been
+	// enqueued on every unit, and every enqueued action has finished.
+	ActionRolloutCompleted ActionRolloutStatus = "completed"
+
+	// ActionRolloutStopped indicates that the rollout was halted
+	// because too many of its actions failed. Actions already
+	// enqueued are not affected, but no more are enqueued.
+	ActionRolloutStopped ActionRolloutStatus = "stopped"
+)
+
+// AddActionRolloutParams contains the parameters for adding an action
+// rollout.
+type AddActionRolloutParams struct {
+	// Application is the name of the application whose units the
+	// action is enqueued on.
+	Application string
+
+	// Action is the name of the action to enqueue.
+	Action string
+
+	// Parameters holds the parameters for the action.
+	Parameters map[string]interface{}
+
+	// MaxParallel is the maximum number of the rollout's actions
+	// that may be pending or running at once. If zero, the action is
+	// enqueued on every unit at once.
+	MaxParallel int
+
+	// StopOnFailures is the number of failed actions after which the
+	// rollout stops enqueueing actions. If zero, the rollout runs to
+	// completion regardless of failures.
+	StopOnFailures int
+}
+
+// ActionRollout enqueues an action on the units of an application in
+// batches, waiting for the actions in one batch to finish before
+// enqueueing the next.
+type ActionRollout struct {
+	st  *State
+	doc actionRolloutDoc
+}
+
+// actionRolloutDoc records the progress of an action rollout.
+type actionRolloutDoc struct {
+	DocID          string                 `bson:"_id"`
+	ModelUUID      string                 `bson:"model-uuid"`
+	Id             string                 `bson:"id"`
+	Application    string                 `bson:"application"`
+	Action         string                 `bson:"action"`
+	Parameters     map[string]interface{} `bson:"parameters,omitempty"`
+	MaxParallel    int                    `bson:"max-parallel"`
+	StopOnFailures int                    `bson:"stop-on-failures"`
+	Status         ActionRolloutStatus    `bson:"status"`
+	Created        time.Time              `bson:"created"`
+	Finished       time.Time              `bson:"finished"`
+	ActionIds      []string               `bson:"action-ids"`
+	PendingUnits   []string               `bson:"pending-units"`
+	FailedUnits    []string               `bson:"failed-units,omitempty"`
+}
+
+// Id returns the ID of the rollout.
+func (r *ActionRollout) Id() string {
+	return r.doc.Id
+}
+
+// Application returns the name of the application whose units the
+// action is enqueued on.
+func (r *ActionRollout) Application() string {
+	return r.doc.Application
+}
+
+// Action returns the name of the enqueued action.
+func (r *ActionRollout) Action() string {
+	return r.doc.Action
+}
+
+// Parameters returns the parameters of the enqueued action.
+func (r *ActionRollout) Parameters() map[string]interface{} {
+	return r.doc.Parameters
+}
+
+// MaxParallel returns the maximum number of the rollout's actions that
+// may be pending or running at once, or zero if there is no limit.
+func (r *ActionRollout) MaxParallel() int {
+	return r.doc.MaxParallel
+}
+
+// StopOnFailures returns the number of failed actions after which the
+// rollout stops, or zero if the rollout never stops early.
+func (r *ActionRollout) StopOnFailures() int {
+	return r.doc.StopOnFailures
+}
+
+// Status returns the status of the rollout.
+func (r *ActionRollout) Status() ActionRolloutStatus {
+	return r.doc.Status
+}
+
+// Created returns the time at which the rollout was added.
+func (r *ActionRollout) Created() time.Time {
+	return r.doc.Created
+}
+
+// Finished returns the time at which the rollout completed or
+// stopped, or the zero time if it is still running.
+func (r *ActionRollout) Finished() time.Time {
+	return r.doc.Finished
+}
+
+// ActionIds returns the IDs of the actions enqueued by the rollout,
+// in the order in which they were enqueued.
+func (r *ActionRollout) ActionIds() []string {
+	return r.doc.ActionIds
+}
+
+// PendingUnits returns the names of the units on which the rollout
+// has yet to enqueue the action.
+func (r *ActionRollout) PendingUnits() []string {
+	return r.doc.PendingUnits
+}
+
+// FailedUnits returns the names of the units on which the rollout
+// could not enqueue the action. Each counts as a failed action.
+func (r *ActionRollout) FailedUnits() []string {
+	return r.doc.FailedUnits
+}
+
+// ActionRollout returns the action rollout with the specified ID.
+func (st *State) ActionRollout(id string) (*ActionRollout, error) {
+	coll, closer := st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var doc actionRolloutDoc
+	if err := coll.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action rollout %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "querying action rollout %q", id)
+	}
+	return &ActionRollout{st, doc}, nil
+}
+
+// AddActionRollout adds a rollout that enqueues the action on every
+// alive unit of the application, at most MaxParallel at a time, and
+// enqueues the first batch of actions. Later batches are enqueued by
+// ProgressActionRollouts as earlier actions finish.
+func (st *State) AddActionRollout(args AddActionRolloutParams) (_ *ActionRollout, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add action rollout for application %q", args.Application)
+
+	if args.MaxParallel < 0 {
+		return nil, errors.NotValidf("max parallel %d", args.MaxParallel)
+	}
+	if args.StopOnFailures < 0 {
+		return nil, errors.NotValidf("stop on failures %d", args.StopOnFailures)
+	}
+	if err := checkModelActive(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := st.Application(args.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", args.Application)
+	}
+	if err := validateScheduledAction(app, args.Action, args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitNames []string
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	if len(unitNames) == 0 {
+		return nil, errors.Errorf("application %q has no alive units", args.Application)
+	}
+	naturalsort.Sort(unitNames)
+
+	seq, err := sequence(st, "actionrollout")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionRolloutDoc{
+		DocID:          id,
+		ModelUUID:      st.ModelUUID(),
+		Id:             id,
+		Application:    args.Application,
+		Action:         args.Action,
+		Parameters:     args.Parameters,
+		MaxParallel:    args.MaxParallel,
+		StopOnFailures: args.StopOnFailures,
+		Status:         ActionRolloutRunning,
+		Created:        st.clock().Now(),
+		ActionIds:      []string{},
+		PendingUnits:   unitNames,
+	}
+	ops := []txn.Op{{
+		C:      actionRolloutsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := st.progressActionRollout(doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.ActionRollout(id)
+}
+
+// ProgressActionRollouts enqueues the next actions of every running
+// action rollout for which earlier actions have finished, and records
+// rollouts that have completed or stopped. Failure to progress one
+// rollout is logged, and does not prevent the others progressing.
+func (st *State) ProgressActionRollouts() error {
+	coll, closer := st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var docs []actionRolloutDoc
+	if err := coll.Find(bson.D{{"status", ActionRolloutRunning}}).All(&docs); err != nil {
+		return errors.Annotate(err, "querying running action rollouts")
+	}
+	for _, doc := range docs {
+		if err := st.progressActionRollout(doc); err != nil {
+			actionLogger.Errorf("progressing action rollout %q: %v", doc.Id, err)
+		}
+	}
+	return nil
+}
+
+// progressActionRollout enqueues as many of the rollout's pending
+// actions as its parallelism limit allows, or updates the rollout's
+// status if it has completed or failed too often. The actions are
+// enqueued in the same transaction that updates the rollout, so that
+// concurrent attempts to progress it cannot enqueue any action twice.
+func (st *State) progressActionRollout(doc actionRolloutDoc) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			rollout, err := st.ActionRollout(doc.Id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			doc = rollout.doc
+			if doc.Status != ActionRolloutRunning {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return st.progressActionRolloutOps(doc)
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// progressActionRolloutOps returns the operations required to
+// progress the rollout, as described by progressActionRollout.
+func (st *State) progressActionRolloutOps(doc actionRolloutDoc) ([]txn.Op, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unfinished := 0
+	failures := len(doc.FailedUnits)
+	for _, id := range doc.ActionIds {
+		action, err := m.Action(id)
+		if errors.IsNotFound(err) {
+			// The action has been pruned, so it must have
+			// finished long ago.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch action.Status() {
+		case ActionPending, ActionRunning:
+			unfinished++
+		case ActionFailed, ActionCancelled:
+			failures++
+		}
+	}
+
+	set := bson.D{}
+	pending := doc.PendingUnits
+	failed := doc.FailedUnits
+	var (
+		actionIds []string
+		ops       []txn.Op
+	)
+	if doc.StopOnFailures > 0 && failures >= doc.StopOnFailures {
+		set = append(set,
+			bson.DocElem{"status", ActionRolloutStopped},
+			bson.DocElem{"finished", st.clock().Now()},
+		)
+	} else {
+		for len(pending) > 0 && (doc.MaxParallel == 0 || unfinished < doc.MaxParallel) {
+			unitName := pending[0]
+			pending = pending[1:]
+			unit, err := st.Unit(unitName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if unit.Life() != Alive {
+				continue
+			}
+			payload, err := unit.validateActionPayload(doc.Action, doc.Parameters)
+			var (
+				adoc      actionDoc
+				actionOps []txn.Op
+			)
+			if err == nil {
+				adoc, actionOps, err = enqueueActionOps(st, unit.Tag(), doc.Action, payload)
+			}
+			if err != nil {
+				actionLogger.Warningf(
+					"action rollout %q: cannot enqueue action %q on unit %q: %v",
+					doc.Id, doc.Action, unitName, err,
+				)
+				failed = append(failed, unitName)
+				failures++
+				if doc.StopOnFailures > 0 && failures >= doc.StopOnFailures {
+					break
+				}
+				continue
+			}
+			actionIds = append(actionIds, st.localID(adoc.DocId))
+			ops = append(ops, actionOps...)
+			unfinished++
+		}
+		switch {
+		case doc.StopOnFailures > 0 && failures >= doc.StopOnFailures:
+			set = append(set,
+				bson.DocElem{"status", ActionRolloutStopped},
+				bson.DocElem{"finished", st.clock().Now()},
+			)
+		case len(pending) == 0 && unfinished == 0:
+			set = append(set,
+				bson.DocElem{"status", ActionRolloutCompleted},
+				bson.DocElem{"finished", st.clock().Now()},
+			)
+		}
+	}
+	if len(set) == 0 && len(pending) == len(doc.PendingUnits) {
+		return nil, jujutxn.ErrNoOperations
+	}
+
+	set = append(set,
+		bson.DocElem{"pending-units", pending},
+		bson.DocElem{"failed-units", failed},
+	)
+	update := bson.D{{"$set", set}}
+	if len(actionIds) > 0 {
+		update = append(update, bson.DocElem{
+			"$push", bson.D{{"action-ids", bson.D{{"$each", actionIds}}}},
+		})
+	}
+	ops = append(ops, txn.Op{
+		C:  actionRolloutsC,
+		Id: doc.DocID,
+		Assert: bson.D{
+			{"status", ActionRolloutRunning},
+			{"pending-units", doc.PendingUnits},
+		},
+		Update: update,
+	})
+	return ops, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionRolloutSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&ActionRolloutSuite{})
+
+func (s *ActionRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	for i := 0; i < 4; i++ {
+		_, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionRolloutSuite) addRollout(c *gc.C, maxParallel, stopOnFailures int) *state.ActionRollout {
+	rollout, err := s.State.AddActionRollout(state.AddActionRolloutParams{
+		Application:    "dummy",
+		Action:         "snapshot",
+		Parameters:     map[string]interface{}{"outfile": "out.bz2"},
+		MaxParallel:    maxParallel,
+		StopOnFailures: stopOnFailures,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return rollout
+}
+
+func (s *ActionRolloutSuite) refresh(c *gc.C, rollout *state.ActionRollout) *state.ActionRollout {
+	rollout, err := s.State.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	return rollout
+}
+
+func (s *ActionRolloutSuite) finishActions(c *gc.C, status state.ActionStatus, ids ...string) {
+	for _, id := range ids {
+		action, err := s.Model.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionRolloutSuite) actionReceivers(c *gc.C, ids []string) []string {
+	var receivers []string
+	for _, id := range ids {
+		action, err := s.Model.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(action.Name(), gc.Equals, "snapshot")
+		receivers = append(receivers, action.Receiver())
+	}
+	return receivers
+}
+
+func (s *ActionRolloutSuite) TestAddActionRollout(c *gc.C) {
+	rollout := s.addRollout(c, 0, 0)
+	c.Assert(rollout.Id(), gc.Equals, "0")
+	c.Assert(rollout.Application(), gc.Equals, "dummy")
+	c.Assert(rollout.Action(), gc.Equals, "snapshot")
+	c.Assert(rollout.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutRunning)
+	c.Assert(rollout.PendingUnits(), gc.HasLen, 0)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{
+		"dummy/0", "dummy/1", "dummy/2", "dummy/3",
+	})
+
+	rollout = s.addRollout(c, 0, 0)
+	c.Assert(rollout.Id(), gc.Equals, "1")
+}
+
+func (s *ActionRolloutSuite) TestAddActionRolloutInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.AddActionRolloutParams
+		err  string
+	}{{
+		args: state.AddActionRolloutParams{Application: "dummy", Action: "snapshot", MaxParallel: -1},
+		err:  `cannot add action rollout for application "dummy": max parallel -1 not valid`,
+	}, {
+		args: state.AddActionRolloutParams{Application: "dummy", Action: "snapshot", StopOnFailures: -1},
+		err:  `cannot add action rollout for application "dummy": stop on failures -1 not valid`,
+	}, {
+		args: state.AddActionRolloutParams{Application: "dummy", Action: "missing"},
+		err:  `cannot add action rollout for application "dummy": action "missing" not defined on application "dummy"`,
+	}, {
+		args: state.AddActionRolloutParams{Application: "missing", Action: "snapshot"},
+		err:  `cannot add action rollout for application "missing": application "missing" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionRollout(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionRolloutSuite) TestAddActionRolloutNoUnits(c *gc.C) {
+	s.AddTestingApplication(c, "empty", s.AddTestingCharm(c, "dummy"))
+	_, err := s.State.AddActionRollout(state.AddActionRolloutParams{
+		Application: "empty",
+		Action:      "snapshot",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add action rollout for application "empty": application "empty" has no alive units`)
+}
+
+func (s *ActionRolloutSuite) TestProgressActionRollouts(c *gc.C) {
+	rollout := s.addRollout(c, 2, 0)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"dummy/2", "dummy/3"})
+
+	// Nothing changes until an action finishes.
+	err := s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+
+	// Failures don't stop the rollout when no limit is set.
+	s.finishActions(c, state.ActionFailed, rollout.ActionIds()[0])
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1", "dummy/2"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"dummy/3"})
+
+	s.finishActions(c, state.ActionCompleted, rollout.ActionIds()[1:]...)
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 4)
+	c.Assert(rollout.PendingUnits(), gc.HasLen, 0)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutRunning)
+
+	s.finishActions(c, state.ActionCompleted, rollout.ActionIds()[3])
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutCompleted)
+	c.Assert(rollout.Finished().IsZero(), jc.IsFalse)
+}
+
+func (s *ActionRolloutSuite) TestRunningActionRolloutsUnmigratable(c *gc.C) {
+	rollout := s.addRollout(c, 0, 0)
+	entities, err := s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []string{"running action rollouts (1)"})
+
+	// Finished rollouts don't stop the model being migrated.
+	s.finishActions(c, state.ActionCompleted, rollout.ActionIds()...)
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.refresh(c, rollout).Status(), gc.Equals, state.ActionRolloutCompleted)
+	entities, err = s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, gc.HasLen, 0)
+}
+
+func (s *ActionRolloutSuite) TestProgressActionRolloutsConcurrently(c *gc.C) {
+	// The worker progresses the rollout after it is added, but
+	// before AddActionRollout enqueues the first batch.
+	defer state.SetBeforeHooks(c, s.State, nil, func() {
+		err := s.State.ProgressActionRollouts()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	rollout := s.addRollout(c, 2, 0)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"dummy/2", "dummy/3"})
+
+	// No action was enqueued twice.
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range units {
+		actions, err := unit.PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(len(actions) <= 1, jc.IsTrue, gc.Commentf("unit %s", unit.Name()))
+	}
+}
+
+func (s *ActionRolloutSuite) TestProgressActionRolloutsContinuesAfterFailure(c *gc.C) {
+	broken := s.addRollout(c, 1, 0)
+	s.finishActions(c, state.ActionCompleted, broken.ActionIds()...)
+	err := s.MgoSuite.Session.DB("juju").C("actionrollouts").Update(
+		bson.D{{"id", broken.Id()}},
+		bson.D{{"$set", bson.D{{"pending-units", []string{"bogus"}}}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.addRollout(c, 1, 0)
+	s.finishActions(c, state.ActionCompleted, rollout.ActionIds()...)
+
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.refresh(c, broken).PendingUnits(), jc.DeepEquals, []string{"bogus"})
+	rollout = s.refresh(c, rollout)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+}
+
+func (s *ActionRolloutSuite) TestProgressActionRolloutsStopOnFailures(c *gc.C) {
+	rollout := s.addRollout(c, 1, 2)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 1)
+
+	s.finishActions(c, state.ActionFailed, rollout.ActionIds()[0])
+	err := s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutRunning)
+
+	s.finishActions(c, state.ActionCancelled, rollout.ActionIds()[1])
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"dummy/2", "dummy/3"})
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutStopped)
+
+	// Stopped rollouts are not progressed further.
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+}
+
+func (s *ActionRolloutSuite) TestProgressActionRolloutsSkipsRemovedUnits(c *gc.C) {
+	rollout := s.addRollout(c, 1, 0)
+	unit, err := s.State.Unit("dummy/1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.finishActions(c, state.ActionCompleted, rollout.ActionIds()[0])
+	err = s.State.ProgressActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.refresh(c, rollout)
+	c.Assert(s.actionReceivers(c, rollout.ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/2"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"dummy/3"})
+}
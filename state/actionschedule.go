@@ -0,0 +1,721 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/cron"
+)
+
+// ActionScheduleTarget describes which units of an application an
+// action schedule enqueues actions on.
+type ActionScheduleTarget string
+
+const (
+	// ActionScheduleAllUnits enqueues the action on every unit of
+	// the application at once.
+	ActionScheduleAllUnits ActionScheduleTarget = "all"
+
+	// ActionScheduleLeader enqueues the action on the application's
+	// leader unit only.
+	ActionScheduleLeader ActionScheduleTarget = "leader"
+
+	// ActionScheduleOneAtATime enqueues the action on each unit of
+	// the application in turn, waiting for the action on one unit to
+	// finish before enqueueing it on the next.
+	ActionScheduleOneAtATime ActionScheduleTarget = "one-at-a-time"
+)
+
+// Validate returns an error if the target is not known.
+func (t ActionScheduleTarget) Validate() error {
+	switch t {
+	case ActionScheduleAllUnits, ActionScheduleLeader, ActionScheduleOneAtATime:
+		return nil
+	}
+	return errors.NotValidf("action schedule target %q", t)
+}
+
+// minActionScheduleInterval is the shortest interval at which an
+// action schedule may run.
+const minActionScheduleInterval = time.Minute
+
+// maxActionScheduleRuns is the number of most recent runs recorded
+// for each action schedule; older runs are removed as new runs start.
+const maxActionScheduleRuns = 100
+
+var validActionScheduleName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// IsValidActionScheduleName reports whether the string is a valid
+// action schedule name.
+func IsValidActionScheduleName(name string) bool {
+	return validActionScheduleName.MatchString(name)
+}
+
+// AddActionScheduleParams contains the parameters for adding an
+// action schedule. Exactly one of Cron and Interval must be set.
+type AddActionScheduleParams struct {
+	// Name is the name of the schedule, unique within the model.
+	Name string
+
+	// Application is the name of the application whose units the
+	// action is enqueued on.
+	Application string
+
+	// Action is the name of the action to enqueue.
+	Action string
+
+	// Parameters holds the parameters for the action.
+	Parameters map[string]interface{}
+
+	// Cron is a cron specification describing when the schedule
+	// runs. Cron specifications are evaluated in UTC.
+	Cron string
+
+	// Interval is the time between runs of the schedule.
+	Interval time.Duration
+
+	// Target describes which units the action is enqueued on. If
+	// empty, the action is enqueued on all units.
+	Target ActionScheduleTarget
+}
+
+// ActionSchedule describes an action that is enqueued on the units of
+// an application at regular times.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// actionScheduleDoc records information about an action schedule.
+type actionScheduleDoc struct {
+	DocID       string                 `bson:"_id"`
+	ModelUUID   string                 `bson:"model-uuid"`
+	Name        string                 `bson:"name"`
+	Application string                 `bson:"application"`
+	Action      string                 `bson:"action"`
+	Parameters  map[string]interface{} `bson:"parameters,omitempty"`
+	Cron        string                 `bson:"cron,omitempty"`
+	Interval    time.Duration          `bson:"interval,omitempty"`
+	Target      ActionScheduleTarget   `bson:"target"`
+	Paused      bool                   `bson:"paused"`
+	Created     time.Time              `bson:"created"`
+	NextRun     time.Time              `bson:"next-run"`
+	RunCount    int                    `bson:"run-count"`
+}
+
+// actionScheduleRunDoc records the actions enqueued by a run of an
+// action schedule.
+type actionScheduleRunDoc struct {
+	DocID        string    `bson:"_id"`
+	ModelUUID    string    `bson:"model-uuid"`
+	Schedule     string    `bson:"schedule"`
+	Run          int       `bson:"run"`
+	Started      time.Time `bson:"started"`
+	ActionIds    []string  `bson:"action-ids"`
+	PendingUnits []string  `bson:"pending-units,omitempty"`
+}
+
+func actionScheduleRunId(schedule string, run int) string {
+	return fmt.Sprintf("%s#%d", schedule, run)
+}
+
+// Name returns the name of the schedule.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Application returns the name of the application whose units the
+// action is enqueued on.
+func (s *ActionSchedule) Application() string {
+	return s.doc.Application
+}
+
+// Action returns the name of the scheduled action.
+func (s *ActionSchedule) Action() string {
+	return s.doc.Action
+}
+
+// Parameters returns the parameters of the scheduled action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Cron returns the cron specification describing when the schedule
+// runs, or the empty string if the schedule runs at an interval.
+func (s *ActionSchedule) Cron() string {
+	return s.doc.Cron
+}
+
+// Interval returns the time between runs of the schedule, or zero if
+// the schedule runs according to a cron specification.
+func (s *ActionSchedule) Interval() time.Duration {
+	return s.doc.Interval
+}
+
+// Target returns which units the action is enqueued on.
+func (s *ActionSchedule) Target() ActionScheduleTarget {
+	return s.doc.Target
+}
+
+// Paused reports whether the schedule is paused.
+func (s *ActionSchedule) Paused() bool {
+	return s.doc.Paused
+}
+
+// Created returns the time at which the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time at which the schedule will next run.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// nextRun returns the first time after t at which the schedule runs.
+func (s *ActionSchedule) nextRun(t time.Time) (time.Time, error) {
+	return nextActionScheduleRun(s.doc.Cron, s.doc.Interval, t)
+}
+
+func nextActionScheduleRun(spec string, interval time.Duration, t time.Time) (time.Time, error) {
+	if spec == "" {
+		return t.Add(interval), nil
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	next := schedule.Next(t.UTC())
+	if next.IsZero() {
+		return time.Time{}, errors.NotValidf("cron specification %q that never fires", spec)
+	}
+	return next, nil
+}
+
+// Runs returns the history of the schedule's runs, oldest first.
+func (s *ActionSchedule) Runs() ([]*ActionScheduleRun, error) {
+	coll, closer := s.st.db().GetCollection(actionScheduleRunsC)
+	defer closer()
+
+	var docs []actionScheduleRunDoc
+	if err := coll.Find(bson.D{{"schedule", s.doc.Name}}).Sort("run").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "querying action schedule %q runs", s.doc.Name)
+	}
+	runs := make([]*ActionScheduleRun, len(docs))
+	for i, doc := range docs {
+		runs[i] = &ActionScheduleRun{doc}
+	}
+	return runs, nil
+}
+
+// ActionScheduleRun records the actions enqueued by one run of an
+// action schedule.
+type ActionScheduleRun struct {
+	doc actionScheduleRunDoc
+}
+
+// Schedule returns the name of the schedule.
+func (r *ActionScheduleRun) Schedule() string {
+	return r.doc.Schedule
+}
+
+// Run returns the sequence number of the run, starting at 1.
+func (r *ActionScheduleRun) Run() int {
+	return r.doc.Run
+}
+
+// Started returns the time at which the run started.
+func (r *ActionScheduleRun) Started() time.Time {
+	return r.doc.Started
+}
+
+// ActionIds returns the IDs of the actions enqueued by the run.
+func (r *ActionScheduleRun) ActionIds() []string {
+	return r.doc.ActionIds
+}
+
+// PendingUnits returns the names of the units that the action has
+// yet to be enqueued on, for schedules that target one unit at a time.
+func (r *ActionScheduleRun) PendingUnits() []string {
+	return r.doc.PendingUnits
+}
+
+// ActionSchedule returns the action schedule with the specified name.
+func (st *State) ActionSchedule(name string) (*ActionSchedule, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{st, doc}, nil
+}
+
+// AllActionSchedules returns all of the action schedules in the model.
+func (st *State) AllActionSchedules() ([]*ActionSchedule, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying action schedules")
+	}
+	schedules := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		schedules[i] = &ActionSchedule{st, doc}
+	}
+	return schedules, nil
+}
+
+// AddActionSchedule adds an action schedule with the specified
+// parameters.
+func (st *State) AddActionSchedule(args AddActionScheduleParams) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add action schedule %q", args.Name)
+
+	if !IsValidActionScheduleName(args.Name) {
+		return nil, errors.NotValidf("action schedule name %q", args.Name)
+	}
+	if args.Target == "" {
+		args.Target = ActionScheduleAllUnits
+	}
+	if err := args.Target.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case args.Cron == "" && args.Interval == 0:
+		return nil, errors.New("either a cron specification or an interval must be specified")
+	case args.Cron != "" && args.Interval != 0:
+		return nil, errors.New("cron specification and interval are mutually exclusive")
+	case args.Cron == "" && args.Interval < minActionScheduleInterval:
+		return nil, errors.NotValidf("interval %v shorter than %v", args.Interval, minActionScheduleInterval)
+	}
+	now := st.clock().Now()
+	nextRun, err := nextActionScheduleRun(args.Cron, args.Interval, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	doc := actionScheduleDoc{
+		DocID:       args.Name,
+		ModelUUID:   st.ModelUUID(),
+		Name:        args.Name,
+		Application: args.Application,
+		Action:      args.Action,
+		Parameters:  args.Parameters,
+		Cron:        args.Cron,
+		Interval:    args.Interval,
+		Target:      args.Target,
+		Created:     now,
+		NextRun:     nextRun,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := st.ActionSchedule(args.Name); err == nil {
+			return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		app, err := st.Application(args.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", args.Application)
+		}
+		if err := validateScheduledAction(app, args.Action, args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &ActionSchedule{st, doc}, nil
+}
+
+// validateScheduledAction checks that the application's charm defines
+// the action, and that the parameters are valid for it.
+func validateScheduledAction(app *Application, name string, params map[string]interface{}) error {
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var chActions ActionSpecsByName
+		if ch.Actions() != nil {
+			chActions = ch.Actions().ActionSpecs
+		}
+		if spec, ok = chActions[name]; !ok {
+			return errors.Errorf("action %q not defined on application %q", name, app.Name())
+		}
+	}
+	return errors.Trace(spec.ValidateParams(params))
+}
+
+// PauseActionSchedule stops the action schedule with the specified name
+// from running until it is resumed.
+func (st *State) PauseActionSchedule(name string) error {
+	return errors.Annotatef(st.setActionSchedulePaused(name, true), "cannot pause action schedule %q", name)
+}
+
+// ResumeActionSchedule resumes the paused action schedule with the
+// specified name. The schedule next runs at the first scheduled time
+// after it is resumed.
+func (st *State) ResumeActionSchedule(name string) error {
+	return errors.Annotatef(st.setActionSchedulePaused(name, false), "cannot resume action schedule %q", name)
+}
+
+func (st *State) setActionSchedulePaused(name string, paused bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		schedule, err := st.ActionSchedule(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if schedule.doc.Paused == paused {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"paused", paused}}
+		if !paused {
+			nextRun, err := schedule.nextRun(st.clock().Now())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			update = append(update, bson.DocElem{"next-run", nextRun})
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     name,
+			Assert: bson.D{{"paused", schedule.doc.Paused}},
+			Update: bson.D{{"$set", update}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// RemoveActionSchedule removes the action schedule with the specified
+// name, along with the history of its runs. Actions already enqueued
+// by the schedule are not affected.
+func (st *State) RemoveActionSchedule(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		schedule, err := st.ActionSchedule(name)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return removeActionScheduleOps(st, schedule.doc)
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "cannot remove action schedule %q", name)
+}
+
+func removeActionScheduleOps(st *State, doc actionScheduleDoc) ([]txn.Op, error) {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.Name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	coll, closer := st.db().GetCollection(actionScheduleRunsC)
+	defer closer()
+	var runs []actionScheduleRunDoc
+	err := coll.Find(bson.D{{"schedule", doc.Name}}).Select(bson.D{{"run", 1}}).All(&runs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading action schedule %q runs", doc.Name)
+	}
+	for _, run := range runs {
+		ops = append(ops, txn.Op{
+			C:      actionScheduleRunsC,
+			Id:     actionScheduleRunId(doc.Name, run.Run),
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// removeApplicationActionSchedulesOps returns the operations required
+// to remove all action schedules for the application, along with the
+// history of their runs.
+func removeApplicationActionSchedulesOps(st *State, application string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+	var docs []actionScheduleDoc
+	if err := coll.Find(bson.D{{"application", application}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading application %q action schedules", application)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		scheduleOps, err := removeActionScheduleOps(st, doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, scheduleOps...)
+	}
+	return ops, nil
+}
+
+// RunDueActionSchedules enqueues the actions of every unpaused action
+// schedule whose next run time has passed, and continues runs that
+// enqueue actions on one unit at a time. Runs missed while the
+// controller was unavailable are not made up; a schedule that is
+// overdue runs once, and then at the next scheduled time. Failure to
+// run one schedule is logged, and does not prevent the others from
+// running.
+func (st *State) RunDueActionSchedules() error {
+	schedules, err := st.AllActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := st.clock().Now()
+	paused := make(map[string]bool)
+	for _, schedule := range schedules {
+		paused[schedule.doc.Name] = schedule.doc.Paused
+		if schedule.doc.Paused || schedule.doc.NextRun.After(now) {
+			continue
+		}
+		if err := st.runActionSchedule(schedule, now); err != nil {
+			actionLogger.Errorf("running action schedule %q: %v", schedule.doc.Name, err)
+		}
+	}
+
+	coll, closer := st.db().GetCollection(actionScheduleRunsC)
+	defer closer()
+	var runs []actionScheduleRunDoc
+	err = coll.Find(bson.D{{"pending-units.0", bson.D{{"$exists", true}}}}).All(&runs)
+	if err != nil {
+		return errors.Annotate(err, "querying in-progress action schedule runs")
+	}
+	for _, run := range runs {
+		if paused[run.Schedule] {
+			continue
+		}
+		if err := st.continueActionScheduleRun(run); err != nil {
+			actionLogger.Errorf("continuing action schedule %q run %d: %v", run.Schedule, run.Run, err)
+		}
+	}
+	return nil
+}
+
+// runActionSchedule enqueues the schedule's action on the targeted
+// units, and records the run, in a single transaction.
+func (st *State) runActionSchedule(schedule *ActionSchedule, now time.Time) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			var err error
+			schedule, err = st.ActionSchedule(schedule.doc.Name)
+			if errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if schedule.doc.Paused || schedule.doc.NextRun.After(now) {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return st.runActionScheduleOps(schedule, now)
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// runActionScheduleOps returns the operations required to enqueue
+// the schedule's action on the targeted units, and to record the run.
+func (st *State) runActionScheduleOps(schedule *ActionSchedule, now time.Time) ([]txn.Op, error) {
+	app, err := st.Application(schedule.doc.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allUnits, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string]*Unit)
+	var unitNames []string
+	for _, unit := range allUnits {
+		if unit.Life() == Alive {
+			byName[unit.Name()] = unit
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	naturalsort.Sort(unitNames)
+	units := make([]*Unit, len(unitNames))
+	for i, name := range unitNames {
+		units[i] = byName[name]
+	}
+
+	var (
+		actionIds, pending []string
+		ops                []txn.Op
+	)
+	switch schedule.doc.Target {
+	case ActionScheduleLeader:
+		leaders, err := st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[app.Name()]
+		if !ok {
+			actionLogger.Warningf("action schedule %q: application %q has no leader", schedule.doc.Name, app.Name())
+		}
+		for _, unit := range units {
+			if unit.Name() == leader {
+				actionIds, ops = st.scheduledActionOps(schedule, unit, actionIds, ops)
+			}
+		}
+	case ActionScheduleOneAtATime:
+		for len(units) > 0 && len(actionIds) == 0 {
+			actionIds, ops = st.scheduledActionOps(schedule, units[0], actionIds, ops)
+			units = units[1:]
+		}
+		for _, unit := range units {
+			pending = append(pending, unit.Name())
+		}
+	default:
+		for _, unit := range units {
+			actionIds, ops = st.scheduledActionOps(schedule, unit, actionIds, ops)
+		}
+	}
+
+	nextRun, err := schedule.nextRun(now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	run := schedule.doc.RunCount + 1
+	ops = append(ops, txn.Op{
+		C:      actionSchedulesC,
+		Id:     schedule.doc.Name,
+		Assert: bson.D{{"run-count", schedule.doc.RunCount}},
+		Update: bson.D{{"$set", bson.D{
+			{"run-count", run},
+			{"next-run", nextRun},
+		}}},
+	}, txn.Op{
+		C:      actionScheduleRunsC,
+		Id:     actionScheduleRunId(schedule.doc.Name, run),
+		Assert: txn.DocMissing,
+		Insert: &actionScheduleRunDoc{
+			DocID:        actionScheduleRunId(schedule.doc.Name, run),
+			ModelUUID:    st.ModelUUID(),
+			Schedule:     schedule.doc.Name,
+			Run:          run,
+			Started:      now,
+			ActionIds:    actionIds,
+			PendingUnits: pending,
+		},
+	})
+	if run > maxActionScheduleRuns {
+		ops = append(ops, txn.Op{
+			C:      actionScheduleRunsC,
+			Id:     actionScheduleRunId(schedule.doc.Name, run-maxActionScheduleRuns),
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// continueActionScheduleRun enqueues the schedule's action on the next
+// pending unit of a run, if the action enqueued on the previous unit
+// has finished. The action is enqueued in the same transaction that
+// updates the run's pending units.
+func (st *State) continueActionScheduleRun(run actionScheduleRunDoc) error {
+	m, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(run.ActionIds); n > 0 {
+		action, err := m.Action(run.ActionIds[n-1])
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err == nil {
+			switch action.Status() {
+			case ActionPending, ActionRunning:
+				return nil
+			}
+		}
+	}
+	schedule, err := st.ActionSchedule(run.Schedule)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		actionIds []string
+		ops       []txn.Op
+	)
+	pending := run.PendingUnits
+	for len(pending) > 0 && len(actionIds) == 0 {
+		unit, err := st.Unit(pending[0])
+		pending = pending[1:]
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if unit.Life() == Alive {
+			actionIds, ops = st.scheduledActionOps(schedule, unit, actionIds, ops)
+		}
+	}
+
+	update := bson.D{{"$set", bson.D{{"pending-units", pending}}}}
+	if len(actionIds) > 0 {
+		update = append(update, bson.DocElem{"$push", bson.D{{"action-ids", actionIds[0]}}})
+	}
+	ops = append(ops, txn.Op{
+		C:      actionScheduleRunsC,
+		Id:     actionScheduleRunId(run.Schedule, run.Run),
+		Assert: bson.D{{"pending-units", run.PendingUnits}},
+		Update: update,
+	})
+	return errors.Trace(st.db().RunTransaction(ops))
+}
+
+// scheduledActionOps appends the operations required to enqueue the
+// schedule's action on the unit to ops, and the ID of the action to
+// actionIds. An action that is not valid for the unit is logged rather
+// than returned, so that one broken unit does not prevent the action
+// from running on the others.
+func (st *State) scheduledActionOps(schedule *ActionSchedule, unit *Unit, actionIds []string, ops []txn.Op) ([]string, []txn.Op) {
+	payload, err := unit.validateActionPayload(schedule.doc.Action, schedule.doc.Parameters)
+	var (
+		doc       actionDoc
+		actionOps []txn.Op
+	)
+	if err == nil {
+		doc, actionOps, err = enqueueActionOps(st, unit.Tag(), schedule.doc.Action, payload)
+	}
+	if err != nil {
+		actionLogger.Warningf(
+			"action schedule %q: cannot enqueue action %q on unit %q: %v",
+			schedule.doc.Name, schedule.doc.Action, unit.Name(), err,
+		)
+		return actionIds, ops
+	}
+	return append(actionIds, st.localID(doc.DocId)), append(ops, actionOps...)
+}
@@ -0,0 +1,336 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.InitialTime = time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.units = nil
+	for i := 0; i < 2; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, target state.ActionScheduleTarget) *state.ActionSchedule {
+	schedule, err := s.State.AddActionSchedule(state.AddActionScheduleParams{
+		Name:        "nightly-snapshot",
+		Application: "dummy",
+		Action:      "snapshot",
+		Parameters:  map[string]interface{}{"outfile": "nightly.bz2"},
+		Interval:    time.Hour,
+		Target:      target,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) runs(c *gc.C, name string) []*state.ActionScheduleRun {
+	schedule, err := s.State.ActionSchedule(name)
+	c.Assert(err, jc.ErrorIsNil)
+	runs, err := schedule.Runs()
+	c.Assert(err, jc.ErrorIsNil)
+	return runs
+}
+
+func (s *ActionScheduleSuite) actionReceivers(c *gc.C, ids []string) []string {
+	var receivers []string
+	for _, id := range ids {
+		action, err := s.Model.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(action.Name(), gc.Equals, "snapshot")
+		c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+		receivers = append(receivers, action.Receiver())
+	}
+	return receivers
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "")
+	c.Assert(schedule.Name(), gc.Equals, "nightly-snapshot")
+	c.Assert(schedule.Application(), gc.Equals, "dummy")
+	c.Assert(schedule.Action(), gc.Equals, "snapshot")
+	c.Assert(schedule.Interval(), gc.Equals, time.Hour)
+	c.Assert(schedule.Cron(), gc.Equals, "")
+	c.Assert(schedule.Target(), gc.Equals, state.ActionScheduleAllUnits)
+	c.Assert(schedule.Paused(), jc.IsFalse)
+	c.Assert(schedule.NextRun().UTC(), gc.Equals, s.Clock.Now().Add(time.Hour))
+
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Name(), gc.Equals, "nightly-snapshot")
+	c.Assert(schedules[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleCron(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(state.AddActionScheduleParams{
+		Name:        "nightly-snapshot",
+		Application: "dummy",
+		Action:      "snapshot",
+		Cron:        "30 2 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Cron(), gc.Equals, "30 2 * * *")
+	next := schedule.NextRun().UTC()
+	c.Assert(next.After(s.Clock.Now()), jc.IsTrue)
+	c.Assert(next.Hour(), gc.Equals, 2)
+	c.Assert(next.Minute(), gc.Equals, 30)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		about string
+		args  state.AddActionScheduleParams
+		err   string
+	}{{
+		about: "invalid name",
+		args:  state.AddActionScheduleParams{Name: "Nightly", Application: "dummy", Action: "snapshot", Interval: time.Hour},
+		err:   `cannot add action schedule "Nightly": action schedule name "Nightly" not valid`,
+	}, {
+		about: "no schedule",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "snapshot"},
+		err:   `cannot add action schedule "nightly": either a cron specification or an interval must be specified`,
+	}, {
+		about: "cron and interval",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "snapshot", Cron: "@daily", Interval: time.Hour},
+		err:   `cannot add action schedule "nightly": cron specification and interval are mutually exclusive`,
+	}, {
+		about: "short interval",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "snapshot", Interval: time.Second},
+		err:   `cannot add action schedule "nightly": interval 1s shorter than 1m0s not valid`,
+	}, {
+		about: "invalid cron",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "snapshot", Cron: "* *"},
+		err:   `cannot add action schedule "nightly": cron specification "\* \*" \(expected 5 fields, got 2\) not valid`,
+	}, {
+		about: "invalid target",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "snapshot", Interval: time.Hour, Target: "some"},
+		err:   `cannot add action schedule "nightly": action schedule target "some" not valid`,
+	}, {
+		about: "unknown application",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "foo", Action: "snapshot", Interval: time.Hour},
+		err:   `cannot add action schedule "nightly": application "foo" not found`,
+	}, {
+		about: "unknown action",
+		args:  state.AddActionScheduleParams{Name: "nightly", Application: "dummy", Action: "backup", Interval: time.Hour},
+		err:   `cannot add action schedule "nightly": action "backup" not defined on application "dummy"`,
+	}, {
+		about: "invalid parameters",
+		args: state.AddActionScheduleParams{
+			Name: "nightly", Application: "dummy", Action: "snapshot", Interval: time.Hour,
+			Parameters: map[string]interface{}{"outfile": 5},
+		},
+		err: `cannot add action schedule "nightly": validation failed: .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		_, err := s.State.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleDuplicate(c *gc.C) {
+	s.addSchedule(c, "")
+	_, err := s.State.AddActionSchedule(state.AddActionScheduleParams{
+		Name:        "nightly-snapshot",
+		Application: "dummy",
+		Action:      "snapshot",
+		Interval:    time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule "nightly-snapshot": action schedule "nightly-snapshot" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesAllUnits(c *gc.C) {
+	s.addSchedule(c, state.ActionScheduleAllUnits)
+
+	err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs(c, "nightly-snapshot"), gc.HasLen, 0)
+
+	s.Clock.Advance(time.Hour)
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	runs := s.runs(c, "nightly-snapshot")
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0].Run(), gc.Equals, 1)
+	c.Assert(runs[0].Started().UTC(), gc.Equals, s.Clock.Now())
+	c.Assert(runs[0].PendingUnits(), gc.HasLen, 0)
+	c.Assert(s.actionReceivers(c, runs[0].ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+
+	schedule, err := s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().UTC(), gc.Equals, s.Clock.Now().Add(time.Hour))
+
+	// The schedule does not run again until the next run time.
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs(c, "nightly-snapshot"), gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesConcurrentRun(c *gc.C) {
+	s.addSchedule(c, state.ActionScheduleAllUnits)
+	s.Clock.Advance(time.Hour)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.RunDueActionSchedules()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs(c, "nightly-snapshot"), gc.HasLen, 1)
+
+	// The actions of the aborted run were not enqueued.
+	for _, unit := range s.units {
+		actions, err := unit.PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(actions, gc.HasLen, 1)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesContinuesAfterFailure(c *gc.C) {
+	_, err := s.State.AddActionSchedule(state.AddActionScheduleParams{
+		Name:        "broken",
+		Application: "dummy",
+		Action:      "snapshot",
+		Interval:    time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.MgoSuite.Session.DB("juju").C("actionschedules").Update(
+		bson.D{{"name", "broken"}},
+		bson.D{{"$set", bson.D{{"cron", "bogus"}, {"interval", 0}}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addSchedule(c, state.ActionScheduleAllUnits)
+
+	s.Clock.Advance(time.Hour)
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs(c, "broken"), gc.HasLen, 0)
+	c.Assert(s.runs(c, "nightly-snapshot"), gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesLeader(c *gc.C) {
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("actionschedule_test"))
+	target.Claimed(lease.Key{"application-leadership", s.State.ModelUUID(), "dummy"}, "dummy/1")
+	s.addSchedule(c, state.ActionScheduleLeader)
+
+	s.Clock.Advance(time.Hour)
+	err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	runs := s.runs(c, "nightly-snapshot")
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(s.actionReceivers(c, runs[0].ActionIds()), jc.DeepEquals, []string{"dummy/1"})
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesOneAtATime(c *gc.C) {
+	s.addSchedule(c, state.ActionScheduleOneAtATime)
+
+	s.Clock.Advance(time.Hour)
+	err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	runs := s.runs(c, "nightly-snapshot")
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(s.actionReceivers(c, runs[0].ActionIds()), jc.DeepEquals, []string{"dummy/0"})
+	c.Assert(runs[0].PendingUnits(), jc.DeepEquals, []string{"dummy/1"})
+
+	// The action is not enqueued on the next unit until the
+	// action on the first has finished.
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	runs = s.runs(c, "nightly-snapshot")
+	c.Assert(runs[0].ActionIds(), gc.HasLen, 1)
+
+	action, err := s.Model.Action(runs[0].ActionIds()[0])
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	runs = s.runs(c, "nightly-snapshot")
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(s.actionReceivers(c, runs[0].ActionIds()), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(runs[0].PendingUnits(), gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestPauseResumeActionSchedule(c *gc.C) {
+	s.addSchedule(c, "")
+	err := s.State.PauseActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(90 * time.Minute)
+	err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs(c, "nightly-snapshot"), gc.HasLen, 0)
+
+	err = s.State.ResumeActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsFalse)
+	c.Assert(schedule.NextRun().UTC(), gc.Equals, s.Clock.Now().Add(time.Hour))
+
+	err = s.State.PauseActionSchedule("missing")
+	c.Assert(err, gc.ErrorMatches, `cannot pause action schedule "missing": action schedule "missing" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	s.addSchedule(c, "")
+	s.Clock.Advance(time.Hour)
+	err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	coll, closer := state.GetRawCollection(s.State, "actionscheduleruns")
+	defer closer()
+	n, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+
+	// Removing a missing schedule is not an error.
+	err = s.State.RemoveActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestRemoveApplicationRemovesActionSchedules(c *gc.C) {
+	s.addSchedule(c, "")
+	for _, unit := range s.units {
+		err := unit.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -371,6 +371,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -397,6 +402,35 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// These collections hold action schedules, and the history
+		// of the actions enqueued by each run of a schedule.
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+		actionScheduleRunsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "schedule"},
+			}},
+		},
+
+		// This collection holds action rollouts, which enqueue an
+		// action on the units of an application in batches.
+		actionRolloutsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "status"},
+			}},
+		},
+
+		// This collection holds the progress of rolling charm
+		// upgrades, one per application.
+		charmRolloutsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "status"},
+			}},
+		},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -408,6 +442,20 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// These collections hold charm secrets and their encrypted
+		// revisions. The key used to encrypt them is derived from
+		// controller key material held outside the database.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
 		// This collection holds information associated with charm resources.
 		// See resource/persistence/mongo.go, where it should never have
 		// been put in the first place.
@@ -518,6 +566,9 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionRolloutsC            = "actionrollouts"
+	actionScheduleRunsC        = "actionscheduleruns"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
@@ -525,6 +576,7 @@ const (
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
+	charmRolloutsC             = "charmrollouts"
 	charmsC                    = "charms"
 	cleanupsC                  = "cleanups"
 	cloudimagemetadataC        = "cloudimagemetadata"
@@ -571,6 +623,8 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretRevisionsC           = "secretrevisions"
+	secretsC                   = "secrets"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
@@ -598,6 +652,7 @@ const (
 	usersC                     = "users"
 	volumeAttachmentsC         = "volumeattachments"
 	volumesC                   = "volumes"
+	volumeSnapshotsC           = "volumesnapshots"
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
@@ -389,6 +389,27 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove secrets owned by the application.
+	removeSecretsOps, err := removeApplicationSecretsOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSecretsOps...)
+
+	// Remove action schedules for the application.
+	removeSchedulesOps, err := removeApplicationActionSchedulesOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSchedulesOps...)
+
+	// Remove the record of any rolling charm upgrade.
+	removeRolloutOps, err := removeCharmRolloutOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeRolloutOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -1020,6 +1041,10 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// Rollout, if set, releases the upgrade to the application's
+	// existing units a batch at a time rather than all at once.
+	Rollout *CharmRolloutParams
 }
 
 // SetCharm changes the charm for the application.
@@ -1083,6 +1108,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if cfg.Rollout != nil {
+		if err := cfg.Rollout.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var newCharmModifiedVersion int
 	channel := string(cfg.Channel)
@@ -1141,6 +1171,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			rolloutOps, err := a.charmRolloutOps(cfg.Charm, cfg.Rollout)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, rolloutOps...)
 			newCharmModifiedVersion++
 		}
 
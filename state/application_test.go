@@ -3278,6 +3278,27 @@ func (s *ApplicationSuite) TestWatchCharmConfig(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Update config a couple of times, check a single event.
+	err := s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "foo"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "bar"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "bar"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 var updateApplicationConfigTests = []struct {
 	about   string
 	initial application.ConfigAttributes
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// tempPrefix is used for files which are still being written.
+const tempPrefix = ".tmp-"
+
+type directoryTarget struct {
+	dir string
+}
+
+// NewDirectoryTarget returns an ArchiveTarget which keeps backups in
+// the given directory, creating it if necessary. The directory may be
+// a network mount shared between the controller machines.
+func NewDirectoryTarget(dir string) ArchiveTarget {
+	return &directoryTarget{dir: dir}
+}
+
+// Put is part of the ArchiveTarget interface. The content is written
+// to a temporary file which is then renamed, so that partially written
+// files are never seen.
+func (t *directoryTarget) Put(name string, content io.Reader, size int64) error {
+	if err := validateTargetName(name); err != nil {
+		return errors.Trace(err)
+	}
+	// Backups include the controller's secrets, so they
+	// must only be readable by the controller.
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	file, err := ioutil.TempFile(t.dir, tempPrefix+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "writing %q", name)
+	}
+	if size >= 0 && written != size {
+		return errors.Errorf("writing %q: expected %d bytes, got %d", name, size, written)
+	}
+	if err := os.Rename(file.Name(), filepath.Join(t.dir, name)); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Get is part of the ArchiveTarget interface.
+func (t *directoryTarget) Get(name string) (io.ReadCloser, error) {
+	if err := validateTargetName(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := os.Open(filepath.Join(t.dir, name))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup file %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return file, nil
+}
+
+// List is part of the ArchiveTarget interface.
+func (t *directoryTarget) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), tempPrefix) {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// Remove is part of the ArchiveTarget interface.
+func (t *directoryTarget) Remove(name string) error {
+	if err := validateTargetName(name); err != nil {
+		return errors.Trace(err)
+	}
+	err := os.Remove(filepath.Join(t.dir, name))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup file %q", name)
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+)
+
+const s3ContentType = "application/octet-stream"
+
+// S3Config holds the details needed to keep backups in an
+// S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store, eg
+	// "https://s3.amazonaws.com". Buckets are addressed by path, as
+	// supported by most S3-compatible stores.
+	Endpoint string
+
+	// Region is the region used when signing requests.
+	Region string
+
+	// Bucket is the bucket in which backups are kept.
+	Bucket string
+
+	// AccessKey and SecretKey are the credentials used to
+	// sign requests.
+	AccessKey string
+	SecretKey string
+}
+
+// Validate returns an error if the config is not valid.
+func (c S3Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if c.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if c.Region == "" {
+		return errors.NotValidf("empty Region")
+	}
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	return nil
+}
+
+type s3Target struct {
+	bucket *s3.Bucket
+}
+
+// NewS3Target returns an ArchiveTarget which keeps backups in a bucket
+// of an S3-compatible object store.
+func NewS3Target(config S3Config) (ArchiveTarget, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	auth := aws.Auth{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+	}
+	// Leaving S3BucketEndpoint empty addresses the bucket by path.
+	region := aws.Region{
+		Name:       config.Region,
+		S3Endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+	}
+	bucket, err := s3.New(auth, region).Bucket(config.Bucket)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening S3 bucket")
+	}
+	return &s3Target{bucket: bucket}, nil
+}
+
+// Put is part of the ArchiveTarget interface.
+func (t *s3Target) Put(name string, content io.Reader, size int64) error {
+	if err := validateTargetName(name); err != nil {
+		return errors.Trace(err)
+	}
+	err := t.bucket.PutReader(name, content, size, s3ContentType, s3.Private)
+	return errors.Annotatef(s3Error(err), "storing %q", name)
+}
+
+// Get is part of the ArchiveTarget interface.
+func (t *s3Target) Get(name string) (io.ReadCloser, error) {
+	if err := validateTargetName(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	content, err := t.bucket.GetReader(name)
+	if err != nil {
+		return nil, errors.Annotatef(s3Error(err), "getting %q", name)
+	}
+	return content, nil
+}
+
+// List is part of the ArchiveTarget interface.
+func (t *s3Target) List() ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		result, err := t.bucket.List("", "", marker, 0)
+		if err != nil {
+			return nil, errors.Annotate(s3Error(err), "listing bucket")
+		}
+		for _, key := range result.Contents {
+			names = append(names, key.Key)
+		}
+		if !result.IsTruncated || len(result.Contents) == 0 {
+			return names, nil
+		}
+		marker = result.Contents[len(result.Contents)-1].Key
+	}
+}
+
+// Remove is part of the ArchiveTarget interface.
+func (t *s3Target) Remove(name string) error {
+	if err := validateTargetName(name); err != nil {
+		return errors.Trace(err)
+	}
+	err := t.bucket.Del(name)
+	return errors.Annotatef(s3Error(err), "removing %q", name)
+}
+
+// s3Error returns err, converted to a NotFound error if the object
+// store reports that the object or bucket doesn't exist.
+func s3Error(err error) error {
+	if err, ok := err.(*s3.Error); ok && err.StatusCode == http.StatusNotFound {
+		return errors.NewNotFound(err, "")
+	}
+	return err
+}
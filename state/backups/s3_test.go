@@ -0,0 +1,221 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type s3TargetSuite struct {
+	testing.BaseSuite
+	server *fakeS3
+	target backups.ArchiveTarget
+}
+
+var _ = gc.Suite(&s3TargetSuite{})
+
+func (s *s3TargetSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.server = newFakeS3(c, "backups")
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	target, err := backups.NewS3Target(backups.S3Config{
+		Endpoint:  s.server.URL,
+		Region:    "us-east-1",
+		Bucket:    "backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.target = target
+}
+
+func (s *s3TargetSuite) TestNewS3TargetValidates(c *gc.C) {
+	_, err := backups.NewS3Target(backups.S3Config{
+		Endpoint: "http://localhost:9000",
+		Region:   "us-east-1",
+		Bucket:   "backups",
+	})
+	c.Assert(err, gc.ErrorMatches, "missing credentials not valid")
+}
+
+func (s *s3TargetSuite) TestPutGetRemove(c *gc.C) {
+	err := s.target.Put("one.json", bytes.NewBufferString("hello"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(s.server.objects["one.json"]), gc.Equals, "hello")
+
+	file, err := s.target.Get("one.json")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(file)
+	file.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "hello")
+
+	err = s.target.Remove("one.json")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.target.Get("one.json")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *s3TargetSuite) TestListPages(c *gc.C) {
+	for _, name := range []string{"a", "b", "c"} {
+		err := s.target.Put(name, bytes.NewBufferString(name), 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"a", "b", "c"})
+}
+
+func (s *s3TargetSuite) TestRequestsAreSigned(c *gc.C) {
+	err := s.target.Put("one.json", bytes.NewBufferString("hello"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.authorizations, gc.HasLen, 1)
+	c.Assert(s.server.authorizations[0], gc.Matches,
+		`AWS4-HMAC-SHA256 Credential=access/\d{8}/us-east-1/s3/aws4_request, `+
+			`SignedHeaders=[a-z0-9;-]*host[a-z0-9;-]*, Signature=[0-9a-f]{64}`)
+}
+
+func (s *s3TargetSuite) TestErrorResponse(c *gc.C) {
+	s.server.fail = true
+	err := s.target.Put("one.json", bytes.NewBufferString("hello"), 5)
+	c.Assert(err, gc.ErrorMatches, `storing "one.json": Access Denied`)
+}
+
+func (s *s3TargetSuite) TestNameOutsideBucketRejected(c *gc.C) {
+	_, err := s.target.Get("../other/one.json")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *s3TargetSuite) TestTargetStorage(c *gc.C) {
+	storage := backups.NewTargetStorage(s.target)
+	meta := backups.NewMetadata()
+	meta.Origin.Model = "49db53ac-a42f-4ab2-86e1-0c6fa0fec762"
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "main-host"
+	err := meta.MarkComplete(10, "787b8915389d921fa23fb40e16ae81ea979758bf")
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := storage.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := storage.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Assert(list[0].ID(), gc.Equals, id)
+	c.Assert(s.server.objects[id+".tar.gz"], gc.DeepEquals, []byte("0123456789"))
+}
+
+// fakeS3 is a minimal stand-in for an S3-compatible object store,
+// holding the objects of a single bucket in memory.
+type fakeS3 struct {
+	*httptest.Server
+	c      *gc.C
+	bucket string
+
+	mu             sync.Mutex
+	objects        map[string][]byte
+	authorizations []string
+	fail           bool
+}
+
+func newFakeS3(c *gc.C, bucket string) *fakeS3 {
+	f := &fakeS3{
+		c:       c,
+		bucket:  bucket,
+		objects: make(map[string][]byte),
+	}
+	f.Server = httptest.NewServer(f)
+	return f
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied", "Access Denied")
+		return
+	}
+	if req.Method == "PUT" {
+		f.authorizations = append(f.authorizations, req.Header.Get("Authorization"))
+	}
+	prefix := "/" + f.bucket
+	if req.URL.Path == prefix || req.URL.Path == prefix+"/" {
+		f.list(w, req)
+		return
+	}
+	if !strings.HasPrefix(req.URL.Path, prefix+"/") {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+	key := strings.TrimPrefix(req.URL.Path, prefix+"/")
+	switch req.Method {
+	case "PUT":
+		data, err := ioutil.ReadAll(req.Body)
+		f.c.Check(err, jc.ErrorIsNil)
+		f.c.Check(int64(len(data)), gc.Equals, req.ContentLength)
+		f.objects[key] = data
+	case "GET":
+		data, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+			return
+		}
+		w.Write(data)
+	case "DELETE":
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed")
+	}
+}
+
+// list returns one key per page, to exercise listing from a marker.
+func (f *fakeS3) list(w http.ResponseWriter, req *http.Request) {
+	var keys []string
+	for key := range f.objects {
+		if key > req.URL.Query().Get("marker") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	type content struct {
+		Key string
+	}
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Contents    []content
+		IsTruncated bool
+	}{Name: f.bucket}
+	if len(keys) > 0 {
+		result.Contents = []content{{Key: keys[0]}}
+		result.IsTruncated = len(keys) > 1
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+// writeS3Error writes an error response as sent by S3.
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,239 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	archiveSuffix  = ".tar.gz"
+	metadataSuffix = ".json"
+)
+
+// ArchiveTarget is somewhere other than the controller's database in
+// which backup archives can be kept, so that they survive the loss of
+// the controller.
+type ArchiveTarget interface {
+	// Put stores the content under the given name, replacing
+	// anything already stored under that name.
+	Put(name string, content io.Reader, size int64) error
+
+	// Get returns the content stored under the given name. If there
+	// is no such content, an error satisfying errors.IsNotFound is
+	// returned.
+	Get(name string) (io.ReadCloser, error)
+
+	// List returns the names of everything stored.
+	List() ([]string, error)
+
+	// Remove removes the content stored under the given name.
+	Remove(name string) error
+}
+
+// validateTargetName returns an error if the name could refer to
+// something outside the target, as names may come from the client.
+func validateTargetName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return errors.NotValidf("backup file name %q", name)
+	}
+	return nil
+}
+
+// OpenStorage returns the FileStorage in which backups are kept,
+// according to the controller's backup-storage configuration.
+func OpenStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	switch kind := cfg.BackupStorage(); kind {
+	case controller.BackupStorageController:
+		return NewStorage(st), nil
+	case controller.BackupStorageLocal:
+		return NewTargetStorage(NewDirectoryTarget(cfg.BackupStoragePath())), nil
+	case controller.BackupStorageS3:
+		target, err := NewS3Target(S3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			Bucket:    cfg.BackupS3Bucket(),
+			AccessKey: cfg.BackupS3AccessKey(),
+			SecretKey: cfg.BackupS3SecretKey(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return NewTargetStorage(target), nil
+	default:
+		return nil, errors.NotSupportedf("backup storage %q", kind)
+	}
+}
+
+// targetStorage is a FileStorage which keeps each backup archive in an
+// ArchiveTarget alongside a JSON file holding its metadata, so that
+// the backups can be listed and restored without the controller's
+// database.
+type targetStorage struct {
+	target ArchiveTarget
+}
+
+// NewTargetStorage returns a FileStorage which keeps backup archives
+// and their metadata in the given target.
+func NewTargetStorage(target ArchiveTarget) filestorage.FileStorage {
+	return &targetStorage{target: target}
+}
+
+// Metadata implements filestorage.FileStorage.
+func (s *targetStorage) Metadata(id string) (filestorage.Metadata, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+func (s *targetStorage) metadata(id string) (*Metadata, error) {
+	file, err := s.target.Get(id + metadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	defer file.Close()
+	meta, err := NewMetadataJSONReader(file)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	return meta, nil
+}
+
+// Get implements filestorage.FileStorage.
+func (s *targetStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := s.target.Get(id + archiveSuffix)
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("backup archive %q", id)
+	} else if err != nil {
+		return nil, nil, errors.Annotatef(err, "reading backup archive %q", id)
+	}
+	return meta, archive, nil
+}
+
+// List implements filestorage.FileStorage.
+func (s *targetStorage) List() ([]filestorage.Metadata, error) {
+	names, err := s.target.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	var result []filestorage.Metadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, metadataSuffix) {
+			continue
+		}
+		meta, err := s.metadata(strings.TrimSuffix(name, metadataSuffix))
+		if errors.IsNotFound(err) {
+			// Removed since the listing.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, meta)
+	}
+	return result, nil
+}
+
+// Add implements filestorage.FileStorage. The archive is stored before
+// its metadata, so that only complete backups are listed.
+func (s *targetStorage) Add(meta filestorage.Metadata, archive io.Reader) (string, error) {
+	backupMeta, ok := meta.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("meta must be of type *backups.Metadata")
+	}
+	doc := newStorageMetaDoc(backupMeta)
+	doc.ID = newStorageID(&doc)
+	if err := doc.validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := s.metadata(doc.ID); err == nil {
+		return "", errors.AlreadyExistsf("backup metadata %q", doc.ID)
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	if archive != nil {
+		if err := s.target.Put(doc.ID+archiveSuffix, archive, backupMeta.Size()); err != nil {
+			return "", errors.Annotate(err, "storing backup archive")
+		}
+	}
+
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	doc.Stored = metadocTimeToUnix(time.Now())
+	if err := s.putMetadata(docAsMetadata(&doc), backupMeta); err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.ID, nil
+}
+
+// putMetadata stores stored, with the CA details taken from the
+// original metadata, which aren't part of the storage doc.
+func (s *targetStorage) putMetadata(stored, original *Metadata) error {
+	stored.CACert = original.CACert
+	stored.CAPrivateKey = original.CAPrivateKey
+	buf, err := stored.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.target.Put(stored.ID()+metadataSuffix, bytes.NewReader(data), int64(len(data)))
+	return errors.Annotate(err, "storing backup metadata")
+}
+
+// SetFile implements filestorage.FileStorage.
+func (s *targetStorage) SetFile(id string, archive io.Reader) error {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.target.Put(id+archiveSuffix, archive, meta.Size()); err != nil {
+		return errors.Annotate(err, "storing backup archive")
+	}
+	stored := time.Now().UTC()
+	meta.SetStored(&stored)
+	return errors.Trace(s.putMetadata(meta, meta))
+}
+
+// Remove implements filestorage.FileStorage. The metadata is removed
+// first, so that a partially removed backup is not listed.
+func (s *targetStorage) Remove(id string) error {
+	if _, err := s.metadata(id); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.target.Remove(id + metadataSuffix); err != nil {
+		return errors.Annotatef(err, "removing backup metadata %q", id)
+	}
+	if err := s.target.Remove(id + archiveSuffix); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing backup archive %q", id)
+	}
+	return nil
+}
+
+// Close implements filestorage.FileStorage.
+func (s *targetStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type targetStorageSuite struct {
+	testing.BaseSuite
+	dir     string
+	storage filestorage.FileStorage
+}
+
+var _ = gc.Suite(&targetStorageSuite{})
+
+func (s *targetStorageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+	s.storage = backups.NewTargetStorage(backups.NewDirectoryTarget(s.dir))
+}
+
+func (s *targetStorageSuite) addBackup(c *gc.C) (*backups.Metadata, string) {
+	meta := backupstesting.NewMetadataStarted()
+	backupstesting.FinishMetadata(meta)
+	meta.CACert = "ca-cert"
+	id, err := s.storage.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	return meta, id
+}
+
+func (s *targetStorageSuite) TestAddAndGet(c *gc.C) {
+	original, id := s.addBackup(c)
+	c.Check(id, gc.Equals, original.Started.UTC().Format("20060102-150405")+"."+original.Origin.Model)
+
+	meta, archive, err := s.storage.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "0123456789")
+
+	backupMeta := meta.(*backups.Metadata)
+	c.Check(backupMeta.ID(), gc.Equals, id)
+	c.Check(backupMeta.Size(), gc.Equals, int64(10))
+	c.Check(backupMeta.Checksum(), gc.Equals, original.Checksum())
+	c.Check(backupMeta.Origin, jc.DeepEquals, original.Origin)
+	c.Check(backupMeta.CACert, gc.Equals, "ca-cert")
+	c.Check(backupMeta.Stored(), gc.NotNil)
+
+	info, err := os.Stat(filepath.Join(s.dir, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm()&0077, gc.Equals, os.FileMode(0))
+}
+
+func (s *targetStorageSuite) TestAddDuplicate(c *gc.C) {
+	meta, _ := s.addBackup(c)
+	_, err := s.storage.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *targetStorageSuite) TestAddWrongSize(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	backupstesting.FinishMetadata(meta)
+	_, err := s.storage.Add(meta, bytes.NewBufferString("short"))
+	c.Assert(err, gc.ErrorMatches, `storing backup archive: writing ".*.tar.gz": expected 10 bytes, got 5`)
+
+	list, err := s.storage.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 0)
+}
+
+func (s *targetStorageSuite) TestList(c *gc.C) {
+	list, err := s.storage.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 0)
+
+	_, id := s.addBackup(c)
+	// Unrelated files are ignored.
+	err = ioutil.WriteFile(filepath.Join(s.dir, "README"), []byte("hello"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err = s.storage.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Assert(list[0].ID(), gc.Equals, id)
+}
+
+func (s *targetStorageSuite) TestRemove(c *gc.C) {
+	_, id := s.addBackup(c)
+	err := s.storage.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.storage.Get(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.storage.Remove(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	files, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, gc.HasLen, 0)
+}
+
+func (s *targetStorageSuite) TestMetadataNotFound(c *gc.C) {
+	_, err := s.storage.Metadata("20180101-000000.missing")
+	c.Assert(err, gc.ErrorMatches, `backup metadata "20180101-000000.missing" not found`)
+}
+
+func (s *targetStorageSuite) TestNamesOutsideDirectoryRejected(c *gc.C) {
+	err := os.MkdirAll(s.dir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	outside := filepath.Join(filepath.Dir(s.dir), "secret.json")
+	err = ioutil.WriteFile(outside, []byte("{}"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storage.Metadata("../secret")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	err = s.storage.Remove("../secret")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	target := backups.NewDirectoryTarget(s.dir)
+	_, err = target.Get("../secret.json")
+	c.Check(err, gc.ErrorMatches, `backup file name "../secret.json" not valid`)
+	err = target.Remove("../secret.json")
+	c.Check(err, gc.ErrorMatches, `backup file name "../secret.json" not valid`)
+	err = target.Put("sub/dir", bytes.NewBufferString("x"), 1)
+	c.Check(err, gc.ErrorMatches, `backup file name "sub/dir" not valid`)
+
+	_, err = os.Stat(outside)
+	c.Assert(err, jc.ErrorIsNil)
+}
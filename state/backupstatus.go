@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// backupStatusKey is the key for the controllers document recording
+// the outcome of scheduled backups.
+const backupStatusKey = "backupStatus"
+
+// BackupStatus records the outcome of the controller's most recent
+// scheduled backups.
+type BackupStatus struct {
+	// LastSuccess is when the last successful backup completed.
+	LastSuccess time.Time `bson:"last-success"`
+
+	// LastBackupID is the ID of the last successful backup.
+	LastBackupID string `bson:"last-backup-id"`
+
+	// LastFailure is when the last backup failed.
+	LastFailure time.Time `bson:"last-failure"`
+
+	// LastError is the error with which the last backup failed.
+	LastError string `bson:"last-error"`
+}
+
+// BackupStatus returns the outcome of the controller's most recent
+// scheduled backups. If no backup has been scheduled, the zero
+// value is returned.
+func (st *State) BackupStatus() (BackupStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var status BackupStatus
+	err := controllers.FindId(backupStatusKey).One(&status)
+	if err == mgo.ErrNotFound {
+		return BackupStatus{}, nil
+	} else if err != nil {
+		return BackupStatus{}, errors.Annotate(err, "cannot get backup status")
+	}
+	return status, nil
+}
+
+// SetBackupSucceeded records that the scheduled backup with the given
+// ID completed at the given time.
+func (st *State) SetBackupSucceeded(id string, when time.Time) error {
+	return errors.Trace(st.updateBackupStatus(bson.D{
+		{"last-success", when.UTC()},
+		{"last-backup-id", id},
+	}))
+}
+
+// SetBackupFailed records that a scheduled backup failed at the given
+// time with the given error.
+func (st *State) SetBackupFailed(reason string, when time.Time) error {
+	return errors.Trace(st.updateBackupStatus(bson.D{
+		{"last-failure", when.UTC()},
+		{"last-error", reason},
+	}))
+}
+
+func (st *State) updateBackupStatus(fields bson.D) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		controllers, closer := st.db().GetCollection(controllersC)
+		defer closer()
+
+		count, err := controllers.FindId(backupStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     backupStatusKey,
+				Assert: txn.DocMissing,
+				Insert: fields,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", fields}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set backup status")
+	}
+	return nil
+}
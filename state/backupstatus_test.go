@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type backupStatusSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&backupStatusSuite{})
+
+func (s *backupStatusSuite) TestBackupStatusDefault(c *gc.C) {
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{})
+}
+
+func (s *backupStatusSuite) TestSetBackupSucceeded(c *gc.C) {
+	when := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	err := s.State.SetBackupSucceeded("backup-id", when)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.LastSuccess.Equal(when), jc.IsTrue)
+	c.Check(status.LastBackupID, gc.Equals, "backup-id")
+	c.Check(status.LastFailure.IsZero(), jc.IsTrue)
+	c.Check(status.LastError, gc.Equals, "")
+}
+
+func (s *backupStatusSuite) TestSetBackupFailedKeepsLastSuccess(c *gc.C) {
+	succeeded := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	err := s.State.SetBackupSucceeded("backup-id", succeeded)
+	c.Assert(err, jc.ErrorIsNil)
+	failed := succeeded.Add(24 * time.Hour)
+	err = s.State.SetBackupFailed("disk full", failed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.LastSuccess.Equal(succeeded), jc.IsTrue)
+	c.Check(status.LastBackupID, gc.Equals, "backup-id")
+	c.Check(status.LastFailure.Equal(failed), jc.IsTrue)
+	c.Check(status.LastError, gc.Equals, "disk full")
+}
@@ -0,0 +1,614 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// CharmRolloutStatus describes the progress of a rolling charm upgrade.
+type CharmRolloutStatus string
+
+const (
+	// CharmRolloutRunning indicates that the upgrade is still being
+	// released to the application's units.
+	CharmRolloutRunning CharmRolloutStatus = "running"
+
+	// CharmRolloutPaused indicates that a unit went into an error
+	// state during the upgrade. Units that have not yet been released
+	// remain on the old charm until the rollout is resumed.
+	CharmRolloutPaused CharmRolloutStatus = "paused"
+
+	// CharmRolloutCompleted indicates that the upgrade has been
+	// released to every unit, and every unit became ready.
+	CharmRolloutCompleted CharmRolloutStatus = "completed"
+
+	// CharmRolloutRolledBack indicates that a unit went into an
+	// error state during the upgrade, and the application's charm
+	// was set back to the charm it was upgraded from.
+	CharmRolloutRolledBack CharmRolloutStatus = "rolled-back"
+
+	// CharmRolloutCancelled indicates that the application's charm
+	// was changed again before the upgrade had been released to
+	// every unit.
+	CharmRolloutCancelled CharmRolloutStatus = "cancelled"
+)
+
+// CharmRolloutOnError describes what a rolling charm upgrade does when
+// a unit goes into an error state.
+type CharmRolloutOnError string
+
+const (
+	// CharmRolloutPause pauses the rollout, leaving units that have
+	// not yet been released on the old charm.
+	CharmRolloutPause CharmRolloutOnError = "pause"
+
+	// CharmRolloutRollback sets the application's charm back to the
+	// charm it was upgraded from.
+	CharmRolloutRollback CharmRolloutOnError = "rollback"
+)
+
+// Validate returns an error if the value is not a known action.
+func (e CharmRolloutOnError) Validate() error {
+	switch e {
+	case CharmRolloutPause, CharmRolloutRollback:
+		return nil
+	}
+	return errors.NotValidf("on error action %q", string(e))
+}
+
+// CharmRolloutParams contains the parameters for upgrading an
+// application's charm a few units at a time.
+type CharmRolloutParams struct {
+	// BatchSize is the number of units to which the upgrade is
+	// released at once. The next batch is released once every unit
+	// in the batch is ready.
+	BatchSize int
+
+	// ReadyStatus is the workload status that a unit must report,
+	// with an idle agent, to be considered ready. If empty, units
+	// must report an active workload status.
+	ReadyStatus status.Status
+
+	// OnError is what to do when a released unit goes into an error
+	// state. If empty, the rollout is paused.
+	OnError CharmRolloutOnError
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p CharmRolloutParams) Validate() error {
+	if p.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", p.BatchSize)
+	}
+	if p.ReadyStatus != "" && !status.ValidWorkloadStatus(p.ReadyStatus) {
+		return errors.NotValidf("ready status %q", p.ReadyStatus)
+	}
+	if p.OnError != "" {
+		if err := p.OnError.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CharmRollout records the progress of a rolling upgrade of an
+// application's charm. While the rollout is running or paused, units
+// to which the upgrade has not been released are told to keep running
+// the charm the application was upgraded from.
+type CharmRollout struct {
+	st  *State
+	doc charmRolloutDoc
+}
+
+// charmRolloutDoc records the progress of a rolling charm upgrade.
+// There is at most one per application, keyed on the application
+// name; starting a new rolling upgrade replaces the previous record.
+type charmRolloutDoc struct {
+	DocID                    string              `bson:"_id"`
+	ModelUUID                string              `bson:"model-uuid"`
+	Application              string              `bson:"application"`
+	FromCharmURL             string              `bson:"from-charm-url"`
+	FromCharmModifiedVersion int                 `bson:"from-charm-modified-version"`
+	ToCharmURL               string              `bson:"to-charm-url"`
+	BatchSize                int                 `bson:"batch-size"`
+	ReadyStatus              status.Status       `bson:"ready-status"`
+	OnError                  CharmRolloutOnError `bson:"on-error"`
+	Status                   CharmRolloutStatus  `bson:"status"`
+	Message                  string              `bson:"message,omitempty"`
+	Started                  time.Time           `bson:"started"`
+	Finished                 time.Time           `bson:"finished"`
+	ReleasedUnits            []string            `bson:"released-units"`
+	PendingUnits             []string            `bson:"pending-units"`
+}
+
+// Application returns the name of the upgraded application.
+func (r *CharmRollout) Application() string {
+	return r.doc.Application
+}
+
+// FromCharmURL returns the URL of the charm the application was
+// upgraded from.
+func (r *CharmRollout) FromCharmURL() string {
+	return r.doc.FromCharmURL
+}
+
+// ToCharmURL returns the URL of the charm the application was
+// upgraded to.
+func (r *CharmRollout) ToCharmURL() string {
+	return r.doc.ToCharmURL
+}
+
+// BatchSize returns the number of units to which the upgrade is
+// released at once.
+func (r *CharmRollout) BatchSize() int {
+	return r.doc.BatchSize
+}
+
+// ReadyStatus returns the workload status that units must report to
+// be considered ready.
+func (r *CharmRollout) ReadyStatus() status.Status {
+	return r.doc.ReadyStatus
+}
+
+// OnError returns what the rollout does when a unit goes into an
+// error state.
+func (r *CharmRollout) OnError() CharmRolloutOnError {
+	return r.doc.OnError
+}
+
+// Status returns the status of the rollout.
+func (r *CharmRollout) Status() CharmRolloutStatus {
+	return r.doc.Status
+}
+
+// Message returns a description of why the rollout was paused or
+// rolled back, if it was.
+func (r *CharmRollout) Message() string {
+	return r.doc.Message
+}
+
+// Started returns the time at which the upgrade started.
+func (r *CharmRollout) Started() time.Time {
+	return r.doc.Started
+}
+
+// Finished returns the time at which the rollout completed, was
+// rolled back or was cancelled, or the zero time if it is still
+// running or paused.
+func (r *CharmRollout) Finished() time.Time {
+	return r.doc.Finished
+}
+
+// ReleasedUnits returns the names of the units to which the upgrade
+// has been released, in the order in which they were released.
+func (r *CharmRollout) ReleasedUnits() []string {
+	return r.doc.ReleasedUnits
+}
+
+// PendingUnits returns the names of the units to which the upgrade
+// has yet to be released.
+func (r *CharmRollout) PendingUnits() []string {
+	return r.doc.PendingUnits
+}
+
+// active reports whether the rollout is still holding units back.
+func (doc *charmRolloutDoc) active() bool {
+	return doc.Status == CharmRolloutRunning || doc.Status == CharmRolloutPaused
+}
+
+// holdsUnit reports whether the rollout is holding the named unit on
+// the charm the application was upgraded from.
+func (doc *charmRolloutDoc) holdsUnit(unitName string) bool {
+	if !doc.active() {
+		return false
+	}
+	for _, name := range doc.PendingUnits {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *State) charmRolloutDoc(appName string) (*charmRolloutDoc, error) {
+	coll, closer := st.db().GetCollection(charmRolloutsC)
+	defer closer()
+
+	var doc charmRolloutDoc
+	if err := coll.FindId(appName).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm rollout for application %q", appName)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "querying charm rollout for application %q", appName)
+	}
+	return &doc, nil
+}
+
+// CharmRollout returns the record of the most recent rolling upgrade
+// of the application's charm.
+func (a *Application) CharmRollout() (*CharmRollout, error) {
+	doc, err := a.st.charmRolloutDoc(a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CharmRollout{a.st, *doc}, nil
+}
+
+// UnitCharmURL returns the charm URL that the named unit of the
+// application should be running, and whether the unit should upgrade
+// to it even if it is in an error state. This is the application's
+// charm URL unless a rolling upgrade has yet to be released to the
+// unit, in which case it is the URL of the charm being upgraded from.
+func (a *Application) UnitCharmURL(unitName string) (*charm.URL, bool, error) {
+	doc, err := a.heldCharmRollout(unitName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if doc == nil {
+		return a.doc.CharmURL, a.doc.ForceCharm, nil
+	}
+	curl, err := charm.ParseURL(doc.FromCharmURL)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return curl, false, nil
+}
+
+// UnitCharmModifiedVersion returns the charm modified version that
+// the named unit of the application should be running. As with
+// UnitCharmURL, this accounts for units held back by a rolling upgrade.
+func (a *Application) UnitCharmModifiedVersion(unitName string) (int, error) {
+	doc, err := a.heldCharmRollout(unitName)
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	if doc == nil {
+		return a.doc.CharmModifiedVersion, nil
+	}
+	return doc.FromCharmModifiedVersion, nil
+}
+
+// heldCharmRollout returns the application's charm rollout if it is
+// holding back the named unit, or nil if it is not.
+func (a *Application) heldCharmRollout(unitName string) (*charmRolloutDoc, error) {
+	doc, err := a.st.charmRolloutDoc(a.doc.Name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc.ToCharmURL != a.doc.CharmURL.String() || !doc.holdsUnit(unitName) {
+		return nil, nil
+	}
+	return doc, nil
+}
+
+// ResumeCharmRollout resumes the application's paused rolling charm
+// upgrade.
+func (a *Application) ResumeCharmRollout() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resume charm rollout for application %q", a.doc.Name)
+	ops := []txn.Op{{
+		C:  charmRolloutsC,
+		Id: a.doc.Name,
+		Assert: bson.D{
+			{"status", CharmRolloutPaused},
+		},
+		Update: bson.D{{"$set", bson.D{
+			{"status", CharmRolloutRunning},
+			{"message", ""},
+		}}},
+	}}
+	err = a.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		doc, err := a.st.charmRolloutDoc(a.doc.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("rollout is %s, not paused", doc.Status)
+	}
+	return errors.Trace(err)
+}
+
+// charmRolloutOps returns the operations necessary to record the start
+// of a rolling upgrade from the application's current charm to the
+// specified one, or to cancel the application's active rollout when
+// the charm is changed without one. It must be called with the
+// application's document up to date.
+func (a *Application) charmRolloutOps(to *Charm, rollout *CharmRolloutParams) ([]txn.Op, error) {
+	var ops []txn.Op
+	existing, err := a.st.charmRolloutDoc(a.doc.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if existing != nil {
+		if existing.active() {
+			if rollout != nil {
+				return nil, errors.Errorf("rolling upgrade to %q is already %s", existing.ToCharmURL, existing.Status)
+			}
+			ops = append(ops, txn.Op{
+				C:      charmRolloutsC,
+				Id:     existing.DocID,
+				Assert: bson.D{{"status", existing.Status}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", CharmRolloutCancelled},
+					{"finished", a.st.clock().Now()},
+				}}},
+			})
+		}
+	}
+	if rollout == nil {
+		return ops, nil
+	}
+
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitNames []string
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	naturalsort.Sort(unitNames)
+	batch := rollout.BatchSize
+	if batch > len(unitNames) {
+		batch = len(unitNames)
+	}
+	readyStatus := rollout.ReadyStatus
+	if readyStatus == "" {
+		readyStatus = status.Active
+	}
+	onError := rollout.OnError
+	if onError == "" {
+		onError = CharmRolloutPause
+	}
+	doc := &charmRolloutDoc{
+		DocID:                    a.doc.Name,
+		ModelUUID:                a.st.ModelUUID(),
+		Application:              a.doc.Name,
+		FromCharmURL:             a.doc.CharmURL.String(),
+		FromCharmModifiedVersion: a.doc.CharmModifiedVersion,
+		ToCharmURL:               to.URL().String(),
+		BatchSize:                rollout.BatchSize,
+		ReadyStatus:              readyStatus,
+		OnError:                  onError,
+		Status:                   CharmRolloutRunning,
+		Started:                  a.st.clock().Now(),
+		ReleasedUnits:            unitNames[:batch],
+		PendingUnits:             unitNames[batch:],
+	}
+	if existing == nil {
+		ops = append(ops, txn.Op{
+			C:      charmRolloutsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	} else {
+		// Replace the record of the previous rollout.
+		ops = append(ops, txn.Op{
+			C:      charmRolloutsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"status", existing.Status}},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"from-charm-url", doc.FromCharmURL},
+					{"from-charm-modified-version", doc.FromCharmModifiedVersion},
+					{"to-charm-url", doc.ToCharmURL},
+					{"batch-size", doc.BatchSize},
+					{"ready-status", doc.ReadyStatus},
+					{"on-error", doc.OnError},
+					{"status", doc.Status},
+					{"started", doc.Started},
+					{"finished", doc.Finished},
+					{"released-units", doc.ReleasedUnits},
+					{"pending-units", doc.PendingUnits},
+				}},
+				{"$unset", bson.D{{"message", nil}}},
+			},
+		})
+	}
+	return ops, nil
+}
+
+// removeCharmRolloutOps returns the operations necessary to remove the
+// record of the application's rolling charm upgrade, if there is one.
+func removeCharmRolloutOps(st *State, appName string) ([]txn.Op, error) {
+	if _, err := st.charmRolloutDoc(appName); errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      charmRolloutsC,
+		Id:     appName,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}, nil
+}
+
+// ProgressCharmRollouts releases rolling charm upgrades to the next
+// batch of units once every unit in the previous batch is ready, and
+// pauses or rolls back upgrades for which a released unit is in an
+// error state. Failure to progress one application's upgrade is
+// logged, and does not prevent the others progressing.
+func (st *State) ProgressCharmRollouts() error {
+	coll, closer := st.db().GetCollection(charmRolloutsC)
+	defer closer()
+
+	var docs []charmRolloutDoc
+	if err := coll.Find(bson.D{{"status", CharmRolloutRunning}}).All(&docs); err != nil {
+		return errors.Annotate(err, "querying running charm rollouts")
+	}
+	for _, doc := range docs {
+		if err := st.progressCharmRollout(doc); err != nil {
+			logger.Errorf("progressing charm rollout for application %q: %v", doc.Application, err)
+		}
+	}
+	return nil
+}
+
+func (st *State) progressCharmRollout(doc charmRolloutDoc) error {
+	app, err := st.Application(doc.Application)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if app.doc.CharmURL.String() != doc.ToCharmURL {
+		// The charm has since been changed without cancelling
+		// the rollout; there is nothing left to release.
+		return nil
+	}
+
+	var unready, failed []string
+	for _, unitName := range doc.ReleasedUnits {
+		unit, err := st.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			continue
+		}
+		ready, inError, err := charmRolloutUnitReady(unit, doc)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if inError {
+			failed = append(failed, unitName)
+		} else if !ready {
+			unready = append(unready, unitName)
+		}
+	}
+	if len(failed) > 0 {
+		message := fmt.Sprintf("unit %s in error state", strings.Join(failed, ", "))
+		if len(failed) > 1 {
+			message = fmt.Sprintf("units %s in error state", strings.Join(failed, ", "))
+		}
+		if doc.OnError == CharmRolloutRollback {
+			return errors.Trace(st.rollBackCharmRollout(app, doc, message))
+		}
+		return errors.Trace(st.updateCharmRollout(doc, bson.D{
+			{"status", CharmRolloutPaused},
+			{"message", message},
+		}))
+	}
+	if len(unready) > 0 {
+		return nil
+	}
+	if len(doc.PendingUnits) == 0 {
+		return errors.Trace(st.updateCharmRollout(doc, bson.D{
+			{"status", CharmRolloutCompleted},
+			{"finished", st.clock().Now()},
+		}))
+	}
+	batch := doc.BatchSize
+	if batch > len(doc.PendingUnits) {
+		batch = len(doc.PendingUnits)
+	}
+	released := append(append([]string{}, doc.ReleasedUnits...), doc.PendingUnits[:batch]...)
+	return errors.Trace(st.updateCharmRollout(doc, bson.D{
+		{"released-units", released},
+		{"pending-units", doc.PendingUnits[batch:]},
+	}))
+}
+
+// updateCharmRollout sets the specified fields of a running rollout.
+func (st *State) updateCharmRollout(doc charmRolloutDoc, set bson.D) error {
+	ops := []txn.Op{{
+		C:  charmRolloutsC,
+		Id: doc.DocID,
+		Assert: bson.D{
+			{"status", CharmRolloutRunning},
+			{"to-charm-url", doc.ToCharmURL},
+			{"pending-units", doc.PendingUnits},
+		},
+		Update: bson.D{{"$set", set}},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// The rollout was changed concurrently; it will be
+		// progressed again next time.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// rollBackCharmRollout sets the application's charm back to the charm
+// it was upgraded from, and records why.
+func (st *State) rollBackCharmRollout(app *Application, doc charmRolloutDoc, message string) error {
+	curl, err := charm.ParseURL(doc.FromCharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		// Without the old charm there is nothing to roll back
+		// to, so hold the remaining units where they are.
+		return errors.Trace(st.updateCharmRollout(doc, bson.D{
+			{"status", CharmRolloutPaused},
+			{"message", fmt.Sprintf("%s; cannot roll back: %v", message, err)},
+		}))
+	}
+	if err := app.SetCharm(SetCharmConfig{
+		Charm:      ch,
+		Channel:    csparams.Channel(app.doc.Channel),
+		ForceUnits: true,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	// Changing the charm cancelled the rollout; record that it was
+	// rolled back instead.
+	ops := []txn.Op{{
+		C:  charmRolloutsC,
+		Id: doc.DocID,
+		Assert: bson.D{
+			{"status", CharmRolloutCancelled},
+			{"to-charm-url", doc.ToCharmURL},
+		},
+		Update: bson.D{{"$set", bson.D{
+			{"status", CharmRolloutRolledBack},
+			{"message", message},
+		}}},
+	}}
+	if err := st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// charmRolloutUnitReady reports whether the unit has upgraded to the
+// rollout's charm and reports the rollout's ready status with an idle
+// agent, and whether the unit is in an error state.
+func charmRolloutUnitReady(unit *Unit, doc charmRolloutDoc) (ready, inError bool, _ error) {
+	workload, err := unit.Status()
+	if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	if workload.Status == status.Error {
+		return false, true, nil
+	}
+	if curl, _ := unit.CharmURL(); curl == nil || curl.String() != doc.ToCharmURL {
+		return false, false, nil
+	}
+	agent, err := unit.AgentStatus()
+	if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	return agent.Status == status.Idle && workload.Status == doc.ReadyStatus, false, nil
+}
@@ -0,0 +1,349 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type CharmRolloutSuite struct {
+	ConnSuite
+	charm       *state.Charm
+	newCharm    *state.Charm
+	application *state.Application
+	units       []*state.Unit
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.application = s.AddTestingApplication(c, "mysql", s.charm)
+	s.units = nil
+	for i := 0; i < 5; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToNewMachine()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *CharmRolloutSuite) upgrade(c *gc.C, onError state.CharmRolloutOnError) {
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		Rollout: &state.CharmRolloutParams{
+			BatchSize: 2,
+			OnError:   onError,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) rollout(c *gc.C) *state.CharmRollout {
+	err := s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err := s.application.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	return rollout
+}
+
+// setReady upgrades the unit and makes it report an active workload
+// with an idle agent.
+func (s *CharmRolloutSuite) setReady(c *gc.C, unit *state.Unit) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) setError(c *gc.C, unit *state.Unit) {
+	err := unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "upgrade-charm"`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) assertUnitCharmURL(c *gc.C, unitName string, expect *state.Charm) {
+	err := s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _, err := s.application.UnitCharmURL(unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, expect.URL())
+}
+
+func (s *CharmRolloutSuite) TestSetCharmStartsRollout(c *gc.C) {
+	oldVersion := s.application.CharmModifiedVersion()
+	s.upgrade(c, "")
+
+	rollout := s.rollout(c)
+	c.Assert(rollout.Application(), gc.Equals, "mysql")
+	c.Assert(rollout.FromCharmURL(), gc.Equals, s.charm.URL().String())
+	c.Assert(rollout.ToCharmURL(), gc.Equals, s.newCharm.URL().String())
+	c.Assert(rollout.BatchSize(), gc.Equals, 2)
+	c.Assert(rollout.ReadyStatus(), gc.Equals, status.Active)
+	c.Assert(rollout.OnError(), gc.Equals, state.CharmRolloutPause)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Assert(rollout.ReleasedUnits(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"mysql/2", "mysql/3", "mysql/4"})
+
+	// The application's charm has changed, but units that the
+	// upgrade has not been released to are held on the old charm.
+	curl, _ := s.application.CharmURL()
+	c.Assert(curl, gc.DeepEquals, s.newCharm.URL())
+	s.assertUnitCharmURL(c, "mysql/1", s.newCharm)
+	s.assertUnitCharmURL(c, "mysql/2", s.charm)
+	version, err := s.application.UnitCharmModifiedVersion("mysql/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, oldVersion)
+	version, err = s.application.UnitCharmModifiedVersion("mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, oldVersion+1)
+
+	// Units added during the rollout get the new charm.
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitCharmURL(c, unit.Name(), s.newCharm)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmRolloutInvalid(c *gc.C) {
+	for i, test := range []struct {
+		rollout state.CharmRolloutParams
+		err     string
+	}{{
+		rollout: state.CharmRolloutParams{},
+		err:     `.*batch size 0 not valid`,
+	}, {
+		rollout: state.CharmRolloutParams{BatchSize: 1, ReadyStatus: "idle"},
+		err:     `.*ready status "idle" not valid`,
+	}, {
+		rollout: state.CharmRolloutParams{BatchSize: 1, OnError: "explode"},
+		err:     `.*on error action "explode" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		rollout := test.rollout
+		err := s.application.SetCharm(state.SetCharmConfig{
+			Charm:   s.newCharm,
+			Rollout: &rollout,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRollouts(c *gc.C) {
+	s.upgrade(c, "")
+
+	// Nothing is released until the first batch is ready.
+	s.setReady(c, s.units[0])
+	err := s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rollout(c).PendingUnits(), gc.HasLen, 3)
+
+	s.setReady(c, s.units[1])
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.rollout(c)
+	c.Assert(rollout.ReleasedUnits(), jc.DeepEquals, []string{"mysql/0", "mysql/1", "mysql/2", "mysql/3"})
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"mysql/4"})
+	s.assertUnitCharmURL(c, "mysql/3", s.newCharm)
+	s.assertUnitCharmURL(c, "mysql/4", s.charm)
+
+	for _, unit := range s.units[2:4] {
+		s.setReady(c, unit)
+	}
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rollout(c).PendingUnits(), gc.HasLen, 0)
+
+	s.setReady(c, s.units[4])
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutCompleted)
+	c.Assert(rollout.Finished().IsZero(), jc.IsFalse)
+}
+
+func (s *CharmRolloutSuite) TestActiveCharmRolloutsUnmigratable(c *gc.C) {
+	s.upgrade(c, "")
+	entities, err := s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []string{"active charm rollouts (1)"})
+
+	// Completed rollouts don't stop the model being migrated.
+	for _, unit := range s.units {
+		s.setReady(c, unit)
+	}
+	for i := 0; i < 3; i++ {
+		err = s.State.ProgressCharmRollouts()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.rollout(c).Status(), gc.Equals, state.CharmRolloutCompleted)
+	entities, err = s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, gc.HasLen, 0)
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRolloutsContinuesAfterFailure(c *gc.C) {
+	s.upgrade(c, "")
+	err := s.MgoSuite.Session.DB("juju").C("charmrollouts").Update(
+		bson.D{{"application", "mysql"}},
+		bson.D{{"$set", bson.D{{"released-units", []string{"bogus"}}}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := s.AddTestingApplication(c, "other", s.charm)
+	var units []*state.Unit
+	for i := 0; i < 3; i++ {
+		unit, err := other.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		units = append(units, unit)
+	}
+	err = other.SetCharm(state.SetCharmConfig{
+		Charm:   s.newCharm,
+		Rollout: &state.CharmRolloutParams{BatchSize: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range units[:2] {
+		s.setReady(c, unit)
+	}
+
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rollout(c).ReleasedUnits(), jc.DeepEquals, []string{"bogus"})
+	rollout, err := other.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.PendingUnits(), gc.HasLen, 0)
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRolloutsPausesOnError(c *gc.C) {
+	s.upgrade(c, state.CharmRolloutPause)
+	s.setReady(c, s.units[0])
+	s.setError(c, s.units[1])
+
+	err := s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutPaused)
+	c.Assert(rollout.Message(), gc.Equals, "unit mysql/1 in error state")
+	c.Assert(rollout.PendingUnits(), gc.HasLen, 3)
+	s.assertUnitCharmURL(c, "mysql/2", s.charm)
+
+	// Paused rollouts are not progressed, even once the unit is
+	// no longer in error.
+	s.setReady(c, s.units[1])
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rollout(c).PendingUnits(), gc.HasLen, 3)
+
+	err = s.application.ResumeCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Assert(rollout.Message(), gc.Equals, "")
+	err = s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rollout(c).PendingUnits(), gc.HasLen, 1)
+
+	err = s.application.ResumeCharmRollout()
+	c.Assert(err, gc.ErrorMatches, `cannot resume charm rollout for application "mysql": rollout is running, not paused`)
+}
+
+func (s *CharmRolloutSuite) TestProgressCharmRolloutsRollsBackOnError(c *gc.C) {
+	oldVersion := s.application.CharmModifiedVersion()
+	s.upgrade(c, state.CharmRolloutRollback)
+	s.setError(c, s.units[0])
+
+	err := s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutRolledBack)
+	c.Assert(rollout.Message(), gc.Equals, "unit mysql/0 in error state")
+
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, force := s.application.CharmURL()
+	c.Assert(curl, gc.DeepEquals, s.charm.URL())
+	c.Assert(force, jc.IsTrue)
+	c.Assert(s.application.CharmModifiedVersion(), gc.Equals, oldVersion+2)
+	s.assertUnitCharmURL(c, "mysql/0", s.charm)
+	s.assertUnitCharmURL(c, "mysql/4", s.charm)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmCancelsRollout(c *gc.C) {
+	s.upgrade(c, "")
+
+	newerCharm := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm: newerCharm,
+		Rollout: &state.CharmRolloutParams{
+			BatchSize: 1,
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-3": rolling upgrade to "local:quantal/quantal-mysql-2" is already running`)
+
+	// Upgrading without a rollout releases the upgrade to every
+	// unit at once.
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: newerCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutCancelled)
+	s.assertUnitCharmURL(c, "mysql/4", newerCharm)
+
+	// A new rolling upgrade replaces the record of the old one.
+	err = s.application.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		Rollout: &state.CharmRolloutParams{
+			BatchSize: 4,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rollout = s.rollout(c)
+	c.Assert(rollout.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Assert(rollout.FromCharmURL(), gc.Equals, newerCharm.URL().String())
+	c.Assert(rollout.PendingUnits(), jc.DeepEquals, []string{"mysql/4"})
+}
+
+func (s *CharmRolloutSuite) TestWatchApplicationNotifiesOnRelease(c *gc.C) {
+	s.upgrade(c, "")
+	w := s.application.Watch()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.setReady(c, s.units[0])
+	s.setReady(c, s.units[1])
+	err := s.State.ProgressCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *CharmRolloutSuite) TestRemoveApplicationRemovesRollout(c *gc.C) {
+	s.upgrade(c, "")
+	for _, unit := range s.units {
+		err := unit.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -42,6 +42,16 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.CharmStoreURL,
 		controller.Features,
 		controller.MeteringURL,
+		controller.BackupStorage,
+		controller.BackupStoragePath,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
+		controller.BackupSchedule,
+		controller.BackupRetentionCount,
+		controller.BackupRetentionAge,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -61,6 +61,11 @@ type Filesystem interface {
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
 
+	// RequestedSize returns the size in MiB that the provisioned
+	// filesystem has been asked to grow to, and true if it has yet
+	// to reach it.
+	RequestedSize() (uint64, bool)
+
 	// Detachable reports whether or not the filesystem is detachable.
 	Detachable() bool
 
@@ -116,6 +121,11 @@ type filesystemDoc struct {
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
 
+	// RequestedSize, if non-zero, is the size in MiB that the
+	// filesystem has been asked to grow to since it was provisioned.
+	// It is removed once the filesystem has been resized.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the filesystem as being non-detachable, and to determine
@@ -153,6 +163,11 @@ type FilesystemParams struct {
 	// filesystem entity for an existing volume backed filesystem.
 	volumeInfo *VolumeInfo
 
+	// snapshot, if non-empty, is the ID of the volume snapshot whose
+	// contents the filesystem's backing volume is to be created with.
+	// Only volume-backed filesystems may be created from snapshots.
+	snapshot string
+
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
 }
@@ -247,6 +262,14 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	if f.doc.Info == nil || f.doc.RequestedSize <= f.doc.Info.Size {
+		return 0, false
+	}
+	return f.doc.RequestedSize, true
+}
+
 // Releasing is required to implement Filesystem.
 func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
@@ -1060,6 +1083,11 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 	if err != nil {
 		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
 	}
+	if provider.Supports(storage.StorageKindFilesystem) && params.snapshot != "" {
+		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.NotSupportedf(
+			"creating filesystem from snapshot with storage pool %q", params.Pool,
+		)
+	}
 	if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		if params.volumeInfo != nil {
@@ -1068,10 +1096,11 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.filesystemId = filesystemTag.String()
 		}
 		volumeParams := VolumeParams{
-			params.storage,
-			params.volumeInfo,
-			params.Pool,
-			params.Size,
+			storage:    params.storage,
+			volumeInfo: params.volumeInfo,
+			Pool:       params.Pool,
+			Size:       params.Size,
+			Snapshot:   params.snapshot,
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
@@ -1253,7 +1282,13 @@ func (sb *storageBackend) SetFilesystemInfo(tag names.FilesystemTag, info Filesy
 				return nil, err
 			}
 		}
-		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		// If the filesystem has been resized as requested,
+		// the request is removed along with setting info.
+		var resized uint64
+		if requested, ok := fs.RequestedSize(); ok && info.Size >= requested {
+			resized = requested
+		}
+		ops := setFilesystemInfoOps(tag, info, unsetParams, resized)
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1275,15 +1310,23 @@ func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	return nil
 }
 
-func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams bool) []txn.Op {
+func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams bool, resized uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if resized > 0 {
+		asserts = append(asserts, bson.DocElem{"requestedsize", resized})
+		unset = append(unset, bson.DocElem{"requestedsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      filesystemsC,
@@ -1293,6 +1336,64 @@ func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetPar
 	}}
 }
 
+// ResizeFilesystem requests that the filesystem be grown to the specified
+// size in MiB. If the filesystem has not yet been provisioned, its
+// provisioning parameters are updated instead. Filesystems cannot be
+// shrunk.
+func (sb *storageBackend) ResizeFilesystem(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize filesystem %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return resizeFilesystemOps(f, size)
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+func resizeFilesystemOps(f *filesystem, size uint64) ([]txn.Op, error) {
+	if f.Life() != Alive {
+		return nil, errors.New("filesystem is not alive")
+	}
+	if params, ok := f.Params(); ok {
+		if size < params.Size {
+			return nil, errors.Errorf("cannot shrink filesystem from %dM to %dM", params.Size, size)
+		}
+		if size == params.Size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: append(bson.D{{"params", bson.D{{"$exists", true}}}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"params.size", size}}}},
+		}}, nil
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size < info.Size {
+		return nil, errors.Errorf("cannot shrink filesystem from %dM to %dM", info.Size, size)
+	}
+	requested, _ := f.RequestedSize()
+	if size == requested || (requested == 0 && size == info.Size) {
+		return nil, jujutxn.ErrNoOperations
+	}
+	update := bson.D{{"$set", bson.D{{"requestedsize", size}}}}
+	if size == info.Size {
+		// Withdraw the pending request.
+		update = bson.D{{"$unset", bson.D{{"requestedsize", nil}}}}
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: update,
+	}}, nil
+}
+
 // SetFilesystemAttachmentInfo sets the FilesystemAttachmentInfo for the
 // specified filesystem attachment.
 func (sb *storageBackend) SetFilesystemAttachmentInfo(
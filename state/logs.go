@@ -314,6 +314,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -321,7 +322,16 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// MessageRegex, if set, is a regular expression that the message
+	// of each record must match. It is matched by MongoDB, whose PCRE
+	// syntax is a superset of the RE2 syntax used by Go's regexp
+	// package; patterns are validated as RE2 so that features
+	// outside it, such as lookaround, are rejected rather than
+	// behaving differently from wherever else a pattern is checked.
+	MessageRegex string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -357,6 +367,11 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	if params.MessageRegex != "" {
+		if _, err := regexp.Compile(params.MessageRegex); err != nil {
+			return nil, errors.Errorf("message regex %q is not valid: %v", params.MessageRegex, err)
+		}
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
@@ -618,8 +633,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+	if !params.StartTime.IsZero() || !params.EndTime.IsZero() {
+		timeSel := bson.M{}
+		if !params.StartTime.IsZero() {
+			timeSel["$gte"] = params.StartTime.UnixNano()
+		}
+		if !params.EndTime.IsZero() {
+			timeSel["$lte"] = params.EndTime.UnixNano()
+		}
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -640,6 +662,9 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
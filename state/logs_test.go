@@ -386,6 +386,40 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeWindowFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(6*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(5 * time.Second),
+		NoTail:    true,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -781,6 +815,32 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	refused := logTemplate{Message: "dial tcp 10.0.0.1:17070: connection refused"}
+	other := logTemplate{Message: "all good"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 2, refused)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		MessageRegex: "connection (refused|reset)",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, refused)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessageRegexInvalid(c *gc.C) {
+	// Lookahead is supported by MongoDB, but not by Go's regexp
+	// package, so the pattern is rejected.
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		MessageRegex: "connection (?=refused)",
+	})
+	c.Assert(err, gc.ErrorMatches, `message regex "connection \(\?=refused\)" is not valid: .*`)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -317,6 +317,57 @@ func (st *State) MetricBatchesForApplication(application string) ([]MetricBatch,
 	return st.queryMetricBatches(bson.M{"$or": unitNames})
 }
 
+// LatestUnitMetrics returns the latest value of each metric recorded
+// by each of the application's units, keyed on unit name. Metrics
+// recorded with labels are not included.
+func (a *Application) LatestUnitMetrics() (map[string][]Metric, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]Metric)
+	if len(units) == 0 {
+		return result, nil
+	}
+	unitNames := make([]string, len(units))
+	for i, u := range units {
+		unitNames[i] = u.Name()
+	}
+	batches, err := a.st.queryMetricBatches(bson.M{
+		"model-uuid": a.st.ModelUUID(),
+		"unit":       bson.M{"$in": unitNames},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	latest := make(map[string]map[string]Metric)
+	for _, batch := range batches {
+		unitLatest, ok := latest[batch.Unit()]
+		if !ok {
+			unitLatest = make(map[string]Metric)
+			latest[batch.Unit()] = unitLatest
+		}
+		for _, m := range batch.Metrics() {
+			if len(m.Labels) > 0 {
+				continue
+			}
+			if last, ok := unitLatest[m.Key]; ok && last.Time.After(m.Time) {
+				continue
+			}
+			unitLatest[m.Key] = m
+		}
+	}
+	for unitName, unitLatest := range latest {
+		metrics := make([]Metric, 0, len(unitLatest))
+		for _, m := range unitLatest {
+			metrics = append(metrics, m)
+		}
+		sort.Sort(byKey(metrics))
+		result[unitName] = metrics
+	}
+	return result, nil
+}
+
 // MetricBatch returns the metric batch with the given id.
 func (st *State) MetricBatch(id string) (*MetricBatch, error) {
 	c, closer := st.db().GetCollection(metricsC)
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -705,6 +706,75 @@ func (s *MetricSuite) TestNoSuchApplicationMetricBatches(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `application "unicorn-app" not found`)
 }
 
+func (s *MetricSuite) TestLatestUnitMetrics(c *gc.C) {
+	now := state.NowToTheSecond(s.State)
+	earlier := now.Add(-time.Minute)
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application, SetCharmURL: true})
+	s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit: s.unit,
+		Time: &earlier,
+		Metrics: []state.Metric{
+			{Key: "pings", Value: "5", Time: earlier},
+			{Key: "pongs", Value: "6", Time: earlier},
+		},
+	})
+	s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit: s.unit,
+		Time: &now,
+		Metrics: []state.Metric{
+			{Key: "pings", Value: "7", Time: now},
+			{Key: "pings", Value: "8", Time: now, Labels: map[string]string{"foo": "bar"}},
+		},
+	})
+	s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit:    unit1,
+		Time:    &now,
+		Metrics: []state.Metric{{Key: "pings", Value: "9", Time: now}},
+	})
+
+	metrics, err := s.application.LatestUnitMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics, gc.HasLen, 2)
+	c.Assert(metrics[s.unit.Name()], gc.HasLen, 2)
+	c.Check(metrics[s.unit.Name()][0].Key, gc.Equals, "pings")
+	c.Check(metrics[s.unit.Name()][0].Value, gc.Equals, "7")
+	c.Check(metrics[s.unit.Name()][1].Key, gc.Equals, "pongs")
+	c.Check(metrics[s.unit.Name()][1].Value, gc.Equals, "6")
+	c.Assert(metrics[unit1.Name()], gc.HasLen, 1)
+	c.Check(metrics[unit1.Name()][0].Value, gc.Equals, "9")
+}
+
+func (s *MetricSuite) TestLatestUnitMetricsNoUnits(c *gc.C) {
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "unmetered",
+		Charm: s.meteredCharm,
+	})
+	metrics, err := application.LatestUnitMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics, gc.HasLen, 0)
+}
+
+func (s *MetricSuite) TestWatchMetrics(c *gc.C) {
+	w := s.application.WatchMetrics()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit})
+	wc.AssertOneChange()
+
+	// Metrics of other applications are not reported.
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "other",
+		Charm: s.meteredCharm,
+	})
+	otherUnit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: other, SetCharmURL: true})
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: otherUnit})
+	wc.AssertNoChange()
+}
+
 type MetricLocalCharmSuite struct {
 	ConnSuite
 	unit         *state.Unit
@@ -204,6 +204,15 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		relationNetworksC,
 		firewallRulesC,
 		dockerResourcesC,
+		// Models with these cannot be migrated: see
+		// unmigratedCollections.
+		volumeSnapshotsC,
+		secretsC,
+		secretRevisionsC,
+		actionSchedulesC,
+		actionScheduleRunsC,
+		actionRolloutsC,
+		charmRolloutsC,
 		// TODO(raftlease)
 		// This collection shouldn't be migrated, but we need to make
 		// sure the leader units' leases are claimed in the target
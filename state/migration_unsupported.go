@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// unmigratedCollections holds the model collections whose documents
+// are not exported when the model is migrated, with a description of
+// what the documents are. Models with documents in any of them which
+// match the query, if any, cannot be migrated, as the documents would
+// be lost.
+var unmigratedCollections = []struct {
+	name        string
+	description string
+	query       bson.D
+}{
+	{volumeSnapshotsC, "volume snapshots", nil},
+	// Secrets are encrypted with a key derived from key material
+	// held by the source controller, so they could not be read
+	// in the target controller even if they were exported.
+	{secretsC, "secrets", nil},
+	{actionSchedulesC, "action schedules", nil},
+	// Finished rollouts are only a record, and may be lost.
+	{actionRolloutsC, "running action rollouts", bson.D{{"status", ActionRolloutRunning}}},
+	// The units an active charm rollout holds back would all
+	// be upgraded at once in the target controller.
+	{charmRolloutsC, "active charm rollouts", bson.D{{"status", bson.D{{"$in", []CharmRolloutStatus{
+		CharmRolloutRunning, CharmRolloutPaused,
+	}}}}}},
+}
+
+// UnmigratableEntities returns a description of each kind of entity
+// in the model which model migration does not support, such as
+// "volume snapshots (2)". The model cannot be migrated unless the
+// result is empty.
+func (st *State) UnmigratableEntities() ([]string, error) {
+	var result []string
+	for _, c := range unmigratedCollections {
+		coll, closer := st.db().GetCollection(c.name)
+		count, err := coll.Find(c.query).Count()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "counting %s", c.description)
+		}
+		if count > 0 {
+			result = append(result, fmt.Sprintf("%s (%d)", c.description, count))
+		}
+	}
+	return result, nil
+}
@@ -378,6 +378,7 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 		st.newPolicy,
 		st.clock(),
 		st.runTransactionObserver,
+		st.secretsKey,
 	)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "could not create state for new model")
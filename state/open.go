@@ -47,6 +47,11 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// SecretsKey holds the controller key material from which the
+	// keys used to encrypt each model's secrets are derived. If it
+	// is empty, secrets can be neither added nor read.
+	SecretsKey []byte
 }
 
 // Validate validates the OpenParams.
@@ -100,6 +105,7 @@ func Open(args OpenParams) (*State, error) {
 		args.NewPolicy,
 		args.Clock,
 		args.RunTransactionObserver,
+		args.SecretsKey,
 	)
 	if err != nil {
 		session.Close()
@@ -128,8 +134,9 @@ func open(
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
+	secretsKey []byte,
 ) (*State, error) {
-	st, err := newState(controllerModelTag, controllerModelTag, session, newPolicy, clock, runTransactionObserver)
+	st, err := newState(controllerModelTag, controllerModelTag, session, newPolicy, clock, runTransactionObserver, secretsKey)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -157,6 +164,7 @@ func newState(
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
+	secretsKey []byte,
 ) (_ *State, err error) {
 
 	defer func() {
@@ -182,6 +190,7 @@ func newState(
 		database:               db,
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
+		secretsKey:             secretsKey,
 	}
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
@@ -137,6 +137,7 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 		args.NewPolicy,
 		args.Clock,
 		args.RunTransactionObserver,
+		args.SecretsKey,
 	)
 	if err != nil {
 		session.Close()
@@ -242,6 +243,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 		modelTag, p.systemState.controllerModelTag,
 		session, p.systemState.newPolicy, p.systemState.stateClock,
 		p.systemState.runTransactionObserver,
+		p.systemState.secretsKey,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
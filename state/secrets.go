@@ -0,0 +1,525 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Secret describes a set of sensitive values owned by an application.
+// Each change to the values creates a new revision of the secret; the
+// values of every revision are stored encrypted, and may only be read
+// by units of the owning application, or by units that the secret has
+// been granted to.
+type Secret struct {
+	doc secretDoc
+}
+
+// SecretGrant records that a secret may be read by the units of an
+// application, or by a single unit, at the other end of a relation.
+type SecretGrant struct {
+	// RelationId is the ID of the relation the grant is scoped to.
+	// The grant lapses when the relation is removed.
+	RelationId int `bson:"relation-id"`
+
+	// RelationKey is the key of the relation the grant is scoped to.
+	RelationKey string `bson:"relation-key"`
+
+	// Application is the name of the application the secret is
+	// granted to.
+	Application string `bson:"application"`
+
+	// Unit is the name of the unit the secret is granted to. If
+	// empty, the secret is granted to all units of the application.
+	Unit string `bson:"unit,omitempty"`
+}
+
+// secretDoc records information about a secret in the model.
+type secretDoc struct {
+	DocID       string        `bson:"_id"`
+	ModelUUID   string        `bson:"model-uuid"`
+	Application string        `bson:"application"`
+	Name        string        `bson:"name"`
+	Revision    int           `bson:"revision"`
+	Created     time.Time     `bson:"created"`
+	Updated     time.Time     `bson:"updated"`
+	Grants      []SecretGrant `bson:"grants,omitempty"`
+}
+
+// secretRevisionDoc records the encrypted values of a revision
+// of a secret.
+type secretRevisionDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	SecretId  string    `bson:"secret-id"`
+	Revision  int       `bson:"revision"`
+	Created   time.Time `bson:"created"`
+	Data      []byte    `bson:"data"`
+}
+
+var validSecretName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// IsValidSecretName reports whether the string is a valid secret name,
+// or a valid key for one of the values of a secret.
+func IsValidSecretName(name string) bool {
+	return validSecretName.MatchString(name)
+}
+
+// SecretId returns the ID of the secret with the specified name,
+// owned by the specified application.
+func SecretId(application, name string) string {
+	return application + "/" + name
+}
+
+// ParseSecretId returns the owning application and name of the
+// secret with the specified ID.
+func ParseSecretId(id string) (application, name string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || !names.IsValidApplication(parts[0]) || !IsValidSecretName(parts[1]) {
+		return "", "", errors.NotValidf("secret ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func secretRevisionId(id string, revision int) string {
+	return fmt.Sprintf("%s#%d", id, revision)
+}
+
+// Id returns the ID of the secret.
+func (s *Secret) Id() string {
+	return SecretId(s.doc.Application, s.doc.Name)
+}
+
+// Application returns the name of the application that owns the secret.
+func (s *Secret) Application() string {
+	return s.doc.Application
+}
+
+// Name returns the name of the secret.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Revision returns the latest revision of the secret.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// Created returns the time at which the secret was created.
+func (s *Secret) Created() time.Time {
+	return s.doc.Created
+}
+
+// Updated returns the time at which the latest revision of the
+// secret was created.
+func (s *Secret) Updated() time.Time {
+	return s.doc.Updated
+}
+
+// Grants returns the grants that allow units of other applications
+// to read the secret.
+func (s *Secret) Grants() []SecretGrant {
+	grants := make([]SecretGrant, len(s.doc.Grants))
+	copy(grants, s.doc.Grants)
+	return grants
+}
+
+// Secret returns the secret with the specified ID.
+func (st *State) Secret(id string) (*Secret, error) {
+	coll, cleanup := st.db().GetCollection(secretsC)
+	defer cleanup()
+
+	var doc secretDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{doc}, nil
+}
+
+// AllSecrets returns all of the secrets in the model.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	coll, cleanup := st.db().GetCollection(secretsC)
+	defer cleanup()
+
+	var docs []secretDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying secrets")
+	}
+	secrets := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		secrets[i] = &Secret{doc}
+	}
+	return secrets, nil
+}
+
+// AddSecret adds a secret with the specified name and values, owned by
+// the specified application. If the application already owns a secret
+// with the name, a new revision of that secret is created.
+func (st *State) AddSecret(app names.ApplicationTag, name string, values map[string]string) (*Secret, error) {
+	if !IsValidSecretName(name) {
+		return nil, errors.NotValidf("secret name %q", name)
+	}
+	id := SecretId(app.Id(), name)
+	secret, err := st.addSecretRevision(id, values, true)
+	return secret, errors.Annotatef(err, "cannot add secret %q", id)
+}
+
+// RotateSecret creates a new revision of the secret with the specified
+// ID, holding the specified values.
+func (st *State) RotateSecret(id string, values map[string]string) (*Secret, error) {
+	secret, err := st.addSecretRevision(id, values, false)
+	return secret, errors.Annotatef(err, "cannot rotate secret %q", id)
+}
+
+func (st *State) addSecretRevision(id string, values map[string]string, create bool) (*Secret, error) {
+	appName, name, err := ParseSecretId(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateSecretValues(values); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var doc secretDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		key, err := st.secretKey()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data, err := encryptSecretValues(key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := st.clock().Now()
+
+		var ops []txn.Op
+		existing, err := st.Secret(id)
+		if errors.IsNotFound(err) && create {
+			app, err := st.Application(appName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if app.Life() != Alive {
+				return nil, errors.Errorf("application %q is not alive", appName)
+			}
+			doc = secretDoc{
+				DocID:       id,
+				ModelUUID:   st.ModelUUID(),
+				Application: appName,
+				Name:        name,
+				Revision:    1,
+				Created:     now,
+				Updated:     now,
+			}
+			ops = append(ops, txn.Op{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			}, txn.Op{
+				C:      secretsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			doc = existing.doc
+			doc.Revision++
+			doc.Updated = now
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Assert: bson.D{{"revision", existing.doc.Revision}},
+				Update: bson.D{{"$set", bson.D{
+					{"revision", doc.Revision},
+					{"updated", doc.Updated},
+				}}},
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:      secretRevisionsC,
+			Id:     secretRevisionId(id, doc.Revision),
+			Assert: txn.DocMissing,
+			Insert: &secretRevisionDoc{
+				DocID:     secretRevisionId(id, doc.Revision),
+				ModelUUID: st.ModelUUID(),
+				SecretId:  id,
+				Revision:  doc.Revision,
+				Created:   now,
+				Data:      data,
+			},
+		})
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &Secret{doc}, nil
+}
+
+func validateSecretValues(values map[string]string) error {
+	if len(values) == 0 {
+		return errors.NotValidf("empty secret")
+	}
+	for key := range values {
+		if !IsValidSecretName(key) {
+			return errors.NotValidf("secret key %q", key)
+		}
+	}
+	return nil
+}
+
+// SecretValues returns the values of the specified revision of the
+// secret with the specified ID. If revision is zero, the values of
+// the latest revision are returned.
+//
+// SecretValues does not check whether any unit may read the secret;
+// use CanReadSecret for that.
+func (st *State) SecretValues(id string, revision int) (map[string]string, error) {
+	if revision == 0 {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision = secret.Revision()
+	}
+	coll, cleanup := st.db().GetCollection(secretRevisionsC)
+	defer cleanup()
+
+	var doc secretRevisionDoc
+	err := coll.FindId(secretRevisionId(id, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", id, revision)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q revision %d", id, revision)
+	}
+	key, err := st.secretKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values, err := decryptSecretValues(key, doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decrypt secret %q revision %d", id, revision)
+	}
+	return values, nil
+}
+
+// CanReadSecret reports whether the specified unit may read the
+// secret with the specified ID. Units of the owning application may
+// always read the secret; other units may read it only if it has been
+// granted to them over a relation that still exists.
+func (st *State) CanReadSecret(id string, unit names.UnitTag) (bool, error) {
+	appName, err := names.UnitApplication(unit.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	secret, err := st.Secret(id)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if secret.doc.Application == appName {
+		return true, nil
+	}
+	for _, grant := range secret.doc.Grants {
+		if grant.Application != appName {
+			continue
+		}
+		if grant.Unit != "" && grant.Unit != unit.Id() {
+			continue
+		}
+		if _, err := st.Relation(grant.RelationId); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// GrantSecret grants the secret with the specified ID to the application
+// at the other end of the specified relation. If unitName is not empty,
+// the secret is granted only to that unit of the application. The grant
+// lapses when the relation is removed.
+func (st *State) GrantSecret(id string, relationKey string, unitName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot grant secret %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rel, err := st.KeyRelation(relationKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if rel.Life() != Alive {
+			return nil, errors.Errorf("relation %q is not alive", relationKey)
+		}
+		grant := SecretGrant{
+			RelationId:  rel.Id(),
+			RelationKey: relationKey,
+			Unit:        unitName,
+		}
+		var owned bool
+		for _, ep := range rel.Endpoints() {
+			if ep.ApplicationName == secret.doc.Application {
+				owned = true
+			} else {
+				grant.Application = ep.ApplicationName
+			}
+		}
+		if !owned {
+			return nil, errors.Errorf("relation %q does not involve application %q", relationKey, secret.doc.Application)
+		}
+		if grant.Application == "" {
+			return nil, errors.NotValidf("peer relation %q", relationKey)
+		}
+		if unitName != "" {
+			unitApp, err := names.UnitApplication(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if unitApp != grant.Application {
+				return nil, errors.NotValidf("unit %q for application %q", unitName, grant.Application)
+			}
+		}
+		for _, existing := range secret.doc.Grants {
+			if existing == grant {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     rel.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$push", bson.D{{"grants", grant}}}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// removeApplicationSecretsOps returns the operations required to
+// remove all secrets owned by the application, along with all of
+// their revisions.
+func removeApplicationSecretsOps(st *State, application string) ([]txn.Op, error) {
+	secretsColl, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := secretsColl.Find(bson.D{{"application", application}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading application %q secrets", application)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	revisionsColl, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var ops []txn.Op
+	for _, doc := range docs {
+		id := SecretId(doc.Application, doc.Name)
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     id,
+			Remove: true,
+		})
+		var revisions []secretRevisionDoc
+		err := revisionsColl.Find(bson.D{{"secret-id", id}}).Select(bson.D{{"revision", 1}}).All(&revisions)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading secret %q revisions", id)
+		}
+		for _, rev := range revisions {
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     secretRevisionId(id, rev.Revision),
+				Remove: true,
+			})
+		}
+	}
+	return ops, nil
+}
+
+// secretKey returns the key used to encrypt the secrets in the model.
+// It is derived from the controller's secrets key material, which the
+// controller agents hold in their configuration.
+func (st *State) secretKey() ([]byte, error) {
+	if len(st.secretsKey) == 0 {
+		return nil, errors.New("no secrets key configured")
+	}
+	mac := hmac.New(sha256.New, st.secretsKey)
+	mac.Write([]byte(st.ModelUUID()))
+	return mac.Sum(nil), nil
+}
+
+// encryptSecretValues encrypts the values with AES-GCM, returning
+// the ciphertext prefixed with the nonce used to create it.
+func encryptSecretValues(key []byte, values map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decryptSecretValues decrypts values encrypted with encryptSecretValues.
+func decryptSecretValues(key []byte, data []byte) (map[string]string, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return values, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
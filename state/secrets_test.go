@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *SecretsSuite) addRelation(c *gc.C) *state.Relation {
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	secret, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Id(), gc.Equals, "mysql/password")
+	c.Assert(secret.Application(), gc.Equals, "mysql")
+	c.Assert(secret.Name(), gc.Equals, "password")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+
+	secret, err = s.State.Secret("mysql/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	values, err := s.State.SecretValues("mysql/password", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"root": "s3cret"})
+}
+
+func (s *SecretsSuite) TestAddSecretEncrypted(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetRawCollection(s.State, "secretrevisions")
+	defer closer()
+	var doc bson.M
+	err = coll.Find(nil).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := doc["data"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(string(data), gc.Not(jc.Contains), "s3cret")
+}
+
+func (s *SecretsSuite) openState(c *gc.C, secretsKey []byte) *state.State {
+	pool, err := state.OpenStatePool(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      s.State.ControllerTag(),
+		ControllerModelTag: s.modelTag,
+		MongoSession:       s.Session,
+		SecretsKey:         secretsKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { pool.Close() })
+	return pool.SystemState()
+}
+
+func (s *SecretsSuite) TestSecretValuesNeedControllerKey(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.openState(c, nil)
+	_, err = st.SecretValues("mysql/password", 0)
+	c.Assert(err, gc.ErrorMatches, "no secrets key configured")
+	_, err = st.AddSecret(s.mysql.ApplicationTag(), "other", map[string]string{"root": "s3cret"})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret "mysql/other": no secrets key configured`)
+
+	st = s.openState(c, []byte("another-controller-key"))
+	_, err = st.SecretValues("mysql/password", 0)
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt secret "mysql/password" revision 1: .*`)
+}
+
+func (s *SecretsSuite) TestSecretsBlockMigration(c *gc.C) {
+	entities, err := s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, gc.HasLen, 0)
+
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	entities, err = s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []string{"secrets (1)"})
+}
+
+func (s *SecretsSuite) TestAddSecretNewRevision(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+
+	values, err := s.State.SecretValues("mysql/password", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"root": "foo"})
+	values, err = s.State.SecretValues("mysql/password", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"root": "bar"})
+
+	_, err = s.State.SecretValues("mysql/password", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "Password", map[string]string{"root": "foo"})
+	c.Assert(err, gc.ErrorMatches, `secret name "Password" not valid`)
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add secret "mysql/password": empty secret not valid`)
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root user": "foo"})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret "mysql/password": secret key "root user" not valid`)
+	_, err = s.State.AddSecret(names.NewApplicationTag("foo"), "password", map[string]string{"root": "foo"})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret "foo/password": application "foo" not found`)
+}
+
+func (s *SecretsSuite) TestRotateSecret(c *gc.C) {
+	_, err := s.State.RotateSecret("mysql/password", map[string]string{"root": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot rotate secret "mysql/password": secret "mysql/password" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.RotateSecret("mysql/password", map[string]string{"root": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+}
+
+func (s *SecretsSuite) TestAllSecrets(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(s.wordpress.ApplicationTag(), "api-key", map[string]string{"key": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 2)
+	c.Assert(secrets[0].Id(), gc.Equals, "mysql/password")
+	c.Assert(secrets[1].Id(), gc.Equals, "wordpress/api-key")
+}
+
+func (s *SecretsSuite) TestCanReadSecret(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	canRead := func(unit string) bool {
+		ok, err := s.State.CanReadSecret("mysql/password", names.NewUnitTag(unit))
+		c.Assert(err, jc.ErrorIsNil)
+		return ok
+	}
+	c.Assert(canRead("mysql/0"), jc.IsTrue)
+	c.Assert(canRead("wordpress/0"), jc.IsFalse)
+
+	rel := s.addRelation(c)
+	err = s.State.GrantSecret("mysql/password", rel.String(), "wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead("wordpress/0"), jc.IsFalse)
+	c.Assert(canRead("wordpress/1"), jc.IsTrue)
+
+	err = s.State.GrantSecret("mysql/password", rel.String(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead("wordpress/0"), jc.IsTrue)
+
+	// Grants lapse when the relation is removed.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead("wordpress/0"), jc.IsFalse)
+	c.Assert(canRead("mysql/0"), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestGrantSecret(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c)
+
+	err = s.State.GrantSecret("mysql/password", rel.String(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting again is a no-op.
+	err = s.State.GrantSecret("mysql/password", rel.String(), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret("mysql/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []state.SecretGrant{{
+		RelationId:  rel.Id(),
+		RelationKey: rel.String(),
+		Application: "wordpress",
+	}})
+}
+
+func (s *SecretsSuite) TestGrantSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c)
+
+	err = s.State.GrantSecret("mysql/password", rel.String(), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "mysql/password": unit "mysql/0" for application "wordpress" not valid`)
+	err = s.State.GrantSecret("mysql/password", "foo:bar baz:qux", "")
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "mysql/password": relation "foo:bar baz:qux" not found`)
+	err = s.State.GrantSecret("mysql/missing", rel.String(), "")
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "mysql/missing": secret "mysql/missing" not found`)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	_, err := s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(s.mysql.ApplicationTag(), "password", map[string]string{"root": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret("mysql/password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.SecretValues("mysql/password", 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestParseSecretId(c *gc.C) {
+	app, name, err := state.ParseSecretId("mysql/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app, gc.Equals, "mysql")
+	c.Assert(name, gc.Equals, "password")
+
+	for _, id := range []string{"mysql", "mysql/", "mysql/password/1", "Mysql/password", "mysql/pass word"} {
+		_, _, err := state.ParseSecretId(id)
+		c.Check(err, gc.ErrorMatches, `secret ID ".*" not valid`)
+	}
+}
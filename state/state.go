@@ -93,6 +93,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// secretsKey holds the controller key material from which
+	// the key used to encrypt the model's secrets is derived.
+	secretsKey []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -141,6 +145,7 @@ func (st *State) newStateNoWorkers(modelUUID string) (*State, error) {
 		st.newPolicy,
 		st.stateClock,
 		st.runTransactionObserver,
+		st.secretsKey,
 	)
 	// We explicitly don't start the workers.
 	if err != nil {
@@ -156,6 +161,12 @@ func (st *State) IsController() bool {
 	return st.modelTag == st.controllerModelTag
 }
 
+// SecretsKey returns the controller key material from which
+// the keys used to encrypt model secrets are derived.
+func (st *State) SecretsKey() []byte {
+	return st.secretsKey
+}
+
 // ControllerUUID returns the UUID for the controller
 // of this state instance.
 func (st *State) ControllerUUID() string {
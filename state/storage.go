@@ -215,8 +215,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool     string `bson:"pool"`
+	Size     uint64 `bson:"size"`
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -321,6 +322,52 @@ func IsStorageAttachedError(err error) bool {
 	return ok
 }
 
+// ResizeStorageInstance requests that the volume and/or filesystem
+// backing the storage instance be grown to the specified size in MiB.
+// Storage cannot be shrunk.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		var ops []txn.Op
+		var found bool
+		if v, err := sb.storageInstanceVolume(tag); err == nil {
+			found = true
+			volumeOps, err := resizeVolumeOps(v, size)
+			if err != nil && err != jujutxn.ErrNoOperations {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, volumeOps...)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if f, err := sb.storageInstanceFilesystem(tag); err == nil {
+			found = true
+			filesystemOps, err := resizeFilesystemOps(f, size)
+			if err != nil && err != jujutxn.ErrNoOperations {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, filesystemOps...)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if !found {
+			return nil, errors.NotFoundf("volume or filesystem for storage %q", tag.Id())
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 // DestroyStorageInstance ensures that the storage instance will be removed at
 // some point, after the cloud storage resources have been destroyed.
 //
@@ -769,8 +816,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1578,6 +1626,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot whose
+	// contents the storage instances are to be created with.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1834,7 +1886,11 @@ func addDefaultStorageConstraints(sb *storageBackend, allCons map[string]Storage
 				)
 			}
 		}
-		cons, err := storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
+		cons, err := sb.storageConstraintsWithSnapshot(cons)
+		if err != nil {
+			return errors.Annotatef(err, "storage %q", name)
+		}
+		cons, err = storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1985,6 +2041,10 @@ func (sb *storageBackend) addStorageForUnitOps(
 	}
 	ops := u.assertCharmOps(ch)
 
+	cons, err = sb.storageConstraintsWithSnapshot(cons)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	if cons.Pool == "" || cons.Size == 0 {
 		// Either pool or size, or both, were not specified. Take the
 		// values from the unit's recorded storage constraints.
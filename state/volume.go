@@ -46,6 +46,10 @@ type Volume interface {
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
 
+	// RequestedSize returns the size in MiB that the provisioned volume
+	// has been asked to grow to, and true if it has yet to reach it.
+	RequestedSize() (uint64, bool)
+
 	// Detachable reports whether or not the volume is detachable.
 	Detachable() bool
 
@@ -98,6 +102,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize, if non-zero, is the size in MiB that the
+	// volume has been asked to grow to since it was provisioned.
+	// It is removed once the volume has been resized.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -130,6 +139,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot
+	// whose contents the volume is to be created with.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -206,6 +219,14 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	if v.doc.Info == nil || v.doc.RequestedSize <= v.doc.Info.Size {
+		return 0, false
+	}
+	return v.doc.RequestedSize, true
+}
+
 // Releasing is required to imeplement Volume.
 func (v *volume) Releasing() bool {
 	return v.doc.Releasing
@@ -491,7 +512,7 @@ func (sb *storageBackend) removeMachineVolumesOps(m *Machine) ([]txn.Op, error)
 				},
 			)
 		}
-		ops = append(ops, sb.removeVolumeOps(v.VolumeTag())...)
+		ops = append(ops, sb.removeVolumeOps(v)...)
 	}
 	return ops, nil
 }
@@ -799,7 +820,7 @@ func destroyVolumeOps(im *storageBackend, v *volume, release bool, extraAssert b
 func (sb *storageBackend) RemoveVolume(tag names.VolumeTag) (err error) {
 	defer errors.DeferredAnnotatef(&err, "removing volume %s", tag.Id())
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		volume, err := sb.Volume(tag)
+		volume, err := getVolumeByTag(sb.mb, tag)
 		if errors.IsNotFound(err) {
 			return nil, jujutxn.ErrNoOperations
 		} else if err != nil {
@@ -808,22 +829,29 @@ func (sb *storageBackend) RemoveVolume(tag names.VolumeTag) (err error) {
 		if volume.Life() != Dead {
 			return nil, errors.New("volume is not dead")
 		}
-		return sb.removeVolumeOps(tag), nil
+		return sb.removeVolumeOps(volume), nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
-func (sb *storageBackend) removeVolumeOps(tag names.VolumeTag) []txn.Op {
-	return []txn.Op{
+func (sb *storageBackend) removeVolumeOps(v *volume) []txn.Op {
+	ops := []txn.Op{
 		{
 			C:      volumesC,
-			Id:     tag.Id(),
+			Id:     v.doc.Name,
 			Assert: txn.DocExists,
 			Remove: true,
 		},
-		removeModelVolumeRefOp(sb.mb, tag.Id()),
-		removeStatusOp(sb.mb, volumeGlobalKey(tag.Id())),
+		removeModelVolumeRefOp(sb.mb, v.doc.Name),
+		removeStatusOp(sb.mb, volumeGlobalKey(v.doc.Name)),
 	}
+	// A volume removed before it was created from a
+	// snapshot is no longer waiting for the snapshot.
+	if v.doc.Params != nil && v.doc.Params.Snapshot != "" {
+		ops[0].Assert = bson.D{{"params.snapshot", v.doc.Params.Snapshot}}
+		ops = append(ops, decVolumeSnapshotRestoresOp(v.doc.Params.Snapshot))
+	}
+	return ops
 }
 
 // newVolumeName returns a unique volume name.
@@ -860,6 +888,11 @@ func (sb *storageBackend) addVolumeOps(params VolumeParams, hostId string) ([]tx
 	if err != nil {
 		return nil, names.VolumeTag{}, errors.Annotate(err, "validating volume params")
 	}
+	if params.Snapshot != "" {
+		if err := validateVolumeSnapshotHost(params.Snapshot, hostId); err != nil {
+			return nil, names.VolumeTag{}, errors.Trace(err)
+		}
+	}
 	name, err := newVolumeName(sb.mb, hostId)
 	if err != nil {
 		return nil, names.VolumeTag{}, errors.Annotate(err, "cannot generate volume name")
@@ -886,7 +919,11 @@ func (sb *storageBackend) addVolumeOps(params VolumeParams, hostId string) ([]tx
 	if !detachable {
 		doc.HostId = origHostId
 	}
-	return sb.newVolumeOps(doc, statusDoc), names.NewVolumeTag(name), nil
+	ops := sb.newVolumeOps(doc, statusDoc)
+	if doc.Params != nil && doc.Params.Snapshot != "" {
+		ops = append(ops, incVolumeSnapshotRestoresOp(doc.Params.Snapshot))
+	}
+	return ops, names.NewVolumeTag(name), nil
 }
 
 func (sb *storageBackend) newVolumeOps(doc volumeDoc, status statusDoc) []txn.Op {
@@ -1105,6 +1142,9 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
 			unsetParams = true
+			if params.Snapshot != "" {
+				ops = append(ops, decVolumeSnapshotRestoresOp(params.Snapshot))
+			}
 		} else {
 			// Ensure immutable properties do not change.
 			oldInfo, err := v.Info()
@@ -1115,7 +1155,13 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 				return nil, err
 			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		// If the volume has been resized as requested,
+		// the request is removed along with setting info.
+		var resized uint64
+		if requested, ok := v.RequestedSize(); ok && info.Size >= requested {
+			resized = requested
+		}
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, resized)...)
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1137,15 +1183,23 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool, resized uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if resized > 0 {
+		asserts = append(asserts, bson.DocElem{"requestedsize", resized})
+		unset = append(unset, bson.DocElem{"requestedsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -1155,6 +1209,63 @@ func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []
 	}}
 }
 
+// ResizeVolume requests that the volume be grown to the specified size
+// in MiB. If the volume has not yet been provisioned, its provisioning
+// parameters are updated instead. Volumes cannot be shrunk.
+func (sb *storageBackend) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return resizeVolumeOps(v, size)
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.New("volume is not alive")
+	}
+	if params, ok := v.Params(); ok {
+		if size < params.Size {
+			return nil, errors.Errorf("cannot shrink volume from %dM to %dM", params.Size, size)
+		}
+		if size == params.Size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: append(bson.D{{"params", bson.D{{"$exists", true}}}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"params.size", size}}}},
+		}}, nil
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size < info.Size {
+		return nil, errors.Errorf("cannot shrink volume from %dM to %dM", info.Size, size)
+	}
+	requested, _ := v.RequestedSize()
+	if size == requested || (requested == 0 && size == info.Size) {
+		return nil, jujutxn.ErrNoOperations
+	}
+	update := bson.D{{"$set", bson.D{{"requestedsize", size}}}}
+	if size == info.Size {
+		// Withdraw the pending request.
+		update = bson.D{{"$unset", bson.D{{"requestedsize", nil}}}}
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.Name,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: update,
+	}}, nil
+}
+
 // AllVolumes returns all Volumes scoped to the model.
 func (sb *storageBackend) AllVolumes() ([]Volume, error) {
 	volumes, err := sb.volumes(nil)
@@ -0,0 +1,433 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time copy of the contents of a
+// volume in the model. Snapshots outlive the volumes they are taken
+// from, and may be used to create new storage.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Id returns the unique ID of the snapshot. Snapshots of
+	// machine-scoped volumes have IDs prefixed with the machine ID,
+	// in the same way as the volumes themselves.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot is of.
+	Volume() names.VolumeTag
+
+	// StorageInstance returns the tag of the storage instance that the
+	// volume was assigned to when the snapshot was requested. If the
+	// volume was not assigned to a storage instance, an error
+	// satisfying errors.IsNotAssigned will be returned.
+	StorageInstance() (names.StorageTag, error)
+
+	// Pool returns the name of the storage pool that the volume was
+	// provisioned from. Storage may only be created from the snapshot
+	// using the same pool.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Name      string              `bson:"name"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	Volume    string              `bson:"volumeid"`
+	StorageId string              `bson:"storageid,omitempty"`
+	Pool      string              `bson:"pool"`
+	Created   time.Time           `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+
+	// Restores is the number of volumes waiting to be created
+	// from the snapshot. The snapshot cannot be destroyed while
+	// it is non-zero.
+	Restores int `bson:"restores"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// StorageInstance is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if s.doc.StorageId == "" {
+		msg := fmt.Sprintf("volume snapshot %q is not of any storage instance", s.doc.Name)
+		return names.StorageTag{}, errors.NewNotAssigned(nil, msg)
+	}
+	return names.NewStorageTag(s.doc.StorageId), nil
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// IsValidVolumeSnapshotId reports whether the string is a valid
+// volume snapshot ID.
+func IsValidVolumeSnapshotId(id string) bool {
+	// Snapshot IDs are formed in the same way as volume names.
+	return names.IsValidVolume(id)
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified ID.
+func (sb *storageBackend) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (sb *storageBackend) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots in the model.
+func (sb *storageBackend) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// CreateStorageSnapshot requests a snapshot of the volume backing the
+// specified storage instance. The snapshot is taken asynchronously by
+// the storage provisioner responsible for the volume.
+func (sb *storageBackend) CreateStorageSnapshot(tag names.StorageTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot storage %q", tag.Id())
+	var doc volumeSnapshotDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		v, err := sb.storageInstanceBackingVolume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		doc, ops, err = sb.addVolumeSnapshotOps(v, info.Pool)
+		return ops, errors.Trace(err)
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// storageInstanceBackingVolume returns the volume that holds the
+// contents of the storage instance: either the storage's volume, or
+// the volume backing the storage's filesystem.
+func (sb *storageBackend) storageInstanceBackingVolume(tag names.StorageTag) (*volume, error) {
+	v, err := sb.storageInstanceVolume(tag)
+	if err == nil || !errors.IsNotFound(err) {
+		return v, errors.Trace(err)
+	}
+	f, err := sb.storageInstanceFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("volume or filesystem for storage %q", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := f.Volume()
+	if errors.Cause(err) == ErrNoBackingVolume {
+		return nil, errors.NotSupportedf("snapshots of filesystems not backed by a volume")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return getVolumeByTag(sb.mb, volumeTag)
+}
+
+func (sb *storageBackend) addVolumeSnapshotOps(v *volume, pool string) (volumeSnapshotDoc, []txn.Op, error) {
+	// Snapshots are scoped in the same way as the volumes they are
+	// taken from, so that the same storage provisioner manages both.
+	volumeName := v.doc.Name
+	var hostId string
+	if i := strings.LastIndex(volumeName, "/"); i >= 0 {
+		hostId = volumeName[:i]
+	}
+	seq, err := sequence(sb.mb, "volumesnapshot")
+	if err != nil {
+		return volumeSnapshotDoc{}, nil, errors.Annotate(err, "cannot generate volume snapshot ID")
+	}
+	name := fmt.Sprint(seq)
+	if hostId != "" {
+		name = hostId + "/" + name
+	}
+	doc := volumeSnapshotDoc{
+		Name:      name,
+		ModelUUID: sb.mb.modelUUID(),
+		Life:      Alive,
+		Volume:    volumeName,
+		StorageId: v.doc.StorageId,
+		Pool:      pool,
+		Created:   sb.mb.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     volumeName,
+		Assert: append(bson.D{{"info", bson.D{{"$exists", true}}}}, isAliveDoc...),
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     name,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	return doc, ops, nil
+}
+
+// SetVolumeSnapshotInfo records the information about a volume
+// snapshot once it has been taken.
+func (sb *storageBackend) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					oldInfo.SnapshotId, info.SnapshotId,
+				)
+			}
+			if oldInfo == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot will be removed
+// at some point, after the cloud storage resources have been destroyed.
+// A snapshot cannot be destroyed while there are volumes waiting to be
+// created from it.
+func (sb *storageBackend) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) && attempt > 0 {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if s.doc.Restores > 0 {
+			volumes, err := sb.volumes(bson.D{{"params.snapshot", id}})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if len(volumes) > 0 {
+				return nil, errors.Errorf(
+					"snapshot is being restored to %s",
+					names.ReadableString(volumes[0].VolumeTag()),
+				)
+			}
+			return nil, errors.New("snapshot is being restored")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: append(bson.D{{"restores", 0}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// incVolumeSnapshotRestoresOp returns a txn.Op that records that a
+// volume is to be created from the snapshot, which must be alive.
+func incVolumeSnapshotRestoresOp(id string) txn.Op {
+	return txn.Op{
+		C:      volumeSnapshotsC,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"restores", 1}}}},
+	}
+}
+
+// decVolumeSnapshotRestoresOp returns a txn.Op that records that a
+// volume is no longer waiting to be created from the snapshot, either
+// because it has been provisioned or because it has been removed.
+func decVolumeSnapshotRestoresOp(id string) txn.Op {
+	return txn.Op{
+		C:      volumeSnapshotsC,
+		Id:     id,
+		Assert: bson.D{{"restores", bson.D{{"$gt", 0}}}},
+		Update: bson.D{{"$inc", bson.D{{"restores", -1}}}},
+	}
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot from the model. The
+// snapshot must not be Alive, and it is expected that the storage
+// provisioner has already destroyed the cloud resource.
+func (sb *storageBackend) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// storageConstraintsWithSnapshot returns constraints derived from cons,
+// checking that storage may be created from the volume snapshot named
+// in them, if any. The snapshot's pool is used if no pool is specified,
+// and the size is increased to that of the snapshot if it is smaller.
+func (sb *storageBackend) storageConstraintsWithSnapshot(cons StorageConstraints) (StorageConstraints, error) {
+	if cons.Snapshot == "" {
+		return cons, nil
+	}
+	s, err := sb.volumeSnapshot(cons.Snapshot)
+	if err != nil {
+		return cons, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return cons, errors.Errorf("volume snapshot %q is not alive", cons.Snapshot)
+	}
+	info, err := s.Info()
+	if err != nil {
+		return cons, errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = s.Pool()
+	} else if cons.Pool != s.Pool() {
+		return cons, errors.NotValidf(
+			"pool %q for volume snapshot %q from pool %q",
+			cons.Pool, cons.Snapshot, s.Pool(),
+		)
+	}
+	if cons.Size < info.Size {
+		cons.Size = info.Size
+	}
+	return cons, nil
+}
+
+// validateVolumeSnapshotHost returns an error if a volume created on the
+// specified host, or model-scoped if the host ID is empty, cannot be
+// created from the volume snapshot with the specified ID.
+func validateVolumeSnapshotHost(snapshotId, hostId string) error {
+	var snapshotHostId string
+	if i := strings.LastIndex(snapshotId, "/"); i >= 0 {
+		snapshotHostId = snapshotId[:i]
+	}
+	if snapshotHostId == hostId {
+		return nil
+	}
+	if snapshotHostId == "" {
+		return errors.Errorf("volume snapshot %q cannot be restored to a machine-scoped volume", snapshotId)
+	}
+	return errors.Errorf("volume snapshot %q can only be restored on machine %s", snapshotId, snapshotHostId)
+}
@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) setupProvisionedStorage(c *gc.C) (*state.Unit, names.StorageTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-ume",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageSnapshot(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c)
+
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Volume(), gc.Equals, names.NewVolumeTag("0/0"))
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	snapshotStorageTag, err := snapshot.StorageInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotStorageTag, gc.Equals, storageTag)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	err = s.storageBackend.SetVolumeSnapshotInfo("0/0", info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.VolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotInfo, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotInfo, jc.DeepEquals, info)
+
+	err = s.storageBackend.SetVolumeSnapshotInfo("0/0", state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0": cannot change snapshot ID from "snap-0" to "snap-1"`)
+
+	all, err := s.storageBackend.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, "0/0")
+
+	// Models with volume snapshots cannot be migrated.
+	entities, err := s.State.UnmigratableEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []string{"volume snapshots (1)"})
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage "data/0": volume "0/0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyRemoveVolumeSnapshot(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot "0/0": volume snapshot is not dying`)
+
+	err = s.storageBackend.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	err = s.storageBackend.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	u, storageTag := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The snapshot must have been taken before it can be used.
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0: volume snapshot "0/0" not provisioned`)
+
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Count:    1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 1)
+
+	// The volume takes its pool and size from the snapshot.
+	volume := s.storageInstanceVolume(c, tags[0])
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams, jc.DeepEquals, state.VolumeParams{
+		Pool:     "loop-pool",
+		Size:     2048,
+		Snapshot: "0/0",
+	})
+
+	// The snapshot cannot be destroyed until the volume is created.
+	err = s.storageBackend.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot destroy volume snapshot "0/0": snapshot is being restored to volume 0/1`)
+
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-restored",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyVolumeSnapshotConcurrentRestore(c *gc.C) {
+	u, storageTag := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+			Snapshot: snapshot.Id(),
+			Count:    1,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.storageBackend.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot destroy volume snapshot "0/0": snapshot is being restored to volume 0/1`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotPoolMismatch(c *gc.C) {
+	u, storageTag := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Pool:     "static",
+		Snapshot: snapshot.Id(),
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `.*pool "static" for volume snapshot "0/0" from pool "loop-pool" not valid`)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c)
+	w := s.storageBackend.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(snapshot.Id())
+	wc.AssertNoChange()
+
+	err = s.storageBackend.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(snapshot.Id())
+	wc.AssertNoChange()
+
+	modelWatcher := s.storageBackend.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, modelWatcher)
+	modelWC := testing.NewStringsWatcherC(c, s.State, modelWatcher)
+	modelWC.AssertChangeInSingleEvent() // initial
+	modelWC.AssertNoChange()
+}
@@ -234,14 +234,17 @@ func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatche
 	mb := sb.mb
 	pattern := fmt.Sprintf("^%s$", mb.docID(machineOrUnitSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	filter := func(id interface{}) bool {
+	return newLifecycleWatcher(mb, collection, members, modelHostStorageFilter(mb), nil)
+}
+
+func modelHostStorageFilter(mb modelBackend) func(interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -270,16 +273,72 @@ func (sb *storageBackend) watchHostStorage(host names.Tag, collection string) St
 	// The host parameter passed into this method is the application name, any of whose units we are interested in.
 	pattern := fmt.Sprintf("^%s(/%s)?/%s$", mb.docID(host.Id()), names.NumberSnippet, names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	return newLifecycleWatcher(mb, collection, members, hostStorageFilter(mb, host), nil)
+}
+
+func hostStorageFilter(mb modelBackend, host names.Tag) func(interface{}) bool {
 	prefix := fmt.Sprintf("%s(/%s)?/.*", host.Id(), names.NumberSnippet)
 	matchExp := regexp.MustCompile(prefix)
-	filter := func(id interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return matchExp.MatchString(k)
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped volumes, so that requests to resize them
+// can be acted upon.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: modelHostStorageFilter(sb.mb),
+	})
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped filesystems, so that requests to resize
+// them can be acted upon.
+func (sb *storageBackend) WatchModelFilesystemResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    filesystemsC,
+		filter: modelHostStorageFilter(sb.mb),
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to any volumes scoped to the specified machine, so that
+// requests to resize them can be acted upon.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: hostStorageFilter(sb.mb, m),
+	})
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies
+// of changes to any filesystems scoped to the specified machine, so that
+// requests to resize them can be acted upon.
+func (sb *storageBackend) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    filesystemsC,
+		filter: hostStorageFilter(sb.mb, m),
+	})
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of model-scoped volumes.
+func (sb *storageBackend) WatchModelVolumeSnapshots() StringsWatcher {
+	return sb.watchModelHostStorage(volumeSnapshotsC)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return sb.watchHostStorage(m, volumeSnapshotsC)
 }
 
 // WatchModelVolumeAttachments returns a StringsWatcher that notifies of
@@ -438,6 +497,27 @@ func (a *Application) WatchScale() NotifyWatcher {
 	return newNotifyCollWatcher(a.st, applicationsC, filter)
 }
 
+// WatchMetrics returns a NotifyWatcher that notifies of metric
+// batches being recorded by the application's units.
+func (a *Application) WatchMetrics() NotifyWatcher {
+	unitPrefix := a.doc.Name + "/"
+	filter := func(id interface{}) bool {
+		metrics, closer := a.st.db().GetCollection(metricsC)
+		defer closer()
+
+		var doc struct {
+			ModelUUID string `bson:"model-uuid"`
+			Unit      string `bson:"unit"`
+		}
+		fields := bson.D{{"model-uuid", 1}, {"unit", 1}}
+		if err := metrics.FindId(id).Select(fields).One(&doc); err != nil {
+			return false
+		}
+		return doc.ModelUUID == a.st.ModelUUID() && strings.HasPrefix(doc.Unit, unitPrefix)
+	}
+	return newNotifyCollWatcher(a.st, metricsC, filter)
+}
+
 // WatchRelations returns a StringsWatcher that notifies of changes to the
 // lifecycles of relations involving a.
 func (a *Application) WatchRelations() StringsWatcher {
@@ -1569,9 +1649,15 @@ func (m *Machine) Watch() NotifyWatcher {
 	return newEntityWatcher(m.st, machinesC, m.doc.DocID)
 }
 
-// Watch returns a watcher for observing changes to an application.
+// Watch returns a watcher for observing changes to an application,
+// including the release of a rolling charm upgrade to its units.
 func (a *Application) Watch() NotifyWatcher {
-	return newEntityWatcher(a.st, applicationsC, a.doc.DocID)
+	return newDocWatcher(a.st, []docKey{
+		{applicationsC, a.doc.DocID},
+		// Units held back by a rolling charm upgrade need to
+		// know when the upgrade is released to them.
+		{charmRolloutsC, a.st.docID(a.doc.Name)},
+	})
 }
 
 // WatchLeaderSettings returns a watcher for observing changed to an application's
@@ -1647,6 +1733,17 @@ func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTa
 	return newEntityWatcher(sb.mb, volumeAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchFilesystemAttachment returns a watcher for observing changes
 // to a filesystem attachment.
 func (sb *storageBackend) WatchFilesystemAttachment(host names.Tag, f names.FilesystemTag) NotifyWatcher {
@@ -1662,6 +1759,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to
+// the application's own configuration settings, as opposed to those of
+// its charm.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
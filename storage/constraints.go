@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
 )
 
 var logger = loggo.GetLogger("juju.storage")
@@ -27,19 +28,26 @@ type Constraints struct {
 
 	// Count is the number of instances of the storage to create.
 	Count uint64
+
+	// Snapshot is the ID of the volume snapshot from which the
+	// storage should be created, or "" if the storage should be
+	// created empty.
+	Snapshot string
 }
 
 var (
 	poolRE  = regexp.MustCompile("^[a-zA-Z]+[-?a-zA-Z0-9]*$")
 	countRE = regexp.MustCompile("^-?[0-9]+$")
 	sizeRE  = regexp.MustCompile("^-?[0-9]+(?:\\.[0-9]+)?[MGTPEZY](?:i?B)?$")
+
+	snapshotRE = regexp.MustCompile("^snapshot:(.*)$")
 )
 
 // ParseConstraints parses the specified string and creates a
 // Constraints structure.
 //
 // The acceptable format for storage constraints is a comma separated
-// sequence of: POOL, COUNT, and SIZE, where
+// sequence of: POOL, COUNT, SIZE and SNAPSHOT, where
 //
 //    POOL identifies the storage pool. POOL can be a string
 //    starting with a letter, followed by zero or more digits
@@ -53,6 +61,9 @@ var (
 //    create. SIZE is a floating point number and multiplier from
 //    the set (M, G, T, P, E, Z, Y), which are all treated as
 //    powers of 1024.
+//
+//    SNAPSHOT is "snapshot:" followed by the ID of a volume
+//    snapshot from which the storage is to be created.
 func ParseConstraints(s string) (Constraints, error) {
 	var cons Constraints
 	fields := strings.Split(s, ",")
@@ -82,9 +93,16 @@ func ParseConstraints(s string) (Constraints, error) {
 			cons.Size = size
 			continue
 		}
+		if snapshot, ok, err := parseSnapshot(field); ok {
+			if err != nil {
+				return cons, errors.Annotate(err, "cannot parse snapshot")
+			}
+			cons.Snapshot = snapshot
+			continue
+		}
 		logger.Debugf("ignoring unknown storage constraint %q", field)
 	}
-	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" {
+	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" && cons.Snapshot == "" {
 		return Constraints{}, errors.New("storage constraints require at least one field to be specified")
 	}
 	if cons.Count == 0 {
@@ -170,3 +188,15 @@ func parseSize(s string) (uint64, bool, error) {
 	}
 	return size, true, nil
 }
+
+func parseSnapshot(s string) (string, bool, error) {
+	match := snapshotRE.FindStringSubmatch(s)
+	if match == nil {
+		return "", false, nil
+	}
+	// Snapshot IDs are formed in the same way as volume IDs.
+	if !names.IsValidVolume(match[1]) {
+		return "", true, errors.NotValidf("snapshot ID %q", match[1])
+	}
+	return match[1], true, nil
+}
@@ -75,6 +75,20 @@ func (s *ConstraintsSuite) TestParseConstraintsSizeRange(c *gc.C) {
 	s.testParseError(c, "p,-100M", `cannot parse size: expected a non-negative number, got "-100M"`)
 }
 
+func (s *ConstraintsSuite) TestParseConstraintsSnapshot(c *gc.C) {
+	s.testParse(c, "snapshot:0/1", storage.Constraints{
+		Count:    1,
+		Snapshot: "0/1",
+	})
+	s.testParse(c, "p,2,snapshot:3", storage.Constraints{
+		Pool:     "p",
+		Count:    2,
+		Snapshot: "3",
+	})
+	s.testParseError(c, "p,snapshot:", `cannot parse snapshot: snapshot ID "" not valid`)
+	s.testParseError(c, "p,snapshot:0/x", `cannot parse snapshot: snapshot ID "0/x" not valid`)
+}
+
 func (*ConstraintsSuite) testParse(c *gc.C, s string, expect storage.Constraints) {
 	cons, err := storage.ParseConstraints(s)
 	c.Check(err, jc.ErrorIsNil)
@@ -216,6 +216,54 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes in place,
+// while they are attached and in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows each of the specified volumes to at least
+	// the requested size, returning the resulting volume information.
+	// Volumes may not be shrunk.
+	ResizeVolumes(
+		ctx context.ProviderCallContext,
+		params []VolumeResizeParams,
+	) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter provides an interface for taking snapshots of
+// volumes, and for managing those snapshots. A VolumeSource that
+// implements VolumeSnapshotter must also accept the SnapshotId field
+// of VolumeParams, creating the volume from the snapshot's contents.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes a snapshot of each of the specified
+	// volumes, returning information about the new snapshots.
+	CreateVolumeSnapshots(
+		ctx context.ProviderCallContext,
+		params []VolumeSnapshotParams,
+	) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots lists the provider snapshot IDs of all of
+	// the snapshots managed by this volume source.
+	ListVolumeSnapshots(ctx context.ProviderCallContext) ([]string, error)
+
+	// DestroyVolumeSnapshots destroys the snapshots with the specified
+	// provider snapshot IDs.
+	DestroyVolumeSnapshots(
+		ctx context.ProviderCallContext,
+		snapshotIds []string,
+	) ([]error, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems in
+// place, while they are attached and in use.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows each of the specified filesystems to at
+	// least the requested size, returning the resulting filesystem
+	// information. Filesystems may not be shrunk.
+	ResizeFilesystems(
+		ctx context.ProviderCallContext,
+		params []FilesystemResizeParams,
+	) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -250,6 +298,11 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the provider-supplied ID of the
+	// snapshot whose contents the volume should be created with. Only
+	// volume sources that implement VolumeSnapshotter support this.
+	SnapshotId string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -339,6 +392,71 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju to the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the minimum size of the volume in MiB, once resized.
+	Size uint64
+
+	// Provider is the name of the storage provider that manages
+	// the volume.
+	Provider ProviderType
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju to the volume.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that manages
+	// the volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju to the filesystem.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	// A filesystem is only resized after its backing volume has been.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Size is the minimum size of the filesystem in MiB, once resized.
+	Size uint64
+
+	// Provider is the name of the storage provider that manages
+	// the filesystem.
+	Provider ProviderType
+
+	// Attachments holds the known attachments of the filesystem,
+	// for providers that must act on the mounted filesystem.
+	Attachments []FilesystemAttachment
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -369,6 +487,29 @@ type CreateFilesystemsResult struct {
 	Error      error
 }
 
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Volume should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Volume *Volume
+	Error  error
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
+
 // AttachFilesystemsResult contains the result of a FilesystemSource.AttachFilesystems call
 // for one filesystem. FilesystemAttachment should only be used if Error is nil.
 type AttachFilesystemsResult struct {
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -103,7 +104,15 @@ type loopVolumeSource struct {
 	storageDir string
 }
 
-var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*loopVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*loopVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+)
+
+// loopSnapshotPrefix is the prefix of the IDs of loop volume snapshots,
+// which are copies of the volumes' backing files.
+const loopSnapshotPrefix = "snapshot-"
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -124,6 +133,15 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -248,6 +266,159 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.Volume, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	sizeInMiB := uint64(fi.Size()) / (1024 * 1024)
+	if sizeInMiB < arg.Size {
+		// fallocate extends the file, leaving its contents intact.
+		if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+			return nil, errors.Annotate(err, "could not grow block file")
+		}
+		sizeInMiB = arg.Size
+	}
+	// Any loop devices attached to the file must be told to
+	// re-read its size before the new space can be used.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDevice(lvs.run, deviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.Volume{
+		arg.Tag,
+		storage.VolumeInfo{
+			VolumeId: arg.Tag.String(),
+			Size:     sizeInMiB,
+		},
+	}, nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	snapshotId := loopSnapshotPrefix + strings.Replace(arg.Id, "/", "-", -1)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		arg.Id,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			VolumeId:   arg.VolumeId,
+			Size:       uint64(fi.Size()) / (1024 * 1024),
+		},
+	}, nil
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext) ([]string, error) {
+	fis, err := ioutil.ReadDir(lvs.snapshotDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading snapshot directory")
+	}
+	var snapshotIds []string
+	for _, fi := range fis {
+		if strings.HasPrefix(fi.Name(), loopSnapshotPrefix) {
+			snapshotIds = append(snapshotIds, fi.Name())
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroyVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroyVolumeSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
+func (lvs *loopVolumeSource) snapshotDir() string {
+	return filepath.Join(lvs.storageDir, "snapshots")
+}
+
+// snapshotFilePath returns the path of the file holding the snapshot
+// with the specified ID, checking that the ID cannot refer to a file
+// outside of the snapshot directory.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if !strings.HasPrefix(snapshotId, loopSnapshotPrefix) || filepath.Base(snapshotId) != snapshotId {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.snapshotDir(), snapshotId), nil
+}
+
+// copyBlockFile copies the file at one path to another, preserving
+// any holes in the source file.
+func copyBlockFile(run runCommandFunc, sourcePath, targetPath string) error {
+	_, err := run("cp", "--sparse=always", sourcePath, targetPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying %q to %q", sourcePath, targetPath)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -298,6 +469,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDevice updates the capacity of the loop device with the
+// specified name to match its backing file.
+func refreshLoopDevice(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
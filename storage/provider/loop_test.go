@@ -276,3 +276,138 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0",
+			Size:     4,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume 1: reading loop backing file: .*")
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	s.commands.expect("cp", "--sparse=always", fileName, filepath.Join(snapshotDir, "snapshot-0-3"))
+
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "0/3",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}, {
+		Id:       "0/4",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0/3",
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "snapshot-0-3",
+			VolumeId:   "volume-0",
+			Size:       2,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating snapshot of volume 1: reading loop backing file: .*")
+	c.Assert(dirFuncs.Dirs.Contains(snapshotDir), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("cp", "--sparse=always", filepath.Join(s.storageDir, "snapshots", "snapshot-0-3"), fileName)
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       4,
+		SnapshotId: "snapshot-0-3",
+	}, {
+		Tag:        names.NewVolumeTag("1"),
+		Size:       4,
+		SnapshotId: "../../etc/passwd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0",
+			Size:     4,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume: invalid loop snapshot ID "../../etc/passwd"`)
+}
+
+func (s *loopSuite) TestListVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	snapshotIds, err := snapshotter.ListVolumeSnapshots(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, gc.HasLen, 0)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err = os.MkdirAll(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{"snapshot-0-3", "snapshot-4", "junk"} {
+		err := ioutil.WriteFile(filepath.Join(snapshotDir, name), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	snapshotIds, err = snapshotter.ListVolumeSnapshots(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.SameContents, []string{"snapshot-0-3", "snapshot-4"})
+}
+
+func (s *loopSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotDir, "snapshot-0-3")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DestroyVolumeSnapshots(s.callCtx, []string{
+		"snapshot-0-3", "snapshot-4", "../volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "../volume-0": invalid loop snapshot ID "../volume-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
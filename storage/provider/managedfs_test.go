@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -189,3 +190,104 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false)
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// sda's partition is grown before the filesystem on it.
+	s.commands.expect("sgdisk", "-e", "/dev/sda")
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		HardwareId: "weetbix",
+		Size:       3,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   4,
+	}, {
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+		Size:   6,
+	}, {
+		Tag:    names.NewFilesystemTag("0/2"),
+		Volume: names.NewVolumeTag("2"),
+		Size:   6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[:2], jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         4,
+			},
+		},
+	}, {
+		// The block device's new size has not been reported yet.
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/1"),
+			names.NewVolumeTag("1"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-1",
+				Size:         6,
+			},
+		},
+	}})
+	c.Assert(results[2].Error, gc.ErrorMatches, "backing-volume 2 is not yet attached")
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c)
+	// growpart fails if the partition already fills the disk,
+	// but the filesystem on it may still need to be grown.
+	s.commands.expect("sgdisk", "-e", "/dev/sda")
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 is size 8386527. it cannot be grown", errors.New("exit status 1"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       4,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsGrowpartFails(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("sgdisk", "-e", "/dev/sda")
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("FAILED: disk=/dev/sda partition=1: failed to resize", errors.New("exit status 2"))
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       4,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "growpart failed: exit status 2")
+}
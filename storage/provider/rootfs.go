@@ -133,7 +133,10 @@ func ensureEmptyDir(d dirFuncs, path string) error {
 	return nil
 }
 
-var _ storage.FilesystemSource = (*rootfsFilesystemSource)(nil)
+var (
+	_ storage.FilesystemSource  = (*rootfsFilesystemSource)(nil)
+	_ storage.FilesystemResizer = (*rootfsFilesystemSource)(nil)
+)
 
 // ValidateFilesystemParams is defined on the FilesystemSource interface.
 func (s *rootfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
@@ -187,6 +190,41 @@ func (s *rootfsFilesystemSource) createFilesystem(params storage.FilesystemParam
 	}, nil
 }
 
+// ResizeFilesystems is defined on the FilesystemResizer interface.
+func (s *rootfsFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *rootfsFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (*storage.Filesystem, error) {
+	// A rootfs filesystem is a directory on the root filesystem, so
+	// there is nothing to grow; it is only as big as the filesystem
+	// that contains the storage directory, which may since have grown.
+	sizeInMiB, err := s.dirFuncs.calculateSize(s.storageDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if sizeInMiB < arg.Size {
+		return nil, errors.Errorf("filesystem is not big enough (%dM < %dM)", sizeInMiB, arg.Size)
+	}
+	return &storage.Filesystem{
+		arg.Tag,
+		names.VolumeTag{},
+		storage.FilesystemInfo{
+			FilesystemId: arg.Tag.Id(),
+			Size:         sizeInMiB,
+		},
+	}, nil
+}
+
 // DestroyFilesystems is defined on the FilesystemSource interface.
 func (s *rootfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; we leave the storage directory
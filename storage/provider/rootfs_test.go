@@ -357,3 +357,31 @@ func (s *rootfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.rootfsFilesystemSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false)
 }
+
+func (s *rootfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=size", s.storageDir)
+	cmd.respond("1K-blocks\n4096", nil)
+	cmd = s.commands.expect("df", "--output=size", s.storageDir)
+	cmd.respond("1K-blocks\n4096", nil)
+
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 3,
+	}, {
+		Tag:  names.NewFilesystemTag("7"),
+		Size: 5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "6",
+				Size:         4,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `filesystem is not big enough \(4M < 5M\)`)
+}
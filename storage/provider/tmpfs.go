@@ -100,7 +100,10 @@ type tmpfsFilesystemSource struct {
 	storageDir string
 }
 
-var _ storage.FilesystemSource = (*tmpfsFilesystemSource)(nil)
+var (
+	_ storage.FilesystemSource  = (*tmpfsFilesystemSource)(nil)
+	_ storage.FilesystemResizer = (*tmpfsFilesystemSource)(nil)
+)
 
 // ValidateFilesystemParams is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
@@ -130,17 +133,9 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	if err := s.ValidateFilesystemParams(params); err != nil {
 		return nil, errors.Trace(err)
 	}
-	// Align size to the page size in MiB.
-	sizeInMiB := params.Size
-	pageSizeInMiB := uint64(getpagesize()) / (1024 * 1024)
-	if pageSizeInMiB > 0 {
-		x := (sizeInMiB + pageSizeInMiB - 1)
-		sizeInMiB = x - x%pageSizeInMiB
-	}
-
 	info := storage.FilesystemInfo{
 		FilesystemId: params.Tag.String(),
-		Size:         sizeInMiB,
+		Size:         alignToPageSize(params.Size),
 	}
 
 	// Creating the mount is the responsibility of AttachFilesystems.
@@ -154,6 +149,55 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	return &storage.Filesystem{params.Tag, params.Volume, info}, nil
 }
 
+// alignToPageSize rounds the size in MiB up to a multiple of the
+// page size.
+func alignToPageSize(sizeInMiB uint64) uint64 {
+	pageSizeInMiB := uint64(getpagesize()) / (1024 * 1024)
+	if pageSizeInMiB > 0 {
+		x := (sizeInMiB + pageSizeInMiB - 1)
+		sizeInMiB = x - x%pageSizeInMiB
+	}
+	return sizeInMiB
+}
+
+// ResizeFilesystems is defined on the FilesystemResizer interface.
+func (s *tmpfsFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing filesystem %v", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *tmpfsFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (*storage.Filesystem, error) {
+	info, err := s.readFilesystemInfo(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if sizeInMiB := alignToPageSize(arg.Size); sizeInMiB > info.Size {
+		info.Size = sizeInMiB
+		if err := s.saveFilesystemInfo(arg.Tag, info); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// A tmpfs mount can be resized in place by remounting it.
+	options := fmt.Sprintf("remount,size=%dm", info.Size)
+	for _, attachment := range arg.Attachments {
+		if attachment.Path == "" {
+			continue
+		}
+		if _, err := s.run("mount", "-o", options, attachment.Path); err != nil {
+			return nil, errors.Annotatef(err, "cannot remount tmpfs at %q", attachment.Path)
+		}
+	}
+	return &storage.Filesystem{arg.Tag, arg.Volume, info}, nil
+}
+
 // DestroyFilesystems is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; there is nothing to destroy,
@@ -241,6 +285,11 @@ func (s *tmpfsFilesystemSource) writeFilesystemInfo(tag names.FilesystemTag, inf
 	if _, err := os.Stat(filename); err == nil {
 		return errors.Errorf("filesystem %v already exists", tag.Id())
 	}
+	return s.saveFilesystemInfo(tag, info)
+}
+
+func (s *tmpfsFilesystemSource) saveFilesystemInfo(tag names.FilesystemTag, info storage.FilesystemInfo) error {
+	filename := s.filesystemInfoFile(tag)
 	if err := ensureDir(s.dirFuncs, filepath.Dir(filename)); err != nil {
 		return errors.Trace(err)
 	}
@@ -283,3 +283,50 @@ func (s *tmpfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false)
 }
+
+func (s *tmpfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("mount", "-o", "remount,size=4m", "/srv")
+
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 4,
+		Attachments: []storage.FilesystemAttachment{{
+			Filesystem: names.NewFilesystemTag("6"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv",
+			},
+		}},
+	}, {
+		Tag:  names.NewFilesystemTag("7"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "filesystem-6",
+				Size:         4,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing filesystem 7: reading filesystem info from disk: .*")
+
+	// The new size is used for subsequent mounts.
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("header\nnone", nil)
+	s.commands.expect("mount", "-t", "tmpfs", "filesystem-6", "/srv", "-o", "size=4m")
+	attachResults, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachResults[0].Error, jc.ErrorIsNil)
+}
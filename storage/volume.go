@@ -36,6 +36,30 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot identifies and describes a point-in-time copy of
+// the contents of a volume.
+type VolumeSnapshot struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume that the
+	// snapshot was taken from.
+	VolumeId string
+
+	// Size is the size of the volume when the snapshot was taken,
+	// in MiB. Volumes created from the snapshot must be at least
+	// this large.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
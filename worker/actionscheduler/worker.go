@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// period is the amount of time to wait between checks for action
+// schedules that are due to run. Cron specifications have a
+// granularity of one minute, so this is frequent enough that no
+// scheduled run is missed. Runs that enqueue actions on one unit at
+// a time also progress at this rate, and action rollouts are
+// progressed at this rate in case a change to action results is missed.
+const period = 30 * time.Second
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade exposes the controller functionality required by the action
+// scheduler worker.
+type Facade interface {
+	RunActionSchedules() error
+	ProgressActionRollouts() error
+	WatchActionResults() (watcher.StringsWatcher, error)
+}
+
+// Worker periodically asks the controller to enqueue the actions of
+// any action schedules that are due to run, and asks it to progress
+// action rollouts whenever actions finish.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	clock    clock.Clock
+}
+
+// New returns a worker.Worker that runs action schedules.
+func New(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	w := &Worker{
+		facade: facade,
+		clock:  clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	resultsWatcher, err := w.facade.WatchActionResults()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(resultsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	timer := w.clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-resultsWatcher.Changes():
+			if !ok {
+				return errors.New("action results watcher closed")
+			}
+			w.progressActionRollouts()
+		case <-timer.Chan():
+			if err := w.facade.RunActionSchedules(); err != nil {
+				// Failing to run a schedule should not stop the
+				// others; we retry when the timer next fires.
+				logger.Errorf("cannot run action schedules: %v", err)
+			}
+			w.progressActionRollouts()
+			timer.Reset(period)
+		}
+	}
+}
+
+func (w *Worker) progressActionRollouts() {
+	if err := w.facade.ProgressActionRollouts(); err != nil {
+		// Rollouts are progressed again when the next action
+		// finishes, or when the timer next fires.
+		logger.Errorf("cannot progress action rollouts: %v", err)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
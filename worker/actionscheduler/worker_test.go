@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		calls:         make(chan string, 2),
+		actionResults: make(chan []string),
+	}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C, names ...string) {
+	for _, name := range names {
+		select {
+		case call := <-s.facade.calls:
+			c.Assert(call, gc.Equals, name)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	w, err := actionscheduler.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c, "RunActionSchedules", "ProgressActionRollouts")
+	for i := 0; i < 2; i++ {
+		s.clock.WaitAdvance(29*time.Second, coretesting.LongWait, 1)
+		s.assertNotCalled(c)
+		s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		s.assertCalled(c, "RunActionSchedules", "ProgressActionRollouts")
+	}
+}
+
+func (s *WorkerSuite) TestProgressesRolloutsOnActionResults(c *gc.C) {
+	w, err := actionscheduler.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case s.facade.actionResults <- []string{"1"}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out sending action results")
+		}
+		s.assertCalled(c, "ProgressActionRollouts")
+	}
+}
+
+func (s *WorkerSuite) TestErrorIsLogged(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := actionscheduler.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c, "RunActionSchedules", "ProgressActionRollouts")
+	// The worker continues running after an error.
+	s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c, "RunActionSchedules", "ProgressActionRollouts")
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.actionscheduler cannot run action schedules: boom")
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.actionscheduler cannot progress action rollouts: boom")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("boom")
+	w, err := actionscheduler.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockFacade struct {
+	calls         chan string
+	actionResults chan []string
+	err           error
+	watchErr      error
+}
+
+func (m *mockFacade) RunActionSchedules() error {
+	m.calls <- "RunActionSchedules"
+	return m.err
+}
+
+func (m *mockFacade) ProgressActionRollouts() error {
+	m.calls <- "ProgressActionRollouts"
+	return m.err
+}
+
+func (m *mockFacade) WatchActionResults() (watcher.StringsWatcher, error) {
+	if m.watchErr != nil {
+		return nil, m.watchErr
+	}
+	return watchertest.NewMockStringsWatcher(m.actionResults), nil
+}
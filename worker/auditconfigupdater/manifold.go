@@ -20,6 +20,11 @@ type ManifoldConfig struct {
 	AgentName string
 	StateName string
 	NewWorker func(ConfigSource, auditlog.Config, AuditLogFactory) (worker.Worker, error)
+
+	// ForwardTo, if set, receives a copy of every audit log record
+	// written to the audit log file, so that it can be forwarded
+	// elsewhere.
+	ForwardTo auditlog.AuditLog
 }
 
 // Validate validates the manifold configuration.
@@ -78,7 +83,11 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	st := statePool.SystemState()
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		logFile := auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		if config.ForwardTo == nil {
+			return logFile
+		}
+		return auditlog.NewMultiLog(logFile, config.ForwardTo)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -131,6 +131,30 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	c.Assert(args[2], gc.NotNil)
 }
 
+func (s *manifoldSuite) TestStartWithForwardTo(c *gc.C) {
+	buffer := auditlog.NewBuffer(10)
+	s.manifold = auditconfigupdater.Manifold(auditconfigupdater.ManifoldConfig{
+		AgentName: "agent",
+		StateName: "state",
+		NewWorker: s.newWorker,
+		ForwardTo: buffer,
+	})
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	target := s.stub.Calls()[0].Args[1].(auditlog.Config).Target
+	c.Assert(target, gc.NotNil)
+	defer target.Close()
+
+	err = target.AddConversation(auditlog.Conversation{ConversationID: "c1"})
+	c.Assert(err, jc.ErrorIsNil)
+	records, _ := buffer.Take()
+	c.Assert(records, gc.HasLen, 1)
+	c.Assert(records[0].Conversation.ConversationID, gc.Equals, "c1")
+}
+
 func (s *manifoldSuite) TestStartWithAuditingDisabled(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		"auditing-enabled": false,
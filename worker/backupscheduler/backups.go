@@ -0,0 +1,129 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewStateBackups returns Backups which back up the controller from
+// the machine with the given agent config, in the same way as the
+// Backups facade does.
+func NewStateBackups(st *state.State, agentConfig agent.Config) Backups {
+	return &stateBackups{st: st, agentConfig: agentConfig}
+}
+
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// stateDB is the backups.DB for the controller model.
+type stateDB struct {
+	*state.State
+	*state.Model
+}
+
+// open returns the backups kept in the storage currently configured
+// for the controller.
+func (b *stateBackups) open() (backups.Backups, io.Closer, stateDB, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, nil, stateDB{}, errors.Trace(err)
+	}
+	db := stateDB{b.st, model}
+	stor, err := backups.OpenStorage(db)
+	if err != nil {
+		return nil, nil, stateDB{}, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, db, nil
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (string, error) {
+	backupsMethods, closer, db, err := b.open()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer closer.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return "", errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info found in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return "", errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(db, machineID, machine.Series())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Notes = notes
+
+	modelConfig, err := db.Model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backupsMethods.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	backupsMethods, closer, _, err := b.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	metas, err := backupsMethods.List()
+	return metas, errors.Trace(err)
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	backupsMethods, closer, _, err := b.open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer.Close()
+	return errors.Trace(backupsMethods.Remove(id))
+}
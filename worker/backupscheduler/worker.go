@@ -0,0 +1,242 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ScheduledBackupNotes is the note attached to backups created by the
+// scheduler. Only backups with this note are pruned, so that backups
+// created by hand are kept until they are removed by hand.
+const ScheduledBackupNotes = "scheduled backup"
+
+// Backend exposes the controller state needed by the backup
+// scheduler. (Primary implementation is State.)
+type Backend interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+	BackupStatus() (state.BackupStatus, error)
+	SetBackupSucceeded(id string, when time.Time) error
+	SetBackupFailed(reason string, when time.Time) error
+}
+
+// Backups exposes the backup operations needed by the backup
+// scheduler.
+type Backups interface {
+	// Create creates and stores a new backup with the given notes,
+	// returning its ID.
+	Create(notes string) (string, error)
+
+	// List returns the metadata of all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove removes the stored backup with the given ID.
+	Remove(id string) error
+}
+
+// Config holds the configuration and dependencies of the backup
+// scheduler.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional backup scheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which backs up the controller on the
+// schedule given by its backup-schedule configuration, pruning old
+// scheduled backups according to the backup-retention-count and
+// backup-retention-age configuration. This worker must not be run in
+// more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &backupWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type backupWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *backupWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *backupWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// retention holds the limits applied when pruning scheduled backups.
+type retention struct {
+	count int
+	age   time.Duration
+}
+
+func (w *backupWorker) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedule time.Duration
+		limits   retention
+		backupCh <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			cfg, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			limits = retention{
+				count: cfg.BackupRetentionCount(),
+				age:   cfg.BackupRetentionAge(),
+			}
+			if newSchedule := cfg.BackupSchedule(); newSchedule != schedule {
+				logger.Infof("backup schedule: %v", newSchedule)
+				schedule = newSchedule
+			}
+			if schedule == 0 {
+				backupCh = nil
+				continue
+			}
+			delay, err := w.nextBackupDelay(schedule)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			logger.Debugf("next scheduled backup in %v", delay)
+			backupCh = w.config.Clock.After(delay)
+
+		case <-backupCh:
+			if err := w.backup(limits); err != nil {
+				return errors.Trace(err)
+			}
+			backupCh = w.config.Clock.After(schedule)
+		}
+	}
+}
+
+// nextBackupDelay returns how long to wait before the next scheduled
+// backup, taking into account the last scheduled backup, which may
+// have been made by another controller agent.
+func (w *backupWorker) nextBackupDelay(schedule time.Duration) (time.Duration, error) {
+	status, err := w.config.Backend.BackupStatus()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot get backup status")
+	}
+	last := status.LastSuccess
+	if status.LastFailure.After(last) {
+		last = status.LastFailure
+	}
+	if last.IsZero() {
+		return 0, nil
+	}
+	delay := last.Add(schedule).Sub(w.config.Clock.Now())
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, nil
+}
+
+// backup creates a backup, records the outcome and prunes old
+// scheduled backups. A failed backup is recorded rather than treated
+// as an error, so that it is tried again at the next scheduled time.
+func (w *backupWorker) backup(limits retention) error {
+	logger.Infof("creating scheduled backup")
+	id, err := w.config.Backups.Create(ScheduledBackupNotes)
+	now := w.config.Clock.Now()
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		return errors.Trace(w.config.Backend.SetBackupFailed(err.Error(), now))
+	}
+	logger.Infof("created scheduled backup %q", id)
+	if err := w.config.Backend.SetBackupSucceeded(id, now); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.prune(limits, now); err != nil {
+		logger.Errorf("cannot prune scheduled backups: %v", err)
+	}
+	return nil
+}
+
+// prune removes the scheduled backups beyond the retention limits.
+func (w *backupWorker) prune(limits retention, now time.Time) error {
+	if limits.count == 0 && limits.age == 0 {
+		return nil
+	}
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == ScheduledBackupNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first.
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+	for i, meta := range scheduled {
+		tooMany := limits.count > 0 && i >= limits.count
+		tooOld := limits.age > 0 && now.Sub(meta.Started) > limits.age
+		if !tooMany && !tooOld {
+			continue
+		}
+		logger.Infof("removing scheduled backup %q (started %v)", meta.ID(), meta.Started)
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	backend *fakeBackend
+	backups *fakeBackups
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		watcher:   watchertest.NewNotifyWatcher(s.changes),
+		config:    controller.Config{controller.BackupSchedule: "24h"},
+		succeeded: make(chan string, 10),
+		failed:    make(chan string, 10),
+	}
+	s.backups = &fakeBackups{
+		clock:   s.clock,
+		created: make(chan string, 10),
+		removed: make(chan string, 10),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	s.changes <- struct{}{}
+	return w
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	_, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestBacksUpStraightAwayWithoutHistory(c *gc.C) {
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backups.created, backupscheduler.ScheduledBackupNotes)
+	s.expectValue(c, s.backend.succeeded, "backup-1")
+
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backups.created, backupscheduler.ScheduledBackupNotes)
+	s.expectValue(c, s.backend.succeeded, "backup-2")
+
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestWaitsForLastBackupPlusSchedule(c *gc.C) {
+	s.backend.status.LastSuccess = s.clock.Now().Add(-time.Hour)
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(22*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectNoValue(c, s.backups.created)
+
+	s.clock.Advance(time.Hour)
+	s.expectValue(c, s.backups.created, backupscheduler.ScheduledBackupNotes)
+	s.expectValue(c, s.backend.succeeded, "backup-1")
+
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.backend.config = controller.Config{}
+	w := s.startWorker(c)
+
+	workertest.CheckAlive(c, w)
+	s.expectNoValue(c, s.backups.created)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestFailureRecorded(c *gc.C) {
+	s.backups.createErr = errors.New("disk full")
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backend.failed, "disk full")
+
+	// The worker keeps running, and tries again at the next
+	// scheduled time.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backend.failed, "disk full")
+
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestPrunesByCount(c *gc.C) {
+	s.backend.config[controller.BackupRetentionCount] = 2
+	now := s.clock.Now()
+	s.backups.metas = []*backups.Metadata{
+		newMetadata("old", backupscheduler.ScheduledBackupNotes, now.Add(-48*time.Hour)),
+		newMetadata("manual", "before upgrade", now.Add(-72*time.Hour)),
+		newMetadata("recent", backupscheduler.ScheduledBackupNotes, now.Add(-24*time.Hour)),
+		newMetadata("oldest", backupscheduler.ScheduledBackupNotes, now.Add(-96*time.Hour)),
+	}
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backend.succeeded, "backup-1")
+	s.expectValue(c, s.backups.removed, "old")
+	s.expectValue(c, s.backups.removed, "oldest")
+	s.expectNoValue(c, s.backups.removed)
+
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestPrunesByAge(c *gc.C) {
+	s.backend.config[controller.BackupRetentionAge] = "36h"
+	now := s.clock.Now()
+	s.backups.metas = []*backups.Metadata{
+		newMetadata("old", backupscheduler.ScheduledBackupNotes, now.Add(-48*time.Hour)),
+		newMetadata("manual", "before upgrade", now.Add(-72*time.Hour)),
+		newMetadata("recent", backupscheduler.ScheduledBackupNotes, now.Add(-24*time.Hour)),
+	}
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectValue(c, s.backend.succeeded, "backup-1")
+	s.expectValue(c, s.backups.removed, "old")
+	s.expectNoValue(c, s.backups.removed)
+
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) expectValue(c *gc.C, ch <-chan string, expect string) {
+	select {
+	case value := <-ch:
+		c.Assert(value, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q", expect)
+	}
+}
+
+func (s *WorkerSuite) expectNoValue(c *gc.C, ch <-chan string) {
+	select {
+	case value := <-ch:
+		c.Fatalf("unexpected %q", value)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func newMetadata(id, notes string, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started = started
+	return meta
+}
+
+type fakeBackend struct {
+	watcher   state.NotifyWatcher
+	config    controller.Config
+	status    state.BackupStatus
+	succeeded chan string
+	failed    chan string
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) BackupStatus() (state.BackupStatus, error) {
+	return b.status, nil
+}
+
+func (b *fakeBackend) SetBackupSucceeded(id string, when time.Time) error {
+	b.succeeded <- id
+	return nil
+}
+
+func (b *fakeBackend) SetBackupFailed(reason string, when time.Time) error {
+	b.failed <- reason
+	return nil
+}
+
+type fakeBackups struct {
+	mu        sync.Mutex
+	clock     *testclock.Clock
+	count     int
+	createErr error
+	metas     []*backups.Metadata
+	created   chan string
+	removed   chan string
+}
+
+func (b *fakeBackups) Create(notes string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.created <- notes
+	if b.createErr != nil {
+		return "", b.createErr
+	}
+	b.count++
+	id := fmt.Sprintf("backup-%d", b.count)
+	b.metas = append(b.metas, newMetadata(id, notes, b.clock.Now()))
+	return id, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.metas, nil
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.removed <- id
+	return nil
+}
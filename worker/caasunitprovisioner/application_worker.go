@@ -126,6 +126,14 @@ func (aw *applicationWorker) loop() error {
 			args := params.UpdateApplicationUnits{
 				ApplicationTag: names.NewApplicationTag(aw.application).String(),
 			}
+			// If the substrate is autoscaling the application, the
+			// application's scale follows the autoscaler's.
+			scale, err := aw.containerBroker.AutoscaledUnits(aw.application)
+			if err == nil {
+				args.Scale = &scale
+			} else if !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
 			for _, u := range units {
 				// For pods managed by the substrate, any marked as dying
 				// are treated as non-existing.
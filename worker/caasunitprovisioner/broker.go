@@ -13,6 +13,7 @@ type ContainerBroker interface {
 	Provider() caas.ContainerEnvironProvider
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
 	Units(appName string) ([]caas.Unit, error)
+	AutoscaledUnits(appName string) (int, error)
 	DeleteService(appName string) error
 	UnexposeService(appName string) error
 }
@@ -29,6 +29,9 @@ type Client interface {
 type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
+	WatchApplicationMetrics(string) (watcher.NotifyWatcher, error)
+	ApplicationMetrics(string) (map[string]map[string]float64, error)
 	WatchApplicationScale(string) (watcher.NotifyWatcher, error)
 	ApplicationScale(string) (int, error)
 }
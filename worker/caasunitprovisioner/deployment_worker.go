@@ -4,6 +4,8 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -65,15 +67,35 @@ func (w *deploymentWorker) loop() error {
 	}
 	w.catacomb.Add(appScaleWatcher)
 
+	// The application config holds the autoscaling policy,
+	// so the service is ensured again when it changes.
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.catacomb.Add(appConfigWatcher)
+
+	// The units' metrics may determine how many units
+	// the application is autoscaled to.
+	appMetricsWatcher, err := w.applicationGetter.WatchApplicationMetrics(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.catacomb.Add(appMetricsWatcher)
+
 	var (
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
 
-		currentScale int
-		currentSpec  string
+		currentScale   int
+		currentSpec    string
+		currentMetrics map[string]map[string]float64
+		unitMetrics    map[string]map[string]float64
 	)
 
 	gotSpecNotify := false
+	gotConfigNotify := false
+	configChanged := false
 	serviceUpdated := false
 	scale := 0
 	for {
@@ -103,6 +125,25 @@ func (w *deploymentWorker) loop() error {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-appConfigWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			// The initial event is not a change; the
+			// config is read whenever the service is ensured.
+			if !gotConfigNotify {
+				gotConfigNotify = true
+				continue
+			}
+			configChanged = true
+		case _, ok := <-appMetricsWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			unitMetrics, err = w.applicationGetter.ApplicationMetrics(w.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 		if scale == 0 {
 			if cw != nil {
@@ -131,12 +172,15 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
-		if scale == currentScale && specStr == currentSpec {
+		if scale == currentScale && specStr == currentSpec && !configChanged &&
+			!metricsChanged(currentMetrics, unitMetrics) {
 			continue
 		}
 
 		currentScale = scale
 		currentSpec = specStr
+		currentMetrics = unitMetrics
+		configChanged = false
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -159,6 +203,7 @@ func (w *deploymentWorker) loop() error {
 			ResourceTags: info.Tags,
 			Filesystems:  info.Filesystems,
 			Devices:      info.Devices,
+			UnitMetrics:  unitMetrics,
 		}
 		err = w.broker.EnsureService(w.application, serviceParams, currentScale, appConfig)
 		if err != nil {
@@ -183,3 +228,10 @@ func (w *deploymentWorker) loop() error {
 		}
 	}
 }
+
+func metricsChanged(current, latest map[string]map[string]float64) bool {
+	if len(current) == 0 && len(latest) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(current, latest)
+}
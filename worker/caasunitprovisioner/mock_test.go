@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
 
@@ -78,6 +79,7 @@ type mockContainerBroker struct {
 	serviceDeleted     chan<- struct{}
 	unitsWatcher       *watchertest.MockNotifyWatcher
 	reportedUnitStatus status.Status
+	autoscaledUnits    int
 	podSpec            *caas.PodSpec
 }
 
@@ -125,11 +127,26 @@ func (m *mockContainerBroker) Units(appName string) ([]caas.Unit, error) {
 		m.NextErr()
 }
 
+func (m *mockContainerBroker) AutoscaledUnits(appName string) (int, error) {
+	m.MethodCall(m, "AutoscaledUnits", appName)
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	if m.autoscaledUnits == 0 {
+		return 0, errors.NotFoundf("autoscaler for %q", appName)
+	}
+	return m.autoscaledUnits, nil
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
-	watcher      *watchertest.MockStringsWatcher
-	scaleWatcher *watchertest.MockNotifyWatcher
-	scale        int
+	watcher        *watchertest.MockStringsWatcher
+	scaleWatcher   *watchertest.MockNotifyWatcher
+	configWatcher  *watchertest.MockNotifyWatcher
+	metricsWatcher *watchertest.MockNotifyWatcher
+	scale          int
+	config         application.ConfigAttributes
+	metrics        map[string]map[string]float64
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -142,11 +159,38 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
 }
 
+func (a *mockApplicationGetter) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationConfig", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.configWatcher, nil
+}
+
+func (a *mockApplicationGetter) WatchApplicationMetrics(application string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationMetrics", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.metricsWatcher, nil
+}
+
+func (a *mockApplicationGetter) ApplicationMetrics(application string) (map[string]map[string]float64, error) {
+	a.MethodCall(a, "ApplicationMetrics", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.metrics, nil
+}
+
 func (a *mockApplicationGetter) WatchApplicationScale(application string) (watcher.NotifyWatcher, error) {
 	a.MethodCall(a, "WatchApplicationScale", application)
 	if err := a.NextErr(); err != nil {
@@ -42,14 +42,16 @@ type WorkerSuite struct {
 	lifeGetter         mockLifeGetter
 	unitUpdater        mockUnitUpdater
 
-	applicationChanges      chan []string
-	applicationScaleChanges chan struct{}
-	caasUnitsChanges        chan struct{}
-	containerSpecChanges    chan struct{}
-	serviceDeleted          chan struct{}
-	serviceEnsured          chan struct{}
-	serviceUpdated          chan struct{}
-	clock                   *testclock.Clock
+	applicationChanges        chan []string
+	applicationScaleChanges   chan struct{}
+	applicationConfigChanges  chan struct{}
+	applicationMetricsChanges chan struct{}
+	caasUnitsChanges          chan struct{}
+	containerSpecChanges      chan struct{}
+	serviceDeleted            chan struct{}
+	serviceEnsured            chan struct{}
+	serviceUpdated            chan struct{}
+	clock                     *testclock.Clock
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -98,6 +100,8 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.applicationScaleChanges = make(chan struct{})
+	s.applicationConfigChanges = make(chan struct{})
+	s.applicationMetricsChanges = make(chan struct{})
 	s.caasUnitsChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
 	s.serviceDeleted = make(chan struct{})
@@ -105,8 +109,10 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.serviceUpdated = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		watcher:      watchertest.NewMockStringsWatcher(s.applicationChanges),
-		scaleWatcher: watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		watcher:        watchertest.NewMockStringsWatcher(s.applicationChanges),
+		scaleWatcher:   watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		configWatcher:  watchertest.NewMockNotifyWatcher(s.applicationConfigChanges),
+		metricsWatcher: watchertest.NewMockNotifyWatcher(s.applicationMetricsChanges),
 	}
 	s.applicationUpdater = mockApplicationUpdater{
 		updated: s.serviceUpdated,
@@ -249,7 +255,7 @@ func (s *WorkerSuite) TestScaleChanged(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationScale", "WatchApplicationConfig", "WatchApplicationMetrics", "ApplicationScale", "ApplicationConfig")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
@@ -302,6 +308,80 @@ func (s *WorkerSuite) TestScaleChanged(c *gc.C) {
 		"gitlab", &newExpectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) sendApplicationConfigChange(c *gc.C) {
+	select {
+	case s.applicationConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+}
+
+func (s *WorkerSuite) TestApplicationConfigChanged(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	// The initial event is not a change.
+	s.serviceBroker.ResetCalls()
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	autoscaledConfig := application.ConfigAttributes{
+		"kubernetes-autoscaling-max-units":  3,
+		"kubernetes-autoscaling-target-cpu": 80,
+	}
+	s.applicationGetter.config = autoscaledConfig
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedServiceParams, 1, autoscaledConfig)
+}
+
+func (s *WorkerSuite) sendApplicationMetricsChange(c *gc.C) {
+	select {
+	case s.applicationMetricsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application metrics change")
+	}
+}
+
+func (s *WorkerSuite) TestApplicationMetricsChanged(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	// No metrics recorded yet, nothing happens.
+	s.serviceBroker.ResetCalls()
+	s.sendApplicationMetricsChange(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.applicationGetter.metrics = map[string]map[string]float64{
+		"gitlab/0": {"pings": 5},
+	}
+	s.sendApplicationMetricsChange(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	expectedParams := *expectedServiceParams
+	expectedParams.UnitMetrics = s.applicationGetter.metrics
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", &expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestNewPodSpecChange(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -625,38 +705,58 @@ func (s *WorkerSuite) TestUnitsChange(c *gc.C) {
 	}
 	s.containerBroker.CheckCallNames(c, "WatchUnits")
 
-	s.assertUnitChange(c, status.Allocating, status.Allocating)
-	s.assertUnitChange(c, status.Allocating, status.Unknown)
+	s.assertUnitChange(c, status.Allocating, status.Allocating, nil)
+	s.assertUnitChange(c, status.Allocating, status.Unknown, nil)
 }
 
-func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expected status.Status) {
-	s.containerBroker.ResetCalls()
-	s.unitUpdater.ResetCalls()
-	s.containerBroker.reportedUnitStatus = reported
+func (s *WorkerSuite) TestUnitsChangeAutoscaled(c *gc.C) {
+	s.containerBroker.autoscaledUnits = 3
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
 
 	select {
-	case s.caasUnitsChanges <- struct{}{}:
+	case s.applicationChanges <- []string{"gitlab"}:
 	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
+		c.Fatal("timed out sending applications change")
 	}
+	defer workertest.CleanKill(c, w)
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
 		if len(s.containerBroker.Calls()) > 0 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "Units")
-	c.Assert(s.containerBroker.Calls()[0].Args, jc.DeepEquals, []interface{}{"gitlab"})
+	s.containerBroker.CheckCallNames(c, "WatchUnits")
+
+	scale := 3
+	s.assertUnitChange(c, status.Allocating, status.Allocating, &scale)
+}
+
+func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expected status.Status, scale *int) {
+	s.containerBroker.ResetCalls()
+	s.unitUpdater.ResetCalls()
+	s.containerBroker.reportedUnitStatus = reported
+
+	select {
+	case s.caasUnitsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
 		if len(s.unitUpdater.Calls()) > 0 {
 			break
 		}
 	}
+	s.containerBroker.CheckCallNames(c, "Units", "AutoscaledUnits")
+	c.Assert(s.containerBroker.Calls()[0].Args, jc.DeepEquals, []interface{}{"gitlab"})
+
 	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
 	c.Assert(s.unitUpdater.Calls()[0].Args, jc.DeepEquals, []interface{}{
 		params.UpdateApplicationUnits{
 			ApplicationTag: names.NewApplicationTag("gitlab").String(),
+			Scale:          scale,
 			Units: []params.ApplicationUnitParams{
 				{ProviderId: "u1", Address: "10.0.0.1", Ports: []string(nil), Status: expected.String(),
 					FilesystemInfo: []params.KubernetesFilesystemInfo{
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// applicationWorker starts a log follower for each unit of an
+// application, and stops it once the unit's pod has gone.
+type applicationWorker struct {
+	catacomb    catacomb.Catacomb
+	application string
+	broker      Broker
+	unitGetter  UnitGetter
+	logWriter   LogWriter
+	clock       clock.Clock
+
+	// since is when to follow the logs of the units first
+	// seen by the worker from. Units seen later have all
+	// their logs followed.
+	since time.Time
+
+	// followers holds the log follower of each unit,
+	// keyed on the unit's provider id.
+	followers map[string]worker.Worker
+}
+
+func newApplicationWorker(
+	application string,
+	since time.Time,
+	broker Broker,
+	unitGetter UnitGetter,
+	logWriter LogWriter,
+	clock clock.Clock,
+) (*applicationWorker, error) {
+	w := &applicationWorker{
+		application: application,
+		since:       since,
+		broker:      broker,
+		unitGetter:  unitGetter,
+		logWriter:   logWriter,
+		clock:       clock,
+		followers:   make(map[string]worker.Worker),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (aw *applicationWorker) Kill() {
+	aw.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (aw *applicationWorker) Wait() error {
+	return aw.catacomb.Wait()
+}
+
+func (aw *applicationWorker) loop() error {
+	var unitsWatcher watcher.NotifyWatcher
+	// The caas watcher can just die from underneath us hence it needs to be
+	// restarted all the time. So we don't abuse the catacomb by adding new
+	// workers unbounded, use a defer to stop the running worker.
+	defer func() {
+		if unitsWatcher != nil {
+			worker.Stop(unitsWatcher)
+		}
+	}()
+
+	var retry <-chan time.Time
+	for {
+		if unitsWatcher == nil {
+			var err error
+			unitsWatcher, err = aw.broker.WatchUnits(aw.application)
+			if err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
+					return nil
+				}
+				return errors.Annotatef(err, "failed to start unit watcher for %q", aw.application)
+			}
+		}
+		select {
+		case <-aw.catacomb.Dying():
+			return aw.catacomb.ErrDying()
+		case _, ok := <-unitsWatcher.Changes():
+			if !ok {
+				logger.Debugf("%v", unitsWatcher.Wait())
+				worker.Stop(unitsWatcher)
+				unitsWatcher = nil
+				continue
+			}
+		case <-retry:
+		}
+		unmapped, err := aw.updateFollowers()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		retry = nil
+		if unmapped {
+			// Juju records which unit a pod belongs to some time
+			// after the pod appears, so look again later.
+			retry = aw.clock.After(retryDelay)
+		}
+	}
+}
+
+// updateFollowers starts following the logs of any new units and
+// stops following those of units whose pods have gone. It reports
+// whether there are pods whose unit is not yet known.
+func (aw *applicationWorker) updateFollowers() (bool, error) {
+	units, err := aw.broker.Units(aw.application)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	unitTags, err := aw.unitGetter.UnitProviderIds(aw.application)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	unmapped := false
+	current := set.NewStrings()
+	for _, u := range units {
+		current.Add(u.Id)
+		// Terminating pods keep their followers until
+		// they've gone, but don't get new ones.
+		if _, ok := aw.followers[u.Id]; ok || u.Dying {
+			continue
+		}
+		unitTag, ok := unitTags[u.Id]
+		if !ok {
+			unmapped = true
+			continue
+		}
+		logger.Debugf("following workload logs of %v", unitTag.Id())
+		f, err := newLogFollower(aw.application, u.Id, unitTag, aw.since, aw.broker, aw.logWriter, aw.clock)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if err := aw.catacomb.Add(f); err != nil {
+			return false, errors.Trace(err)
+		}
+		aw.followers[u.Id] = f
+	}
+	aw.since = time.Time{}
+
+	for id, f := range aw.followers {
+		if current.Contains(id) {
+			continue
+		}
+		if err := worker.Stop(f); err != nil {
+			logger.Errorf("stopping log follower for %v: %v", id, err)
+		}
+		delete(aw.followers, id)
+	}
+	return unmapped, nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Broker provides an interface for following
+// the logs of an application's units.
+type Broker interface {
+	WatchUnits(appName string) (watcher.NotifyWatcher, error)
+	Units(appName string) ([]caas.Unit, error)
+	WorkloadContainers(appName, unitId string) ([]caas.WorkloadContainer, error)
+	WorkloadLog(appName, unitId, container string, since time.Time) (io.ReadCloser, error)
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client provides an interface for interacting with the
+// CAASUnitProvisioner API. Subsets of this should be passed
+// to the CAAS workload logs worker.
+type Client interface {
+	ApplicationGetter
+	LifeGetter
+	UnitGetter
+}
+
+// ApplicationGetter provides an interface for
+// watching for the lifecycle state changes
+// (including addition) of applications in the
+// model.
+type ApplicationGetter interface {
+	WatchApplications() (watcher.StringsWatcher, error)
+}
+
+// LifeGetter provides an interface for getting the
+// lifecycle state value for an application.
+type LifeGetter interface {
+	Life(string) (life.Value, error)
+}
+
+// UnitGetter provides an interface for getting the
+// units of an application, keyed on their provider ids.
+type UnitGetter interface {
+	UnitProviderIds(appName string) (map[string]names.UnitTag, error)
+}
+
+// LogWriter provides an interface for writing
+// log records to the controller.
+type LogWriter interface {
+	WriteLog(*params.LogRecord) error
+	Close() error
+}
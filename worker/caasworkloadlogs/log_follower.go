@@ -0,0 +1,261 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// maxLineLength is the length beyond which
+// a line of a container's log is truncated.
+const maxLineLength = 256 * 1024
+
+// logFollower writes the log output of the containers of
+// a unit's pod to the model's log, as logged by the unit.
+type logFollower struct {
+	catacomb    catacomb.Catacomb
+	application string
+	unitId      string
+	unitTag     names.UnitTag
+	since       time.Time
+	broker      Broker
+	logWriter   LogWriter
+	clock       clock.Clock
+
+	// streams holds the open log stream of each container.
+	streams map[string]io.ReadCloser
+
+	// last holds the time of the last line
+	// written from each container.
+	last map[string]time.Time
+}
+
+func newLogFollower(
+	application, unitId string,
+	unitTag names.UnitTag,
+	since time.Time,
+	broker Broker,
+	logWriter LogWriter,
+	clock clock.Clock,
+) (*logFollower, error) {
+	f := &logFollower{
+		application: application,
+		unitId:      unitId,
+		unitTag:     unitTag,
+		since:       since,
+		broker:      broker,
+		logWriter:   logWriter,
+		clock:       clock,
+		streams:     make(map[string]io.ReadCloser),
+		last:        make(map[string]time.Time),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &f.catacomb,
+		Work: f.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (f *logFollower) Kill() {
+	f.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (f *logFollower) Wait() error {
+	return f.catacomb.Wait()
+}
+
+func (f *logFollower) loop() error {
+	lines := make(chan logLine)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		for _, stream := range f.streams {
+			stream.Close()
+		}
+	}()
+
+	for {
+		pending, err := f.openStreams(lines, done)
+		if errors.IsNotFound(err) {
+			logger.Debugf("pod of %v has gone", f.unitTag.Id())
+			return nil
+		}
+		if err != nil {
+			logger.Warningf("cannot follow workload logs of %v: %v", f.unitTag.Id(), err)
+			pending = true
+		}
+		// Containers yet to start have no streams, and streams
+		// end when their containers stop, so look again later
+		// for containers which have (re)started.
+		var retry <-chan time.Time
+		if pending {
+			retry = f.clock.After(retryDelay)
+		}
+		if err := f.follow(lines, retry); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// openStreams opens a log stream for each started container which
+// is not already followed, from the time of the last line written
+// from it. It reports whether any container is left unfollowed.
+func (f *logFollower) openStreams(lines chan<- logLine, done <-chan struct{}) (bool, error) {
+	containers, err := f.broker.WorkloadContainers(f.application, f.unitId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	pending := false
+	for _, container := range containers {
+		if _, ok := f.streams[container.Name]; ok {
+			continue
+		}
+		if !container.Started {
+			pending = true
+			continue
+		}
+		since, ok := f.last[container.Name]
+		if !ok {
+			since = f.since
+		}
+		stream, err := f.broker.WorkloadLog(f.application, f.unitId, container.Name, since)
+		if errors.IsNotFound(err) {
+			return false, errors.Trace(err)
+		}
+		if err != nil {
+			logger.Warningf("cannot follow workload logs of container %q of %v: %v", container.Name, f.unitTag.Id(), err)
+			pending = true
+			continue
+		}
+		f.streams[container.Name] = stream
+		go readLines(container.Name, stream, lines, done)
+	}
+	return pending, nil
+}
+
+// logLine is a line read from a container's log stream.
+type logLine struct {
+	container string
+	line      string
+	end       bool
+}
+
+// follow writes the lines read from the log streams, closing
+// each stream as it ends, until it is time to retry opening
+// the streams of containers which are not followed.
+func (f *logFollower) follow(lines <-chan logLine, retry <-chan time.Time) error {
+	for {
+		select {
+		case <-f.catacomb.Dying():
+			return f.catacomb.ErrDying()
+		case <-retry:
+			return nil
+		case l := <-lines:
+			if !l.end {
+				if err := f.writeLine(l.container, l.line); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			f.streams[l.container].Close()
+			delete(f.streams, l.container)
+			if retry == nil {
+				retry = f.clock.After(retryDelay)
+			}
+		}
+	}
+}
+
+func (f *logFollower) writeLine(container, line string) error {
+	t, message := parseLogLine(line, f.clock.Now())
+	// Reopened streams repeat lines from the
+	// second of the last line already written.
+	if last, ok := f.last[container]; ok && !t.After(last) {
+		return nil
+	}
+	f.last[container] = t
+	return f.logWriter.WriteLog(&params.LogRecord{
+		Entity:  f.unitTag.String(),
+		Time:    t,
+		Module:  "workload." + container,
+		Level:   loggo.INFO.String(),
+		Message: message,
+	})
+}
+
+// readLines sends the lines read from a container's stream, and
+// then a line marking the end of the stream, until done is closed.
+func readLines(container string, stream io.Reader, lines chan<- logLine, done <-chan struct{}) {
+	send := func(line logLine) bool {
+		select {
+		case lines <- line:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	r := bufio.NewReader(stream)
+	for {
+		line, err := readLine(r)
+		if err == nil || line != "" {
+			if !send(logLine{container: container, line: line}) {
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				logger.Debugf("reading logs of container %q: %v", container, err)
+			}
+			break
+		}
+	}
+	send(logLine{container: container, end: true})
+}
+
+// readLine reads a line, without its line ending. Lines
+// longer than maxLineLength are truncated to that length.
+func readLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := r.ReadLine()
+		if err != nil {
+			return string(line), err
+		}
+		if room := maxLineLength - len(line); room > 0 {
+			if len(chunk) > room {
+				chunk = chunk[:room]
+			}
+			line = append(line, chunk...)
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// parseLogLine splits a line into its timestamp and message. Lines
+// without a timestamp are given the specified time.
+func parseLogLine(line string, now time.Time) (time.Time, string) {
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			return t, line[i+1:]
+		}
+	}
+	return now, line
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/caas"
+)
+
+// ManifoldConfig defines a CAAS workload logs worker's dependencies.
+type ManifoldConfig struct {
+	APICallerName string
+	BrokerName    string
+	ClockName     string
+
+	NewClient    func(base.APICaller) Client
+	NewLogWriter func(base.APICaller) (LogWriter, error)
+	NewWorker    func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.BrokerName == "" {
+		return errors.NotValidf("empty BrokerName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.NewClient == nil {
+		return errors.NotValidf("nil NewClient")
+	}
+	if config.NewLogWriter == nil {
+		return errors.NotValidf("nil NewLogWriter")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var broker caas.Broker
+	if err := context.Get(config.BrokerName, &broker); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	logWriter, err := config.NewLogWriter(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := config.NewClient(apiCaller)
+	w, err := config.NewWorker(Config{
+		ApplicationGetter: client,
+		LifeGetter:        client,
+		UnitGetter:        client,
+		Broker:            broker,
+		LogWriter:         logWriter,
+		Clock:             clock,
+	})
+	if err != nil {
+		logWriter.Close()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Manifold creates a manifold that runs a CAAS workload logs worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.BrokerName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/caasworkloadlogs"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	testing.Stub
+	manifold dependency.Manifold
+	context  dependency.Context
+
+	apiCaller fakeAPICaller
+	broker    fakeBroker
+	client    fakeClient
+	logWriter fakeLogWriter
+	clock     *testclock.Clock
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.ResetCalls()
+
+	s.clock = testclock.NewClock(time.Time{})
+	s.context = s.newContext(nil)
+	s.manifold = caasworkloadlogs.Manifold(s.validConfig())
+}
+
+func (s *ManifoldSuite) validConfig() caasworkloadlogs.ManifoldConfig {
+	return caasworkloadlogs.ManifoldConfig{
+		APICallerName: "api-caller",
+		BrokerName:    "broker",
+		ClockName:     "clock",
+		NewClient:     s.newClient,
+		NewLogWriter:  s.newLogWriter,
+		NewWorker:     s.newWorker,
+	}
+}
+
+func (s *ManifoldSuite) newClient(apiCaller base.APICaller) caasworkloadlogs.Client {
+	s.MethodCall(s, "NewClient", apiCaller)
+	return &s.client
+}
+
+func (s *ManifoldSuite) newLogWriter(apiCaller base.APICaller) (caasworkloadlogs.LogWriter, error) {
+	s.MethodCall(s, "NewLogWriter", apiCaller)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return &s.logWriter, nil
+}
+
+func (s *ManifoldSuite) newWorker(config caasworkloadlogs.Config) (worker.Worker, error) {
+	s.MethodCall(s, "NewWorker", config)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	w := worker.NewRunner(worker.RunnerParams{})
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w, nil
+}
+
+func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"api-caller": &s.apiCaller,
+		"broker":     &s.broker,
+		"clock":      s.clock,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+func (s *ManifoldSuite) TestMissingAPICallerName(c *gc.C) {
+	config := s.validConfig()
+	config.APICallerName = ""
+	s.checkConfigInvalid(c, config, "empty APICallerName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingBrokerName(c *gc.C) {
+	config := s.validConfig()
+	config.BrokerName = ""
+	s.checkConfigInvalid(c, config, "empty BrokerName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	config := s.validConfig()
+	config.ClockName = ""
+	s.checkConfigInvalid(c, config, "empty ClockName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewClient(c *gc.C) {
+	config := s.validConfig()
+	config.NewClient = nil
+	s.checkConfigInvalid(c, config, "nil NewClient not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewLogWriter(c *gc.C) {
+	config := s.validConfig()
+	config.NewLogWriter = nil
+	s.checkConfigInvalid(c, config, "nil NewLogWriter not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	config := s.validConfig()
+	config.NewWorker = nil
+	s.checkConfigInvalid(c, config, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkConfigInvalid(c *gc.C, config caasworkloadlogs.ManifoldConfig, expect string) {
+	err := config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+var expectedInputs = []string{"api-caller", "broker", "clock"}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *ManifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range expectedInputs {
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	s.CheckCallNames(c, "NewLogWriter", "NewClient", "NewWorker")
+	s.CheckCall(c, 0, "NewLogWriter", &s.apiCaller)
+	s.CheckCall(c, 1, "NewClient", &s.apiCaller)
+
+	args := s.Calls()[2].Args
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0], gc.FitsTypeOf, caasworkloadlogs.Config{})
+	config := args[0].(caasworkloadlogs.Config)
+
+	c.Assert(config, jc.DeepEquals, caasworkloadlogs.Config{
+		ApplicationGetter: &s.client,
+		LifeGetter:        &s.client,
+		UnitGetter:        &s.client,
+		Broker:            &s.broker,
+		LogWriter:         &s.logWriter,
+		Clock:             s.clock,
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasworkloadlogs"
+)
+
+type fakeAPICaller struct {
+	base.APICaller
+}
+
+type fakeBroker struct {
+	caas.Broker
+}
+
+type fakeClient struct {
+	caasworkloadlogs.Client
+}
+
+type fakeLogWriter struct {
+	caasworkloadlogs.LogWriter
+}
+
+func (*fakeLogWriter) Close() error {
+	return nil
+}
+
+type mockClient struct {
+	testing.Stub
+	mu       sync.Mutex
+	watcher  *watchertest.MockStringsWatcher
+	life     life.Value
+	unitTags map[string]names.UnitTag
+}
+
+func (m *mockClient) WatchApplications() (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchApplications")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+func (m *mockClient) setLife(life life.Value) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.life = life
+}
+
+func (m *mockClient) Life(entityName string) (life.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "Life", entityName)
+	return m.life, m.NextErr()
+}
+
+func (m *mockClient) setUnitTags(unitTags map[string]names.UnitTag) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unitTags = unitTags
+}
+
+func (m *mockClient) UnitProviderIds(appName string) (map[string]names.UnitTag, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "UnitProviderIds", appName)
+	return m.unitTags, m.NextErr()
+}
+
+type mockBroker struct {
+	testing.Stub
+	mu           sync.Mutex
+	unitsWatcher *watchertest.MockNotifyWatcher
+	units        []caas.Unit
+	containers   []caas.WorkloadContainer
+	logs         map[string]string
+	following    map[string]bool
+	logsOpened   chan logsOpened
+}
+
+// logsOpened records the opening of a container's log stream.
+type logsOpened struct {
+	container string
+	since     time.Time
+}
+
+func (m *mockBroker) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchUnits", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.unitsWatcher, nil
+}
+
+func (m *mockBroker) setUnits(units []caas.Unit) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.units = units
+}
+
+func (m *mockBroker) Units(appName string) ([]caas.Unit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "Units", appName)
+	return m.units, m.NextErr()
+}
+
+func (m *mockBroker) setLogs(logs map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = logs
+}
+
+func (m *mockBroker) setContainers(containers []caas.WorkloadContainer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.containers = containers
+}
+
+func (m *mockBroker) WorkloadContainers(appName, unitId string) ([]caas.WorkloadContainer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "WorkloadContainers", appName, unitId)
+	return m.containers, m.NextErr()
+}
+
+// WorkloadLog returns a stream of the container's logs which ends
+// after them, or which stays open if the container is following.
+func (m *mockBroker) WorkloadLog(appName, unitId, container string, since time.Time) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "WorkloadLog", appName, unitId, container, since)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	var stream io.ReadCloser = ioutil.NopCloser(strings.NewReader(m.logs[container]))
+	if m.following[container] {
+		r, w := io.Pipe()
+		go io.WriteString(w, m.logs[container])
+		stream = r
+	}
+	m.logsOpened <- logsOpened{container, since}
+	return stream, nil
+}
+
+func (m *mockBroker) assertLogsOpened(c *gc.C, container string, since time.Time) {
+	select {
+	case opened := <-m.logsOpened:
+		c.Assert(opened, gc.DeepEquals, logsOpened{container, since})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs to be opened")
+	}
+}
+
+func (m *mockBroker) assertNoLogsOpened(c *gc.C) {
+	select {
+	case opened := <-m.logsOpened:
+		c.Fatalf("unexpected logs opened %#v", opened)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockLogWriter struct {
+	testing.Stub
+	records chan *params.LogRecord
+}
+
+func (m *mockLogWriter) WriteLog(record *params.LogRecord) error {
+	m.MethodCall(m, "WriteLog", record)
+	m.records <- record
+	return m.NextErr()
+}
+
+func (m *mockLogWriter) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockLogWriter) assertRecord(c *gc.C, expect params.LogRecord) {
+	select {
+	case record := <-m.records:
+		c.Assert(*record, gc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for log record")
+	}
+}
+
+func (m *mockLogWriter) assertNoRecord(c *gc.C) {
+	select {
+	case record := <-m.records:
+		c.Fatalf("unexpected log record %#v", record)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
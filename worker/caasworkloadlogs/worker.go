@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+)
+
+var logger = loggo.GetLogger("juju.workers.caasworkloadlogs")
+
+// retryDelay is how long to wait before looking again for the
+// unit of a pod, or before reopening the log streams of a unit.
+const retryDelay = 5 * time.Second
+
+// Config holds configuration for the CAAS workload logs worker.
+type Config struct {
+	ApplicationGetter ApplicationGetter
+	LifeGetter        LifeGetter
+	UnitGetter        UnitGetter
+	Broker            Broker
+
+	// LogWriter is used to send the workload logs to the
+	// controller. It is closed when the worker stops.
+	LogWriter LogWriter
+	Clock     clock.Clock
+}
+
+// Validate validates the worker configuration.
+func (config Config) Validate() error {
+	if config.ApplicationGetter == nil {
+		return errors.NotValidf("missing ApplicationGetter")
+	}
+	if config.LifeGetter == nil {
+		return errors.NotValidf("missing LifeGetter")
+	}
+	if config.UnitGetter == nil {
+		return errors.NotValidf("missing UnitGetter")
+	}
+	if config.Broker == nil {
+		return errors.NotValidf("missing Broker")
+	}
+	if config.LogWriter == nil {
+		return errors.NotValidf("missing LogWriter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
+	return nil
+}
+
+// NewWorker starts and returns a new CAAS workload logs worker,
+// which follows the logs of the containers of each CAAS unit
+// and writes them to the model's log.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &logsWorker{
+		config:     config,
+		logWriter:  &lockedLogWriter{LogWriter: config.LogWriter},
+		started:    config.Clock.Now(),
+		appWorkers: make(map[string]worker.Worker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, err
+}
+
+type logsWorker struct {
+	catacomb  catacomb.Catacomb
+	config    Config
+	logWriter LogWriter
+
+	// started is when the worker started. Units which
+	// already existed then only have their newer logs
+	// followed, as older ones may already be recorded.
+	started    time.Time
+	appWorkers map[string]worker.Worker
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *logsWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *logsWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *logsWorker) loop() error {
+	defer w.config.LogWriter.Close()
+
+	appWatcher, err := w.config.ApplicationGetter.WatchApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	initial := true
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case apps, ok := <-appWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			var since time.Time
+			if initial {
+				since = w.started
+				initial = false
+			}
+			for _, appId := range apps {
+				if err := w.applicationChanged(appId, since); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+func (w *logsWorker) applicationChanged(appId string, since time.Time) error {
+	appLife, err := w.config.LifeGetter.Life(appId)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	aw, ok := w.appWorkers[appId]
+	if errors.IsNotFound(err) || appLife == life.Dead {
+		if ok {
+			if err := worker.Stop(aw); err != nil {
+				logger.Errorf("stopping application worker for %v: %v", appId, err)
+			}
+			delete(w.appWorkers, appId)
+		}
+		return nil
+	}
+	if ok {
+		return nil
+	}
+	aw, err = newApplicationWorker(appId, since, w.config.Broker, w.config.UnitGetter, w.logWriter, w.config.Clock)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.appWorkers[appId] = aw
+	return w.catacomb.Add(aw)
+}
+
+// lockedLogWriter allows the log followers
+// of all units to share a log writer.
+type lockedLogWriter struct {
+	mu sync.Mutex
+	LogWriter
+}
+
+// WriteLog is part of the LogWriter interface.
+func (w *lockedLogWriter) WriteLog(m *params.LogRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.LogWriter.WriteLog(m)
+}
@@ -0,0 +1,362 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasworkloadlogs"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	config    caasworkloadlogs.Config
+	client    mockClient
+	broker    mockBroker
+	logWriter mockLogWriter
+
+	applicationChanges chan []string
+	caasUnitsChanges   chan struct{}
+	clock              *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var startTime = time.Date(2018, 10, 16, 9, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.applicationChanges = make(chan []string)
+	s.caasUnitsChanges = make(chan struct{})
+	s.clock = testclock.NewClock(startTime)
+
+	s.client = mockClient{
+		watcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
+		life:    life.Alive,
+		unitTags: map[string]names.UnitTag{
+			"uuid": names.NewUnitTag("gitlab/0"),
+		},
+	}
+	s.broker = mockBroker{
+		unitsWatcher: watchertest.NewMockNotifyWatcher(s.caasUnitsChanges),
+		units:        []caas.Unit{{Id: "uuid"}},
+		containers:   []caas.WorkloadContainer{{Name: "gitlab", Started: true}},
+		logs: map[string]string{
+			"gitlab": "2018-10-16T10:00:00.5Z hello\n2018-10-16T10:00:01Z world\n",
+		},
+		logsOpened: make(chan logsOpened, 10),
+	}
+	s.logWriter = mockLogWriter{
+		records: make(chan *params.LogRecord, 10),
+	}
+
+	s.config = caasworkloadlogs.Config{
+		ApplicationGetter: &s.client,
+		LifeGetter:        &s.client,
+		UnitGetter:        &s.client,
+		Broker:            &s.broker,
+		LogWriter:         &s.logWriter,
+		Clock:             s.clock,
+	}
+}
+
+func (s *WorkerSuite) sendApplicationChange(c *gc.C) {
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+}
+
+func (s *WorkerSuite) sendUnitsChange(c *gc.C) {
+	select {
+	case s.caasUnitsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.ApplicationGetter = nil
+	}, `missing ApplicationGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.LifeGetter = nil
+	}, `missing LifeGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.UnitGetter = nil
+	}, `missing UnitGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.Broker = nil
+	}, `missing Broker not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.LogWriter = nil
+	}, `missing LogWriter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.Clock = nil
+	}, `missing Clock not valid`)
+}
+
+func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasworkloadlogs.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := caasworkloadlogs.NewWorker(config)
+	if err == nil {
+		workertest.DirtyKill(c, w)
+	}
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestStartStop(c *gc.C) {
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	s.logWriter.CheckCallNames(c, "Close")
+}
+
+func (s *WorkerSuite) TestFollowsUnitLogs(c *gc.C) {
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+
+	// Units existing when the worker starts
+	// only have their newer logs followed.
+	s.broker.assertLogsOpened(c, "gitlab", startTime)
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 0, 5e8, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "hello",
+	})
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 1, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "world",
+	})
+	s.client.CheckCall(c, 1, "Life", "gitlab")
+	s.broker.CheckCall(c, 2, "WorkloadContainers", "gitlab", "uuid")
+	s.broker.CheckCall(c, 3, "WorkloadLog", "gitlab", "uuid", "gitlab", startTime)
+}
+
+func (s *WorkerSuite) TestReopenedLogsSkipWrittenLines(c *gc.C) {
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+	s.broker.assertLogsOpened(c, "gitlab", startTime)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-s.logWriter.records:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for log record")
+		}
+	}
+
+	s.broker.setLogs(map[string]string{
+		"gitlab": "2018-10-16T10:00:01Z world\n2018-10-16T10:00:02Z again\n",
+	})
+	err := s.clock.WaitAdvance(caasworkloadlogs.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.broker.assertLogsOpened(c, "gitlab", time.Date(2018, 10, 16, 10, 0, 1, 0, time.UTC))
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 2, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "again",
+	})
+	s.logWriter.assertNoRecord(c)
+}
+
+func (s *WorkerSuite) TestContainersFollowedIndependently(c *gc.C) {
+	s.broker.setContainers([]caas.WorkloadContainer{
+		{Name: "gitlab", Started: true},
+		{Name: "sidecar", Started: true},
+	})
+	s.broker.setLogs(map[string]string{
+		"gitlab":  "2018-10-16T10:00:00Z hello\n",
+		"sidecar": "2018-10-16T10:00:00Z sidecar\n",
+	})
+	s.broker.following = map[string]bool{"sidecar": true}
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+	s.broker.assertLogsOpened(c, "gitlab", startTime)
+	s.broker.assertLogsOpened(c, "sidecar", startTime)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-s.logWriter.records:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for log record")
+		}
+	}
+
+	// Only the stream which ended is reopened,
+	// from the time of its own last line.
+	s.broker.setLogs(map[string]string{
+		"gitlab": "2018-10-16T10:00:00Z hello\n2018-10-16T10:00:03Z again\n",
+	})
+	err := s.clock.WaitAdvance(caasworkloadlogs.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.broker.assertLogsOpened(c, "gitlab", time.Date(2018, 10, 16, 10, 0, 0, 0, time.UTC))
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 3, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "again",
+	})
+	s.broker.assertNoLogsOpened(c)
+}
+
+func (s *WorkerSuite) TestContainerStartedLater(c *gc.C) {
+	s.broker.setContainers([]caas.WorkloadContainer{
+		{Name: "gitlab", Started: true},
+		{Name: "sidecar", Started: false},
+	})
+	s.broker.setLogs(map[string]string{
+		"gitlab":  "2018-10-16T10:00:00Z hello\n",
+		"sidecar": "2018-10-16T10:00:01Z sidecar\n",
+	})
+	s.broker.following = map[string]bool{"gitlab": true}
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+	s.broker.assertLogsOpened(c, "gitlab", startTime)
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 0, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "hello",
+	})
+
+	s.broker.setContainers([]caas.WorkloadContainer{
+		{Name: "gitlab", Started: true},
+		{Name: "sidecar", Started: true},
+	})
+	err := s.clock.WaitAdvance(caasworkloadlogs.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.broker.assertLogsOpened(c, "sidecar", startTime)
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 1, 0, time.UTC),
+		Module:  "workload.sidecar",
+		Level:   "INFO",
+		Message: "sidecar",
+	})
+	s.broker.assertNoLogsOpened(c)
+}
+
+func (s *WorkerSuite) TestLongLinesTruncated(c *gc.C) {
+	prefix := "2018-10-16T10:00:00Z "
+	message := strings.Repeat("x", caasworkloadlogs.MaxLineLength-len(prefix))
+	s.broker.setLogs(map[string]string{
+		"gitlab": prefix + message + "truncated\n2018-10-16T10:00:01Z world\n",
+	})
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 0, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: message,
+	})
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 1, 0, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "world",
+	})
+}
+
+func (s *WorkerSuite) TestUnknownUnitRetried(c *gc.C) {
+	s.client.setUnitTags(nil)
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+
+	// The pod's unit is looked for again until it is known.
+	err := s.clock.WaitAdvance(caasworkloadlogs.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.setUnitTags(map[string]names.UnitTag{
+		"uuid": names.NewUnitTag("gitlab/0"),
+	})
+	err = s.clock.WaitAdvance(caasworkloadlogs.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Units appearing after the worker has
+	// started have all their logs followed.
+	s.broker.assertLogsOpened(c, "gitlab", time.Time{})
+	s.logWriter.assertRecord(c, params.LogRecord{
+		Entity:  "unit-gitlab-0",
+		Time:    time.Date(2018, 10, 16, 10, 0, 0, 5e8, time.UTC),
+		Module:  "workload.gitlab",
+		Level:   "INFO",
+		Message: "hello",
+	})
+}
+
+func (s *WorkerSuite) TestDyingUnitNotFollowed(c *gc.C) {
+	s.broker.setUnits([]caas.Unit{{Id: "uuid", Dying: true}})
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+	// Send another change to be sure the first has been handled.
+	s.sendUnitsChange(c)
+
+	s.broker.CheckCall(c, 1, "Units", "gitlab")
+	s.broker.assertNoLogsOpened(c)
+}
+
+func (s *WorkerSuite) TestApplicationRemoved(c *gc.C) {
+	s.startWorker(c)
+	s.sendApplicationChange(c)
+	s.sendUnitsChange(c)
+	s.broker.assertLogsOpened(c, "gitlab", startTime)
+
+	s.client.setLife(life.Dead)
+	s.sendApplicationChange(c)
+	// The units watcher is stopped along
+	// with the application's worker.
+	workertest.CheckKilled(c, s.broker.unitsWatcher)
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+// period is the amount of time to wait between asking the controller
+// to progress rolling charm upgrades. Units take at least this long to
+// run their upgrade hooks, so checking more often gains little.
+const period = 10 * time.Second
+
+var logger = loggo.GetLogger("juju.worker.charmrollout")
+
+// Facade exposes the controller functionality required by the charm
+// rollout worker.
+type Facade interface {
+	ProgressCharmRollouts() error
+}
+
+// Worker periodically asks the controller to release rolling charm
+// upgrades to the next batch of units.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	clock    clock.Clock
+}
+
+// New returns a worker.Worker that progresses rolling charm upgrades.
+func New(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	w := &Worker{
+		facade: facade,
+		clock:  clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.facade.ProgressCharmRollouts(); err != nil {
+				// Rollouts are progressed again when the timer
+				// next fires.
+				logger.Errorf("cannot progress charm rollouts: %v", err)
+			}
+			timer.Reset(period)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
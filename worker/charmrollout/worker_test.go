@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/charmrollout"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{calls: make(chan struct{}, 1)}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ProgressCharmRollouts")
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected ProgressCharmRollouts")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	w, err := charmrollout.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c)
+	for i := 0; i < 2; i++ {
+		s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+		s.assertNotCalled(c)
+		s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		s.assertCalled(c)
+	}
+}
+
+func (s *WorkerSuite) TestErrorIsLogged(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := charmrollout.New(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c)
+	// The worker continues running after an error.
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.charmrollout cannot progress charm rollouts: boom")
+}
+
+type mockFacade struct {
+	calls chan struct{}
+	err   error
+}
+
+func (m *mockFacade) ProgressCharmRollouts() error {
+	m.calls <- struct{}{}
+	return m.err
+}
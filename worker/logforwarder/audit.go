@@ -0,0 +1,365 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+)
+
+// maxAuditConversations is the number of conversations remembered by
+// an AuditForwarder, so that requests can be attributed to the user
+// who made them.
+const maxAuditConversations = 1000
+
+// AuditSource supplies the audit log records to be forwarded. It is
+// implemented by *auditlog.Buffer.
+type AuditSource interface {
+	// Ready returns a channel which receives a value when there are
+	// records to be taken.
+	Ready() <-chan struct{}
+
+	// Take returns the records written since the last call, and the
+	// number of records which had to be dropped in the meantime.
+	Take() ([]auditlog.Record, int)
+}
+
+// AuditForwarderConfig holds the information needed to run an
+// AuditForwarder.
+type AuditForwarderConfig struct {
+	// Source supplies the audit log records to forward.
+	Source AuditSource
+
+	// LogForwardConfig is the API used to access log forwarding config.
+	LogForwardConfig LogForwardConfig
+
+	// OpenSink is the function that opens the log sink to which
+	// audit log records will be forwarded.
+	OpenSink LogSinkFn
+
+	// Origin describes the controller agent forwarding the records.
+	// The model and user of each record are filled in from the audit
+	// log.
+	Origin logfwd.Origin
+
+	// Clock is used to timestamp records whose time can't be read
+	// from the audit log.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start an
+// AuditForwarder.
+func (config AuditForwarderConfig) Validate() error {
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.LogForwardConfig == nil {
+		return errors.NotValidf("nil LogForwardConfig")
+	}
+	if config.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	if config.Origin.ControllerUUID == "" {
+		return errors.NotValidf("empty Origin.ControllerUUID")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// AuditForwarder is a worker that forwards a controller's audit log
+// records to the log forwarding target of the controller model.
+type AuditForwarder struct {
+	catacomb catacomb.Catacomb
+	config   AuditForwarderConfig
+
+	nextID        int64
+	conversations map[string]auditlog.Conversation
+	order         []string
+}
+
+// NewAuditForwarder returns a worker that forwards audit log records
+// from the configured source to the log forwarding target, whenever
+// log forwarding is enabled.
+func NewAuditForwarder(config AuditForwarderConfig) (*AuditForwarder, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	af := &AuditForwarder{
+		config:        config,
+		conversations: make(map[string]auditlog.Conversation),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &af.catacomb,
+		Work: af.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return af, nil
+}
+
+// Kill implements Worker.Kill()
+func (af *AuditForwarder) Kill() {
+	af.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (af *AuditForwarder) Wait() error {
+	return af.catacomb.Wait()
+}
+
+func (af *AuditForwarder) loop() error {
+	configWatcher, err := af.config.LogForwardConfig.WatchForLogForwardConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := af.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var sink SendCloser
+	defer func() {
+		if sink != nil {
+			sink.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-af.catacomb.Dying():
+			return af.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("log forward configuration watcher closed")
+			}
+			if sink, err = af.processNewConfig(sink); err != nil {
+				return errors.Trace(err)
+			}
+		case <-af.config.Source.Ready():
+			auditRecords, dropped := af.config.Source.Take()
+			if dropped > 0 {
+				logger.Warningf("dropped %d audit log records waiting to be forwarded", dropped)
+			}
+			// Records are converted even when there's nowhere to
+			// send them, so that the conversations are tracked.
+			records := af.convert(auditRecords)
+			if sink == nil || len(records) == 0 {
+				continue
+			}
+			if err := sink.Send(records); err != nil {
+				return errors.Annotate(err, "forwarding audit log records")
+			}
+		}
+	}
+}
+
+// processNewConfig acts on a change to the log forward config,
+// returning the sink to which records should now be sent.
+func (af *AuditForwarder) processNewConfig(current SendCloser) (SendCloser, error) {
+	closeCurrent := func() error {
+		if current != nil {
+			return current.Close()
+		}
+		return nil
+	}
+
+	cfg, ok, err := readSinkConfig(af.config.LogForwardConfig)
+	if err != nil {
+		return current, errors.Trace(err)
+	}
+	if !ok || !cfg.Enabled() {
+		logger.Infof("config change - audit log forwarding not enabled")
+		return nil, errors.Trace(closeCurrent())
+	}
+	// As for the log forwarder, an invalid config is reported and
+	// otherwise ignored until it is fixed.
+	if err := cfg.Validate(); err != nil {
+		logger.Errorf("invalid log forward config change: %v", err)
+		return current, nil
+	}
+
+	if err := closeCurrent(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink, err := af.config.OpenSink(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening log sink")
+	}
+	logger.Infof("audit log forwarding enabled")
+	return sink, nil
+}
+
+// convert returns the log records describing the audit log records,
+// skipping responses which report no errors.
+func (af *AuditForwarder) convert(auditRecords []auditlog.Record) []logfwd.Record {
+	var records []logfwd.Record
+	for i := range auditRecords {
+		auditRecord := &auditRecords[i]
+		var (
+			conversation auditlog.Conversation
+			when         string
+			level        loggo.Level
+			message      string
+		)
+		switch {
+		case auditRecord.Conversation != nil:
+			conversation = *auditRecord.Conversation
+			af.remember(conversation)
+			when = conversation.When
+			level = loggo.INFO
+			message = fmt.Sprintf("%s connected to model %q: %s",
+				userName(conversation), conversation.ModelName, conversation.What)
+		case auditRecord.Request != nil:
+			request := auditRecord.Request
+			conversation = af.conversations[request.ConversationID]
+			when = request.When
+			level = loggo.INFO
+			message = fmt.Sprintf("%s called %s.%s v%d",
+				userName(conversation), request.Facade, request.Method, request.Version)
+		case auditRecord.Errors != nil:
+			response := auditRecord.Errors
+			failures := describeErrors(response.Errors)
+			if failures == "" {
+				continue
+			}
+			conversation = af.conversations[response.ConversationID]
+			when = response.When
+			level = loggo.ERROR
+			message = fmt.Sprintf("request %d by %s failed: %s",
+				response.RequestID, userName(conversation), failures)
+		default:
+			continue
+		}
+
+		af.nextID++
+		records = append(records, logfwd.Record{
+			ID:        af.nextID,
+			Origin:    af.origin(conversation),
+			Timestamp: af.parseTime(when),
+			Level:     level,
+			Message:   message,
+			Audit:     newAuditRecord(*auditRecord),
+		})
+	}
+	return records
+}
+
+// newAuditRecord returns the detail of the audited activity in the
+// form included in forwarded log records.
+func newAuditRecord(rec auditlog.Record) *logfwd.AuditRecord {
+	switch {
+	case rec.Conversation != nil:
+		c := rec.Conversation
+		return &logfwd.AuditRecord{
+			Kind:           logfwd.AuditConversation,
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+			Who:            c.Who,
+			What:           c.What,
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+		}
+	case rec.Request != nil:
+		r := rec.Request
+		return &logfwd.AuditRecord{
+			Kind:           logfwd.AuditRequest,
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+			Facade:         r.Facade,
+			Method:         r.Method,
+			Version:        r.Version,
+			Args:           r.Args,
+		}
+	case rec.Errors != nil:
+		r := rec.Errors
+		result := &logfwd.AuditRecord{
+			Kind:           logfwd.AuditErrors,
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+		}
+		for _, e := range r.Errors {
+			if e == nil {
+				continue
+			}
+			result.Errors = append(result.Errors, logfwd.AuditError{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+		return result
+	}
+	return nil
+}
+
+// remember records the conversation so that later requests in it can
+// be attributed, forgetting the oldest conversation if necessary.
+func (af *AuditForwarder) remember(conversation auditlog.Conversation) {
+	if _, ok := af.conversations[conversation.ConversationID]; !ok {
+		af.order = append(af.order, conversation.ConversationID)
+	}
+	af.conversations[conversation.ConversationID] = conversation
+	if len(af.order) > maxAuditConversations {
+		delete(af.conversations, af.order[0])
+		af.order = af.order[1:]
+	}
+}
+
+func (af *AuditForwarder) origin(conversation auditlog.Conversation) logfwd.Origin {
+	origin := af.config.Origin
+	if conversation.ModelUUID != "" {
+		origin.ModelUUID = conversation.ModelUUID
+	}
+	if names.IsValidUser(conversation.Who) {
+		origin.Type = logfwd.OriginTypeUser
+		origin.Name = conversation.Who
+	} else {
+		origin.Type = logfwd.OriginTypeUnknown
+		origin.Name = ""
+	}
+	return origin
+}
+
+func (af *AuditForwarder) parseTime(when string) time.Time {
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return af.config.Clock.Now()
+	}
+	return t
+}
+
+func userName(conversation auditlog.Conversation) string {
+	if conversation.Who == "" {
+		return "unknown user"
+	}
+	return conversation.Who
+}
+
+func describeErrors(errs []*auditlog.Error) string {
+	var descriptions []string
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if e.Code == "" {
+			descriptions = append(descriptions, e.Message)
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", e.Message, e.Code))
+		}
+	}
+	return strings.Join(descriptions, "; ")
+}
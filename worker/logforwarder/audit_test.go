@@ -0,0 +1,270 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type AuditForwarderSuite struct {
+	testing.IsolationSuite
+
+	buffer *auditlog.Buffer
+	api    *mockLogForwardConfig
+	sender *auditSender
+	opened chan string
+	clock  *testclock.Clock
+	origin logfwd.Origin
+}
+
+var _ = gc.Suite(&AuditForwarderSuite{})
+
+func (s *AuditForwarderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.buffer = auditlog.NewBuffer(10)
+	s.api = &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	s.sender = &auditSender{sent: make(chan []logfwd.Record, 10)}
+	s.opened = make(chan string, 10)
+	s.clock = testclock.NewClock(time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC))
+	s.origin = logfwd.OriginForMachineAgent(
+		names.NewMachineTag("0"),
+		"feebdaed-2f18-4fd2-967d-db9663db7bea",
+		"c0ffee00-2f18-4fd2-967d-db9663db7bea",
+		version.Current,
+	)
+}
+
+func (s *AuditForwarderSuite) newForwarder(c *gc.C) *logforwarder.AuditForwarder {
+	af, err := logforwarder.NewAuditForwarder(logforwarder.AuditForwarderConfig{
+		Source:           s.buffer,
+		LogForwardConfig: s.api,
+		OpenSink: func(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			s.opened <- cfg.Syslog.Host
+			return &logforwarder.LogSink{s.sender}, nil
+		},
+		Origin: s.origin,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return af
+}
+
+func (s *AuditForwarderSuite) waitOpened(c *gc.C) {
+	select {
+	case host := <-s.opened:
+		c.Assert(host, gc.Equals, "10.0.0.1")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sink to open")
+	}
+}
+
+func (s *AuditForwarderSuite) waitSent(c *gc.C) []logfwd.Record {
+	var records []logfwd.Record
+	select {
+	case records = <-s.sender.sent:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for records to be sent")
+	}
+	return records
+}
+
+func (s *AuditForwarderSuite) addConversation(c *gc.C) auditlog.Conversation {
+	conversation := auditlog.Conversation{
+		Who:            "mary",
+		What:           "juju deploy mysql",
+		When:           "2018-10-01T09:00:00Z",
+		ModelName:      "prod",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		ConversationID: "c1",
+		ConnectionID:   "1",
+	}
+	c.Assert(s.buffer.AddConversation(conversation), jc.ErrorIsNil)
+	return conversation
+}
+
+func (s *AuditForwarderSuite) TestForward(c *gc.C) {
+	af := s.newForwarder(c)
+	defer workertest.DirtyKill(c, af)
+	s.waitOpened(c)
+
+	s.addConversation(c)
+	request := auditlog.Request{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-10-01T09:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	}
+	success := auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-10-01T09:00:02Z",
+		Errors:         []*auditlog.Error{nil},
+	}
+	failure := auditlog.ResponseErrors{
+		ConversationID: "c1",
+		ConnectionID:   "1",
+		RequestID:      2,
+		When:           "bad time",
+		Errors:         []*auditlog.Error{{Message: "no such charm", Code: "not found"}},
+	}
+	c.Assert(s.buffer.AddRequest(request), jc.ErrorIsNil)
+	c.Assert(s.buffer.AddResponse(success), jc.ErrorIsNil)
+	c.Assert(s.buffer.AddResponse(failure), jc.ErrorIsNil)
+
+	var records []logfwd.Record
+	for len(records) < 3 {
+		records = append(records, s.waitSent(c)...)
+	}
+	workertest.CleanKill(c, af)
+
+	origin := s.origin
+	origin.ModelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	origin.Type = logfwd.OriginTypeUser
+	origin.Name = "mary"
+	c.Assert(records, jc.DeepEquals, []logfwd.Record{{
+		ID:        1,
+		Origin:    origin,
+		Timestamp: time.Date(2018, 10, 1, 9, 0, 0, 0, time.UTC),
+		Level:     loggo.INFO,
+		Message:   `mary connected to model "prod": juju deploy mysql`,
+		Audit: &logfwd.AuditRecord{
+			Kind:           logfwd.AuditConversation,
+			ConversationID: "c1",
+			ConnectionID:   "1",
+			Who:            "mary",
+			What:           "juju deploy mysql",
+			ModelName:      "prod",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		},
+	}, {
+		ID:        2,
+		Origin:    origin,
+		Timestamp: time.Date(2018, 10, 1, 9, 0, 1, 0, time.UTC),
+		Level:     loggo.INFO,
+		Message:   "mary called Application.Deploy v6",
+		Audit: &logfwd.AuditRecord{
+			Kind:           logfwd.AuditRequest,
+			ConversationID: "c1",
+			ConnectionID:   "1",
+			RequestID:      1,
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        6,
+		},
+	}, {
+		ID:        3,
+		Origin:    origin,
+		Timestamp: s.clock.Now(),
+		Level:     loggo.ERROR,
+		Message:   "request 2 by mary failed: no such charm (not found)",
+		Audit: &logfwd.AuditRecord{
+			Kind:           logfwd.AuditErrors,
+			ConversationID: "c1",
+			ConnectionID:   "1",
+			RequestID:      2,
+			Errors:         []logfwd.AuditError{{Message: "no such charm", Code: "not found"}},
+		},
+	}})
+}
+
+func (s *AuditForwarderSuite) TestUnknownConversation(c *gc.C) {
+	af := s.newForwarder(c)
+	defer workertest.DirtyKill(c, af)
+	s.waitOpened(c)
+
+	c.Assert(s.buffer.AddRequest(auditlog.Request{
+		ConversationID: "c0",
+		RequestID:      7,
+		When:           "2018-10-01T09:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	}), jc.ErrorIsNil)
+
+	records := s.waitSent(c)
+	workertest.CleanKill(c, af)
+
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Message, gc.Equals, "unknown user called Application.Deploy v6")
+	c.Check(records[0].Origin.Type, gc.Equals, logfwd.OriginTypeUnknown)
+	c.Check(records[0].Origin.Name, gc.Equals, "")
+	c.Check(records[0].Origin.ModelUUID, gc.Equals, s.origin.ModelUUID)
+}
+
+func (s *AuditForwarderSuite) TestNotEnabled(c *gc.C) {
+	s.api.enabled = false
+	af := s.newForwarder(c)
+	defer workertest.DirtyKill(c, af)
+
+	s.addConversation(c)
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, af)
+
+	// The records were taken, but there was nowhere to send them.
+	records, _ := s.buffer.Take()
+	c.Check(records, gc.HasLen, 0)
+	c.Check(s.opened, gc.HasLen, 0)
+	s.sender.stub.CheckNoCalls(c)
+}
+
+func (s *AuditForwarderSuite) TestSendError(c *gc.C) {
+	s.sender.stub.SetErrors(errors.New("connection refused"))
+	af := s.newForwarder(c)
+	defer workertest.DirtyKill(c, af)
+	s.waitOpened(c)
+
+	s.addConversation(c)
+	err := workertest.CheckKilled(c, af)
+	c.Assert(err, gc.ErrorMatches, "forwarding audit log records: connection refused")
+	s.sender.stub.CheckCallNames(c, "Send", "Close")
+}
+
+func (s *AuditForwarderSuite) TestValidate(c *gc.C) {
+	_, err := logforwarder.NewAuditForwarder(logforwarder.AuditForwarderConfig{
+		Source: s.buffer,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil LogForwardConfig not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+type auditSender struct {
+	stub testing.Stub
+	sent chan []logfwd.Record
+}
+
+func (s *auditSender) Send(records []logfwd.Record) error {
+	s.stub.AddCall("Send", records)
+	if err := s.stub.NextErr(); err != nil {
+		return err
+	}
+	s.sent <- records
+	return nil
+}
+
+func (s *auditSender) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/logfwd"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// AuditManifoldConfig holds the information necessary to run an
+// audit log forwarder in a dependency.Engine.
+type AuditManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	ClockName     string
+
+	// Source supplies the audit log records written by this
+	// controller.
+	Source AuditSource
+
+	// OpenSink opens the log sink to which audit log records will be
+	// forwarded.
+	OpenSink LogSinkFn
+
+	NewWorker func(AuditForwarderConfig) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config AuditManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// AuditManifold returns a dependency.Manifold that will run an audit
+// log forwarder.
+func AuditManifold(config AuditManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on AuditManifoldConfig because it's more readable
+// than a closure.
+func (config AuditManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	agentConfig := agent.CurrentConfig()
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine agent, got %s", agentConfig.Tag())
+	}
+
+	agentFacade, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerCfg, err := agentFacade.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
+
+	w, err := config.NewWorker(AuditForwarderConfig{
+		Source:           config.Source,
+		LogForwardConfig: agentFacade,
+		OpenSink:         config.OpenSink,
+		Origin: logfwd.OriginForMachineAgent(
+			tag,
+			controllerCfg.ControllerUUID(),
+			agentConfig.Model().Id(),
+			jujuversion.Current,
+		),
+		Clock: clock,
+	})
+	return w, errors.Trace(err)
+}
+
+// NewAuditWorker is suitable for use as the NewWorker field of an
+// AuditManifoldConfig.
+func NewAuditWorker(config AuditForwarderConfig) (worker.Worker, error) {
+	w, err := NewAuditForwarder(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/logforwarder"
@@ -85,8 +86,12 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			if cfg.Webhook != nil {
+				sender.host = cfg.Webhook.URL
+			} else {
+				sender.host = cfg.Syslog.Host
+			}
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -152,6 +157,29 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestWebhook(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled:    true,
+		host:       "10.0.0.1",
+		webhookURL: "https://logs.example.com/ingest",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	// The webhook takes the place of the syslog host.
+	rec := s.rec
+	rec.Message = "send to https://logs.example.com/ingest"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec}}},
+		{"Close", nil},
+	})
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -207,9 +235,10 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	enabled    bool
+	host       string
+	webhookURL string
+	changes    chan struct{}
 }
 
 type mockWatcher struct {
@@ -258,6 +287,16 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) LogForwardWebhookConfig() (*webhook.RawConfig, bool, error) {
+	if c.webhookURL == "" {
+		return nil, false, nil
+	}
+	return &webhook.RawConfig{
+		Enabled: c.enabled,
+		URL:     c.webhookURL,
+	}, true, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
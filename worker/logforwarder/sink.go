@@ -4,8 +4,11 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -16,6 +19,57 @@ type LogForwardConfig interface {
 
 	// LogForwardConfig returns the current log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// LogForwardWebhookConfig returns the current webhook log forward
+	// configuration. If set, it takes the place of the syslog
+	// configuration.
+	LogForwardWebhookConfig() (*webhook.RawConfig, bool, error)
+}
+
+// SinkConfig holds the configuration of the log sink to which records
+// are forwarded. Exactly one of its fields is set.
+type SinkConfig struct {
+	Syslog  *syslog.RawConfig
+	Webhook *webhook.RawConfig
+}
+
+// Enabled reports whether log forwarding is enabled.
+func (cfg SinkConfig) Enabled() bool {
+	if cfg.Webhook != nil {
+		return cfg.Webhook.Enabled
+	}
+	return cfg.Syslog != nil && cfg.Syslog.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg SinkConfig) Validate() error {
+	switch {
+	case cfg.Webhook != nil:
+		return errors.Trace(cfg.Webhook.Validate())
+	case cfg.Syslog != nil:
+		return errors.Trace(cfg.Syslog.Validate())
+	}
+	return errors.NotValidf("empty sink config")
+}
+
+// readSinkConfig returns the current log sink configuration, or false
+// if log forwarding has not been configured.
+func readSinkConfig(api LogForwardConfig) (*SinkConfig, bool, error) {
+	webhookCfg, ok, err := api.LogForwardWebhookConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if ok {
+		return &SinkConfig{Webhook: webhookCfg}, true, nil
+	}
+	syslogCfg, ok, err := api.LogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return &SinkConfig{Syslog: syslogCfg}, true, nil
 }
 
 type LogSinkSpec struct {
@@ -27,7 +81,7 @@ type LogSinkSpec struct {
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg *SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,22 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns the log sink described by the config: a webhook if one
+// is configured, otherwise syslog.
+func Open(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	switch {
+	case cfg.Webhook != nil:
+		return OpenWebhook(cfg.Webhook)
+	case cfg.Syslog != nil:
+		return OpenSyslog(cfg.Syslog)
+	}
+	return nil, errors.NotValidf("empty sink config")
+}
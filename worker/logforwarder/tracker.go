@@ -10,13 +10,12 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config *SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -37,14 +36,33 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 		return nil, errors.Trace(err)
 	}
 
+	tracker := newLastSentTracker(args.Name, args.Caller)
+	if notifier, ok := sink.SendCloser.(sentNotifier); ok {
+		// The records are only delivered some time after
+		// Send returns, so track them once they have been.
+		notifier.NotifySent(func(records []logfwd.Record) {
+			if err := tracker.setLastSent(records); err != nil {
+				logger.Errorf("recording last log record sent to %s: %v", args.Name, err)
+			}
+		})
+		return sink, nil
+	}
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
-			tracker:    newLastSentTracker(args.Name, args.Caller),
+			tracker:    tracker,
 		},
 	}, nil
 }
 
+// sentNotifier is implemented by log sinks which deliver records in
+// the background, after Send has returned.
+type sentNotifier interface {
+	// NotifySent arranges for f to be called with
+	// each batch of records once it has been delivered.
+	NotifySent(f func([]logfwd.Record))
+}
+
 type trackingSender struct {
 	SendCloser
 	tracker *lastSentTracker
@@ -5,6 +5,7 @@ package provisioner
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/version"
 
@@ -45,7 +46,14 @@ var (
 	CombinedCloudInitData   = combinedCloudInitData
 )
 
-var ClassifyMachine = classifyMachine
+var (
+	ClassifyMachine         = classifyMachine
+	RetryStrategyFromConfig = retryStrategyFromConfig
+)
+
+func (s RetryStrategy) Delay(retry int) time.Duration {
+	return s.delay(retry)
+}
 
 // GetCopyAvailabilityZoneMachines returns a copy of p.(*provisionerTask).availabilityZoneMachines
 func GetCopyAvailabilityZoneMachines(p ProvisionerTask) []AvailabilityZoneMachine {
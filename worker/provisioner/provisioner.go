@@ -30,9 +30,12 @@ var logger = loggo.GetLogger("juju.provisioner")
 var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
+// The defaults for the retry strategy, used for any values
+// not set in the model config.
 var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 10
+	retryStrategyDelay    = 10 * time.Second
+	retryStrategyMaxDelay = time.Minute
+	retryStrategyCount    = 10
 )
 
 // Provisioner represents a running provisioner worker.
@@ -76,8 +79,9 @@ type provisioner struct {
 //
 // TODO(katco): 2016-08-09: lp:1611427
 type RetryStrategy struct {
-	retryDelay time.Duration
-	retryCount int
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+	retryCount    int
 }
 
 // NewRetryStrategy returns a new retry strategy with the specified delay and
@@ -89,6 +93,47 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// NewExponentialRetryStrategy returns a new retry strategy for use with
+// retryable provisioning errors, which waits for the specified delay
+// before the first retry, doubling the delay for each subsequent retry
+// up to maxDelay.
+func NewExponentialRetryStrategy(delay, maxDelay time.Duration, count int) RetryStrategy {
+	return RetryStrategy{
+		retryDelay:    delay,
+		retryMaxDelay: maxDelay,
+		retryCount:    count,
+	}
+}
+
+// delay returns how long to wait before the given retry,
+// where the first retry is 0.
+func (s RetryStrategy) delay(retry int) time.Duration {
+	delay := s.retryDelay
+	for i := 0; i < retry && delay < s.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.retryMaxDelay && s.retryMaxDelay > s.retryDelay {
+		delay = s.retryMaxDelay
+	}
+	return delay
+}
+
+// retryStrategyFromConfig returns the retry strategy for starting
+// instances specified by the model config.
+func retryStrategyFromConfig(cfg *config.Config) RetryStrategy {
+	strategy := NewExponentialRetryStrategy(retryStrategyDelay, retryStrategyMaxDelay, retryStrategyCount)
+	if count, ok := cfg.ProvisionerRetryCount(); ok {
+		strategy.retryCount = count
+	}
+	if delay, ok := cfg.ProvisionerRetryDelay(); ok {
+		strategy.retryDelay = delay
+	}
+	if maxDelay, ok := cfg.ProvisionerRetryMaxDelay(); ok {
+		strategy.retryMaxDelay = maxDelay
+	}
+	return strategy
+}
+
 // configObserver is implemented so that tests can see when the environment
 // configuration changes.
 // The catacomb is set in export_test to the provider's member.
@@ -178,7 +223,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		retryStrategyFromConfig(modelCfg),
 		p.callContext,
 	)
 	if err != nil {
@@ -261,6 +306,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -357,6 +403,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -49,6 +49,11 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetRetryStrategy sets the strategy with which the provisioner
+	// task retries starting instances. Machines already being started
+	// continue with the strategy they started with.
+	SetRetryStrategy(strategy RetryStrategy)
 }
 
 type MachineGetter interface {
@@ -141,6 +146,7 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	retryStrategyMutex         sync.Mutex
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
@@ -214,6 +220,19 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetRetryStrategy implements ProvisionerTask.SetRetryStrategy().
+func (task *provisionerTask) SetRetryStrategy(strategy RetryStrategy) {
+	task.retryStrategyMutex.Lock()
+	defer task.retryStrategyMutex.Unlock()
+	task.retryStartInstanceStrategy = strategy
+}
+
+func (task *provisionerTask) retryStrategy() RetryStrategy {
+	task.retryStrategyMutex.Lock()
+	defer task.retryStrategyMutex.Unlock()
+	return task.retryStartInstanceStrategy
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -1051,16 +1070,21 @@ func (task *provisionerTask) startMachine(
 	}
 
 	// TODO ProvisionerParallelization 2017-10-03
-	// Improve the retry loop, newer methodology
 	// Is rate limiting handled correctly?
 	var result *environs.StartInstanceResult
-
-	// Attempt creating the instance "retryCount" times. If the provider
-	// supports availability zones and we're automatically distributing
-	// across the zones, then we try each zone for every attempt, or until
-	// one of the StartInstance calls returns an error satisfying
-	// environs.IsAvailabilityZoneIndependent.
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; {
+	strategy := task.retryStrategy()
+
+	// Attempt creating the instance "retryCount" times, backing off
+	// between attempts. If the provider supports availability zones
+	// and we're automatically distributing across the zones, then we
+	// try each zone for every attempt, or until one of the StartInstance
+	// calls returns an error satisfying
+	// environs.IsAvailabilityZoneIndependent. Errors that retrying
+	// cannot fix, such as exceeded quotas or invalid credentials, are
+	// not retried.
+	attempt := 0
+	for attemptsLeft := strategy.retryCount; attemptsLeft >= 0; {
+		attempt++
 		startInstanceParams.AvailabilityZone, err = task.machineAvailabilityZoneDistribution(machine.Id(), distributionGroupMachineIds)
 		if err != nil {
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
@@ -1073,12 +1097,24 @@ func (task *provisionerTask) startMachine(
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		errorKind := environs.ClassifyError(err)
+		if attemptsLeft <= 0 || !isRetryableErrorKind(errorKind) {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved.
 			task.removeMachineFromAZMap(machine)
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		}
+		retryDelay := strategy.delay(strategy.retryCount - attemptsLeft)
+		attemptData := map[string]interface{}{
+			"attempt": attempt,
+		}
+		if startInstanceParams.AvailabilityZone != "" {
+			attemptData["availability-zone"] = startInstanceParams.AvailabilityZone
+		}
+		if errorKind != environs.ErrorKindUnknown {
+			attemptData["error-kind"] = string(errorKind)
+		}
 
 		retrying := true
 		retryMsg := ""
@@ -1096,7 +1132,7 @@ func (task *provisionerTask) startMachine(
 				retryMsg = fmt.Sprintf(
 					"failed to start machine %s in zone %q, retrying in %v with new availability zone: %s",
 					machine, startInstanceParams.AvailabilityZone,
-					task.retryStartInstanceStrategy.retryDelay, err,
+					retryDelay, err,
 				)
 				logger.Debugf("%s", retryMsg)
 				// There's still more zones to try, so don't decrement "attemptsLeft" yet.
@@ -1111,20 +1147,21 @@ func (task *provisionerTask) startMachine(
 		if retrying {
 			retryMsg = fmt.Sprintf(
 				"failed to start machine %s (%s), retrying in %v (%d more attempts)",
-				machine, err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft,
+				machine, err.Error(), retryDelay, attemptsLeft,
 			)
 			logger.Warningf("%s", retryMsg)
 			attemptsLeft--
 		}
 
-		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err3 != nil {
+		// Record each failed attempt in the machine's status history.
+		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, attemptData); err3 != nil {
 			logger.Warningf("failed to set instance status: %v", err3)
 		}
 
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(retryDelay):
 		}
 	}
 
@@ -1163,6 +1200,19 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// isRetryableErrorKind reports whether starting an instance should be
+// retried after failing with an error of the given kind. Exceeded
+// quotas and invalid credentials need the user's attention, so there
+// is no point retrying until they have been dealt with, at which point
+// the user can run "juju retry-provisioning".
+func isRetryableErrorKind(kind environs.ErrorKind) bool {
+	switch kind {
+	case environs.ErrorKindQuota, environs.ErrorKindAuth:
+		return false
+	}
+	return true
+}
+
 // markMachineFailedInAZ moves the machine in zone from MachineIds to FailedMachineIds
 // in availabilityZoneMachines, report if there are any availability zones not failed for
 // the specified machine.
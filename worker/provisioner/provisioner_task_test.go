@@ -154,6 +154,37 @@ func (s *ProvisionerTaskSuite) TestStopInstancesIgnoresMachinesWithKeep(c *gc.C)
 	c.Assert(m1.markForRemoval, jc.IsTrue)
 }
 
+func (s *ProvisionerTaskSuite) TestRetryStrategyDelay(c *gc.C) {
+	strategy := provisioner.NewExponentialRetryStrategy(time.Second, 5*time.Second, 5)
+	var delays []time.Duration
+	for retry := 0; retry < 5; retry++ {
+		delays = append(delays, strategy.Delay(retry))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+	})
+
+	// Without a max delay, the delay is constant.
+	strategy = provisioner.NewRetryStrategy(time.Second, 3)
+	c.Assert(strategy.Delay(0), gc.Equals, time.Second)
+	c.Assert(strategy.Delay(2), gc.Equals, time.Second)
+}
+
+func (s *ProvisionerTaskSuite) TestRetryStrategyFromConfig(c *gc.C) {
+	cfg := coretesting.ModelConfig(c)
+	c.Assert(provisioner.RetryStrategyFromConfig(cfg), jc.DeepEquals,
+		provisioner.NewExponentialRetryStrategy(10*time.Second, time.Minute, 10))
+
+	cfg, err := cfg.Apply(map[string]interface{}{
+		"provisioner-retry-count":     3,
+		"provisioner-retry-delay":     "1s",
+		"provisioner-retry-max-delay": "5s",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provisioner.RetryStrategyFromConfig(cfg), jc.DeepEquals,
+		provisioner.NewExponentialRetryStrategy(time.Second, 5*time.Second, 3))
+}
+
 func (s *ProvisionerTaskSuite) sendModelMachinesChange(c *gc.C, ids ...string) {
 	select {
 	case s.modelMachinesChanges <- ids:
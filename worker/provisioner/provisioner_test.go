@@ -761,6 +761,63 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetrya
 	s.checkStartInstance(c, m)
 }
 
+func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceQuotaExceeded(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
+	s.PatchValue(provisioner.RetryStrategyCount, 2)
+
+	errorInjectionChannel := make(chan error, 1)
+	p := s.newEnvironProvisioner(c)
+	defer workertest.CleanKill(c, p)
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// Exceeded quotas are not retried, so the instance would
+	// be started if the provisioner were to try again.
+	quotaError := environs.QuotaExceededError(errors.New("instance limit exceeded"))
+	errorInjectionChannel <- quotaError
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	agentStatus, instanceStatus := s.waitUntilMachineNotPending(c, m)
+	c.Check(agentStatus.Status, gc.Equals, status.Error)
+	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(instanceStatus.Message, gc.Equals, "instance limit exceeded")
+	_, err = m.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *ProvisionerSuite) TestProvisionerRecordsStartInstanceAttempts(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
+	s.PatchValue(provisioner.RetryStrategyCount, 2)
+
+	errorInjectionChannel := make(chan error, 1)
+	p := s.newEnvironProvisioner(c)
+	defer workertest.CleanKill(c, p)
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	errorInjectionChannel <- environs.TransientError(errors.New("request limit exceeded"))
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	history, err := m.InstanceStatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	var attempts []status.StatusInfo
+	for _, info := range history {
+		if info.Data["error-kind"] != nil {
+			attempts = append(attempts, info)
+		}
+	}
+	c.Assert(attempts, gc.HasLen, 1)
+	c.Check(attempts[0].Status, gc.Equals, status.Provisioning)
+	c.Check(attempts[0].Message, gc.Matches, `failed to start machine .* \(request limit exceeded\), retrying in 0s \(2 more attempts\)`)
+	c.Check(attempts[0].Data["error-kind"], gc.Equals, "transient")
+}
+
 func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	// Create the error injection channel and inject
 	// a retryable error
@@ -111,15 +111,16 @@ func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 		Delay: 10 * time.Millisecond,
 	}
 	for a := shortAttempt.Start(); a.Next(); {
-		if len(s.config.Filesystems.(*mockFilesystemAccessor).Calls()) > 0 {
+		if len(s.config.Filesystems.(*mockFilesystemAccessor).Calls()) > 1 {
 			break
 		}
 	}
 
 	workertest.CleanKill(c, w)
-	// Only call is to watch model.
-	s.config.Filesystems.(*mockFilesystemAccessor).CheckCallNames(c, "WatchFilesystems")
+	// Only calls are to watch model.
+	s.config.Filesystems.(*mockFilesystemAccessor).CheckCallNames(c, "WatchFilesystems", "WatchFilesystemResizes")
 	s.config.Filesystems.(*mockFilesystemAccessor).CheckCall(c, 0, "WatchFilesystems", coretesting.ModelTag)
+	s.config.Filesystems.(*mockFilesystemAccessor).CheckCall(c, 1, "WatchFilesystemResizes", coretesting.ModelTag)
 }
 
 func (s *WorkerSuite) TestRemoveApplicationStopsWatchingApplication(c *gc.C) {
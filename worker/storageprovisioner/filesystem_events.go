@@ -106,6 +106,51 @@ func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStor
 	return nil
 }
 
+// filesystemResizesChanged is called when the filesystems with the
+// provided IDs have been seen to have changed, in case they have been
+// asked to grow.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	results, err := ctx.config.Filesystems.ResizeFilesystemParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	for i, result := range results {
+		// Any earlier request is superseded by the current one.
+		ctx.schedule.Remove(resizeKey{tags[i]})
+		if result.Error != nil {
+			// Filesystems that are not yet provisioned will be
+			// created at the requested size, and filesystems that
+			// have been removed are no longer visible to the
+			// provisioner.
+			if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for filesystem %q", tags[i].Id(),
+			)
+		}
+		if result.Result.Size == 0 {
+			continue
+		}
+		args, err := filesystemResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if args.Volume != (names.VolumeTag{}) {
+			// The filesystem can only grow once its backing
+			// volume's block device has; make sure we have
+			// an up-to-date view of it.
+			ctx.pendingVolumeBlockDevices.Add(args.Volume)
+		}
+		scheduleOperations(ctx, &resizeFilesystemOp{args: args})
+	}
+	return nil
+}
+
 // processDyingFilesystems processes the FilesystemResults for Dying filesystems,
 // removing them from provisioning-pending as necessary.
 func processDyingFilesystems(ctx *context, tags []names.FilesystemTag, filesystemResults []params.FilesystemResult) error {
@@ -512,3 +557,24 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 		Path:         in.MountPoint,
 	}, nil
 }
+
+func filesystemResizeParamsFromParams(in params.ResizeFilesystemParams) (storage.FilesystemResizeParams, error) {
+	filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+	if err != nil {
+		return storage.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	if in.VolumeTag != "" {
+		volumeTag, err = names.ParseVolumeTag(in.VolumeTag)
+		if err != nil {
+			return storage.FilesystemResizeParams{}, errors.Trace(err)
+		}
+	}
+	return storage.FilesystemResizeParams{
+		Tag:          filesystemTag,
+		Volume:       volumeTag,
+		FilesystemId: in.FilesystemId,
+		Size:         in.Size,
+		Provider:     storage.ProviderType(in.Provider),
+	}, nil
+}
@@ -4,6 +4,7 @@
 package storageprovisioner
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -357,6 +358,139 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 	return nil
 }
 
+// resizeFilesystems grows filesystems with the specified parameters.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	var reschedule []scheduleOp
+	filesystemParams := make([]storage.FilesystemParams, 0, len(ops))
+	for _, op := range ops {
+		if op.args.Volume != (names.VolumeTag{}) {
+			// A volume-backed filesystem cannot grow until the
+			// block device of its backing volume has.
+			blockDevice, ok := ctx.volumeBlockDevices[op.args.Volume]
+			if !ok || blockDevice.Size < op.args.Size {
+				logger.Debugf(
+					"waiting for %s to grow before resizing %s",
+					names.ReadableString(op.args.Volume),
+					names.ReadableString(op.args.Tag),
+				)
+				ctx.pendingVolumeBlockDevices.Add(op.args.Volume)
+				reschedule = append(reschedule, op)
+				continue
+			}
+		}
+		filesystemParams = append(filesystemParams, storage.FilesystemParams{
+			Tag:      op.args.Tag,
+			Volume:   op.args.Volume,
+			Provider: op.args.Provider,
+		})
+	}
+	paramsBySource, filesystemSources, err := filesystemParamsBySource(
+		ctx.config.StorageDir,
+		filesystemParams,
+		ctx.managedFilesystemSource,
+		ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var filesystems []storage.Filesystem
+	var statuses []params.EntityStatusArgs
+	for sourceName, filesystemParams := range paramsBySource {
+		resizeParams := make([]storage.FilesystemResizeParams, len(filesystemParams))
+		for i, p := range filesystemParams {
+			resizeParams[i] = ops[p.Tag].args
+			resizeParams[i].Attachments = filesystemAttachmentsOf(ctx, p.Tag)
+		}
+		logger.Debugf("resizing filesystems: %v", resizeParams)
+		filesystemResizer, ok := filesystemSources[sourceName].(storage.FilesystemResizer)
+		if !ok {
+			// The provider will never be able to grow these
+			// filesystems, so there's no point in retrying.
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info: fmt.Sprintf(
+						"storage provider %q does not support resizing filesystems",
+						sourceName,
+					),
+				})
+			}
+			continue
+		}
+		results, err := filesystemResizer.ResizeFilesystems(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			entityStatus := params.EntityStatusArgs{
+				Tag:    p.Tag.String(),
+				Status: status.Detached.String(),
+			}
+			if len(p.Attachments) > 0 {
+				entityStatus.Status = status.Attached.String()
+			}
+			if result.Error != nil {
+				// Reschedule the filesystem resize. The filesystem
+				// is still usable at its old size, so we leave the
+				// status as it is, recording why it hasn't grown.
+				reschedule = append(reschedule, ops[p.Tag])
+				entityStatus.Info = result.Error.Error()
+				statuses = append(statuses, entityStatus)
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(p.Tag),
+					result.Error,
+				)
+				continue
+			}
+			statuses = append(statuses, entityStatus)
+			filesystem, ok := ctx.filesystems[p.Tag]
+			if ok {
+				filesystem.Size = result.Filesystem.Size
+			} else {
+				filesystem = *result.Filesystem
+			}
+			filesystems = append(filesystems, filesystem)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, f := range filesystems {
+		updateFilesystem(ctx, f)
+	}
+	return nil
+}
+
+// filesystemAttachmentsOf returns the known attachments of the
+// filesystem with the specified tag.
+func filesystemAttachmentsOf(ctx *context, tag names.FilesystemTag) []storage.FilesystemAttachment {
+	var attachments []storage.FilesystemAttachment
+	for id, attachment := range ctx.filesystemAttachments {
+		if id.AttachmentTag == tag.String() {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments
+}
+
 // filesystemParamsBySource separates the filesystem parameters by filesystem source.
 func filesystemParamsBySource(
 	baseStorageDir string,
@@ -567,3 +701,12 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
@@ -123,13 +123,19 @@ type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
+	resizesWatcher         *mockStringsWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	requestedSizes         map[string]uint64
+	volumeSnapshots        map[string]params.VolumeSnapshotParams
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -155,6 +161,14 @@ func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.No
 	return w.blockDevicesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots(names.Tag) (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (v *mockVolumeAccessor) Volumes(volumes []names.VolumeTag) ([]params.VolumeResult, error) {
 	var result []params.VolumeResult
 	for _, tag := range volumes {
@@ -262,6 +276,55 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		vol, ok := v.provisionedVolumes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: common.ServerError(errors.NotProvisionedf("volume %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  "dummy",
+			VolumeId:  vol.Info.VolumeId,
+			Size:      v.requestedSizes[tag.String()],
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		snapshot, ok := v.volumeSnapshots[id]
+		if !ok {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: common.ServerError(errors.NotFoundf("volume snapshot %q", id)),
+			})
+			continue
+		}
+		result = append(result, params.VolumeSnapshotParamsResult{Result: snapshot})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -281,10 +344,14 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		requestedSizes:         make(map[string]uint64),
+		volumeSnapshots:        make(map[string]params.VolumeSnapshotParams),
 	}
 }
 
@@ -292,9 +359,11 @@ type mockFilesystemAccessor struct {
 	testing.Stub
 	filesystemsWatcher     *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
+	requestedSizes         map[string]uint64
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
@@ -406,6 +475,32 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 	return result, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes(tag names.Tag) (watcher.StringsWatcher, error) {
+	w.AddCall("WatchFilesystemResizes", tag)
+	return w.resizesWatcher, nil
+}
+
+func (f *mockFilesystemAccessor) ResizeFilesystemParams(filesystems []names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error) {
+	var result []params.ResizeFilesystemParamsResult
+	for _, tag := range filesystems {
+		fs, ok := f.provisionedFilesystems[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeFilesystemParamsResult{
+				Error: common.ServerError(errors.NotProvisionedf("filesystem %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.ResizeFilesystemParamsResult{Result: params.ResizeFilesystemParams{
+			FilesystemTag: tag.String(),
+			VolumeTag:     fs.VolumeTag,
+			Provider:      "dummy",
+			FilesystemId:  fs.Info.FilesystemId,
+			Size:          f.requestedSizes[tag.String()],
+		}})
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	if f.setFilesystemInfo != nil {
 		return f.setFilesystemInfo(filesystems)
@@ -424,9 +519,11 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		requestedSizes:         make(map[string]uint64),
 	}
 }
 
@@ -515,6 +612,9 @@ type dummyProvider struct {
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	destroyVolumeSnapshotsFunc   func([]string) ([]error, error)
 }
 
 type dummyVolumeSource struct {
@@ -630,6 +730,55 @@ func (s *dummyVolumeSource) DetachVolumes(ctx context.ProviderCallContext, param
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes to the requested size.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				VolumeId: p.VolumeId,
+				Size:     p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// CreateVolumeSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			p.Id,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-" + p.VolumeId,
+				VolumeId:   p.VolumeId,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots lists volume snapshots.
+func (s *dummyVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext) ([]string, error) {
+	return nil, nil
+}
+
+// DestroyVolumeSnapshots destroys volume snapshots.
+func (s *dummyVolumeSource) DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	if s.provider.destroyVolumeSnapshotsFunc != nil {
+		return s.provider.destroyVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
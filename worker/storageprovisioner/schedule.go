@@ -3,7 +3,11 @@
 
 package storageprovisioner
 
-import "time"
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
 
 // minRetryDelay is the minimum delay to apply
 // to operation retries; this does not apply to
@@ -64,3 +68,17 @@ func (s *exponentialBackoff) delay() time.Duration {
 	}
 	return current
 }
+
+// resizeKey is the key for operations that resize a storage entity.
+// A resize may be requested while other operations on the same entity
+// are pending, so it must not share their key.
+type resizeKey struct {
+	tag names.Tag
+}
+
+// snapshotKey is the key for operations on a volume snapshot. Snapshots
+// are not identified by tags, so their IDs are wrapped to keep them
+// distinct from the keys of other operations.
+type snapshotKey struct {
+	id string
+}
@@ -88,6 +88,31 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that requests to
+	// grow them may be acted upon.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// ResizeVolumeParams returns the parameters for growing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// VolumeSnapshotParams returns the parameters for taking or
+	// destroying the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken
+	// volume snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the specified volume snapshots
+	// from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -126,6 +151,15 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// WatchFilesystemResizes watches for changes to filesystems that
+	// this storage provisioner is responsible for, so that requests
+	// to grow them may be acted upon.
+	WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// ResizeFilesystemParams returns the parameters for growing the
+	// filesystems with the specified tags.
+	ResizeFilesystemParams([]names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -208,6 +242,9 @@ func (w *storageProvisioner) loop() error {
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -265,6 +302,40 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	// Storage attached to units is not resized in place, so only
+	// machine- and model-scoped provisioners watch for resize requests.
+	if w.config.Scope.Kind() != names.ApplicationTagKind {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
+		filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching filesystem resizes")
+		}
+		if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		filesystemResizesChanges = filesystemResizesWatcher.Changes()
+	}
+
+	// Snapshots are only taken of volumes, which units don't have.
+	if w.config.Scope.Kind() != names.ApplicationTagKind {
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+	}
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
@@ -328,6 +399,27 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -359,6 +451,10 @@ func processSchedule(ctx *context) error {
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
+	destroyVolumeSnapshotOps := make(map[string]*destroyVolumeSnapshotOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -379,6 +475,14 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[op.args.Id] = op
+		case *destroyVolumeSnapshotOp:
+			destroyVolumeSnapshotOps[op.args.Id] = op
 		}
 	}
 	if len(removeVolumeOps) > 0 {
@@ -421,6 +525,26 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
+	if len(destroyVolumeSnapshotOps) > 0 {
+		if err := destroyVolumeSnapshots(ctx, destroyVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "destroying volume snapshots")
+		}
+	}
 	return nil
 }
 
@@ -1756,6 +1756,210 @@ func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	assertNoEvent(c, removedChan, "filesystems removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	provisionedVolume := names.NewVolumeTag("1")
+	unprovisionedVolume := names.NewVolumeTag("2")
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(provisionedVolume)
+	volumeAccessor.requestedSizes[provisionedVolume.String()] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].Volume = &storage.Volume{
+				arg.Tag,
+				storage.VolumeInfo{
+					VolumeId: arg.VolumeId,
+					Size:     2050,
+				},
+			}
+		}
+		return results, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{
+		provisionedVolume.Id(),
+		unprovisionedVolume.Id(),
+	}
+
+	// Only the provisioned volume is resized; the other will be
+	// created at the requested size.
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      provisionedVolume,
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2050,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["0"] = params.VolumeSnapshotParams{
+		Id:        "0",
+		Life:      params.Alive,
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Tags:      map[string]string{"very": "fancy"},
+	}
+	volumeAccessor.volumeSnapshots["1"] = params.VolumeSnapshotParams{
+		Id:         "1",
+		Life:       params.Alive,
+		VolumeTag:  "volume-2",
+		VolumeId:   "vol-2",
+		Provider:   "dummy",
+		SnapshotId: "snap-vol-2",
+	}
+
+	snapshotsCreated := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshotsCreated <- args
+		results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+				arg.Id,
+				storage.VolumeSnapshotInfo{
+					SnapshotId: "snap-" + arg.VolumeId,
+					VolumeId:   arg.VolumeId,
+					Size:       1024,
+				},
+			}
+		}
+		return results, nil
+	}
+
+	snapshotInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot "1" has already been taken, and snapshot "2"
+	// does not exist; only snapshot "0" should be taken.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1", "2"}
+
+	created := waitChannel(c, snapshotsCreated, "waiting for volume snapshot to be taken")
+	c.Assert(created, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:           "0",
+		Volume:       names.NewVolumeTag("1"),
+		VolumeId:     "vol-1",
+		Provider:     "dummy",
+		ResourceTags: map[string]string{"very": "fancy"},
+	}})
+	snapshots := waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+		Id: "0",
+		Info: params.VolumeSnapshotInfo{
+			SnapshotId: "snap-vol-1",
+			Size:       1024,
+		},
+	}})
+	assertNoEvent(c, snapshotsCreated, "volume snapshots taken")
+}
+
+func (s *storageProvisionerSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["0"] = params.VolumeSnapshotParams{
+		Id:         "0",
+		Life:       params.Dying,
+		VolumeTag:  "volume-1",
+		VolumeId:   "vol-1",
+		Provider:   "dummy",
+		SnapshotId: "snap-vol-1",
+	}
+	// Snapshot "1" was never taken, so there is nothing
+	// to destroy in the provider.
+	volumeAccessor.volumeSnapshots["1"] = params.VolumeSnapshotParams{
+		Id:        "1",
+		Life:      params.Dying,
+		VolumeTag: "volume-2",
+		Provider:  "dummy",
+	}
+
+	snapshotsDestroyed := make(chan interface{}, 1)
+	s.provider.destroyVolumeSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		snapshotsDestroyed <- snapshotIds
+		return make([]error, len(snapshotIds)), nil
+	}
+
+	snapshotsRemoved := make(chan interface{}, 1)
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		snapshotsRemoved <- ids
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1"}
+
+	destroyed := waitChannel(c, snapshotsDestroyed, "waiting for volume snapshot to be destroyed")
+	c.Assert(destroyed, jc.DeepEquals, []string{"snap-vol-1"})
+	removed := waitChannel(c, snapshotsRemoved, "waiting for volume snapshots to be removed")
+	c.Assert(removed, jc.DeepEquals, []string{"0", "1"})
+	assertNoEvent(c, snapshotsDestroyed, "volume snapshots destroyed")
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystemsUnsupported(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionFilesystem(names.NewFilesystemTag("1"))
+	filesystemAccessor.requestedSizes["filesystem-1"] = 2048
+
+	statusSet := make(chan interface{}, 1)
+	args := &workerArgs{
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+		statusSetter: &mockStatusSetter{
+			setStatus: func(args []params.EntityStatusArgs) error {
+				statusSet <- args
+				return nil
+			},
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+	statuses := waitChannel(c, statusSet, "waiting for filesystem status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "filesystem-1",
+		Status: "error",
+		Info:   `storage provider "dummy" does not support resizing filesystems`,
+	}})
+}
+
 type caasStorageProvisionerSuite struct {
 	coretesting.BaseSuite
 	provider *dummyProvider
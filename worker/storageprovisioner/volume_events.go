@@ -95,6 +95,93 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided
+// IDs have been seen to have changed, in case they have been asked to
+// grow.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	for i, result := range results {
+		// Any earlier request is superseded by the current one.
+		ctx.schedule.Remove(resizeKey{tags[i]})
+		if result.Error != nil {
+			// Volumes that are not yet provisioned will be created
+			// at the requested size, and volumes that have been
+			// removed are no longer visible to the provisioner.
+			if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for volume %q", tags[i].Id(),
+			)
+		}
+		if result.Result.Size == 0 {
+			continue
+		}
+		args, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		scheduleOperations(ctx, &resizeVolumeOp{args: args})
+	}
+	return nil
+}
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	for i, result := range results {
+		id := changes[i]
+		ctx.schedule.Remove(snapshotKey{id})
+		if result.Error != nil {
+			// Snapshots that have been removed are no longer
+			// visible to the provisioner.
+			if params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting params for volume snapshot %q", id)
+		}
+		args, err := volumeSnapshotParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch result.Result.Life {
+		case params.Alive:
+			if result.Result.SnapshotId != "" {
+				// The snapshot has already been taken.
+				continue
+			}
+			if args.VolumeId == "" {
+				logger.Warningf(
+					"cannot take snapshot %q of removed %s",
+					id, names.ReadableString(args.Volume),
+				)
+				continue
+			}
+			scheduleOperations(ctx, &createVolumeSnapshotOp{args: args})
+		default:
+			scheduleOperations(ctx, &destroyVolumeSnapshotOp{
+				args:       args,
+				snapshotId: result.Result.SnapshotId,
+			})
+		}
+	}
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
@@ -512,6 +599,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
@@ -535,3 +623,31 @@ func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage
 		VolumeId: in.VolumeId,
 	}, nil
 }
+
+func volumeResizeParamsFromParams(in params.ResizeVolumeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:      volumeTag,
+		VolumeId: in.VolumeId,
+		Size:     in.Size,
+		Provider: storage.ProviderType(in.Provider),
+	}, nil
+}
+
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Id:           in.Id,
+		Volume:       volumeTag,
+		VolumeId:     in.VolumeId,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+		ResourceTags: in.Tags,
+	}, nil
+}
@@ -4,6 +4,9 @@
 package storageprovisioner
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -361,6 +364,259 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.args.Provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		resizeParams := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		logger.Debugf("resizing volumes: %v", resizeParams)
+		volumeResizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// The provider will never be able to grow these
+			// volumes, so there's no point in retrying.
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info: fmt.Sprintf(
+						"storage provider %q does not support resizing volumes",
+						sourceName,
+					),
+				})
+			}
+			continue
+		}
+		results, err := volumeResizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    p.Tag.String(),
+				Status: volumeAttachedStatus(ctx, p.Tag).String(),
+			})
+			entityStatus := &statuses[len(statuses)-1]
+			if result.Error != nil {
+				// Reschedule the volume resize. The volume is
+				// still usable at its old size, so we leave the
+				// status as it is, recording why it hasn't grown.
+				reschedule = append(reschedule, ops[p.Tag])
+				entityStatus.Info = result.Error.Error()
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(p.Tag),
+					result.Error,
+				)
+				continue
+			}
+			volume, ok := ctx.volumes[p.Tag]
+			if ok {
+				volume.Size = result.Volume.Size
+			} else {
+				volume = *result.Volume
+			}
+			volumes = append(volumes, volume)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
+// volumeAttachedStatus returns the status to report for a provisioned
+// volume, according to whether or not it is known to be attached.
+func volumeAttachedStatus(ctx *context, tag names.VolumeTag) status.Status {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return status.Attached
+		}
+	}
+	return status.Detached
+}
+
+// createVolumeSnapshots takes snapshots of volumes with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	paramsByProvider := make(map[storage.ProviderType][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		paramsByProvider[op.args.Provider] = append(paramsByProvider[op.args.Provider], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshot
+	for providerType, snapshotParams := range paramsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			// The provider will never be able to take these
+			// snapshots, so there's no point in retrying.
+			for _, p := range snapshotParams {
+				logger.Errorf("cannot take volume snapshot %q: %v", p.Id, err)
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("creating volume snapshots: %v", snapshotParams)
+		results, err := snapshotter.CreateVolumeSnapshots(ctx.config.CloudCallContext, snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", providerType)
+		}
+		for i, result := range results {
+			p := snapshotParams[i]
+			if result.Error != nil {
+				// Reschedule the snapshot.
+				reschedule = append(reschedule, ops[p.Id])
+				logger.Debugf("failed to take volume snapshot %q: %v", p.Id, result.Error)
+				continue
+			}
+			snapshots = append(snapshots, params.VolumeSnapshot{
+				Id: p.Id,
+				Info: params.VolumeSnapshotInfo{
+					SnapshotId: result.VolumeSnapshot.SnapshotId,
+					Size:       result.VolumeSnapshot.Size,
+				},
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Id,
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// destroyVolumeSnapshots destroys the specified volume snapshots, and
+// removes them from state.
+func destroyVolumeSnapshots(ctx *context, ops map[string]*destroyVolumeSnapshotOp) error {
+	var remove []string
+	opsByProvider := make(map[storage.ProviderType][]*destroyVolumeSnapshotOp)
+	for id, op := range ops {
+		if op.snapshotId == "" {
+			// The snapshot was never taken, so there is
+			// nothing to destroy.
+			remove = append(remove, id)
+			continue
+		}
+		opsByProvider[op.args.Provider] = append(opsByProvider[op.args.Provider], op)
+	}
+	var reschedule []scheduleOp
+	for providerType, ops := range opsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			for _, op := range ops {
+				logger.Errorf("cannot destroy volume snapshot %q: %v", op.args.Id, err)
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotIds := make([]string, len(ops))
+		for i, op := range ops {
+			snapshotIds[i] = op.snapshotId
+		}
+		logger.Debugf("destroying volume snapshots: %v", snapshotIds)
+		errs, err := snapshotter.DestroyVolumeSnapshots(ctx.config.CloudCallContext, snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume snapshots from source %q", providerType)
+		}
+		for i, err := range errs {
+			op := ops[i]
+			if err != nil {
+				reschedule = append(reschedule, op)
+				logger.Debugf("failed to destroy volume snapshot %q: %v", op.args.Id, err)
+				continue
+			}
+			remove = append(remove, op.args.Id)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(remove) == 0 {
+		return nil
+	}
+	sort.Strings(remove)
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"removing volume snapshot %q from state: %v",
+				remove[i],
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotter returns the volume source for the specified storage
+// provider, if it supports volume snapshots.
+func volumeSnapshotter(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	sourceName := string(providerType)
+	source, err := volumeSource(ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("volume snapshots with non-dynamic storage provider %q", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("volume snapshots with storage provider %q", sourceName)
+	}
+	return snapshotter, nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -407,8 +663,14 @@ func validateVolumeParams(
 ) ([]storage.VolumeParams, []error) {
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
+	_, canSnapshot := volumeSource.(storage.VolumeSnapshotter)
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if params.SnapshotId != "" && !canSnapshot {
+			err = errors.NotSupportedf("creating volumes from snapshots with provider %q", params.Provider)
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -522,3 +784,31 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args storage.VolumeSnapshotParams
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return snapshotKey{op.args.Id}
+}
+
+type destroyVolumeSnapshotOp struct {
+	exponentialBackoff
+	args       storage.VolumeSnapshotParams
+	snapshotId string
+}
+
+func (op *destroyVolumeSnapshotOp) key() interface{} {
+	return snapshotKey{op.args.Id}
+}
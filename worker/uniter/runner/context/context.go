@@ -534,6 +534,28 @@ func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
 	return ctx.cloudSpec, nil
 }
 
+// AddSecret adds a secret owned by the unit's application, returning
+// the ID of the secret. Implements jujuc.ContextSecrets.
+func (ctx *HookContext) AddSecret(name string, values map[string]string) (string, error) {
+	return ctx.state.AddSecret(name, values)
+}
+
+// GetSecret returns the values of a revision of a secret.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) GetSecret(id string, revision int) (map[string]string, error) {
+	return ctx.state.GetSecret(id, revision)
+}
+
+// GrantSecret grants a secret to the application, or a single unit of
+// the application, at the other end of a relation.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) GrantSecret(id string, relationId int, unitName string) error {
+	if _, err := ctx.Relation(relationId); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.state.GrantSecret(id, relationId, unitName)
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
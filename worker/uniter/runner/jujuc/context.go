@@ -41,6 +41,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -303,6 +304,26 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or granted to, the unit's application.
+type ContextSecrets interface {
+	// AddSecret adds a secret with the given name and values, owned by
+	// the unit's application, and returns the secret's ID. If the
+	// application already owns a secret with the name, a new revision
+	// of the secret is added.
+	AddSecret(name string, values map[string]string) (string, error)
+
+	// GetSecret returns the values of the given revision of the secret
+	// with the given ID. If revision is zero, the values of the latest
+	// revision are returned.
+	GetSecret(id string, revision int) (map[string]string, error)
+
+	// GrantSecret grants the secret with the given ID to the application
+	// at the other end of the given relation, or to a single unit of that
+	// application if unitName is not empty.
+	GrantSecret(id string, relationId int, unitName string) error
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Secrets maps secret IDs to the values of their revisions,
+	// oldest first.
+	Secrets map[string][]map[string]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// AddSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) AddSecret(name string, values map[string]string) (string, error) {
+	c.stub.AddCall("AddSecret", name, values)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string][]map[string]string)
+	}
+	id := "u/" + name
+	c.info.Secrets[id] = append(c.info.Secrets[id], values)
+	return id, nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string, revision int) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisions := c.info.Secrets[id]
+	if revision == 0 {
+		revision = len(revisions)
+	}
+	if revision < 1 || revision > len(revisions) {
+		return nil, errors.NotFoundf("secret %q revision %d", id, revision)
+	}
+	return revisions[revision-1], nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, relationId int, unitName string) error {
+	c.stub.AddCall("GrantSecret", id, relationId, unitName)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
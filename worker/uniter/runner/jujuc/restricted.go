@@ -173,3 +173,18 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// AddSecret implements hooks.Context.
+func (*RestrictedContext) AddSecret(string, map[string]string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string, int) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements hooks.Context.
+func (*RestrictedContext) GrantSecret(string, int, string) error {
+	return ErrRestrictedContext
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx    Context
+	name   string
+	values map[string]string
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add stores the supplied key/value pairs as a secret owned by the
+application, and prints the secret's ID. If the application already has a
+secret with the given name, a new revision of the secret is added. Secret
+values are encrypted by the controller, and may only be read by units of the
+application, or by units the secret has been granted to with secret-grant.
+It will fail if called by a unit that is not currently application leader.
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<name> <key>=<value> [...]",
+		Purpose: "add a secret or a new secret revision",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("no secret name specified")
+	}
+	c.name = args[0]
+	if len(args) < 2 {
+		return errors.New("no secret values specified")
+	}
+	c.values, err = keyvalues.Parse(args[1:], false)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.AddSecret(c.name, c.values)
+	if err != nil {
+		return errors.Annotatef(err, "cannot add secret %q", c.name)
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no secret name specified",
+	}, {
+		args: []string{"password"},
+		err:  "no secret values specified",
+	}, {
+		args: []string{"password", "root"},
+		err:  `expected "key=value", got "root"`,
+	}, {
+		args: []string{"password", "root="},
+		err:  `expected "key=value", got "root="`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"password", "root=s3cret", "user=admin"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "u/password\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"AddSecret", []interface{}{"password", map[string]string{"root": "s3cret", "user": "admin"}},
+	}})
+	c.Check(hctx.info.Secrets.Secrets["u/password"], jc.DeepEquals, []map[string]string{
+		{"root": "s3cret", "user": "admin"},
+	})
+}
+
+func (s *SecretAddSuite) TestAddSecretError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"password", "root=s3cret"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot add secret \"password\": boom\n")
+}
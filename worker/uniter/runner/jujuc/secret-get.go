@@ -0,0 +1,80 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	id       string
+	key      string
+	revision int
+	out      cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the specified key of a secret. If no key is
+given, or if the key is "-", all keys and values will be printed. The latest
+revision of the secret is read, unless --revision is specified.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<id> [<key>]",
+		Purpose: "print secret values",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the revision of the secret to read")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret ID specified")
+	}
+	c.id = args[0]
+	if c.revision < 0 {
+		return errors.Errorf("invalid revision %d", c.revision)
+	}
+	c.key = ""
+	if len(args) < 2 {
+		return nil
+	}
+	if key := args[1]; key != "-" {
+		c.key = key
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	values, err := c.ctx.GetSecret(c.id, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, values)
+	}
+	if value, ok := values[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
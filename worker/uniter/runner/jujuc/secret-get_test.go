@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string][]map[string]string{
+		"mysql/password": {
+			{"root": "foo", "user": "admin"},
+			{"root": "bar", "user": "admin"},
+		},
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, nil)
+	c.Check(err, gc.ErrorMatches, "no secret ID specified")
+
+	com = s.createCommand(c, nil)
+	err = cmdtesting.InitCommand(com, []string{"mysql/password", "root", "user"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["user"\]`)
+
+	com = s.createCommand(c, nil)
+	err = cmdtesting.InitCommand(com, []string{"mysql/password", "--revision", "-1"})
+	c.Check(err, gc.ErrorMatches, "invalid revision -1")
+}
+
+func (s *SecretGetSuite) testOutput(c *gc.C, args []string, expect string) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, args)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, expect)
+}
+
+func (s *SecretGetSuite) TestGetKey(c *gc.C) {
+	s.testOutput(c, []string{"mysql/password", "root"}, "bar\n")
+	s.Stub.CheckCalls(c, []testing.StubCall{{"GetSecret", []interface{}{"mysql/password", 0}}})
+}
+
+func (s *SecretGetSuite) TestGetKeyRevision(c *gc.C) {
+	s.testOutput(c, []string{"mysql/password", "root", "--revision", "1"}, "foo\n")
+	s.Stub.CheckCalls(c, []testing.StubCall{{"GetSecret", []interface{}{"mysql/password", 1}}})
+}
+
+func (s *SecretGetSuite) TestGetMissingKey(c *gc.C) {
+	s.testOutput(c, []string{"mysql/password", "missing"}, "")
+}
+
+func (s *SecretGetSuite) TestGetAll(c *gc.C) {
+	s.testOutput(c, []string{"mysql/password", "--format", "json"}, `{"root":"bar","user":"admin"}`+"\n")
+	s.testOutput(c, []string{"mysql/password", "-", "--format", "yaml"}, "root: bar\nuser: admin\n")
+}
+
+func (s *SecretGetSuite) TestGetError(c *gc.C) {
+	com := s.createCommand(c, errors.New("permission denied"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"mysql/password"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read secret \"mysql/password\": permission denied\n")
+}
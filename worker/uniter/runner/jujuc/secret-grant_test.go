@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) createCommand(c *gc.C, relid int, err error) cmd.Command {
+	hctx, _ := s.newHookContext(relid, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		err   string
+	}{{
+		relid: 1,
+		err:   "no secret ID specified",
+	}, {
+		relid: -1,
+		args:  []string{"u/password"},
+		err:   "no relation id specified",
+	}, {
+		relid: -1,
+		args:  []string{"u/password", "-r", "123"},
+		err:   `invalid value "123" for flag -r: relation not found`,
+	}, {
+		relid: 1,
+		args:  []string{"u/password", "--unit", "wordpress"},
+		err:   `unit name "wordpress" not valid`,
+	}, {
+		relid: 1,
+		args:  []string{"u/password", "foo"},
+		err:   `unrecognized args: \["foo"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com := s.createCommand(c, t.relid, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantHookRelation(c *gc.C) {
+	com := s.createCommand(c, 1, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"u/password"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCallNames(c, "HookRelation", "GrantSecret")
+	s.Stub.CheckCall(c, 1, "GrantSecret", "u/password", 1, "")
+}
+
+func (s *SecretGrantSuite) TestGrantUnit(c *gc.C) {
+	com := s.createCommand(c, -1, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"u/password", "-r", "peer0:0", "--unit", "wordpress/1"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCallNames(c, "HookRelation", "Relation", "GrantSecret")
+	s.Stub.CheckCall(c, 2, "GrantSecret", "u/password", 0, "wordpress/1")
+}
+
+func (s *SecretGrantSuite) TestGrantError(c *gc.C) {
+	com := s.createCommand(c, 1, errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"u/password"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot grant secret \"u/password\": boom\n")
+}
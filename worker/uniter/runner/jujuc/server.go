@@ -80,6 +80,12 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:   NewSecretAddCommand,
+	"secret-get" + cmdSuffix:   NewSecretGetCommand,
+	"secret-grant" + cmdSuffix: NewSecretGrantCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -90,6 +96,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
@@ -158,6 +158,18 @@ func (s *stateSuite) TestCommitHook(c *gc.C) {
 		c.Assert(stateFile, jc.IsNonEmptyFile)
 	}
 
+	for i := 0; i < 2; i++ {
+		err := state.CommitHook(hook.Info{
+			Kind:        hook.StorageResized,
+			StorageId:   "data-0",
+			StorageSize: 2048,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		resized, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(storage.StateSize(resized), gc.Equals, uint64(2048))
+	}
+
 	for i := 0; i < 2; i++ {
 		err := state.CommitHook(hook.Info{
 			Kind:      hooks.StorageDetaching,
@@ -197,4 +209,6 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidates(true, hook.StorageResized)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
 }